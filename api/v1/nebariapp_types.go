@@ -21,6 +21,7 @@ import (
 )
 
 // NebariAppSpec defines the desired state of NebariApp
+// +kubebuilder:validation:XValidation:rule="!has(self.createServiceStub) || !self.createServiceStub || has(self.service.port)",message="createServiceStub requires service.port"
 type NebariAppSpec struct {
 	// Hostname is the fully qualified domain name where the application should be accessible.
 	// This will be used to generate HTTPRoute.
@@ -34,6 +35,25 @@ type NebariAppSpec struct {
 	// +kubebuilder:validation:Required
 	Service ServiceReference `json:"service"`
 
+	// Enabled controls whether the operator exposes the app. Setting it to false
+	// deletes the resources the operator manages for the app (HTTPRoutes,
+	// SecurityPolicies, the provisioned OIDC client, TLS listener and
+	// Certificate) while keeping the NebariApp; setting it back to true
+	// recreates them.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// CreateServiceStub makes the operator create a selectorless placeholder
+	// Service when the Service named in spec.service does not exist yet, so the
+	// route resolves (answering 503 until the real Service lands) in GitOps flows
+	// that apply the Service after the NebariApp. The stub's port follows
+	// spec.service.port. Once the Service gets a selector it is treated as the
+	// real Service and the operator stops managing it. Only applies to Services
+	// in the NebariApp's own namespace.
+	// +optional
+	CreateServiceStub bool `json:"createServiceStub,omitempty"`
+
 	// Routing configures routing behavior including path-based rules and TLS.
 	// +optional
 	Routing *RoutingConfig `json:"routing,omitempty"`
@@ -50,6 +70,19 @@ type NebariAppSpec struct {
 	// +optional
 	Gateway string `json:"gateway,omitempty"`
 
+	// Gateways lists every shared Gateway the app should be exposed on, for apps
+	// that must be reachable both publicly and internally with the same backend.
+	// One HTTPRoute is created per entry, and auth and TLS apply to each of them.
+	// The first entry is the primary Gateway: its HTTPRoute keeps the
+	// <name>-route name, and the others are suffixed with the Gateway kind
+	// (for example <name>-route-internal). Overrides gateway when set.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:items:Enum=public;internal
+	Gateways []string `json:"gateways,omitempty"`
+
 	// ServiceAccountName is the name of the Kubernetes ServiceAccount used by the
 	// app's pods. Used for RBAC scoping of OIDC secrets so only the app's pods
 	// can read its credentials. Defaults to the NebariApp's name if omitted.
@@ -61,9 +94,17 @@ type NebariAppSpec struct {
 	// When enabled, the service will be discoverable through the landing page portal.
 	// +optional
 	LandingPage *LandingPageConfig `json:"landingPage,omitempty"`
+
+	// Description is a short, human-readable summary of the application.
+	// It is propagated as the nebari.dev/description annotation onto the generated
+	// HTTPRoute and SecurityPolicy so platform dashboards can display it.
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	Description string `json:"description,omitempty"`
 }
 
 // ServiceReference identifies the Kubernetes Service that backs this application.
+// +kubebuilder:validation:XValidation:rule="has(self.port) != has(self.portName)",message="exactly one of port or portName must be set"
 type ServiceReference struct {
 	// Name is the name of the Kubernetes Service in the same namespace.
 	// +kubebuilder:validation:Required
@@ -71,10 +112,20 @@ type ServiceReference struct {
 	Name string `json:"name"`
 
 	// Port is the port number on the Service to route traffic to.
-	// +kubebuilder:validation:Required
+	// Exactly one of port or portName must be set.
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=65535
-	Port int32 `json:"port"`
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// PortName is the name of the Service port to route traffic to. The
+	// operator resolves it to the port's number on every reconcile, so the
+	// generated HTTPRoutes follow the Service when the named port is
+	// renumbered. Only supported on spec.service with kind Service.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	PortName string `json:"portName,omitempty"`
 
 	// Namespace is the namespace of the Service (if different from the NebariApp).
 	// If not specified, defaults to the NebariApp's namespace.
@@ -83,6 +134,28 @@ type ServiceReference struct {
 	// +optional
 	// +kubebuilder:validation:MinLength=1
 	Namespace string `json:"namespace,omitempty"`
+
+	// Group is the API group of the backend. Leave empty for core Services.
+	// Use "gateway.envoyproxy.io" with kind "Backend" to route to an Envoy
+	// Gateway Backend, or "multicluster.x-k8s.io" with kind "ServiceImport".
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Kind is the kind of the backend. Defaults to "Service".
+	// Supported kinds are Service, Backend (group gateway.envoyproxy.io) and
+	// ServiceImport (group multicluster.x-k8s.io). The operator only checks
+	// that Services exist; other kinds are passed to the Gateway as-is.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Weight is the weight set on the backendRef for spec.service in the
+	// generated HTTPRoutes. With a single backend it does not change where
+	// traffic goes, but an explicit weight lets the route be split later.
+	// Defaults to 100. Ignored for experiment primary and mirror Services.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000000
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
 }
 
 // RoutingConfig configures routing behavior for the application.
@@ -103,10 +176,24 @@ type RoutingConfig struct {
 	// via a separate HTTPRoute that is not protected by the SecurityPolicy.
 	// Each entry uses the same RouteMatch format as routes, supporting both
 	// PathPrefix (default) and Exact matching via the pathType field.
+	// Every entry must set pathPrefix, so an exemption never covers the whole app.
 	// Example: [{pathPrefix: "/api/v1/health", pathType: "Exact"}]
 	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.all(r, has(r.pathPrefix))",message="publicRoutes entries must set pathPrefix"
 	PublicRoutes []RouteMatch `json:"publicRoutes,omitempty"`
 
+	// GatewayPort binds the generated HTTPRoutes to the Gateway listener on this
+	// port, for Gateways with several listeners of the same name on different
+	// ports. It is set as the port of each route's parentRef next to the
+	// listener section name, and the connectivity probe uses it. It does not
+	// apply to the app's own TLS listener (routing.tls with cert-manager or
+	// secretName), which is always on port 443. When omitted, the route attaches
+	// to the listener regardless of port.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	GatewayPort *int32 `json:"gatewayPort,omitempty"`
+
 	// TLS configures TLS certificate management and termination behavior.
 	// When TLS is enabled (the default), the operator creates a cert-manager Certificate
 	// for the application's hostname and adds a per-app HTTPS listener to the shared Gateway.
@@ -120,16 +207,180 @@ type RoutingConfig struct {
 	// annotations always take precedence to avoid breaking internal behaviour.
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// DNSTarget is the load balancer hostname or IP address external-dns should
+	// point spec.hostname at, for setups where the Gateway's own address is not
+	// the one clients reach. When set, the generated HTTPRoutes carry the
+	// external-dns.alpha.kubernetes.io/target and
+	// external-dns.alpha.kubernetes.io/hostname annotations.
+	// Example: "lb.example.com" or "203.0.113.10"
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	DNSTarget string `json:"dnsTarget,omitempty"`
+
+	// RequestTimeout sets the request timeout on the generated HTTPRoute rules,
+	// overriding the operator's per-gateway default. Uses the Gateway API
+	// duration format.
+	// Example: "30s", "5m", "1h30m"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,5}(h|m|s|ms)){1,4}$`
+	RequestTimeout string `json:"requestTimeout,omitempty"`
+
+	// ClientTimeouts configures connection-level timeouts between clients and the
+	// Gateway, e.g. larger idle timeouts for long-polling apps. The operator manages
+	// an Envoy Gateway ClientTrafficPolicy attached to the app's per-app HTTPS
+	// listener, so it only takes effect when per-app TLS is active.
+	// +optional
+	ClientTimeouts *ClientTimeoutsConfig `json:"clientTimeouts,omitempty"`
+
+	// ConnectivityProbe enables an in-cluster HTTP GET of the app through its Gateway.
+	// The result is reported in the ConnectivityReady condition, which then gates Ready.
+	// Probing costs a request per reconcile, so it only runs when the operator is
+	// started with CONNECTIVITY_PROBE_ENABLED=true.
+	// +optional
+	ConnectivityProbe *ConnectivityProbeConfig `json:"connectivityProbe,omitempty"`
+
+	// RateLimit limits requests to the app's HTTPRoute. The operator manages an
+	// Envoy Gateway BackendTrafficPolicy with a local (per Envoy replica) limit.
+	// +optional
+	RateLimit *RateLimitConfig `json:"rateLimit,omitempty"`
+
+	// DefaultResponseHeaders controls whether the operator-wide default response
+	// headers (DEFAULT_RESPONSE_HEADERS, e.g. HSTS) are set on responses from the
+	// app's backend rules. Defaults to true; set to false to opt out.
+	// +optional
+	DefaultResponseHeaders *bool `json:"defaultResponseHeaders,omitempty"`
+
+	// DeletionGracePeriod keeps the app's HTTPRoutes serving for this long after
+	// the NebariApp is deleted, so in-flight requests can finish before the
+	// routes and the rest of the app's resources are removed. During the grace
+	// period the routes carry the nebari.dev/draining-until annotation.
+	// Uses the Gateway API duration format.
+	// Example: "30s", "2m"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,5}(h|m|s|ms)){1,4}$`
+	DeletionGracePeriod string `json:"deletionGracePeriod,omitempty"`
+
+	// GatewayRouting exposes the app on additional gateways for requests that
+	// carry specific headers, e.g. sending "X-Internal: true" traffic through the
+	// internal gateway while everything else stays on the public one. Each entry
+	// adds its gateway to the app's gateways and restricts the HTTPRoutes
+	// generated there to requests matching all of the entry's headers. Gateways
+	// without an entry keep matching on paths alone.
+	// +optional
+	// +listType=map
+	// +listMapKey=gateway
+	// +kubebuilder:validation:MaxItems=2
+	GatewayRouting []GatewayHeaderRoute `json:"gatewayRouting,omitempty"`
+}
+
+// GatewayHeaderRoute restricts an app's routes on one gateway to requests
+// carrying the given headers.
+type GatewayHeaderRoute struct {
+	// Gateway is the gateway the header-matched routes attach to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=public;internal
+	Gateway string `json:"gateway"`
+
+	// Headers must all match for a request to use this gateway's routes.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Headers []HeaderMatch `json:"headers"`
+}
+
+// HeaderMatch matches an HTTP request header.
+type HeaderMatch struct {
+	// Name is the header name. Matching is case-insensitive.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+\-.^_\x60|~]+$`
+	Name string `json:"name"`
+
+	// Type specifies how to match the header value.
+	// +kubebuilder:validation:Enum=Exact;RegularExpression
+	// +kubebuilder:default=Exact
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Value is the header value to match.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=4096
+	Value string `json:"value"`
+}
+
+// RateLimitConfig configures request rate limiting for the app's HTTPRoute.
+type RateLimitConfig struct {
+	// Requests is the number of requests allowed per unit.
+	// +kubebuilder:validation:Minimum=1
+	Requests uint `json:"requests"`
+
+	// Unit is the period the request count applies to.
+	// +kubebuilder:validation:Enum=Second;Minute;Hour;Day
+	// +kubebuilder:default=Minute
+	// +optional
+	Unit string `json:"unit,omitempty"`
+
+	// ByClaim keys the limit on a JWT claim (e.g. "sub"), giving each
+	// authenticated user their own bucket instead of one bucket for the route.
+	// Nested claims use dots ("realm_access.id"). The gateway copies the claim of
+	// validated bearer tokens into a request header, so this requires auth to be
+	// enabled with enforceAtGateway and auth.jwt.enabled; otherwise the limit is
+	// not applied. Requests without a validated token are not limited.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`
+	// +kubebuilder:validation:MaxLength=128
+	// +optional
+	ByClaim string `json:"byClaim,omitempty"`
 }
 
-// RouteMatch defines a path-based routing rule.
+// ConnectivityProbeConfig configures the in-cluster connectivity probe.
+type ConnectivityProbeConfig struct {
+	// Enabled turns the probe on for this app.
+	Enabled bool `json:"enabled"`
+
+	// Path is requested on the app's hostname. A 2xx or 3xx response counts as
+	// reachable; redirects are not followed. Defaults to "/".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+}
+
+// ClientTimeoutsConfig holds connection-level client timeouts. All values use the
+// Gateway API duration format. Unset fields keep Envoy Gateway's defaults.
+type ClientTimeoutsConfig struct {
+	// IdleTimeout closes an HTTP connection after this long without active requests.
+	// Envoy Gateway defaults to 1h.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,5}(h|m|s|ms)){1,4}$`
+	IdleTimeout string `json:"idleTimeout,omitempty"`
+
+	// StreamIdleTimeout resets a request stream after this long without upstream
+	// or downstream activity. Envoy Gateway defaults to 5m.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,5}(h|m|s|ms)){1,4}$`
+	StreamIdleTimeout string `json:"streamIdleTimeout,omitempty"`
+
+	// RequestReceivedTimeout bounds how long Envoy waits to receive the complete
+	// request from the client.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,5}(h|m|s|ms)){1,4}$`
+	RequestReceivedTimeout string `json:"requestReceivedTimeout,omitempty"`
+}
+
+// RouteMatch defines a routing rule matching on the request path, headers and
+// method.
+// +kubebuilder:validation:XValidation:rule="has(self.pathPrefix) || (has(self.headers) && size(self.headers) > 0) || has(self.method)",message="route must set pathPrefix, headers or method"
+// +kubebuilder:validation:XValidation:rule="has(self.pathPrefix) || !has(self.pathType)",message="pathType requires pathPrefix"
 type RouteMatch struct {
 	// PathPrefix specifies the path prefix to match for routing.
 	// Traffic matching this prefix will be routed to the service.
 	// Must start with "/". Example: "/app-1", "/api/v1"
-	// +kubebuilder:validation:Required
+	// Required unless headers or method is set; a route without pathPrefix
+	// matches every path.
 	// +kubebuilder:validation:Pattern=`^/.*`
-	PathPrefix string `json:"pathPrefix"`
+	// +optional
+	PathPrefix string `json:"pathPrefix,omitempty"`
 
 	// PathType specifies how the path should be matched.
 	// Valid values:
@@ -140,9 +391,133 @@ type RouteMatch struct {
 	// +kubebuilder:validation:Enum=PathPrefix;Exact
 	// +optional
 	PathType string `json:"pathType,omitempty"`
+
+	// Headers restricts the route to requests carrying all of these headers,
+	// in addition to the path match. Useful for sending canary or gRPC
+	// traffic to its own backends. Not supported in publicRoutes, since an
+	// auth exemption must not depend on headers the client controls.
+	// Example: [{name: "X-Canary", value: "true"}]
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Headers []HeaderMatch `json:"headers,omitempty"`
+
+	// Method restricts the route to requests with this HTTP method, in
+	// addition to the path and header matches. Empty matches every method.
+	// Example: "GET"
+	// +kubebuilder:validation:Enum=GET;HEAD;POST;PUT;DELETE;CONNECT;OPTIONS;TRACE;PATCH
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Redirect, when set, makes requests matching this route receive an HTTP
+	// redirect instead of being forwarded to the backend service. Useful for
+	// pointing "/" at a status page during maintenance.
+	// The generated rule carries a RequestRedirect filter and no backend ref.
+	// +optional
+	Redirect *RouteRedirect `json:"redirect,omitempty"`
+
+	// Backends, when set, splits traffic matching this route across the listed
+	// Services by weight instead of sending it to spec.service. Other routes keep
+	// using spec.service. Useful for rolling out a new API version on "/api" only.
+	// Weights are relative and must not all be zero. Cannot be combined with redirect.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Backends []WeightedBackend `json:"backends,omitempty"`
+
+	// Experiment, when set, sends traffic matching this route to a primary
+	// Service and mirrors a sample of the requests to a second Service so the
+	// two can be compared. Responses from the mirror are discarded.
+	// Cannot be combined with redirect or backends.
+	// +optional
+	Experiment *RouteExperiment `json:"experiment,omitempty"`
+
+	// Timeout sets the request timeout on the rule generated for this route,
+	// overriding routing.requestTimeout and the operator's per-gateway default.
+	// Only routes that get a rule of their own accept it, i.e. those with
+	// backends or an experiment. Uses the Gateway API duration format.
+	// Example: "30s", "5m"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,5}(h|m|s|ms)){1,4}$`
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// RouteExperiment serves a route from a primary Service while mirroring a
+// percentage of its requests to another Service.
+type RouteExperiment struct {
+	// Primary is the Service that serves the route's traffic.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="has(self.port)",message="experiment services must set port"
+	Primary ServiceReference `json:"primary"`
+
+	// Mirror is the Service that receives copies of the sampled requests.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="has(self.port)",message="experiment services must set port"
+	Mirror ServiceReference `json:"mirror"`
+
+	// Percent is the percentage of requests mirrored to the mirror Service.
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percent *int32 `json:"percent,omitempty"`
+}
+
+// WeightedBackend is a Service that receives a weighted share of a route's traffic.
+type WeightedBackend struct {
+	// Name is the name of the Kubernetes Service.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Port is the port number on the Service to route traffic to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Namespace is the namespace of the Service (if different from the NebariApp).
+	// If not specified, defaults to the NebariApp's namespace.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace,omitempty"`
+
+	// Weight is the relative share of the route's traffic sent to this Service.
+	// A weight of 0 keeps the backend configured but sends it no traffic.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000000
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// RouteRedirect configures an HTTP redirect response for a route.
+// Fields that are left empty keep the value from the original request.
+type RouteRedirect struct {
+	// Scheme is the scheme used in the Location header (http or https).
+	// +kubebuilder:validation:Enum=http;https
+	// +optional
+	Scheme *string `json:"scheme,omitempty"`
+
+	// Hostname is the hostname used in the Location header.
+	// Example: "status.example.com"
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// Path replaces the full request path in the Location header.
+	// Must start with "/". Example: "/maintenance.html"
+	// +kubebuilder:validation:Pattern=`^/.*`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// StatusCode is the HTTP status code returned with the redirect.
+	// +kubebuilder:validation:Enum=301;302
+	// +kubebuilder:default=302
+	// +optional
+	StatusCode *int `json:"statusCode,omitempty"`
 }
 
 // RoutingTLSConfig controls TLS termination for the HTTPRoute.
+// +kubebuilder:validation:XValidation:rule="!(has(self.secretName) && has(self.gatewayRef))",message="secretName and gatewayRef are mutually exclusive"
 type RoutingTLSConfig struct {
 	// Enabled determines whether TLS termination should be used.
 	// When nil or true, the operator will create a cert-manager Certificate
@@ -168,6 +543,31 @@ type RoutingTLSConfig struct {
 	// +kubebuilder:validation:MaxLength=253
 	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
 	SecretName string `json:"secretName,omitempty"`
+
+	// GatewayRef points the HTTPRoute at an existing HTTPS listener on a shared
+	// Gateway instead of a per-app listener. The operator creates no Certificate
+	// and no listener; it checks that the listener exists and is HTTPS, and
+	// TLSReady reflects the listener's Programmed condition. Only the HTTPRoute
+	// attached to the referenced Gateway uses the listener; routes on the app's
+	// other Gateways keep the shared "https" listener.
+	// Mutually exclusive with secretName. Ignored when enabled is false.
+	// +optional
+	GatewayRef *GatewayListenerRef `json:"gatewayRef,omitempty"`
+}
+
+// GatewayListenerRef names a listener on a Gateway in the Gateway namespace
+// (envoy-gateway-system).
+type GatewayListenerRef struct {
+	// Name is the name of the Gateway. It must be one of the Gateways the app
+	// is exposed on.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// SectionName is the name of the listener on the Gateway.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	SectionName string `json:"sectionName"`
 }
 
 // AuthConfig specifies authentication/authorization configuration.
@@ -181,8 +581,8 @@ type AuthConfig struct {
 
 	// Provider specifies the OIDC authentication provider to use.
 	// Supported values: keycloak, generic-oidc
+	// When empty, the operator's default provider is used (DEFAULT_AUTH_PROVIDER, keycloak unless configured).
 	// +kubebuilder:validation:Enum=keycloak;generic-oidc
-	// +kubebuilder:default=keycloak
 	// +optional
 	Provider string `json:"provider,omitempty"`
 
@@ -190,9 +590,58 @@ type AuthConfig struct {
 	// If not specified, defaults to "/oauth2/callback" which is the Envoy Gateway default.
 	// For application-level auth handling, specify the app's callback path (e.g., "/auth/callback").
 	// The full redirect URL will be: https://<hostname><redirectURI>
+	// The path is normalized to start with "/" and have no trailing slash. Absolute
+	// URLs, queries and fragments are rejected unless redirectURLOverride is set.
 	// +optional
 	RedirectURI string `json:"redirectURI,omitempty"`
 
+	// RedirectURLOverride is an absolute OAuth2 redirect URL used verbatim instead of
+	// the computed https://<hostname><redirectURI>. Use this when the app is served
+	// behind an external CDN or proxy with a different public hostname. When
+	// provisioning is enabled, the URL is also registered as a valid redirect URI
+	// on the OIDC client.
+	// Example: "https://cdn.example.com/oauth2/callback"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	RedirectURLOverride string `json:"redirectURLOverride,omitempty"`
+
+	// PostLogoutRedirectURI is where users land after the app itself performs
+	// RP-initiated logout. Either an absolute http(s) URL or a path relative
+	// to the app's root (e.g., "/goodbye"), which resolves to
+	// https://<hostname><path>. When provisioning is enabled, the URL is
+	// registered in the client's post.logout.redirect.uris attribute. Logging
+	// out through the gateway's /logout path returns users to the app's root
+	// URL, which Envoy passes to the IdP as post_logout_redirect_uri itself.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(https?://[^/\s]+(/\S*)?|/([^/\s]\S*)?)$`
+	PostLogoutRedirectURI string `json:"postLogoutRedirectURI,omitempty"`
+
+	// Logout configures how logging out of the app propagates to the IdP's SSO
+	// session. By default the gateway's /logout path only clears the app's own
+	// session cookies, so the next login is completed silently by the IdP.
+	// +optional
+	Logout *LogoutConfig `json:"logout,omitempty"`
+
+	// PKCE requires authorization requests to the provisioned client to carry a
+	// PKCE code challenge using the S256 method, by setting the Keycloak client's
+	// pkce.code.challenge.method attribute. Envoy Gateway has no PKCE setting of
+	// its own; Envoy's OAuth2 filter sends an S256 challenge, so gateway-enforced
+	// auth keeps working. Setting it back to false leaves the attribute on the
+	// client unchanged. Only applies when the operator provisions the client.
+	// +kubebuilder:default=false
+	// +optional
+	PKCE bool `json:"pkce,omitempty"`
+
+	// AdditionalWebOrigins lists extra origins allowed to make CORS requests
+	// with the provisioned client, for apps whose frontend is served from a
+	// different origin than spec.hostname. Each entry is an absolute http(s)
+	// origin without a path, e.g. "https://app.example.com". They are added to
+	// the client's web origins next to the app's own hostname.
+	// Only applies when the operator provisions the client.
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	AdditionalWebOrigins []string `json:"additionalWebOrigins,omitempty"`
+
 	// ClientSecretRef references a Kubernetes Secret containing OIDC client credentials.
 	// The secret must be in the same namespace as the NebariApp and contain:
 	//   - client-id: The OIDC client ID
@@ -202,18 +651,90 @@ type AuthConfig struct {
 	// +optional
 	ClientSecretRef *string `json:"clientSecretRef,omitempty"`
 
+	// ClientTLSSecretRef names a Secret in the NebariApp's namespace holding a
+	// client certificate (tls.crt) and private key (tls.key) the gateway presents
+	// when it calls the IdP's token endpoint, for IdPs that require mutual TLS.
+	// The operator creates an Envoy Gateway Backend for the token endpoint host
+	// and points the SecurityPolicy's OIDC provider at it. The token endpoint
+	// must use https, and Envoy Gateway must have the Backend API enabled.
+	// Only applies when enforceAtGateway is true.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	ClientTLSSecretRef *string `json:"clientTLSSecretRef,omitempty"`
+
 	// Scopes defines the OIDC scopes to request during authentication.
 	// Common scopes: openid, profile, email, roles, groups
 	// If not specified, defaults to: ["openid", "profile", "email"]
+	// Scopes outside the operator's ALLOWED_SCOPES list are rejected.
 	// +optional
 	Scopes []string `json:"scopes,omitempty"`
 
+	// StepUpScopes are additional OIDC scopes requested only for stepUpPaths.
+	// Requests under those paths go through a separate login that asks for
+	// scopes plus stepUpScopes; the rest of the app keeps the base scopes.
+	// Requires stepUpPaths and enforceAtGateway.
+	// +optional
+	StepUpScopes []string `json:"stepUpScopes,omitempty"`
+
+	// StepUpPaths are the path prefixes of sensitive routes that require
+	// stepUpScopes. The operator routes them through a dedicated HTTPRoute
+	// guarded by its own SecurityPolicy, keeping the backends of the
+	// routing.routes entry they fall under. Entries cannot be nested in each
+	// other, and no routing.routes path may lie below one. The step-up login
+	// returns to <first stepUpPath>/oauth2/callback.
+	// Example: ["/admin", "/api/billing"]
+	// +optional
+	// +kubebuilder:validation:items:Pattern=`^/.*`
+	StepUpPaths []string `json:"stepUpPaths,omitempty"`
+
 	// Groups specifies the list of groups that should have access to this application.
 	// When specified, only users belonging to these groups will be authorized.
 	// Group matching is case-sensitive and depends on the OIDC provider's group claim.
+	// The "groups" scope is added to the requested scopes when it is missing.
 	// +optional
 	Groups []string `json:"groups,omitempty"`
 
+	// EnforceGroupsAtGateway makes the gateway check groups itself: with
+	// jwt.enabled or bearerOnly, only requests whose token lists one of the
+	// groups in the groupsClaim claim are admitted. Browser sessions are
+	// checked by forwarding their access token, so forwardAccessToken cannot
+	// be false. Defaults to false, leaving group checks to the IdP and the
+	// application.
+	// +optional
+	EnforceGroupsAtGateway bool `json:"enforceGroupsAtGateway,omitempty"`
+
+	// AllowedEmailDomains lists the email domains whose users have access to
+	// this application, for IdPs that cannot provide a groups claim. When set
+	// together with jwt.enabled, the gateway admits requests whose token has one
+	// of these domains in the emailDomainClaim claim. It can be used alongside
+	// enforceGroupsAtGateway; a request matching either is admitted.
+	// Gateway claim rules only match exact values, so the IdP must put the
+	// domain in a claim of its own (e.g. Google's "hd"), named by
+	// emailDomainClaim.
+	// Example: ["example.com", "example.org"]
+	// +optional
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)+$`
+	AllowedEmailDomains []string `json:"allowedEmailDomains,omitempty"`
+
+	// GroupsClaim names the token claim that lists the user's groups. It is
+	// used by the gateway authorization rule enforceGroupsAtGateway builds and, for
+	// keycloak, as the claim name of the default group-membership mapper.
+	// Nested claims can be addressed with dots, e.g. "resource_access.app.groups".
+	// Defaults to "groups".
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+
+	// EmailDomainClaim names the token claim holding the user's email domain,
+	// used by the gateway authorization rule built from allowedEmailDomains.
+	// Required with allowedEmailDomains; no claim is assumed, since IdPs do
+	// not emit one by default.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	EmailDomainClaim string `json:"emailDomainClaim,omitempty"`
+
 	// ProvisionClient determines whether the operator should automatically provision
 	// an OIDC client in the provider. When true, the operator will create a client
 	// (e.g., in Keycloak) and store the credentials in a Secret.
@@ -245,6 +766,27 @@ type AuthConfig struct {
 	// +optional
 	ForwardAccessToken *bool `json:"forwardAccessToken,omitempty"`
 
+	// ExtraAuthParams adds query parameters to the authorization request the
+	// gateway sends users to, for IdPs that need extra hints such as
+	// prompt=login or access_type=offline. Parameters the OIDC filter sets
+	// itself (client_id, redirect_uri, scope, state, ...) cannot be overridden.
+	// Requires the provider to supply an explicit authorization endpoint
+	// (keycloak with KEYCLOAK_EXTERNAL_URL set).
+	// Only applies when enforceAtGateway is true.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=16
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k != '' && self[k] != '')",message="extraAuthParams keys and values must be non-empty"
+	ExtraAuthParams map[string]string `json:"extraAuthParams,omitempty"`
+
+	// AutoExemptProbes serves the probe paths listed in the backend Service's
+	// nebari.dev/probe-paths annotation (comma-separated) without
+	// authentication, as Exact public routes added to routing.publicRoutes, so
+	// external health checks are not redirected to the login page. The root
+	// path "/" and invalid paths are ignored.
+	// Requires routing and a Service backend.
+	// +optional
+	AutoExemptProbes bool `json:"autoExemptProbes,omitempty"`
+
 	// DenyRedirect configures headers that, when matched, prevent the OIDC filter
 	// from redirecting to the identity provider. Instead, matching requests receive
 	// a 401 response. This prevents PKCE race conditions when SPAs fire multiple
@@ -255,6 +797,48 @@ type AuthConfig struct {
 	// +optional
 	DenyRedirect []DenyRedirectHeader `json:"denyRedirect,omitempty"`
 
+	// AuthenticatedRequestHeaders lists static headers added to requests that
+	// passed gateway authentication before they reach the backend, e.g.
+	// "X-Authenticated: true". Existing headers with the same name are
+	// overwritten, so clients cannot spoof them. Public routes are not
+	// authenticated and never receive these headers. Cannot be combined with
+	// optional, which lets anonymous requests through the same routes.
+	// Only applies when enforceAtGateway is true.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	AuthenticatedRequestHeaders []HeaderValue `json:"authenticatedRequestHeaders,omitempty"`
+
+	// JWT lets API clients authenticate with a bearer JWT instead of the browser
+	// OIDC flow. When enabled, the SecurityPolicy carries a JWT provider next to
+	// OIDC, and requests with a valid "Authorization: Bearer" header skip the
+	// login redirect. Requests without a bearer token still go through OIDC.
+	// Only applies when enforceAtGateway is true.
+	// +optional
+	JWT *JWTAuthConfig `json:"jwt,omitempty"`
+
+	// BearerOnly marks the app as an API resource server that only validates
+	// tokens and never logs users in through the browser. The provisioned
+	// Keycloak client is bearer-only and has no redirect URIs, and the
+	// SecurityPolicy validates bearer JWTs without an OIDC login flow; jwt
+	// settings (jwksURI, audiences) still apply. Cannot be combined with
+	// options of the browser flow such as redirectURI, pkce, logout or
+	// stepUpScopes.
+	// +optional
+	BearerOnly bool `json:"bearerOnly,omitempty"`
+
+	// Optional authenticates requests that carry a login session or a bearer
+	// token without forcing anonymous users to log in. The gateway validates
+	// the token when one is present, rejecting an invalid or expired one with
+	// 401, and forwards it to the app in the Authorization header or the
+	// session's access token cookie; requests without a token reach the app
+	// anonymously. Users start a session at /oauth2/login, which returns them
+	// to the app's root. Requires enforceAtGateway and a JWKS endpoint (the
+	// provider's, or jwt.jwksURI). Cannot be combined with bearerOnly,
+	// enforceGroupsAtGateway or allowedEmailDomains, which would deny anonymous
+	// requests, or with authenticatedRequestHeaders.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+
 	// IssuerURL specifies the OIDC issuer URL for generic-oidc provider.
 	// Required when provider="generic-oidc", ignored for other providers.
 	// Example: https://accounts.google.com, https://login.microsoftonline.com/<tenant>/v2.0
@@ -273,6 +857,15 @@ type AuthConfig struct {
 	// +optional
 	SPAClient *SPAClientConfig `json:"spaClient,omitempty"`
 
+	// CreateAppGroup makes the operator create a Keycloak group named
+	// "<namespace>-<name>" when provisioning the client, and map it to an
+	// "access" client role on the app's client, so admins can grant access to
+	// the app by adding users to the group. The group is deleted together with
+	// the client when the NebariApp is deleted.
+	// Only supported for provider="keycloak" with provisionClient enabled.
+	// +optional
+	CreateAppGroup bool `json:"createAppGroup,omitempty"`
+
 	// DeviceFlowClient configures a public OIDC client for CLI/native app authentication
 	// using the OAuth2 Device Authorization Grant (RFC 8628).
 	// When enabled, the operator provisions a separate public client configured for device flow.
@@ -281,6 +874,17 @@ type AuthConfig struct {
 	// +optional
 	DeviceFlowClient *DeviceFlowClientConfig `json:"deviceFlowClient,omitempty"`
 
+	// ClientAttributes sets extra attributes on the provisioned Keycloak client,
+	// such as login_theme or display.on.consent.screen. Attributes the operator
+	// manages (post.logout.redirect.uris and the front- and back-channel logout
+	// settings) cannot be set here. Removing an entry leaves the attribute on the
+	// client as it was.
+	// Only supported for provider="keycloak" with provisionClient enabled.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=32
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k != '')",message="clientAttributes keys must be non-empty"
+	ClientAttributes map[string]string `json:"clientAttributes,omitempty"`
+
 	// KeycloakConfig provides Keycloak-specific configuration for fine-grained control
 	// over realm resources like groups, client scopes, and protocol mappers.
 	// Only used when provider="keycloak" and provisionClient=true; silently ignored
@@ -360,6 +964,32 @@ type KeycloakProtocolMapperConfig struct {
 	Config map[string]string `json:"config,omitempty"`
 }
 
+// LogoutConfig configures single logout between the app and the IdP.
+type LogoutConfig struct {
+	// EndSSOSession sets the IdP's end session endpoint on the SecurityPolicy,
+	// so logging out through the gateway sends the browser there with the
+	// session's id_token_hint (RP-initiated logout), ending the SSO session so
+	// the next login prompts for credentials.
+	// Requires the provider to supply an explicit end session endpoint (keycloak
+	// with KEYCLOAK_EXTERNAL_URL set) when enforceAtGateway is true.
+	// +optional
+	EndSSOSession bool `json:"endSSOSession,omitempty"`
+
+	// FrontChannel registers the gateway's /logout path as the client's
+	// front-channel logout URL, so Keycloak clears the app's session in the
+	// browser when the SSO session ends elsewhere. Requires provisionClient.
+	// +optional
+	FrontChannel bool `json:"frontChannel,omitempty"`
+
+	// BackchannelLogoutURI is registered as the client's back-channel logout URL.
+	// Keycloak POSTs a logout token to it when the SSO session ends, so the app
+	// must implement the endpoint itself. Either an absolute http(s) URL or a
+	// path relative to the app's root. Requires provisionClient.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(https?://[^/\s]+(/\S*)?|/([^/\s]\S*)?)$`
+	BackchannelLogoutURI string `json:"backchannelLogoutURI,omitempty"`
+}
+
 // SPAClientConfig specifies configuration for provisioning a public OIDC client
 // for Single-Page Applications (SPA) that use browser-based authentication with PKCE.
 // This client is separate from the confidential client used by oauth2-proxy or similar
@@ -419,6 +1049,43 @@ type DenyRedirectHeader struct {
 	Value string `json:"value"`
 }
 
+// JWTAuthConfig configures bearer JWT validation alongside the OIDC browser flow.
+type JWTAuthConfig struct {
+	// Enabled turns on bearer JWT validation.
+	// +kubebuilder:default=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// JWKSURI is the URL of the JSON Web Key Set used to verify token signatures.
+	// Defaults to the provider's key set for keycloak; required for generic-oidc.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	JWKSURI string `json:"jwksURI,omitempty"`
+
+	// Audiences restricts accepted tokens to those carrying one of these audiences.
+	// A token is accepted when its aud claim contains at least one of them, so
+	// tokens issued for a shared IdP client with several audiences validate as
+	// long as one matches. When empty, the audience claim is not checked.
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// HeaderValue is a static HTTP header name and value.
+type HeaderValue struct {
+	// Name is the header name.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+\-.^_\x60|~]+$`
+	Name string `json:"name"`
+
+	// Value is the header value.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=4096
+	Value string `json:"value"`
+}
+
 // LandingPageConfig defines how a service appears on the Nebari landing page.
 type LandingPageConfig struct {
 	// Enabled determines if this service appears on the landing page.
@@ -555,6 +1222,7 @@ type NebariAppStatus struct {
 	//   - "RoutingReady": HTTPRoute has been created and is functioning
 	//   - "TLSReady": TLS certificate is available and configured
 	//   - "AuthReady": Authentication policy is configured (if auth is enabled)
+	//   - "ConnectivityReady": The app answered through its Gateway (if the connectivity probe is enabled)
 	//   - "Ready": All components are ready (aggregate condition)
 	// +listType=map
 	// +listMapKey=type
@@ -589,12 +1257,60 @@ type NebariAppStatus struct {
 	// +optional
 	AuthConfigHash string `json:"authConfigHash,omitempty"`
 
+	// ClientProvisioned records that the operator provisioned the OIDC client for
+	// this NebariApp. When provisionClient is later set to false, the operator
+	// deletes that client and its Secret once and clears this field, so the app
+	// switches to the user-supplied Secret.
+	// +optional
+	ClientProvisioned bool `json:"clientProvisioned,omitempty"`
+
+	// IssuerURL is the OIDC issuer URL configured on the gateway SecurityPolicy.
+	// This is the provider's external issuer when the policy carries explicit
+	// authorization and token endpoints (so Envoy skips discovery), and the
+	// in-cluster issuer Envoy fetches discovery metadata from otherwise. Empty
+	// when auth is disabled or not enforced at the gateway.
+	// +optional
+	IssuerURL string `json:"issuerURL,omitempty"`
+
+	// LastReconcileTime is when the controller last finished reconciling this
+	// NebariApp and wrote its status, whether or not the reconcile succeeded.
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// ReconcileDuration is how long the last reconcile took, from fetching the
+	// NebariApp to writing its status.
+	// +optional
+	ReconcileDuration *metav1.Duration `json:"reconcileDuration,omitempty"`
+
 	// ServiceDiscovery is the computed service discovery descriptor.
 	// The controller populates this after reconciling spec.landingPage so the
 	// webapi watcher can consume a pre-validated, URL-resolved view via
 	// status.serviceDiscovery.* without re-deriving it from spec.
 	// +optional
 	ServiceDiscovery *ServiceDiscoveryStatus `json:"serviceDiscovery,omitempty"`
+
+	// RecentEvents lists the most recent events the operator recorded for this
+	// NebariApp, oldest first, so recent activity shows up without a separate
+	// events query. Repeats of the latest event bump its count and time instead
+	// of adding an entry. Capped at 10 entries.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	RecentEvents []RecentEvent `json:"recentEvents,omitempty"`
+}
+
+// RecentEvent summarises an event recorded for a NebariApp.
+type RecentEvent struct {
+	// Type is the event type, Normal or Warning.
+	Type string `json:"type"`
+
+	// Reason is the event reason, e.g. HTTPRouteCreated.
+	Reason string `json:"reason"`
+
+	// Count is how many times the event was recorded in a row.
+	Count int32 `json:"count"`
+
+	// LastTimestamp is when the event was last recorded.
+	LastTimestamp metav1.Time `json:"lastTimestamp"`
 }
 
 // GatewayReference identifies a Gateway resource.
@@ -630,6 +1346,11 @@ const (
 	// This includes the SecurityPolicy being created and the client secret being available.
 	ConditionTypeAuthReady = "AuthReady"
 
+	// ConditionTypeConnectivityReady indicates that an in-cluster request to the
+	// app's hostname through the Gateway succeeded. Only set when the app enables
+	// routing.connectivityProbe and the operator allows probing.
+	ConditionTypeConnectivityReady = "ConnectivityReady"
+
 	// ConditionTypeReady is an aggregate condition indicating all components are ready.
 	ConditionTypeReady = "Ready"
 )
@@ -651,12 +1372,65 @@ const (
 	// ReasonFailed indicates reconciliation failed
 	ReasonFailed = "Failed"
 
+	// ReasonDisabled indicates spec.enabled is false and the app's managed resources were removed
+	ReasonDisabled = "Disabled"
+
+	// ReasonDependencyNotReady indicates a sub-condition that gates Ready is not True
+	ReasonDependencyNotReady = "DependencyNotReady"
+
 	// ReasonNamespaceNotOptedIn indicates the namespace doesn't have the required label
 	ReasonNamespaceNotOptedIn = "NamespaceNotOptedIn"
 
+	// ReasonProtectedNamespace indicates the NebariApp lives in a namespace the
+	// operator refuses to manage, such as kube-system
+	ReasonProtectedNamespace = "ProtectedNamespace"
+
 	// ReasonServiceNotFound indicates the referenced service doesn't exist
 	ReasonServiceNotFound = "ServiceNotFound"
 
+	// ReasonInvalidRoutes indicates routing.routes or routing.publicRoutes contain
+	// an invalid or conflicting combination of entries
+	ReasonInvalidRoutes = "InvalidRoutes"
+
+	// ReasonConflictingRoutingOptions indicates the routing spec sets options that
+	// are mutually exclusive, such as redirect and backends on the same route
+	ReasonConflictingRoutingOptions = "ConflictingRoutingOptions"
+
+	// ReasonDuplicateRoutes indicates routing.routes or routing.publicRoutes list
+	// the same pathPrefix and pathType more than once
+	ReasonDuplicateRoutes = "DuplicateRoutes"
+
+	// ReasonPathConflict indicates another NebariApp on the same hostname and
+	// Gateway already routes one of this app's paths
+	ReasonPathConflict = "PathConflict"
+
+	// ReasonTooManyRoutes indicates routing.routes has more entries than the
+	// operator's configured per-app limit
+	ReasonTooManyRoutes = "TooManyRoutes"
+
+	// ReasonAwaitingGatewayAcceptance indicates the HTTPRoutes are configured
+	// but a Gateway has not yet accepted their current generation
+	ReasonAwaitingGatewayAcceptance = "AwaitingGatewayAcceptance"
+
+	// ReasonHTTPRouteNotAccepted indicates a Gateway reported one of the app's
+	// HTTPRoutes as not accepted
+	ReasonHTTPRouteNotAccepted = "HTTPRouteNotAccepted"
+
+	// ReasonUnsupportedBackendKind indicates a service reference names a
+	// group/kind pair the operator does not route to
+	ReasonUnsupportedBackendKind = "UnsupportedBackendKind"
+
+	// ReasonInvalidDNSTarget indicates routing.dnsTarget is neither a hostname
+	// nor an IP address
+	ReasonInvalidDNSTarget = "InvalidDNSTarget"
+
+	// ReasonConnectivityProbeSucceeded indicates the connectivity probe got a 2xx or 3xx response
+	ReasonConnectivityProbeSucceeded = "ConnectivityProbeSucceeded"
+
+	// ReasonConnectivityProbeFailed indicates the connectivity probe could not reach the app
+	// or got a response outside 2xx/3xx
+	ReasonConnectivityProbeFailed = "ConnectivityProbeFailed"
+
 	// ReasonSecretNotFound indicates the referenced secret doesn't exist
 	ReasonSecretNotFound = "SecretNotFound"
 
@@ -683,6 +1457,82 @@ const (
 	// UserProvidedSecretNotFound so operators can tell "the secret is missing" apart from "we could
 	// not tell whether the secret is missing".
 	ReasonUserProvidedSecretCheckFailed = "UserProvidedSecretCheckFailed"
+
+	// ReasonGatewayListenerReady indicates the listener named in routing.tls.gatewayRef is programmed.
+	ReasonGatewayListenerReady = "GatewayListenerReady"
+
+	// ReasonGatewayListenerNotReady indicates the listener named in routing.tls.gatewayRef exists
+	// but the Gateway has not reported it as programmed yet.
+	ReasonGatewayListenerNotReady = "GatewayListenerNotReady"
+
+	// ReasonGatewayListenerNotFound indicates routing.tls.gatewayRef names a Gateway the app is not
+	// exposed on, a Gateway that does not exist, or a listener the Gateway does not have.
+	ReasonGatewayListenerNotFound = "GatewayListenerNotFound"
+
+	// ReasonGatewayListenerNotHTTPS indicates the listener named in routing.tls.gatewayRef is not
+	// an HTTPS listener.
+	ReasonGatewayListenerNotHTTPS = "GatewayListenerNotHTTPS"
+)
+
+// Condition reasons set on the AuthReady condition. These values are part of the
+// public contract (alerts match on them) and must not change.
+const (
+	// ReasonAuthDisabled indicates authentication is not enabled for the app
+	ReasonAuthDisabled = "AuthDisabled"
+
+	// ReasonAuthConfigured indicates authentication is fully configured
+	ReasonAuthConfigured = "AuthConfigured"
+
+	// ReasonAuthDegraded indicates authentication works (AuthReady=True) but some
+	// requested scopes could not be provisioned on the OIDC client
+	ReasonAuthDegraded = "AuthDegraded"
+
+	// ReasonInvalidProvider indicates the configured OIDC provider is unknown or not configured
+	ReasonInvalidProvider = "InvalidProvider"
+
+	// ReasonProvisioningNotSupported indicates the provider cannot provision OIDC clients
+	ReasonProvisioningNotSupported = "ProvisioningNotSupported"
+
+	// ReasonProvisioningFailed indicates OIDC client provisioning failed
+	ReasonProvisioningFailed = "ProvisioningFailed"
+
+	// ReasonRBACFailed indicates the Role/RoleBinding for the client secret could not be reconciled
+	ReasonRBACFailed = "RBACFailed"
+
+	// ReasonTokenExchangeFailed indicates token exchange configuration failed
+	ReasonTokenExchangeFailed = "TokenExchangeFailed"
+
+	// ReasonAuthValidationFailed indicates the auth configuration failed validation
+	ReasonAuthValidationFailed = "ValidationFailed"
+
+	// ReasonScopeNotAllowed indicates auth.scopes or auth.stepUpScopes requests a
+	// scope outside the operator's allowlist
+	ReasonScopeNotAllowed = "ScopeNotAllowed"
+
+	// ReasonSecurityPolicyFailed indicates the SecurityPolicy could not be created or updated
+	ReasonSecurityPolicyFailed = "SecurityPolicyFailed"
+
+	// ReasonSecurityPolicyCleanupFailed indicates a stale SecurityPolicy could not be deleted
+	ReasonSecurityPolicyCleanupFailed = "SecurityPolicyCleanupFailed"
+
+	// ReasonSecurityPolicyConflict indicates another SecurityPolicy targets the app's HTTPRoutes
+	ReasonSecurityPolicyConflict = "SecurityPolicyConflict"
+
+	// ReasonIssuerUnreachable indicates the issuer's OIDC discovery document could not be fetched
+	ReasonIssuerUnreachable = "IssuerUnreachable"
+
+	// ReasonClientCleanupFailed indicates the OIDC client the operator provisioned could not be
+	// removed after provisionClient was turned off
+	ReasonClientCleanupFailed = "ClientCleanupFailed"
+
+	// ReasonProviderAuthFailed indicates the OIDC provider rejected the operator's admin credentials
+	ReasonProviderAuthFailed = "ProviderAuthFailed"
+
+	// ReasonClientNotFound indicates the OIDC client an operation needed does not exist in the provider
+	ReasonClientNotFound = "ClientNotFound"
+
+	// ReasonProviderUnreachable indicates the OIDC provider could not be reached or returned a server error
+	ReasonProviderUnreachable = "ProviderUnreachable"
 )
 
 // Event reasons for recording Kubernetes events
@@ -696,9 +1546,24 @@ const (
 	// EventReasonNamespaceNotOptIn is used when namespace is not opted-in
 	EventReasonNamespaceNotOptIn = "NamespaceNotOptedIn"
 
+	// EventReasonProtectedNamespace is used when the NebariApp is in a protected namespace
+	EventReasonProtectedNamespace = "ProtectedNamespace"
+
 	// EventReasonServiceNotFound is used when referenced service doesn't exist
 	EventReasonServiceNotFound = "ServiceNotFound"
 
+	// EventReasonServiceStubCreated is used when a placeholder Service is created
+	// for a missing spec.service
+	EventReasonServiceStubCreated = "ServiceStubCreated"
+
+	// EventReasonDraining is used when a deleted NebariApp's HTTPRoutes are kept
+	// serving for routing.deletionGracePeriod before cleanup
+	EventReasonDraining = "Draining"
+
+	// EventReasonDisabled is used when spec.enabled is set to false and the app's
+	// managed resources are removed
+	EventReasonDisabled = "Disabled"
+
 	// EventReasonHTTPRouteCreated is used when HTTPRoute is created
 	EventReasonHTTPRouteCreated = "HTTPRouteCreated"
 
@@ -765,6 +1630,72 @@ const (
 	// EventReasonUserProvidedSecretCheckFailed is used when the operator could not determine the state
 	// of a user-provided TLS secret (for example, a transient API error).
 	EventReasonUserProvidedSecretCheckFailed = "UserProvidedSecretCheckFailed"
+
+	// EventReasonAmbiguousAdminSecret is used when the Keycloak admin secret sets both
+	// key formats for a credential (e.g. username and admin-username) with different values.
+	EventReasonAmbiguousAdminSecret = "AmbiguousAdminSecret"
+
+	// EventReasonClientSecretResynced is used when the stored OIDC client secret no longer
+	// matched the provider's copy and was rewritten from it.
+	EventReasonClientSecretResynced = "ClientSecretResynced"
+
+	// EventReasonClientSecretInvalid is used when provisionClient is false and the OIDC client
+	// secret the app relies on is missing or lacks the client-secret key.
+	EventReasonClientSecretInvalid = "ClientSecretInvalid"
+
+	// EventReasonScopesDegraded is used when some requested scopes could not be provisioned on the OIDC client
+	EventReasonScopesDegraded = "ScopesDegraded"
+
+	// EventReasonScopesUnknown is used when requested scopes are not defined by the OIDC provider
+	EventReasonScopesUnknown = "ScopesUnknown"
+
+	// EventReasonGroupsScopeAdded is used when the groups scope is added to the requested scopes
+	// because auth.groups is set
+	EventReasonGroupsScopeAdded = "GroupsScopeAdded"
+
+	// EventReasonSecurityPolicyConflict is used when another SecurityPolicy targets the app's HTTPRoutes
+	EventReasonSecurityPolicyConflict = "SecurityPolicyConflict"
+
+	// EventReasonIssuerUnreachable is used when the issuer's OIDC discovery document could not be fetched
+	EventReasonIssuerUnreachable = "IssuerUnreachable"
+
+	// EventReasonClientDeprovisioned is used when provisionClient was turned off and the OIDC client
+	// the operator had provisioned was deleted
+	EventReasonClientDeprovisioned = "ClientDeprovisioned"
+
+	// EventReasonRedirectURIDropped is used when redirect URIs were left off the provisioned OIDC client
+	// because their scheme is not in ALLOWED_REDIRECT_SCHEMES
+	EventReasonRedirectURIDropped = "RedirectURIDropped"
+
+	// EventReasonProbePathsIgnored is used when entries in the backend Service's
+	// nebari.dev/probe-paths annotation are not valid probe paths
+	EventReasonProbePathsIgnored = "ProbePathsIgnored"
+
+	// EventReasonClientTrafficPolicyCreated is used when the ClientTrafficPolicy for client timeouts is created
+	EventReasonClientTrafficPolicyCreated = "ClientTrafficPolicyCreated"
+
+	// EventReasonClientTrafficPolicyUpdated is used when the ClientTrafficPolicy for client timeouts is updated
+	EventReasonClientTrafficPolicyUpdated = "ClientTrafficPolicyUpdated"
+
+	// EventReasonClientTrafficPolicyDeleted is used when the ClientTrafficPolicy for client timeouts is deleted
+	EventReasonClientTrafficPolicyDeleted = "ClientTrafficPolicyDeleted"
+
+	// EventReasonClientTimeoutsNotApplied is used when routing.clientTimeouts is set but the app
+	// has no per-app listener the ClientTrafficPolicy could attach to.
+	EventReasonClientTimeoutsNotApplied = "ClientTimeoutsNotApplied"
+
+	// EventReasonRateLimitPolicyCreated is used when the BackendTrafficPolicy for rate limiting is created
+	EventReasonRateLimitPolicyCreated = "RateLimitPolicyCreated"
+
+	// EventReasonRateLimitPolicyUpdated is used when the BackendTrafficPolicy for rate limiting is updated
+	EventReasonRateLimitPolicyUpdated = "RateLimitPolicyUpdated"
+
+	// EventReasonRateLimitPolicyDeleted is used when the BackendTrafficPolicy for rate limiting is deleted
+	EventReasonRateLimitPolicyDeleted = "RateLimitPolicyDeleted"
+
+	// EventReasonRateLimitNotApplied is used when routing.rateLimit.byClaim is set but the
+	// gateway does not validate bearer JWTs for the app, so no claim header is available.
+	EventReasonRateLimitNotApplied = "RateLimitNotApplied"
 )
 
 // +kubebuilder:object:root=true
@@ -28,21 +28,51 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthConfig) DeepCopyInto(out *AuthConfig) {
 	*out = *in
+	if in.Logout != nil {
+		in, out := &in.Logout, &out.Logout
+		*out = new(LogoutConfig)
+		**out = **in
+	}
+	if in.AdditionalWebOrigins != nil {
+		in, out := &in.AdditionalWebOrigins, &out.AdditionalWebOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ClientSecretRef != nil {
 		in, out := &in.ClientSecretRef, &out.ClientSecretRef
 		*out = new(string)
 		**out = **in
 	}
+	if in.ClientTLSSecretRef != nil {
+		in, out := &in.ClientTLSSecretRef, &out.ClientTLSSecretRef
+		*out = new(string)
+		**out = **in
+	}
 	if in.Scopes != nil {
 		in, out := &in.Scopes, &out.Scopes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.StepUpScopes != nil {
+		in, out := &in.StepUpScopes, &out.StepUpScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StepUpPaths != nil {
+		in, out := &in.StepUpPaths, &out.StepUpPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Groups != nil {
 		in, out := &in.Groups, &out.Groups
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowedEmailDomains != nil {
+		in, out := &in.AllowedEmailDomains, &out.AllowedEmailDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ProvisionClient != nil {
 		in, out := &in.ProvisionClient, &out.ProvisionClient
 		*out = new(bool)
@@ -58,11 +88,28 @@ func (in *AuthConfig) DeepCopyInto(out *AuthConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ExtraAuthParams != nil {
+		in, out := &in.ExtraAuthParams, &out.ExtraAuthParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.DenyRedirect != nil {
 		in, out := &in.DenyRedirect, &out.DenyRedirect
 		*out = make([]DenyRedirectHeader, len(*in))
 		copy(*out, *in)
 	}
+	if in.AuthenticatedRequestHeaders != nil {
+		in, out := &in.AuthenticatedRequestHeaders, &out.AuthenticatedRequestHeaders
+		*out = make([]HeaderValue, len(*in))
+		copy(*out, *in)
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWTAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SPAClient != nil {
 		in, out := &in.SPAClient, &out.SPAClient
 		*out = new(SPAClientConfig)
@@ -73,6 +120,13 @@ func (in *AuthConfig) DeepCopyInto(out *AuthConfig) {
 		*out = new(DeviceFlowClientConfig)
 		**out = **in
 	}
+	if in.ClientAttributes != nil {
+		in, out := &in.ClientAttributes, &out.ClientAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.KeycloakConfig != nil {
 		in, out := &in.KeycloakConfig, &out.KeycloakConfig
 		*out = new(KeycloakClientConfig)
@@ -95,6 +149,36 @@ func (in *AuthConfig) DeepCopy() *AuthConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientTimeoutsConfig) DeepCopyInto(out *ClientTimeoutsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientTimeoutsConfig.
+func (in *ClientTimeoutsConfig) DeepCopy() *ClientTimeoutsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientTimeoutsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityProbeConfig) DeepCopyInto(out *ConnectivityProbeConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityProbeConfig.
+func (in *ConnectivityProbeConfig) DeepCopy() *ConnectivityProbeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityProbeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DenyRedirectHeader) DeepCopyInto(out *DenyRedirectHeader) {
 	*out = *in
@@ -125,6 +209,41 @@ func (in *DeviceFlowClientConfig) DeepCopy() *DeviceFlowClientConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayHeaderRoute) DeepCopyInto(out *GatewayHeaderRoute) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HeaderMatch, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayHeaderRoute.
+func (in *GatewayHeaderRoute) DeepCopy() *GatewayHeaderRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayHeaderRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayListenerRef) DeepCopyInto(out *GatewayListenerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayListenerRef.
+func (in *GatewayListenerRef) DeepCopy() *GatewayListenerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayListenerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayReference) DeepCopyInto(out *GatewayReference) {
 	*out = *in
@@ -140,6 +259,36 @@ func (in *GatewayReference) DeepCopy() *GatewayReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderMatch) DeepCopyInto(out *HeaderMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderMatch.
+func (in *HeaderMatch) DeepCopy() *HeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderValue) DeepCopyInto(out *HeaderValue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderValue.
+func (in *HeaderValue) DeepCopy() *HeaderValue {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheckConfig) DeepCopyInto(out *HealthCheckConfig) {
 	*out = *in
@@ -170,6 +319,26 @@ func (in *HealthCheckConfig) DeepCopy() *HealthCheckConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTAuthConfig) DeepCopyInto(out *JWTAuthConfig) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTAuthConfig.
+func (in *JWTAuthConfig) DeepCopy() *JWTAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakClientConfig) DeepCopyInto(out *KeycloakClientConfig) {
 	*out = *in
@@ -266,6 +435,21 @@ func (in *LandingPageConfig) DeepCopy() *LandingPageConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogoutConfig) DeepCopyInto(out *LogoutConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogoutConfig.
+func (in *LogoutConfig) DeepCopy() *LogoutConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LogoutConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NebariApp) DeepCopyInto(out *NebariApp) {
 	*out = *in
@@ -328,7 +512,12 @@ func (in *NebariAppList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NebariAppSpec) DeepCopyInto(out *NebariAppSpec) {
 	*out = *in
-	out.Service = in.Service
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Routing != nil {
 		in, out := &in.Routing, &out.Routing
 		*out = new(RoutingConfig)
@@ -339,6 +528,11 @@ func (in *NebariAppSpec) DeepCopyInto(out *NebariAppSpec) {
 		*out = new(AuthConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.LandingPage != nil {
 		in, out := &in.LandingPage, &out.LandingPage
 		*out = new(LandingPageConfig)
@@ -376,11 +570,27 @@ func (in *NebariAppStatus) DeepCopyInto(out *NebariAppStatus) {
 		*out = new(ResourceReference)
 		**out = **in
 	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ReconcileDuration != nil {
+		in, out := &in.ReconcileDuration, &out.ReconcileDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.ServiceDiscovery != nil {
 		in, out := &in.ServiceDiscovery, &out.ServiceDiscovery
 		*out = new(ServiceDiscoveryStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RecentEvents != nil {
+		in, out := &in.RecentEvents, &out.RecentEvents
+		*out = make([]RecentEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NebariAppStatus.
@@ -393,6 +603,37 @@ func (in *NebariAppStatus) DeepCopy() *NebariAppStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitConfig) DeepCopyInto(out *RateLimitConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitConfig.
+func (in *RateLimitConfig) DeepCopy() *RateLimitConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecentEvent) DeepCopyInto(out *RecentEvent) {
+	*out = *in
+	in.LastTimestamp.DeepCopyInto(&out.LastTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecentEvent.
+func (in *RecentEvent) DeepCopy() *RecentEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(RecentEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
 	*out = *in
@@ -408,9 +649,53 @@ func (in *ResourceReference) DeepCopy() *ResourceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteExperiment) DeepCopyInto(out *RouteExperiment) {
+	*out = *in
+	in.Primary.DeepCopyInto(&out.Primary)
+	in.Mirror.DeepCopyInto(&out.Mirror)
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteExperiment.
+func (in *RouteExperiment) DeepCopy() *RouteExperiment {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteExperiment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteMatch) DeepCopyInto(out *RouteMatch) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HeaderMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.Redirect != nil {
+		in, out := &in.Redirect, &out.Redirect
+		*out = new(RouteRedirect)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]WeightedBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Experiment != nil {
+		in, out := &in.Experiment, &out.Experiment
+		*out = new(RouteExperiment)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMatch.
@@ -423,18 +708,52 @@ func (in *RouteMatch) DeepCopy() *RouteMatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteRedirect) DeepCopyInto(out *RouteRedirect) {
+	*out = *in
+	if in.Scheme != nil {
+		in, out := &in.Scheme, &out.Scheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRedirect.
+func (in *RouteRedirect) DeepCopy() *RouteRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoutingConfig) DeepCopyInto(out *RoutingConfig) {
 	*out = *in
 	if in.Routes != nil {
 		in, out := &in.Routes, &out.Routes
 		*out = make([]RouteMatch, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.PublicRoutes != nil {
 		in, out := &in.PublicRoutes, &out.PublicRoutes
 		*out = make([]RouteMatch, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GatewayPort != nil {
+		in, out := &in.GatewayPort, &out.GatewayPort
+		*out = new(int32)
+		**out = **in
 	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
@@ -448,6 +767,33 @@ func (in *RoutingConfig) DeepCopyInto(out *RoutingConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.ClientTimeouts != nil {
+		in, out := &in.ClientTimeouts, &out.ClientTimeouts
+		*out = new(ClientTimeoutsConfig)
+		**out = **in
+	}
+	if in.ConnectivityProbe != nil {
+		in, out := &in.ConnectivityProbe, &out.ConnectivityProbe
+		*out = new(ConnectivityProbeConfig)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimitConfig)
+		**out = **in
+	}
+	if in.DefaultResponseHeaders != nil {
+		in, out := &in.DefaultResponseHeaders, &out.DefaultResponseHeaders
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GatewayRouting != nil {
+		in, out := &in.GatewayRouting, &out.GatewayRouting
+		*out = make([]GatewayHeaderRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingConfig.
@@ -468,6 +814,11 @@ func (in *RoutingTLSConfig) DeepCopyInto(out *RoutingTLSConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.GatewayRef != nil {
+		in, out := &in.GatewayRef, &out.GatewayRef
+		*out = new(GatewayListenerRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingTLSConfig.
@@ -518,6 +869,11 @@ func (in *ServiceDiscoveryStatus) DeepCopy() *ServiceDiscoveryStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
 	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
@@ -544,3 +900,23 @@ func (in *TokenExchangeConfig) DeepCopy() *TokenExchangeConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeightedBackend) DeepCopyInto(out *WeightedBackend) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeightedBackend.
+func (in *WeightedBackend) DeepCopy() *WeightedBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(WeightedBackend)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -27,6 +29,8 @@ import (
 
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -47,6 +51,10 @@ import (
 	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/routing"
 	tlsreconciler "github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/tls"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/events"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/metrics"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/tracing"
+	webhookv1 "github.com/nebari-dev/nebari-operator/internal/webhook/v1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -191,6 +199,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Install an OTLP tracer provider when tracing is enabled. Without it the
+	// reconcile spans go to the global no-op provider.
+	var tracerProvider *sdktrace.TracerProvider
+	tracingConfig := config.LoadTracingConfig()
+	if tracingConfig.Enabled {
+		tracerProvider, err = tracing.NewProvider(context.Background())
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		otel.SetTracerProvider(tracerProvider)
+		setupLog.Info("OpenTelemetry tracing enabled")
+	}
+
+	// The controller configuration includes the managed-by label value every
+	// reconciler stamps on the resources it creates.
+	controllerConfig := config.LoadControllerConfig()
+
 	// Load authentication configuration
 	authConfig := config.LoadAuthConfig()
 
@@ -207,12 +233,24 @@ func main() {
 			"issuerServiceName", authConfig.Keycloak.IssuerServiceName,
 			"issuerServiceNamespace", authConfig.Keycloak.IssuerServiceNamespace,
 			"issuerServicePort", authConfig.Keycloak.IssuerServicePort,
-			"issuerContextPath", authConfig.Keycloak.IssuerContextPath)
+			"issuerContextPath", authConfig.Keycloak.IssuerContextPath,
+			"allowedRedirectSchemes", authConfig.AllowedRedirectSchemes,
+			"clientSecretLength", authConfig.Keycloak.ClientSecretLength,
+			"clientSecretEncoding", authConfig.Keycloak.ClientSecretEncoding)
+
+		// Refuse to provision clients with weak or oddly encoded secrets
+		if err := authConfig.Keycloak.ValidateClientSecretSettings(); err != nil {
+			setupLog.Error(err, "invalid Keycloak client secret settings")
+			os.Exit(1)
+		}
 
 		// Initialize provider with config - credentials will be loaded from secret when needed
 		keycloakProvider := &providers.KeycloakProvider{
-			Client: mgr.GetClient(),
-			Config: authConfig.Keycloak,
+			Client:                 mgr.GetClient(),
+			Config:                 authConfig.Keycloak,
+			Recorder:               events.NewRecorder(mgr.GetEventRecorderFor("nebariapp-keycloak")),
+			ManagedBy:              controllerConfig.ManagedBy,
+			AllowedRedirectSchemes: authConfig.AllowedRedirectSchemes,
 		}
 		oidcProviders[constants.ProviderKeycloak] = keycloakProvider
 
@@ -240,13 +278,32 @@ func main() {
 	oidcProviders[constants.ProviderGenericOIDC] = genericProvider
 	setupLog.Info("Generic OIDC provider initialized")
 
+	// The default provider applies to every NebariApp without spec.auth.provider,
+	// so refuse to start rather than fail each of those apps at reconcile time.
+	if _, ok := oidcProviders[authConfig.DefaultProvider]; !ok {
+		setupLog.Error(fmt.Errorf("provider %q is not registered", authConfig.DefaultProvider),
+			"invalid DEFAULT_AUTH_PROVIDER; set it to an enabled provider (keycloak requires KEYCLOAK_ENABLED=true)")
+		os.Exit(1)
+	}
+	setupLog.Info("Default OIDC provider configured", "provider", authConfig.DefaultProvider)
+
 	// Initialize auth reconciler
 	authReconciler := &auth.AuthReconciler{
-		Client:    mgr.GetClient(),
-		Scheme:    mgr.GetScheme(),
-		Recorder:  mgr.GetEventRecorderFor("nebariapp-auth"),
-		Providers: oidcProviders,
+		Client:                 mgr.GetClient(),
+		Scheme:                 mgr.GetScheme(),
+		Recorder:               events.NewRecorder(mgr.GetEventRecorderFor("nebariapp-auth")),
+		Providers:              oidcProviders,
+		DefaultProvider:        authConfig.DefaultProvider,
+		IssuerPreflightEnabled: authConfig.IssuerPreflightEnabled,
+		AllowedScopes:          authConfig.AllowedScopes,
+		AllowedRedirectSchemes: authConfig.AllowedRedirectSchemes,
+		ManagedBy:              controllerConfig.ManagedBy,
+		ServerSideApply:        controllerConfig.ServerSideApply,
+	}
+	if authConfig.IssuerPreflightEnabled {
+		setupLog.Info("Issuer discovery pre-flight enabled")
 	}
+	setupLog.Info("OIDC scope allowlist configured", "allowedScopes", authConfig.AllowedScopes)
 
 	// Load TLS configuration and always wire up the TLS reconciler. The reconciler
 	// itself branches on spec.routing.tls: NebariApps with routing.tls.secretName
@@ -255,10 +312,15 @@ func main() {
 	// condition when ClusterIssuerName is empty.
 	tlsConfig := config.LoadTLSConfig()
 	tlsReconciler := &tlsreconciler.TLSReconciler{
-		Client:            mgr.GetClient(),
-		Scheme:            mgr.GetScheme(),
-		Recorder:          mgr.GetEventRecorderFor("nebariapp-tls"),
-		ClusterIssuerName: tlsConfig.ClusterIssuerName,
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		Recorder:             events.NewRecorder(mgr.GetEventRecorderFor("nebariapp-tls")),
+		ClusterIssuerName:    tlsConfig.ClusterIssuerName,
+		TLSDisabledByDefault: !tlsConfig.DefaultTLSEnabled,
+		ManagedBy:            controllerConfig.ManagedBy,
+	}
+	if !tlsConfig.DefaultTLSEnabled {
+		setupLog.Info("TLS disabled by default; NebariApps must set routing.tls.enabled=true to use HTTPS")
 	}
 	if tlsConfig.ClusterIssuerName != "" {
 		setupLog.Info("TLS reconciler initialized", "clusterIssuer", tlsConfig.ClusterIssuerName)
@@ -270,28 +332,102 @@ func main() {
 
 	// Initialize core and routing reconcilers
 	coreReconciler := &core.CoreReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("nebariapp-core"),
+		Client:              mgr.GetClient(),
+		Scheme:              mgr.GetScheme(),
+		Recorder:            events.NewRecorder(mgr.GetEventRecorderFor("nebariapp-core")),
+		ProtectedNamespaces: controllerConfig.ProtectedNamespaces,
+		ManagedBy:           controllerConfig.ManagedBy,
 	}
+	routingConfig := config.LoadRoutingConfig()
 	routingReconciler := &routing.RoutingReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("nebariapp-routing"),
+		Client:                   mgr.GetClient(),
+		Scheme:                   mgr.GetScheme(),
+		Recorder:                 events.NewRecorder(mgr.GetEventRecorderFor("nebariapp-routing")),
+		DefaultRequestTimeouts:   routingConfig.DefaultRequestTimeouts,
+		TLSDisabledByDefault:     !tlsConfig.DefaultTLSEnabled,
+		MaxRoutes:                routingConfig.MaxRoutesPerApp,
+		ConnectivityProbeEnabled: routingConfig.ConnectivityProbeEnabled,
+		DefaultResponseHeaders:   routingConfig.DefaultResponseHeaders,
+		ManagedBy:                controllerConfig.ManagedBy,
+		ServerSideApply:          controllerConfig.ServerSideApply,
+	}
+	if controllerConfig.ServerSideApply {
+		setupLog.Info("Server-side apply enabled for HTTPRoutes and SecurityPolicies", "fieldManager", constants.FieldManager)
+	}
+	if routingConfig.ConnectivityProbeEnabled {
+		setupLog.Info("Connectivity probe enabled for NebariApps that opt in")
+	}
+	if routingConfig.GatewayClassName != "" {
+		if err := mgr.Add(&routing.GatewayClassEnsurer{
+			Client:         mgr.GetClient(),
+			Name:           routingConfig.GatewayClassName,
+			ControllerName: routingConfig.GatewayControllerName,
+			ManagedBy:      controllerConfig.ManagedBy,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up GatewayClass management")
+			os.Exit(1)
+		}
+		setupLog.Info("GatewayClass management enabled", "name", routingConfig.GatewayClassName,
+			"controllerName", routingConfig.GatewayControllerName)
 	}
+	if len(routingConfig.DefaultResponseHeaders) > 0 {
+		setupLog.Info("Default response headers configured", "headers", routingConfig.DefaultResponseHeaders)
+	}
+	if len(routingConfig.DefaultRequestTimeouts) > 0 {
+		setupLog.Info("Per-gateway default request timeouts configured", "timeouts", routingConfig.DefaultRequestTimeouts)
+	}
+
+	// Log the resolved configuration once, with secrets redacted, and expose
+	// its feature switches as an info metric so misconfiguration can be spotted
+	// without reading the Deployment's environment.
+	effectiveConfig := config.EffectiveConfig{
+		Controller: controllerConfig,
+		Auth:       authConfig,
+		Routing:    routingConfig,
+		TLS:        tlsConfig,
+		Tracing:    tracingConfig,
+	}
+	setupLog.Info("Effective operator configuration", effectiveConfig.LogValues()...)
+	metrics.SetConfigInfo(effectiveConfig.MetricLabels())
+
+	setupLog.Info("NebariApp controller configured", "finalizer", controllerConfig.FinalizerName,
+		"managedBy", controllerConfig.ManagedBy, "readyConditions", controllerConfig.ReadyConditions,
+		"protectedNamespaces", controllerConfig.ProtectedNamespaces)
 
 	if err := (&controller.NebariAppReconciler{
-		Client:            mgr.GetClient(),
-		Scheme:            mgr.GetScheme(),
-		Recorder:          mgr.GetEventRecorderFor("nebariapp-controller"),
-		CoreReconciler:    coreReconciler,
-		TLSReconciler:     tlsReconciler,
-		RoutingReconciler: routingReconciler,
-		AuthReconciler:    authReconciler,
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		Recorder:             events.NewRecorder(mgr.GetEventRecorderFor("nebariapp-controller")),
+		CoreReconciler:       coreReconciler,
+		TLSReconciler:        tlsReconciler,
+		RoutingReconciler:    routingReconciler,
+		AuthReconciler:       authReconciler,
+		FinalizerName:        controllerConfig.FinalizerName,
+		ReadyConditions:      controllerConfig.ReadyConditions,
+		TLSDisabledByDefault: !tlsConfig.DefaultTLSEnabled,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NebariApp")
 		os.Exit(1)
 	}
+
+	if controllerConfig.OrphanSweepInterval > 0 {
+		if err := mgr.Add(&auth.SecurityPolicySweeper{
+			Client:    mgr.GetClient(),
+			Interval:  controllerConfig.OrphanSweepInterval,
+			ManagedBy: controllerConfig.ManagedBy,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up orphaned SecurityPolicy sweep")
+			os.Exit(1)
+		}
+		setupLog.Info("Orphaned SecurityPolicy sweep enabled", "interval", controllerConfig.OrphanSweepInterval)
+	}
+	if controllerConfig.EnableWebhooks {
+		if err := webhookv1.SetupNebariAppWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "NebariApp")
+			os.Exit(1)
+		}
+		setupLog.Info("NebariApp validating webhook enabled")
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -308,4 +444,11 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	// Flush spans still buffered in the batcher
+	if tracerProvider != nil {
+		if err := tracerProvider.Shutdown(context.Background()); err != nil {
+			setupLog.Error(err, "problem shutting down tracer provider")
+		}
+	}
 }
@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -32,6 +34,26 @@ import (
 
 // AuthConfig holds authentication configuration for the operator.
 type AuthConfig struct {
+	// DefaultProvider is the OIDC provider used for NebariApps that leave
+	// spec.auth.provider empty. Set via DEFAULT_AUTH_PROVIDER.
+	DefaultProvider string
+
+	// IssuerPreflightEnabled makes the auth reconciler fetch the issuer's OIDC
+	// discovery document before writing a SecurityPolicy, so an unreachable
+	// issuer is reported on the NebariApp instead of only in Envoy Gateway.
+	// Set via ISSUER_PREFLIGHT_ENABLED.
+	IssuerPreflightEnabled bool
+
+	// AllowedRedirectSchemes lists the URI schemes permitted on the redirect
+	// URIs of provisioned OIDC clients; others are dropped. Set via
+	// ALLOWED_REDIRECT_SCHEMES (comma-separated, default "https,http").
+	AllowedRedirectSchemes []string
+
+	// AllowedScopes lists the OIDC scopes NebariApps may request; apps asking
+	// for any other scope fail validation. Set via ALLOWED_SCOPES
+	// (comma-separated, default "*", which allows any scope).
+	AllowedScopes []string
+
 	// Keycloak configuration
 	Keycloak KeycloakConfig
 }
@@ -85,11 +107,52 @@ type KeycloakConfig struct {
 	// APITimeout is the timeout for Keycloak API calls (authentication, client CRUD).
 	// Prevents reconciliation from hanging indefinitely if Keycloak is unresponsive.
 	APITimeout time.Duration
+
+	// HTTP client settings for Keycloak admin API traffic.
+	// These let the operator reach Keycloak through egress proxies or behind
+	// certificates signed by a private CA.
+
+	// HTTPTimeout is the per-request timeout set on the HTTP client used to talk
+	// to Keycloak. Zero leaves requests bounded only by APITimeout.
+	HTTPTimeout time.Duration
+
+	// HTTPProxy is an explicit proxy URL for Keycloak API traffic.
+	// When empty, the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY variables apply.
+	HTTPProxy string
+
+	// CACertFile is the path to a PEM bundle of additional CA certificates to
+	// trust when connecting to Keycloak (e.g. a self-signed or private CA).
+	// The bundle is appended to the system certificate pool.
+	CACertFile string
+
+	// InsecureSkipVerify disables TLS certificate verification for Keycloak API calls.
+	// Intended for development clusters only.
+	InsecureSkipVerify bool
+
+	// ClientSecretLength is the number of random bytes in the secrets generated
+	// for provisioned clients. The encoded secret is longer than this.
+	ClientSecretLength int
+
+	// ClientSecretEncoding is how generated client secrets are encoded:
+	// "base64url" (unpadded) or "hex".
+	ClientSecretEncoding string
+}
+
+// IssuerURL returns the in-cluster issuer URL of the Keycloak realm, built from
+// the issuer service settings. Envoy Gateway fetches the OIDC configuration
+// from it.
+func (c *KeycloakConfig) IssuerURL() string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d%s/realms/%s",
+		c.IssuerServiceName, c.IssuerServiceNamespace, c.IssuerServicePort, c.IssuerContextPath, c.Realm)
 }
 
 // LoadAuthConfig loads authentication configuration from environment variables.
 func LoadAuthConfig() AuthConfig {
 	return AuthConfig{
+		DefaultProvider:        getEnv("DEFAULT_AUTH_PROVIDER", constants.ProviderKeycloak),
+		IssuerPreflightEnabled: getEnvBool("ISSUER_PREFLIGHT_ENABLED", false),
+		AllowedRedirectSchemes: parseRedirectSchemes(os.Getenv("ALLOWED_REDIRECT_SCHEMES")),
+		AllowedScopes:          parseAllowedScopes(os.Getenv("ALLOWED_SCOPES")),
 		Keycloak: KeycloakConfig{
 			Enabled:              getEnvBool("KEYCLOAK_ENABLED", true),
 			URL:                  getEnv("KEYCLOAK_URL", fmt.Sprintf("http://%s.%s.svc.cluster.local:%d%s", constants.DefaultKeycloakServiceName, constants.DefaultKeycloakNamespace, constants.DefaultKeycloakServicePort, constants.DefaultKeycloakContextPath)),
@@ -105,6 +168,14 @@ func LoadAuthConfig() AuthConfig {
 			IssuerContextPath:      getEnv("KEYCLOAK_ISSUER_CONTEXT_PATH", constants.DefaultKeycloakContextPath),
 			ExternalURL:            getEnv("KEYCLOAK_EXTERNAL_URL", ""),
 			APITimeout:             getEnvDuration("KEYCLOAK_API_TIMEOUT", 30*time.Second),
+			// HTTP client settings (proxy, CA bundle, per-request timeout)
+			HTTPTimeout:        getEnvDuration("KEYCLOAK_HTTP_TIMEOUT", 0),
+			HTTPProxy:          getEnv("KEYCLOAK_HTTP_PROXY", ""),
+			CACertFile:         getEnv("KEYCLOAK_CA_CERT_FILE", ""),
+			InsecureSkipVerify: getEnvBool("KEYCLOAK_TLS_INSECURE_SKIP_VERIFY", false),
+			// Generated client secrets
+			ClientSecretLength:   getEnvInt("KEYCLOAK_CLIENT_SECRET_LENGTH", constants.DefaultClientSecretLength),
+			ClientSecretEncoding: strings.ToLower(getEnv("KEYCLOAK_CLIENT_SECRET_ENCODING", constants.ClientSecretEncodingBase64URL)),
 		},
 	}
 }
@@ -166,6 +237,55 @@ func (c *KeycloakConfig) LoadKeycloakCredentials(ctx context.Context, k8sClient
 	return nil
 }
 
+// ValidateClientSecretSettings checks that generated client secrets carry
+// enough entropy and use a supported encoding.
+func (c *KeycloakConfig) ValidateClientSecretSettings() error {
+	if c.ClientSecretLength < constants.MinClientSecretLength || c.ClientSecretLength > constants.MaxClientSecretLength {
+		return fmt.Errorf("KEYCLOAK_CLIENT_SECRET_LENGTH must be between %d and %d bytes, got %d",
+			constants.MinClientSecretLength, constants.MaxClientSecretLength, c.ClientSecretLength)
+	}
+	switch c.ClientSecretEncoding {
+	case constants.ClientSecretEncodingBase64URL, constants.ClientSecretEncodingHex:
+		return nil
+	default:
+		return fmt.Errorf("KEYCLOAK_CLIENT_SECRET_ENCODING must be %q or %q, got %q",
+			constants.ClientSecretEncodingBase64URL, constants.ClientSecretEncodingHex, c.ClientSecretEncoding)
+	}
+}
+
+// parseRedirectSchemes parses a comma-separated list of URI schemes, lowercasing
+// them and dropping blanks and duplicates. An empty value returns the default set.
+func parseRedirectSchemes(value string) []string {
+	schemes := []string{}
+	for _, entry := range strings.Split(value, ",") {
+		scheme := strings.ToLower(strings.TrimSpace(entry))
+		if scheme != "" && !slices.Contains(schemes, scheme) {
+			schemes = append(schemes, scheme)
+		}
+	}
+	if len(schemes) == 0 {
+		return slices.Clone(constants.DefaultAllowedRedirectSchemes)
+	}
+	return schemes
+}
+
+// parseAllowedScopes parses a comma-separated list of OIDC scopes, dropping
+// blanks and duplicates. Scopes are case-sensitive and kept as written. An empty
+// value returns the default set.
+func parseAllowedScopes(value string) []string {
+	scopes := []string{}
+	for _, entry := range strings.Split(value, ",") {
+		scope := strings.TrimSpace(entry)
+		if scope != "" && !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return slices.Clone(constants.DefaultAllowedScopes)
+	}
+	return scopes
+}
+
 // getEnv gets an environment variable or returns a default value.
 // Uses os.LookupEnv so that setting an env var to empty string is a valid override.
 func getEnv(key, defaultValue string) string {
@@ -19,6 +19,7 @@ package config
 import (
 	"context"
 	"os"
+	"slices"
 	"testing"
 	"time"
 
@@ -142,6 +143,33 @@ func TestLoadAuthConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Custom HTTP client settings",
+			envVars: map[string]string{
+				"KEYCLOAK_HTTP_TIMEOUT":             "10s",
+				"KEYCLOAK_HTTP_PROXY":               "http://proxy.internal:3128",
+				"KEYCLOAK_CA_CERT_FILE":             "/etc/keycloak/ca.crt",
+				"KEYCLOAK_TLS_INSECURE_SKIP_VERIFY": "true",
+			},
+			expected: AuthConfig{
+				Keycloak: KeycloakConfig{
+					Enabled:                true,
+					URL:                    "http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080",
+					Realm:                  "nebari",
+					AdminSecretName:        "nebari-realm-admin-credentials",
+					AdminSecretNamespace:   "keycloak",
+					IssuerServiceName:      "keycloak-keycloakx-http",
+					IssuerServiceNamespace: "keycloak",
+					IssuerServicePort:      8080,
+					IssuerContextPath:      "",
+					APITimeout:             30 * time.Second,
+					HTTPTimeout:            10 * time.Second,
+					HTTPProxy:              "http://proxy.internal:3128",
+					CACertFile:             "/etc/keycloak/ca.crt",
+					InsecureSkipVerify:     true,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -181,6 +209,243 @@ func TestLoadAuthConfig(t *testing.T) {
 			if config.Keycloak.APITimeout != tt.expected.Keycloak.APITimeout {
 				t.Errorf("APITimeout: expected %v, got %v", tt.expected.Keycloak.APITimeout, config.Keycloak.APITimeout)
 			}
+			if config.Keycloak.HTTPTimeout != tt.expected.Keycloak.HTTPTimeout {
+				t.Errorf("HTTPTimeout: expected %v, got %v", tt.expected.Keycloak.HTTPTimeout, config.Keycloak.HTTPTimeout)
+			}
+			if config.Keycloak.HTTPProxy != tt.expected.Keycloak.HTTPProxy {
+				t.Errorf("HTTPProxy: expected %s, got %s", tt.expected.Keycloak.HTTPProxy, config.Keycloak.HTTPProxy)
+			}
+			if config.Keycloak.CACertFile != tt.expected.Keycloak.CACertFile {
+				t.Errorf("CACertFile: expected %s, got %s", tt.expected.Keycloak.CACertFile, config.Keycloak.CACertFile)
+			}
+			if config.Keycloak.InsecureSkipVerify != tt.expected.Keycloak.InsecureSkipVerify {
+				t.Errorf("InsecureSkipVerify: expected %v, got %v", tt.expected.Keycloak.InsecureSkipVerify, config.Keycloak.InsecureSkipVerify)
+			}
+		})
+	}
+}
+
+func TestLoadAuthConfig_DefaultProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			name:     "Defaults to keycloak",
+			envVars:  map[string]string{},
+			expected: "keycloak",
+		},
+		{
+			name:     "Custom default provider",
+			envVars:  map[string]string{"DEFAULT_AUTH_PROVIDER": "generic-oidc"},
+			expected: "generic-oidc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
+			for key, value := range tt.envVars {
+				_ = os.Setenv(key, value)
+			}
+			defer os.Clearenv()
+
+			config := LoadAuthConfig()
+			if config.DefaultProvider != tt.expected {
+				t.Errorf("DefaultProvider: expected %s, got %s", tt.expected, config.DefaultProvider)
+			}
+		})
+	}
+}
+
+func TestLoadAuthConfig_IssuerPreflight(t *testing.T) {
+	tests := []struct {
+		name     string
+		envVars  map[string]string
+		expected bool
+	}{
+		{
+			name:     "Disabled by default",
+			envVars:  map[string]string{},
+			expected: false,
+		},
+		{
+			name:     "Enabled",
+			envVars:  map[string]string{"ISSUER_PREFLIGHT_ENABLED": "true"},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
+			for key, value := range tt.envVars {
+				_ = os.Setenv(key, value)
+			}
+			defer os.Clearenv()
+
+			config := LoadAuthConfig()
+			if config.IssuerPreflightEnabled != tt.expected {
+				t.Errorf("IssuerPreflightEnabled: expected %v, got %v", tt.expected, config.IssuerPreflightEnabled)
+			}
+		})
+	}
+}
+
+func TestLoadAuthConfig_AllowedRedirectSchemes(t *testing.T) {
+	tests := []struct {
+		name     string
+		envVars  map[string]string
+		expected []string
+	}{
+		{
+			name:     "Defaults to https and http",
+			envVars:  map[string]string{},
+			expected: []string{"https", "http"},
+		},
+		{
+			name:     "Only https",
+			envVars:  map[string]string{"ALLOWED_REDIRECT_SCHEMES": "https"},
+			expected: []string{"https"},
+		},
+		{
+			name:     "Normalized and de-duplicated",
+			envVars:  map[string]string{"ALLOWED_REDIRECT_SCHEMES": " HTTPS, myapp ,https,"},
+			expected: []string{"https", "myapp"},
+		},
+		{
+			name:     "Blank falls back to the default",
+			envVars:  map[string]string{"ALLOWED_REDIRECT_SCHEMES": " , "},
+			expected: []string{"https", "http"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
+			for key, value := range tt.envVars {
+				_ = os.Setenv(key, value)
+			}
+			defer os.Clearenv()
+
+			config := LoadAuthConfig()
+			if !slices.Equal(config.AllowedRedirectSchemes, tt.expected) {
+				t.Errorf("AllowedRedirectSchemes: expected %v, got %v", tt.expected, config.AllowedRedirectSchemes)
+			}
+		})
+	}
+}
+
+func TestLoadAuthConfig_AllowedScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		envVars  map[string]string
+		expected []string
+	}{
+		{
+			name:     "Defaults to any scope",
+			envVars:  map[string]string{},
+			expected: []string{"*"},
+		},
+		{
+			name:     "Custom list keeps case and drops duplicates",
+			envVars:  map[string]string{"ALLOWED_SCOPES": " openid, Admin ,openid,"},
+			expected: []string{"openid", "Admin"},
+		},
+		{
+			name:     "Wildcard",
+			envVars:  map[string]string{"ALLOWED_SCOPES": "*"},
+			expected: []string{"*"},
+		},
+		{
+			name:     "Blank falls back to the default",
+			envVars:  map[string]string{"ALLOWED_SCOPES": " , "},
+			expected: []string{"*"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
+			for key, value := range tt.envVars {
+				_ = os.Setenv(key, value)
+			}
+			defer os.Clearenv()
+
+			config := LoadAuthConfig()
+			if !slices.Equal(config.AllowedScopes, tt.expected) {
+				t.Errorf("AllowedScopes: expected %v, got %v", tt.expected, config.AllowedScopes)
+			}
+		})
+	}
+}
+
+func TestLoadAuthConfig_ClientSecretSettings(t *testing.T) {
+	tests := []struct {
+		name             string
+		envVars          map[string]string
+		expectedLength   int
+		expectedEncoding string
+		expectError      bool
+	}{
+		{
+			name:             "Defaults to 32 bytes of base64url",
+			envVars:          map[string]string{},
+			expectedLength:   32,
+			expectedEncoding: "base64url",
+		},
+		{
+			name: "Custom length and hex encoding",
+			envVars: map[string]string{
+				"KEYCLOAK_CLIENT_SECRET_LENGTH":   "48",
+				"KEYCLOAK_CLIENT_SECRET_ENCODING": "HEX",
+			},
+			expectedLength:   48,
+			expectedEncoding: "hex",
+		},
+		{
+			name:             "Length below the minimum is rejected",
+			envVars:          map[string]string{"KEYCLOAK_CLIENT_SECRET_LENGTH": "8"},
+			expectedLength:   8,
+			expectedEncoding: "base64url",
+			expectError:      true,
+		},
+		{
+			name:             "Length above the maximum is rejected",
+			envVars:          map[string]string{"KEYCLOAK_CLIENT_SECRET_LENGTH": "512"},
+			expectedLength:   512,
+			expectedEncoding: "base64url",
+			expectError:      true,
+		},
+		{
+			name:             "Unknown encoding is rejected",
+			envVars:          map[string]string{"KEYCLOAK_CLIENT_SECRET_ENCODING": "base32"},
+			expectedLength:   32,
+			expectedEncoding: "base32",
+			expectError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
+			for key, value := range tt.envVars {
+				_ = os.Setenv(key, value)
+			}
+			defer os.Clearenv()
+
+			config := LoadAuthConfig()
+			if config.Keycloak.ClientSecretLength != tt.expectedLength {
+				t.Errorf("ClientSecretLength: expected %d, got %d", tt.expectedLength, config.Keycloak.ClientSecretLength)
+			}
+			if config.Keycloak.ClientSecretEncoding != tt.expectedEncoding {
+				t.Errorf("ClientSecretEncoding: expected %q, got %q", tt.expectedEncoding, config.Keycloak.ClientSecretEncoding)
+			}
+			err := config.Keycloak.ValidateClientSecretSettings()
+			if (err != nil) != tt.expectError {
+				t.Errorf("expected error=%v, got error=%v", tt.expectError, err)
+			}
 		})
 	}
 }
@@ -0,0 +1,128 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/conditions"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+// ControllerConfig holds settings for the NebariApp controller itself.
+type ControllerConfig struct {
+	// FinalizerName is the finalizer the controller adds to NebariApp resources.
+	// Override it when running multiple operator instances in the same cluster so
+	// each instance only blocks deletion on its own cleanup.
+	FinalizerName string
+
+	// ManagedBy is the app.kubernetes.io/managed-by label value on the resources
+	// the operator creates, and the value it selects on when listing them.
+	// Override it alongside FinalizerName so instances do not manage each
+	// other's resources.
+	ManagedBy string
+
+	// ReadyConditions lists the sub-conditions that must be True for the aggregate
+	// Ready condition to be True. Sub-conditions left out are advisory only.
+	// Conditions that do not apply to an app (e.g. AuthReady when auth is
+	// disabled) never block Ready.
+	ReadyConditions []string
+
+	// OrphanSweepInterval is how often operator-managed SecurityPolicies whose
+	// NebariApp no longer exists are swept, together with the HTTPRoutes they
+	// protect. Zero disables the sweep.
+	OrphanSweepInterval time.Duration
+
+	// ProtectedNamespaces lists namespaces whose NebariApps are refused even if
+	// the namespace is labelled for Nebari management.
+	ProtectedNamespaces []string
+
+	// EnableWebhooks registers the NebariApp validating admission webhook. The
+	// webhook server needs a serving certificate, so it is off by default.
+	EnableWebhooks bool
+
+	// ServerSideApply makes the routing and auth reconcilers write HTTPRoutes
+	// and SecurityPolicies with server-side apply under constants.FieldManager
+	// instead of create/update, so fields other controllers set are left alone.
+	ServerSideApply bool
+}
+
+// LoadControllerConfig loads controller configuration from environment variables.
+// An unset or empty FINALIZER_NAME falls back to constants.NebariAppFinalizer,
+// and an unset or empty MANAGED_BY to constants.DefaultManagedBy.
+// READY_CONDITIONS is a comma-separated subset of RoutingReady, TLSReady and
+// AuthReady; unknown entries are ignored and an unset value keeps all three.
+// PROTECTED_NAMESPACES is a comma-separated list that replaces the default
+// protected namespaces (kube-system, kube-public and the gateway namespace).
+// ENABLE_WEBHOOKS=true registers the validating webhook, and
+// USE_SERVER_SIDE_APPLY=true switches child resource writes to server-side apply.
+func LoadControllerConfig() ControllerConfig {
+	finalizerName := getEnv("FINALIZER_NAME", "")
+	if finalizerName == "" {
+		finalizerName = constants.NebariAppFinalizer
+	}
+	managedBy := getEnv("MANAGED_BY", "")
+	if managedBy == "" {
+		managedBy = constants.DefaultManagedBy
+	}
+	return ControllerConfig{
+		FinalizerName:       finalizerName,
+		ManagedBy:           managedBy,
+		ReadyConditions:     parseReadyConditions(os.Getenv("READY_CONDITIONS")),
+		OrphanSweepInterval: getEnvDuration("ORPHAN_SWEEP_INTERVAL", 10*time.Minute),
+		ProtectedNamespaces: parseProtectedNamespaces(os.Getenv("PROTECTED_NAMESPACES")),
+		EnableWebhooks:      getEnvBool("ENABLE_WEBHOOKS", false),
+		ServerSideApply:     getEnvBool("USE_SERVER_SIDE_APPLY", false),
+	}
+}
+
+// parseReadyConditions parses a comma-separated list of condition types, keeping
+// only those known to gate Ready. An empty value returns the default set.
+func parseReadyConditions(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return slices.Clone(conditions.DefaultReadyConditions)
+	}
+
+	readyConditions := []string{}
+	for _, entry := range strings.Split(value, ",") {
+		conditionType := strings.TrimSpace(entry)
+		if slices.Contains(conditions.DefaultReadyConditions, conditionType) &&
+			!slices.Contains(readyConditions, conditionType) {
+			readyConditions = append(readyConditions, conditionType)
+		}
+	}
+	return readyConditions
+}
+
+// parseProtectedNamespaces parses a comma-separated list of namespaces, dropping
+// blanks and duplicates. An empty value returns the default set.
+func parseProtectedNamespaces(value string) []string {
+	namespaces := []string{}
+	for _, entry := range strings.Split(value, ",") {
+		namespace := strings.TrimSpace(entry)
+		if namespace != "" && !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	if len(namespaces) == 0 {
+		return slices.Clone(constants.DefaultProtectedNamespaces)
+	}
+	return namespaces
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+func TestLoadControllerConfig(t *testing.T) {
+	tests := []struct {
+		name                    string
+		envVars                 map[string]string
+		expectedFinalizer       string
+		expectedReadyConditions []string
+		expectedSweepInterval   time.Duration
+		expectedProtected       []string
+		expectedManagedBy       string
+		expectedWebhooks        bool
+		expectedSSA             bool
+	}{
+		{
+			name:                    "Default values",
+			envVars:                 map[string]string{},
+			expectedFinalizer:       constants.NebariAppFinalizer,
+			expectedReadyConditions: []string{"RoutingReady", "TLSReady", "AuthReady"},
+			expectedSweepInterval:   10 * time.Minute,
+		},
+		{
+			name: "Custom finalizer name",
+			envVars: map[string]string{
+				"FINALIZER_NAME": "apps.nebari.dev/finalizer-tenant-a",
+			},
+			expectedFinalizer:       "apps.nebari.dev/finalizer-tenant-a",
+			expectedReadyConditions: []string{"RoutingReady", "TLSReady", "AuthReady"},
+			expectedSweepInterval:   10 * time.Minute,
+		},
+		{
+			name: "TLS excluded from the Ready gate",
+			envVars: map[string]string{
+				"READY_CONDITIONS": "RoutingReady, AuthReady, Bogus, AuthReady",
+			},
+			expectedFinalizer:       constants.NebariAppFinalizer,
+			expectedReadyConditions: []string{"RoutingReady", "AuthReady"},
+			expectedSweepInterval:   10 * time.Minute,
+		},
+		{
+			name: "Orphan sweep disabled",
+			envVars: map[string]string{
+				"ORPHAN_SWEEP_INTERVAL": "0",
+			},
+			expectedFinalizer:       constants.NebariAppFinalizer,
+			expectedReadyConditions: []string{"RoutingReady", "TLSReady", "AuthReady"},
+		},
+		{
+			name: "Custom protected namespaces",
+			envVars: map[string]string{
+				"PROTECTED_NAMESPACES": "kube-system, platform,,platform",
+			},
+			expectedFinalizer:       constants.NebariAppFinalizer,
+			expectedReadyConditions: []string{"RoutingReady", "TLSReady", "AuthReady"},
+			expectedSweepInterval:   10 * time.Minute,
+			expectedProtected:       []string{"kube-system", "platform"},
+		},
+		{
+			name: "Custom managed-by label value",
+			envVars: map[string]string{
+				"MANAGED_BY": "nebari-operator-staging",
+			},
+			expectedFinalizer:       constants.NebariAppFinalizer,
+			expectedReadyConditions: []string{"RoutingReady", "TLSReady", "AuthReady"},
+			expectedSweepInterval:   10 * time.Minute,
+			expectedManagedBy:       "nebari-operator-staging",
+		},
+		{
+			name: "Webhooks enabled",
+			envVars: map[string]string{
+				"ENABLE_WEBHOOKS": "true",
+			},
+			expectedFinalizer:       constants.NebariAppFinalizer,
+			expectedReadyConditions: []string{"RoutingReady", "TLSReady", "AuthReady"},
+			expectedSweepInterval:   10 * time.Minute,
+			expectedWebhooks:        true,
+		},
+		{
+			name: "Server-side apply enabled",
+			envVars: map[string]string{
+				"USE_SERVER_SIDE_APPLY": "true",
+			},
+			expectedFinalizer:       constants.NebariAppFinalizer,
+			expectedReadyConditions: []string{"RoutingReady", "TLSReady", "AuthReady"},
+			expectedSweepInterval:   10 * time.Minute,
+			expectedSSA:             true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("FINALIZER_NAME", "")
+			t.Setenv("READY_CONDITIONS", "")
+			t.Setenv("ORPHAN_SWEEP_INTERVAL", "")
+			t.Setenv("PROTECTED_NAMESPACES", "")
+			t.Setenv("MANAGED_BY", "")
+			t.Setenv("ENABLE_WEBHOOKS", "")
+			t.Setenv("USE_SERVER_SIDE_APPLY", "")
+			for k, v := range tt.envVars {
+				t.Setenv(k, v)
+			}
+			config := LoadControllerConfig()
+			if config.FinalizerName != tt.expectedFinalizer {
+				t.Errorf("expected FinalizerName %q, got %q", tt.expectedFinalizer, config.FinalizerName)
+			}
+			if !reflect.DeepEqual(config.ReadyConditions, tt.expectedReadyConditions) {
+				t.Errorf("expected ReadyConditions %v, got %v", tt.expectedReadyConditions, config.ReadyConditions)
+			}
+			if config.OrphanSweepInterval != tt.expectedSweepInterval {
+				t.Errorf("expected OrphanSweepInterval %v, got %v", tt.expectedSweepInterval, config.OrphanSweepInterval)
+			}
+			expectedProtected := tt.expectedProtected
+			if expectedProtected == nil {
+				expectedProtected = constants.DefaultProtectedNamespaces
+			}
+			if !reflect.DeepEqual(config.ProtectedNamespaces, expectedProtected) {
+				t.Errorf("expected ProtectedNamespaces %v, got %v", expectedProtected, config.ProtectedNamespaces)
+			}
+			expectedManagedBy := tt.expectedManagedBy
+			if expectedManagedBy == "" {
+				expectedManagedBy = constants.DefaultManagedBy
+			}
+			if config.ManagedBy != expectedManagedBy {
+				t.Errorf("expected ManagedBy %q, got %q", expectedManagedBy, config.ManagedBy)
+			}
+			if config.EnableWebhooks != tt.expectedWebhooks {
+				t.Errorf("expected EnableWebhooks %v, got %v", tt.expectedWebhooks, config.EnableWebhooks)
+			}
+			if config.ServerSideApply != tt.expectedSSA {
+				t.Errorf("expected ServerSideApply %v, got %v", tt.expectedSSA, config.ServerSideApply)
+			}
+		})
+	}
+}
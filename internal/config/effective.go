@@ -0,0 +1,126 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"net/url"
+
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/metrics"
+)
+
+// redacted replaces secret values in the effective configuration.
+const redacted = "[REDACTED]"
+
+// EffectiveConfig is the configuration the operator resolved at startup, after
+// environment variables and defaults were applied. It is logged once and
+// summarized in the nebari_operator_config_info metric to help debug
+// misconfiguration, such as a wrong issuer port.
+type EffectiveConfig struct {
+	Controller ControllerConfig
+	Auth       AuthConfig
+	Routing    RoutingConfig
+	TLS        TLSConfig
+	Tracing    TracingConfig
+}
+
+// LogValues returns the effective configuration as key/value pairs for a
+// structured log line. The Keycloak admin password is redacted, as is any
+// password embedded in a URL; whether a password is set is still visible.
+// Admin credentials read from the admin secret are loaded later and never
+// appear here.
+func (c EffectiveConfig) LogValues() []any {
+	keycloak := c.Auth.Keycloak
+	values := []any{
+		"gatewayNamespace", constants.GatewayNamespace,
+		"finalizer", c.Controller.FinalizerName,
+		"managedBy", c.Controller.ManagedBy,
+		"readyConditions", c.Controller.ReadyConditions,
+		"protectedNamespaces", c.Controller.ProtectedNamespaces,
+		"orphanSweepInterval", c.Controller.OrphanSweepInterval.String(),
+		"webhooksEnabled", c.Controller.EnableWebhooks,
+		"serverSideApply", c.Controller.ServerSideApply,
+		"defaultAuthProvider", c.Auth.DefaultProvider,
+		"issuerPreflightEnabled", c.Auth.IssuerPreflightEnabled,
+		"allowedScopes", c.Auth.AllowedScopes,
+		"allowedRedirectSchemes", c.Auth.AllowedRedirectSchemes,
+		"keycloakEnabled", keycloak.Enabled,
+	}
+	if keycloak.Enabled {
+		values = append(values,
+			"keycloakURL", redactURL(keycloak.URL),
+			"keycloakRealm", keycloak.Realm,
+			"keycloakIssuerURL", keycloak.IssuerURL(),
+			"keycloakExternalURL", redactURL(keycloak.ExternalURL),
+			"keycloakAdminSecret", keycloak.AdminSecretNamespace+"/"+keycloak.AdminSecretName,
+			"keycloakAdminUsername", keycloak.AdminUsername,
+			"keycloakAdminPassword", redactSecret(keycloak.AdminPassword),
+			"keycloakAPITimeout", keycloak.APITimeout.String(),
+			"keycloakHTTPTimeout", keycloak.HTTPTimeout.String(),
+			"keycloakHTTPProxy", redactURL(keycloak.HTTPProxy),
+			"keycloakCACertFile", keycloak.CACertFile,
+			"keycloakTLSInsecureSkipVerify", keycloak.InsecureSkipVerify,
+			"clientSecretLength", keycloak.ClientSecretLength,
+			"clientSecretEncoding", keycloak.ClientSecretEncoding,
+		)
+	}
+	return append(values,
+		"tlsClusterIssuer", c.TLS.ClusterIssuerName,
+		"defaultTLSEnabled", c.TLS.DefaultTLSEnabled,
+		"maxRoutesPerApp", c.Routing.MaxRoutesPerApp,
+		"connectivityProbeEnabled", c.Routing.ConnectivityProbeEnabled,
+		"gatewayClassName", c.Routing.GatewayClassName,
+		"defaultRequestTimeouts", c.Routing.DefaultRequestTimeouts,
+		"defaultResponseHeaders", c.Routing.DefaultResponseHeaders,
+		"tracingEnabled", c.Tracing.Enabled,
+	)
+}
+
+// MetricLabels returns the feature switches exposed on the
+// nebari_operator_config_info metric.
+func (c EffectiveConfig) MetricLabels() metrics.ConfigLabels {
+	return metrics.ConfigLabels{
+		KeycloakEnabled:          c.Auth.Keycloak.Enabled,
+		WebhooksEnabled:          c.Controller.EnableWebhooks,
+		DefaultTLSEnabled:        c.TLS.DefaultTLSEnabled,
+		IssuerPreflightEnabled:   c.Auth.IssuerPreflightEnabled,
+		ConnectivityProbeEnabled: c.Routing.ConnectivityProbeEnabled,
+		TracingEnabled:           c.Tracing.Enabled,
+		DefaultAuthProvider:      c.Auth.DefaultProvider,
+	}
+}
+
+// redactSecret hides a secret value, keeping only whether it is set.
+func redactSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redacted
+}
+
+// redactURL hides the password in a URL's userinfo. A value that does not
+// parse as a URL is redacted entirely, since it cannot be checked.
+func redactURL(value string) string {
+	if value == "" {
+		return ""
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return redacted
+	}
+	return parsed.Redacted()
+}
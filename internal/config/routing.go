@@ -0,0 +1,117 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+// headerNamePattern matches an HTTP header field name (an RFC 7230 token).
+var headerNamePattern = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+.^_`|~-]+$")
+
+// gatewayDurationPattern matches the Gateway API duration format (e.g. "30s", "1h30m").
+var gatewayDurationPattern = regexp.MustCompile(`^([0-9]{1,5}(h|m|s|ms)){1,4}$`)
+
+// RoutingConfig holds operator-wide defaults applied to generated HTTPRoutes.
+type RoutingConfig struct {
+	// DefaultRequestTimeouts maps a Gateway name (e.g. "nebari-gateway") to the
+	// request timeout applied when a NebariApp does not set routing.requestTimeout.
+	DefaultRequestTimeouts map[string]string
+
+	// MaxRoutesPerApp caps the number of routing.routes entries a NebariApp may
+	// declare. Apps over the limit are not routed.
+	MaxRoutesPerApp int
+
+	// ConnectivityProbeEnabled allows NebariApps to opt into routing.connectivityProbe.
+	// Off by default since every probe is an extra request through the Gateway.
+	ConnectivityProbeEnabled bool
+
+	// GatewayClassName, when set, makes the operator create this GatewayClass at
+	// startup if it does not exist. Empty leaves GatewayClasses to the platform.
+	GatewayClassName string
+
+	// GatewayControllerName is the controllerName of a GatewayClass created
+	// for GatewayClassName.
+	GatewayControllerName string
+
+	// DefaultResponseHeaders maps header names to values set on responses from
+	// every generated HTTPRoute rule, unless a NebariApp opts out.
+	DefaultResponseHeaders map[string]string
+}
+
+// LoadRoutingConfig loads routing configuration from environment variables.
+// GATEWAY_REQUEST_TIMEOUTS is a comma-separated list of gateway=duration pairs,
+// e.g. "nebari-gateway=30s,nebari-internal-gateway=5m". Malformed entries are ignored.
+// MAX_ROUTES_PER_APP falls back to constants.DefaultMaxRoutesPerApp when unset or
+// not a positive integer. CONNECTIVITY_PROBE_ENABLED defaults to false.
+// GATEWAY_CLASS_NAME opts into GatewayClass management and is empty by default;
+// GATEWAY_CONTROLLER_NAME defaults to Envoy Gateway's controller name.
+// DEFAULT_RESPONSE_HEADERS is a "|"-separated list of name=value pairs, e.g.
+// "Strict-Transport-Security=max-age=31536000|X-Content-Type-Options=nosniff";
+// "|" is used because header values commonly contain commas and semicolons.
+// Entries with an invalid header name are ignored.
+func LoadRoutingConfig() RoutingConfig {
+	maxRoutes := getEnvInt("MAX_ROUTES_PER_APP", constants.DefaultMaxRoutesPerApp)
+	if maxRoutes <= 0 {
+		maxRoutes = constants.DefaultMaxRoutesPerApp
+	}
+	controllerName := os.Getenv("GATEWAY_CONTROLLER_NAME")
+	if controllerName == "" {
+		controllerName = constants.DefaultGatewayControllerName
+	}
+	return RoutingConfig{
+		DefaultRequestTimeouts:   parseGatewayTimeouts(os.Getenv("GATEWAY_REQUEST_TIMEOUTS")),
+		MaxRoutesPerApp:          maxRoutes,
+		ConnectivityProbeEnabled: getEnvBool("CONNECTIVITY_PROBE_ENABLED", false),
+		GatewayClassName:         getEnv("GATEWAY_CLASS_NAME", ""),
+		GatewayControllerName:    controllerName,
+		DefaultResponseHeaders:   parseResponseHeaders(os.Getenv("DEFAULT_RESPONSE_HEADERS")),
+	}
+}
+
+// parseGatewayTimeouts parses a comma-separated list of gateway=duration pairs.
+func parseGatewayTimeouts(value string) map[string]string {
+	timeouts := map[string]string{}
+	for _, entry := range strings.Split(value, ",") {
+		name, timeout, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		name, timeout = strings.TrimSpace(name), strings.TrimSpace(timeout)
+		if !ok || name == "" || !gatewayDurationPattern.MatchString(timeout) {
+			continue
+		}
+		timeouts[name] = timeout
+	}
+	return timeouts
+}
+
+// parseResponseHeaders parses a "|"-separated list of name=value header pairs.
+// The value is everything after the first "=", so it may itself contain "=".
+func parseResponseHeaders(value string) map[string]string {
+	headers := map[string]string{}
+	for _, entry := range strings.Split(value, "|") {
+		name, headerValue, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		name, headerValue = strings.TrimSpace(name), strings.TrimSpace(headerValue)
+		if !ok || !headerNamePattern.MatchString(name) || headerValue == "" {
+			continue
+		}
+		headers[name] = headerValue
+	}
+	return headers
+}
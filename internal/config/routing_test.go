@@ -0,0 +1,159 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+func TestLoadRoutingConfig(t *testing.T) {
+	tests := []struct {
+		name                 string
+		envVars              map[string]string
+		expectedTimeouts     map[string]string
+		expectedMaxRoutes    int
+		expectedProbeEnabled bool
+		expectedClassName    string
+		expectedController   string
+		expectedHeaders      map[string]string
+	}{
+		{
+			name:             "Default values",
+			envVars:          map[string]string{},
+			expectedTimeouts: map[string]string{},
+		},
+		{
+			name: "Custom route limit",
+			envVars: map[string]string{
+				"MAX_ROUTES_PER_APP": "10",
+			},
+			expectedTimeouts:  map[string]string{},
+			expectedMaxRoutes: 10,
+		},
+		{
+			name: "Invalid route limit falls back to default",
+			envVars: map[string]string{
+				"MAX_ROUTES_PER_APP": "0",
+			},
+			expectedTimeouts: map[string]string{},
+		},
+		{
+			name: "Connectivity probe enabled",
+			envVars: map[string]string{
+				"CONNECTIVITY_PROBE_ENABLED": "true",
+			},
+			expectedTimeouts:     map[string]string{},
+			expectedProbeEnabled: true,
+		},
+		{
+			name: "GatewayClass management enabled",
+			envVars: map[string]string{
+				"GATEWAY_CLASS_NAME":      "nebari-envoy",
+				"GATEWAY_CONTROLLER_NAME": "example.com/gateway-controller",
+			},
+			expectedTimeouts:   map[string]string{},
+			expectedClassName:  "nebari-envoy",
+			expectedController: "example.com/gateway-controller",
+		},
+		{
+			name: "Per-gateway timeouts",
+			envVars: map[string]string{
+				"GATEWAY_REQUEST_TIMEOUTS": "nebari-gateway=30s, nebari-internal-gateway=5m",
+			},
+			expectedTimeouts: map[string]string{
+				"nebari-gateway":          "30s",
+				"nebari-internal-gateway": "5m",
+			},
+		},
+		{
+			name: "Default response headers",
+			envVars: map[string]string{
+				"DEFAULT_RESPONSE_HEADERS": "Strict-Transport-Security=max-age=31536000; includeSubDomains | X-Content-Type-Options=nosniff",
+			},
+			expectedTimeouts: map[string]string{},
+			expectedHeaders: map[string]string{
+				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+				"X-Content-Type-Options":    "nosniff",
+			},
+		},
+		{
+			name: "Malformed response headers are ignored",
+			envVars: map[string]string{
+				"DEFAULT_RESPONSE_HEADERS": "X-Frame-Options=DENY|Bad Header=1|X-Empty=|nonsense",
+			},
+			expectedTimeouts: map[string]string{},
+			expectedHeaders:  map[string]string{"X-Frame-Options": "DENY"},
+		},
+		{
+			name: "Malformed entries are ignored",
+			envVars: map[string]string{
+				"GATEWAY_REQUEST_TIMEOUTS": "nebari-gateway=30s,=10s,nebari-internal-gateway=1.5s,bogus",
+			},
+			expectedTimeouts: map[string]string{
+				"nebari-gateway": "30s",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GATEWAY_REQUEST_TIMEOUTS", "")
+			t.Setenv("MAX_ROUTES_PER_APP", "")
+			t.Setenv("CONNECTIVITY_PROBE_ENABLED", "")
+			t.Setenv("GATEWAY_CLASS_NAME", "")
+			t.Setenv("GATEWAY_CONTROLLER_NAME", "")
+			t.Setenv("DEFAULT_RESPONSE_HEADERS", "")
+			for k, v := range tt.envVars {
+				t.Setenv(k, v)
+			}
+			config := LoadRoutingConfig()
+			if !reflect.DeepEqual(config.DefaultRequestTimeouts, tt.expectedTimeouts) {
+				t.Errorf("expected DefaultRequestTimeouts %v, got %v", tt.expectedTimeouts, config.DefaultRequestTimeouts)
+			}
+			expectedMaxRoutes := tt.expectedMaxRoutes
+			if expectedMaxRoutes == 0 {
+				expectedMaxRoutes = constants.DefaultMaxRoutesPerApp
+			}
+			if config.MaxRoutesPerApp != expectedMaxRoutes {
+				t.Errorf("expected MaxRoutesPerApp %d, got %d", expectedMaxRoutes, config.MaxRoutesPerApp)
+			}
+			if config.ConnectivityProbeEnabled != tt.expectedProbeEnabled {
+				t.Errorf("expected ConnectivityProbeEnabled %v, got %v", tt.expectedProbeEnabled, config.ConnectivityProbeEnabled)
+			}
+			if config.GatewayClassName != tt.expectedClassName {
+				t.Errorf("expected GatewayClassName %q, got %q", tt.expectedClassName, config.GatewayClassName)
+			}
+			expectedController := tt.expectedController
+			if expectedController == "" {
+				expectedController = constants.DefaultGatewayControllerName
+			}
+			if config.GatewayControllerName != expectedController {
+				t.Errorf("expected GatewayControllerName %q, got %q", expectedController, config.GatewayControllerName)
+			}
+			expectedHeaders := tt.expectedHeaders
+			if expectedHeaders == nil {
+				expectedHeaders = map[string]string{}
+			}
+			if !reflect.DeepEqual(config.DefaultResponseHeaders, expectedHeaders) {
+				t.Errorf("expected DefaultResponseHeaders %v, got %v", expectedHeaders, config.DefaultResponseHeaders)
+			}
+		})
+	}
+}
@@ -21,11 +21,17 @@ type TLSConfig struct {
 	// ClusterIssuerName is the name of the cert-manager ClusterIssuer to use.
 	// When empty, the TLS reconciler will not create Certificate resources.
 	ClusterIssuerName string
+
+	// DefaultTLSEnabled is used for NebariApps that leave routing.tls.enabled
+	// unset. Defaults to true; dev clusters without certificates can flip it to
+	// route apps to the plain HTTP listener unless they opt in.
+	DefaultTLSEnabled bool
 }
 
 // LoadTLSConfig loads TLS configuration from environment variables.
 func LoadTLSConfig() TLSConfig {
 	return TLSConfig{
 		ClusterIssuerName: getEnv("TLS_CLUSTER_ISSUER_NAME", ""),
+		DefaultTLSEnabled: getEnvBool("DEFAULT_TLS_ENABLED", true),
 	}
 }
@@ -25,11 +25,13 @@ func TestLoadTLSConfig(t *testing.T) {
 		name               string
 		envVars            map[string]string
 		expectedIssuerName string
+		expectedTLSDefault bool
 	}{
 		{
 			name:               "Default values",
 			envVars:            map[string]string{},
 			expectedIssuerName: "",
+			expectedTLSDefault: true,
 		},
 		{
 			name: "Custom issuer name",
@@ -37,12 +39,22 @@ func TestLoadTLSConfig(t *testing.T) {
 				"TLS_CLUSTER_ISSUER_NAME": "letsencrypt-prod",
 			},
 			expectedIssuerName: "letsencrypt-prod",
+			expectedTLSDefault: true,
+		},
+		{
+			name: "TLS disabled by default",
+			envVars: map[string]string{
+				"DEFAULT_TLS_ENABLED": "false",
+			},
+			expectedIssuerName: "",
+			expectedTLSDefault: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Setenv("TLS_CLUSTER_ISSUER_NAME", "")
+			t.Setenv("DEFAULT_TLS_ENABLED", "")
 			for k, v := range tt.envVars {
 				t.Setenv(k, v)
 			}
@@ -50,6 +62,9 @@ func TestLoadTLSConfig(t *testing.T) {
 			if config.ClusterIssuerName != tt.expectedIssuerName {
 				t.Errorf("expected ClusterIssuerName %q, got %q", tt.expectedIssuerName, config.ClusterIssuerName)
 			}
+			if config.DefaultTLSEnabled != tt.expectedTLSDefault {
+				t.Errorf("expected DefaultTLSEnabled %v, got %v", tt.expectedTLSDefault, config.DefaultTLSEnabled)
+			}
 		})
 	}
 }
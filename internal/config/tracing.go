@@ -0,0 +1,33 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+// TracingConfig holds OpenTelemetry tracing configuration for the operator.
+// The OTLP exporter itself is configured through the standard
+// OTEL_EXPORTER_OTLP_* environment variables (endpoint, headers, TLS).
+type TracingConfig struct {
+	// Enabled turns on OpenTelemetry spans around the reconcile phases and
+	// Keycloak calls. Defaults to false.
+	Enabled bool
+}
+
+// LoadTracingConfig loads tracing configuration from environment variables.
+func LoadTracingConfig() TracingConfig {
+	return TracingConfig{
+		Enabled: getEnvBool("TRACING_ENABLED", false),
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+)
+
+func TestLoadTracingConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		value           string
+		expectedEnabled bool
+	}{
+		{name: "Disabled by default", value: "", expectedEnabled: false},
+		{name: "Enabled", value: "true", expectedEnabled: true},
+		{name: "Explicitly disabled", value: "false", expectedEnabled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TRACING_ENABLED", tt.value)
+			config := LoadTracingConfig()
+			if config.Enabled != tt.expectedEnabled {
+				t.Errorf("expected Enabled %v, got %v", tt.expectedEnabled, config.Enabled)
+			}
+		})
+	}
+}
@@ -19,22 +19,36 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
 
@@ -44,6 +58,10 @@ import (
 	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/tls"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/conditions"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/priority"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/tracing"
 )
 
 // NebariAppReconciler reconciles a NebariApp object
@@ -55,25 +73,45 @@ type NebariAppReconciler struct {
 	TLSReconciler     *tls.TLSReconciler
 	RoutingReconciler *routing.RoutingReconciler
 	AuthReconciler    *auth.AuthReconciler
+
+	// FinalizerName overrides the finalizer added to NebariApp resources.
+	// Defaults to constants.NebariAppFinalizer when empty.
+	FinalizerName string
+
+	// ReadyConditions lists the sub-conditions that gate the aggregate Ready
+	// condition. Defaults to conditions.DefaultReadyConditions when empty.
+	ReadyConditions []string
+
+	// TLSDisabledByDefault mirrors the routing and TLS reconcilers: apps that
+	// leave routing.tls.enabled unset are advertised with an http:// URL.
+	TLSDisabledByDefault bool
 }
 
 // +kubebuilder:rbac:groups=reconcilers.nebari.dev,resources=nebariapps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=reconcilers.nebari.dev,resources=nebariapps/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=reconcilers.nebari.dev,resources=nebariapps/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
-// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses,verbs=get;list;watch;create
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=securitypolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=backends,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=clienttrafficpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=backendtrafficpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
-func (r *NebariAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *NebariAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "reconcile", attribute.String("nebariapp", req.String()))
+	defer func() { tracing.End(span, err) }()
 	logger := logf.FromContext(ctx)
 
 	logger.Info("Reconciling NebariApp", "name", req.Name, "namespace", req.Namespace)
@@ -92,25 +130,35 @@ func (r *NebariAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	// Handle finalizer
+	finalizer := r.finalizerName()
 	if nebariApp.DeletionTimestamp.IsZero() {
 		// Object is not being deleted, ensure finalizer is present
-		if !controllerutil.ContainsFinalizer(nebariApp, constants.NebariAppFinalizer) {
-			controllerutil.AddFinalizer(nebariApp, constants.NebariAppFinalizer)
+		if !controllerutil.ContainsFinalizer(nebariApp, finalizer) {
+			controllerutil.AddFinalizer(nebariApp, finalizer)
 			if err := r.Update(ctx, nebariApp); err != nil {
 				return ctrl.Result{}, err
 			}
 		}
 	} else {
 		// Object is being deleted
-		if controllerutil.ContainsFinalizer(nebariApp, constants.NebariAppFinalizer) {
+		if controllerutil.ContainsFinalizer(nebariApp, finalizer) {
+			// Keep the routes serving until routing.deletionGracePeriod has
+			// passed so in-flight requests can finish, then clean up
+			if deadline, ok := routing.DrainDeadline(nebariApp); ok && time.Now().Before(deadline) {
+				return r.drain(ctx, nebariApp, deadline)
+			}
+
 			// Run cleanup logic
-			if err := r.cleanup(ctx, nebariApp); err != nil {
+			cleanupCtx, cleanupSpan := tracing.Start(ctx, "reconcile.cleanup")
+			err := r.cleanup(cleanupCtx, nebariApp)
+			tracing.End(cleanupSpan, err)
+			if err != nil {
 				logger.Error(err, "Failed to cleanup resources")
 				return ctrl.Result{}, err
 			}
 
 			// Remove finalizer
-			controllerutil.RemoveFinalizer(nebariApp, constants.NebariAppFinalizer)
+			controllerutil.RemoveFinalizer(nebariApp, finalizer)
 			if err := r.Update(ctx, nebariApp); err != nil {
 				return ctrl.Result{}, err
 			}
@@ -124,6 +172,11 @@ func (r *NebariAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		nebariApp.Status.Hostname = nebariApp.Spec.Hostname
 	}
 
+	// A disabled app keeps its NebariApp but none of the resources it manages
+	if !appEnabled(nebariApp) {
+		return r.disable(ctx, nebariApp, start)
+	}
+
 	// Set initial reconciling status only for new resources (no existing Ready condition).
 	// Avoid setting Unknown on every reconcile, which would toggle True->Unknown->True
 	// and update lastTransitionTime even when nothing changed.
@@ -133,37 +186,63 @@ func (r *NebariAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	// Validate namespace opt-in and NebariApp spec
-	if err := r.CoreReconciler.ValidateSpec(ctx, nebariApp); err != nil {
+	validateCtx, validateSpan := tracing.Start(ctx, "reconcile.validate")
+	servicePort, err := r.CoreReconciler.ValidateSpec(validateCtx, nebariApp)
+	tracing.End(validateSpan, err)
+	if err != nil {
 		logger.Error(err, "Core validation failed")
-		if err := r.Status().Update(ctx, nebariApp); err != nil {
+		if err := r.updateStatus(ctx, nebariApp, start); err != nil {
 			return ctrl.Result{}, err
 		}
 		// Requeue after a longer delay for validation failures
-		return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+		return ctrl.Result{RequeueAfter: validationRequeueAfter}, nil
 	}
 
 	// Core validation completed successfully (logged by CoreReconciler)
 
+	// Serve the backend's declared probe paths without authentication
+	probeRoutes, err := r.probeRoutes(ctx, nebariApp)
+	if err != nil {
+		logger.Error(err, "Failed to read probe paths")
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
+			appsv1.ReasonFailed, fmt.Sprintf("Failed to read probe paths: %v", err))
+		if err := r.updateStatus(ctx, nebariApp, start); err != nil {
+			return ctrl.Result{}, err
+		}
+		return resultForError(err)
+	}
+
 	// Reconcile TLS certificates and Gateway listener.
 	// TLSReconciler is always wired up by main.go; the reconciler itself branches on
 	// spec.routing.tls and reports a ClusterIssuerNotConfigured condition when neither
 	// a ClusterIssuer nor routing.tls.secretName is available. The nil guard below is
 	// kept so tests can opt out of TLS reconciliation by leaving the field unset.
 	var tlsListenerName string
+	var tlsActive, tlsPending bool
 	if r.TLSReconciler != nil {
-		tlsResult, err := r.TLSReconciler.ReconcileTLS(ctx, nebariApp)
+		tlsCtx, tlsSpan := tracing.Start(ctx, "reconcile.tls")
+		tlsResult, err := r.TLSReconciler.ReconcileTLS(tlsCtx, nebariApp)
+		tracing.End(tlsSpan, err)
 		if err != nil {
 			logger.Error(err, "TLS reconciliation failed")
 			conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
 				appsv1.ReasonFailed, fmt.Sprintf("TLS reconciliation failed: %v", err))
-			if err := r.Status().Update(ctx, nebariApp); err != nil {
+			if err := r.updateStatus(ctx, nebariApp, start); err != nil {
 				return ctrl.Result{}, err
 			}
-			return ctrl.Result{RequeueAfter: time.Minute}, nil
+			return resultForError(err)
 		}
 		if tlsResult != nil {
+			tlsActive = true
 			tlsListenerName = tlsResult.ListenerName
-			if !tlsResult.CertReady {
+			switch {
+			case tlsResult.CertReady:
+			case !slices.Contains(r.readyGate(), appsv1.ConditionTypeTLSReady):
+				// TLSReady is advisory: keep reconciling routing and auth, and
+				// requeue sooner so the certificate is picked up once issued.
+				logger.Info("TLS secret not ready yet; TLSReady does not gate Ready, continuing")
+				tlsPending = true
+			default:
 				logger.Info("TLS secret not ready yet, will requeue")
 				// Save status so TLSReady=False is visible, then requeue.
 				// On the cert-manager path the Certificate watch will also
@@ -171,7 +250,7 @@ func (r *NebariAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 				// the user-provided-secret path we rely on the periodic
 				// requeue below since there is no Secret watch.
 				nebariApp.Status.ObservedGeneration = nebariApp.Generation
-				if err := r.Status().Update(ctx, nebariApp); err != nil {
+				if err := r.updateStatus(ctx, nebariApp, start); err != nil {
 					return ctrl.Result{}, err
 				}
 				return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
@@ -182,14 +261,23 @@ func (r *NebariAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	// Reconcile routing (HTTPRoute creation/update) if routing is configured
 	if nebariApp.Spec.Routing != nil {
-		if err := r.RoutingReconciler.ReconcileRouting(ctx, nebariApp, tlsListenerName); err != nil {
+		routingCtx, routingSpan := tracing.Start(ctx, "reconcile.routing")
+		err := r.RoutingReconciler.ReconcileRouting(routingCtx, nebariApp, tlsListenerName, servicePort)
+		if err == nil {
+			err = r.RoutingReconciler.ReconcileClientTrafficPolicy(routingCtx, nebariApp, tlsListenerName)
+		}
+		if err == nil {
+			err = r.RoutingReconciler.ReconcileRateLimitPolicy(routingCtx, nebariApp)
+		}
+		tracing.End(routingSpan, err)
+		if err != nil {
 			logger.Error(err, "Routing reconciliation failed")
 			conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
 				appsv1.ReasonFailed, fmt.Sprintf("Routing reconciliation failed: %v", err))
-			if err := r.Status().Update(ctx, nebariApp); err != nil {
+			if err := r.updateStatus(ctx, nebariApp, start); err != nil {
 				return ctrl.Result{}, err
 			}
-			return ctrl.Result{RequeueAfter: time.Minute}, nil
+			return resultForError(err)
 		}
 		logger.Info("Routing reconciled successfully", "nebariapp", nebariApp.Name)
 	} else {
@@ -202,13 +290,21 @@ func (r *NebariAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			logger.Error(err, "Failed to cleanup public HTTPRoute when routing disabled")
 			// Don't fail the reconciliation, just log the error
 		}
+		if err := r.RoutingReconciler.CleanupClientTrafficPolicy(ctx, nebariApp); err != nil {
+			logger.Error(err, "Failed to cleanup ClientTrafficPolicy when routing disabled")
+			// Don't fail the reconciliation, just log the error
+		}
+		if err := r.RoutingReconciler.CleanupRateLimitPolicy(ctx, nebariApp); err != nil {
+			logger.Error(err, "Failed to cleanup rate limit BackendTrafficPolicy when routing disabled")
+			// Don't fail the reconciliation, just log the error
+		}
 		conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
 			"RoutingNotConfigured", "Routing configuration not provided in spec")
 		logger.Info("Routing not configured, cleaned up HTTPRoutes", "nebariapp", nebariApp.Name)
 	}
 
 	// Reconcile public route (unauthenticated paths) if routing has publicRoutes
-	if result, err := r.reconcilePublicRoutes(ctx, nebariApp, tlsListenerName); err != nil || result != nil {
+	if result, err := r.reconcilePublicRoutes(ctx, nebariApp, tlsListenerName, probeRoutes, servicePort, start); err != nil || result != nil {
 		if result != nil {
 			return *result, err
 		}
@@ -216,20 +312,27 @@ func (r *NebariAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	// Reconcile authentication (SecurityPolicy creation/update) if auth is configured
-	if err := r.AuthReconciler.ReconcileAuth(ctx, nebariApp); err != nil {
+	authCtx, authSpan := tracing.Start(ctx, "reconcile.auth")
+	err = r.AuthReconciler.ReconcileAuth(authCtx, nebariApp)
+	tracing.End(authSpan, err)
+	if err != nil {
 		logger.Error(err, "Auth reconciliation failed")
 		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
 			appsv1.ReasonFailed, fmt.Sprintf("Auth reconciliation failed: %v", err))
-		if err := r.Status().Update(ctx, nebariApp); err != nil {
+		if err := r.updateStatus(ctx, nebariApp, start); err != nil {
 			return ctrl.Result{}, err
 		}
-		return ctrl.Result{RequeueAfter: time.Minute}, nil
+		// Invalid auth settings wait longer, an unreachable provider backs off from a short delay
+		return resultForError(err)
 	}
 	logger.Info("Auth reconciled successfully", "nebariapp", nebariApp.Name)
 
-	// Validation succeeded, set Ready condition to True
-	conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionTrue,
-		appsv1.ReasonReconcileSuccess, "NebariApp reconciled successfully")
+	// Check the app is reachable through its Gateway when the probe is enabled
+	r.RoutingReconciler.ProbeConnectivity(ctx, nebariApp, tlsListenerName)
+
+	// All steps succeeded; derive Ready from the sub-conditions that gate it
+	readyStatus, readyReason, readyMessage := conditions.Aggregate(nebariApp, r.requiredReadyConditions(nebariApp, tlsActive))
+	conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, readyStatus, readyReason, readyMessage)
 
 	// Update observed generation
 	nebariApp.Status.ObservedGeneration = nebariApp.Generation
@@ -237,17 +340,67 @@ func (r *NebariAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// Populate the service discovery status so the webapi watcher can read
 	// a pre-validated, URL-resolved view via status.serviceDiscovery.*
 	// without re-deriving it from spec.
-	nebariApp.Status.ServiceDiscovery = buildServiceDiscoveryStatus(nebariApp)
+	nebariApp.Status.ServiceDiscovery = buildServiceDiscoveryStatus(nebariApp, !r.TLSDisabledByDefault)
 
 	// Update status
-	if err := r.Status().Update(ctx, nebariApp); err != nil {
+	if err := r.updateStatus(ctx, nebariApp, start); err != nil {
 		logger.Error(err, "Failed to update NebariApp status")
 		return ctrl.Result{}, err
 	}
 
 	logger.Info("Successfully reconciled NebariApp")
+	if tlsPending {
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
 	// Requeue after 1 minute for now (until full implementation)
-	return ctrl.Result{RequeueAfter: time.Minute}, nil
+	return ctrl.Result{RequeueAfter: defaultRequeueAfter}, nil
+}
+
+// updateStatus records when the reconcile that began at start finished and how
+// long it took, then writes the NebariApp status.
+func (r *NebariAppReconciler) updateStatus(ctx context.Context, nebariApp *appsv1.NebariApp, start time.Time) error {
+	now := time.Now()
+	nebariApp.Status.LastReconcileTime = &metav1.Time{Time: now}
+	nebariApp.Status.ReconcileDuration = &metav1.Duration{Duration: now.Sub(start)}
+	return r.Status().Update(ctx, nebariApp)
+}
+
+// readyGate returns the configured sub-conditions that gate Ready.
+func (r *NebariAppReconciler) readyGate() []string {
+	if len(r.ReadyConditions) > 0 {
+		return r.ReadyConditions
+	}
+	return conditions.DefaultReadyConditions
+}
+
+// requiredReadyConditions narrows the Ready gate to the sub-conditions that apply
+// to this app: RoutingReady when routing is configured, TLSReady when the TLS
+// reconciler handled TLS for the app, and AuthReady when auth is enabled.
+// ConnectivityReady is added when the app's connectivity probe is active.
+func (r *NebariAppReconciler) requiredReadyConditions(nebariApp *appsv1.NebariApp, tlsActive bool) []string {
+	var required []string
+	for _, conditionType := range r.readyGate() {
+		switch conditionType {
+		case appsv1.ConditionTypeRoutingReady:
+			if nebariApp.Spec.Routing == nil {
+				continue
+			}
+		case appsv1.ConditionTypeTLSReady:
+			if !tlsActive {
+				continue
+			}
+		case appsv1.ConditionTypeAuthReady:
+			if nebariApp.Spec.Auth == nil || !nebariApp.Spec.Auth.Enabled {
+				continue
+			}
+		}
+		required = append(required, conditionType)
+	}
+	// An app that opts into the connectivity probe asked for it to gate Ready
+	if r.RoutingReconciler != nil && r.RoutingReconciler.ConnectivityProbeActive(nebariApp) {
+		required = append(required, appsv1.ConditionTypeConnectivityReady)
+	}
+	return required
 }
 
 // buildServiceDiscoveryStatus computes the service discovery descriptor from
@@ -259,7 +412,9 @@ func (r *NebariAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 // - auth disabled → visibility="public", requiredGroups=[]
 // - auth enabled, no groups → visibility="private", requiredGroups=[]
 // - auth enabled, with groups → visibility="private", requiredGroups=auth.groups
-func buildServiceDiscoveryStatus(app *appsv1.NebariApp) *appsv1.ServiceDiscoveryStatus {
+//
+// tlsDefault is the operator-wide TLS default used when routing.tls.enabled is unset.
+func buildServiceDiscoveryStatus(app *appsv1.NebariApp, tlsDefault bool) *appsv1.ServiceDiscoveryStatus {
 	if app.Spec.LandingPage == nil || !app.Spec.LandingPage.Enabled {
 		return &appsv1.ServiceDiscoveryStatus{Enabled: false}
 	}
@@ -279,11 +434,13 @@ func buildServiceDiscoveryStatus(app *appsv1.NebariApp) *appsv1.ServiceDiscovery
 		requiredGroups = app.Spec.Auth.Groups
 	}
 
+	tlsEnabled := tlsDefault
+	if app.Spec.Routing != nil && app.Spec.Routing.TLS != nil && app.Spec.Routing.TLS.Enabled != nil {
+		tlsEnabled = *app.Spec.Routing.TLS.Enabled
+	}
 	scheme := "https"
-	if app.Spec.Routing != nil && app.Spec.Routing.TLS != nil {
-		if app.Spec.Routing.TLS.Enabled != nil && !*app.Spec.Routing.TLS.Enabled {
-			scheme = "http"
-		}
+	if !tlsEnabled {
+		scheme = "http"
 	}
 	url := scheme + "://" + app.Spec.Hostname
 	if lp.ExternalUrl != "" {
@@ -303,35 +460,92 @@ func buildServiceDiscoveryStatus(app *appsv1.NebariApp) *appsv1.ServiceDiscovery
 	}
 }
 
-// reconcilePublicRoutes handles public route reconciliation for paths that bypass OIDC.
+// probeRoutes returns the probe paths declared on the app's Service as public
+// routes when auth.autoExemptProbes is set. They are served alongside
+// routing.publicRoutes without being added to the spec, so the stored NebariApp
+// never picks them up.
+func (r *NebariAppReconciler) probeRoutes(ctx context.Context, nebariApp *appsv1.NebariApp) ([]appsv1.RouteMatch, error) {
+	auth := nebariApp.Spec.Auth
+	if auth == nil || !auth.Enabled || !auth.AutoExemptProbes || nebariApp.Spec.Routing == nil {
+		return nil, nil
+	}
+
+	probeRoutes, ignored, err := core.ProbePublicRoutes(ctx, r.Client, nebariApp)
+	if err != nil {
+		return nil, err
+	}
+	if len(ignored) > 0 {
+		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonProbePathsIgnored,
+			fmt.Sprintf("Ignored invalid entries in the Service's %s annotation: %s",
+				constants.AnnotationProbePaths, strings.Join(ignored, ", ")))
+	}
+	if len(probeRoutes) > 0 {
+		logf.FromContext(ctx).V(1).Info("Exempting probe paths from authentication", "probeRoutes", probeRoutes)
+	}
+	return probeRoutes, nil
+}
+
+// reconcilePublicRoutes handles public route reconciliation for paths that bypass OIDC:
+// routing.publicRoutes plus the app's exempted probeRoutes, forwarded to servicePort.
 // Returns a non-nil Result pointer if the caller should return early.
-func (r *NebariAppReconciler) reconcilePublicRoutes(ctx context.Context, nebariApp *appsv1.NebariApp, tlsListenerName string) (*ctrl.Result, error) {
+func (r *NebariAppReconciler) reconcilePublicRoutes(ctx context.Context, nebariApp *appsv1.NebariApp, tlsListenerName string,
+	probeRoutes []appsv1.RouteMatch, servicePort int32, start time.Time) (*ctrl.Result, error) {
 	logger := logf.FromContext(ctx)
 
-	if nebariApp.Spec.Routing == nil || len(nebariApp.Spec.Routing.PublicRoutes) == 0 {
+	if nebariApp.Spec.Routing == nil {
 		return nil, nil
 	}
 
 	if nebariApp.Spec.Auth == nil || !nebariApp.Spec.Auth.Enabled {
-		// Warn: publicRoutes configured but auth is not enabled, so they have no effect
-		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, "PublicRoutesIgnored",
-			"routing.publicRoutes is configured but auth is not enabled — all routes are already public")
+		if len(nebariApp.Spec.Routing.PublicRoutes) > 0 {
+			// Warn: publicRoutes configured but auth is not enabled, so they have no effect
+			r.Recorder.Event(nebariApp, corev1.EventTypeWarning, "PublicRoutesIgnored",
+				"routing.publicRoutes is configured but auth is not enabled — all routes are already public")
+		}
 		return nil, nil
 	}
 
-	if err := r.RoutingReconciler.ReconcilePublicRoute(ctx, nebariApp, tlsListenerName); err != nil {
+	// Runs without public routes too, so the public HTTPRoute is deleted once the
+	// last path is removed and auth applies to it again.
+	if err := r.RoutingReconciler.ReconcilePublicRoute(ctx, nebariApp, tlsListenerName, probeRoutes, servicePort); err != nil {
 		logger.Error(err, "Public route reconciliation failed")
 		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
 			appsv1.ReasonFailed, fmt.Sprintf("Public route reconciliation failed: %v", err))
-		if err := r.Status().Update(ctx, nebariApp); err != nil {
+		if err := r.updateStatus(ctx, nebariApp, start); err != nil {
 			return &ctrl.Result{}, err
 		}
-		return &ctrl.Result{RequeueAfter: time.Minute}, nil
+		result, err := resultForError(err)
+		return &result, err
 	}
 	logger.Info("Public route reconciled successfully", "nebariapp", nebariApp.Name)
 	return nil, nil
 }
 
+// drain marks a deleted NebariApp's HTTPRoutes as draining and requeues for
+// when the grace period ends. Nothing is removed until then.
+func (r *NebariAppReconciler) drain(ctx context.Context, nebariApp *appsv1.NebariApp, deadline time.Time) (ctrl.Result, error) {
+	if r.RoutingReconciler != nil {
+		marked, err := r.RoutingReconciler.MarkHTTPRoutesDraining(ctx, nebariApp, deadline)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to mark HTTPRoutes as draining")
+			return ctrl.Result{}, err
+		}
+		if marked {
+			r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonDraining,
+				fmt.Sprintf("Draining HTTPRoutes until %s before cleanup", deadline.UTC().Format(time.RFC3339)))
+		}
+	}
+	return ctrl.Result{RequeueAfter: time.Until(deadline)}, nil
+}
+
+// finalizerName returns the finalizer this reconciler manages on NebariApps.
+func (r *NebariAppReconciler) finalizerName() string {
+	if r.FinalizerName != "" {
+		return r.FinalizerName
+	}
+	return constants.NebariAppFinalizer
+}
+
 // cleanup removes resources created by this NebariApp
 func (r *NebariAppReconciler) cleanup(ctx context.Context, nebariApp *appsv1.NebariApp) error {
 	logger := logf.FromContext(ctx)
@@ -339,50 +553,178 @@ func (r *NebariAppReconciler) cleanup(ctx context.Context, nebariApp *appsv1.Neb
 
 	r.Recorder.Event(nebariApp, corev1.EventTypeNormal, "Cleanup", "Starting resource cleanup")
 
-	// Cleanup in reverse pipeline order: Auth -> Routing -> TLS.
-	// Auth depends on routing (SecurityPolicy references HTTPRoute), and
-	// routing depends on TLS (HTTPRoute references the per-app listener).
-
-	// Cleanup authentication resources (delete OIDC client if provisioned)
-	if r.AuthReconciler != nil {
-		if err := r.AuthReconciler.CleanupAuth(ctx, nebariApp); err != nil {
-			logger.Error(err, "Failed to cleanup auth resources")
+	for _, step := range r.cleanupOrder() {
+		if err := step.run(ctx, nebariApp); err != nil {
+			logger.Error(err, "Cleanup step failed", "step", step.name)
 			return err
 		}
 	}
 
-	// Delete HTTPRoute explicitly (also has ownerReferences for GC)
+	logger.Info("Cleanup completed")
+	return nil
+}
+
+// cleanupStep is one phase of finalizer cleanup.
+type cleanupStep struct {
+	name string
+	run  func(context.Context, *appsv1.NebariApp) error
+}
+
+// cleanupOrder lists the finalizer cleanup phases in the order they run, the
+// reverse of the reconcile pipeline: Auth -> Routing -> TLS. The OIDC client is
+// deleted first, while the HTTPRoute its SecurityPolicy references still exists,
+// so a failed client deletion keeps the finalizer and the route in place for the
+// retry. Routing depends on TLS (HTTPRoute references the per-app listener).
+// Phases whose reconciler is not configured are skipped.
+func (r *NebariAppReconciler) cleanupOrder() []cleanupStep {
+	var steps []cleanupStep
+
+	// Delete the provisioned OIDC client; the SecurityPolicy is garbage collected
+	if r.AuthReconciler != nil {
+		steps = append(steps, cleanupStep{"auth", r.AuthReconciler.CleanupAuth})
+	}
+
+	// Delete HTTPRoutes explicitly (they also have ownerReferences for GC).
+	// The ClientTrafficPolicy lives in the Gateway namespace, so it has no ownerReference.
 	if r.RoutingReconciler != nil {
-		if err := r.RoutingReconciler.CleanupHTTPRoute(ctx, nebariApp); err != nil {
-			logger.Error(err, "Failed to delete HTTPRoute")
-			return err
-		}
-		// Also clean up the public HTTPRoute if it exists
-		if err := r.RoutingReconciler.CleanupPublicHTTPRoute(ctx, nebariApp); err != nil {
-			logger.Error(err, "Failed to delete public HTTPRoute")
-			return err
-		}
+		steps = append(steps,
+			cleanupStep{"httproute", r.RoutingReconciler.CleanupHTTPRoute},
+			cleanupStep{"public-httproute", r.RoutingReconciler.CleanupPublicHTTPRoute},
+			cleanupStep{"clienttrafficpolicy", r.RoutingReconciler.CleanupClientTrafficPolicy},
+		)
 	}
 
 	// Cleanup TLS resources (Certificate + Gateway listener)
 	if r.TLSReconciler != nil {
-		if err := r.TLSReconciler.CleanupTLS(ctx, nebariApp); err != nil {
-			logger.Error(err, "Failed to cleanup TLS resources")
-			return err
+		steps = append(steps, cleanupStep{"tls", r.TLSReconciler.CleanupTLS})
+	}
+
+	return steps
+}
+
+// appEnabled reports whether spec.enabled leaves the app exposed. Unset means enabled.
+func appEnabled(nebariApp *appsv1.NebariApp) bool {
+	return nebariApp.Spec.Enabled == nil || *nebariApp.Spec.Enabled
+}
+
+// disable removes the resources the operator manages for a NebariApp with
+// spec.enabled=false and reports the app as disabled. The NebariApp and its
+// finalizer stay; setting spec.enabled back to true recreates everything on
+// the next reconcile.
+func (r *NebariAppReconciler) disable(ctx context.Context, nebariApp *appsv1.NebariApp, start time.Time) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var wasDisabled bool
+	if ready := conditions.GetCondition(nebariApp, appsv1.ConditionTypeReady); ready != nil {
+		wasDisabled = ready.Reason == appsv1.ReasonDisabled
+	}
+
+	for _, step := range r.disableOrder() {
+		if err := step.run(ctx, nebariApp); err != nil {
+			logger.Error(err, "Failed to remove resources of disabled NebariApp", "step", step.name)
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
+				appsv1.ReasonFailed, fmt.Sprintf("Failed to remove resources of the disabled app: %v", err))
+			if err := r.updateStatus(ctx, nebariApp, start); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
 		}
 	}
 
-	// Additional cleanup handled automatically:
+	for _, conditionType := range []string{
+		appsv1.ConditionTypeRoutingReady,
+		appsv1.ConditionTypeTLSReady,
+		appsv1.ConditionTypeAuthReady,
+		appsv1.ConditionTypeConnectivityReady,
+	} {
+		if conditions.GetCondition(nebariApp, conditionType) != nil {
+			conditions.SetCondition(nebariApp, conditionType, metav1.ConditionFalse,
+				appsv1.ReasonDisabled, "The app is disabled")
+		}
+	}
+	conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
+		appsv1.ReasonDisabled, "spec.enabled is false; the app's managed resources were removed")
+	if !wasDisabled {
+		logger.Info("NebariApp disabled, managed resources removed")
+		r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonDisabled,
+			"spec.enabled is false; removed the app's routes, policies and OIDC client")
+	}
 
-	logger.Info("Cleanup completed")
-	return nil
+	nebariApp.Status.ObservedGeneration = nebariApp.Generation
+	nebariApp.Status.ServiceDiscovery = &appsv1.ServiceDiscoveryStatus{Enabled: false}
+	if err := r.updateStatus(ctx, nebariApp, start); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// disableOrder lists the phases that tear down a disabled NebariApp. They follow
+// cleanupOrder, except that the auth phase also deletes the SecurityPolicies
+// (the NebariApp stays, so garbage collection would not remove them) and the
+// rate limit policy is removed as well.
+func (r *NebariAppReconciler) disableOrder() []cleanupStep {
+	steps := r.cleanupOrder()
+	for i := range steps {
+		if steps[i].name == "auth" {
+			steps[i].run = r.AuthReconciler.DisableAuth
+		}
+	}
+	if r.RoutingReconciler != nil {
+		steps = append(steps, cleanupStep{"ratelimitpolicy", r.RoutingReconciler.CleanupRateLimitPolicy})
+	}
+	return steps
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *NebariAppReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Use a priority queue so apps annotated with nebari.dev/priority are
+	// reconciled before other apps when many are waiting in the queue.
+	// Status-only updates are ignored: every reconcile writes
+	// status.lastReconcileTime, which would otherwise trigger another reconcile.
+	// Annotation and label changes still pass for nebari.dev/force-reprovision
+	// and nebari.dev/priority.
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&appsv1.NebariApp{}).
-		Named("nebariapp")
+		For(&appsv1.NebariApp{}, ctrlbuilder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+		))).
+		Named("nebariapp").
+		WithOptions(controller.Options{
+			UsePriorityQueue: ptr.To(true),
+			NewQueue: func(name string, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+				inner := priorityqueue.New(name, func(o *priorityqueue.Opts[reconcile.Request]) {
+					o.Log = mgr.GetLogger().WithValues("controller", name)
+					o.RateLimiter = rateLimiter
+				})
+				return priority.NewQueue(inner, mgr.GetCache())
+			},
+		})
+
+	// Watch Namespaces so NebariApps created before their namespace was opted
+	// in reconcile as soon as the nebari.dev/managed label is added, instead of
+	// waiting for the periodic requeue.
+	builder = builder.Watches(
+		&corev1.Namespace{},
+		handler.EnqueueRequestsFromMapFunc(r.namespaceToNebariApps),
+		ctrlbuilder.WithPredicates(namespaceOptInPredicate()),
+	)
+
+	// Watch Services so a renumbered or removed port is reflected in the
+	// HTTPRoute backends right away. Only port changes are passed on.
+	builder = builder.Watches(
+		&corev1.Service{},
+		handler.EnqueueRequestsFromMapFunc(r.serviceToNebariApps),
+		ctrlbuilder.WithPredicates(servicePortsChangedPredicate()),
+	)
+
+	// Own the SecurityPolicies the auth reconciler creates so an edited or
+	// deleted policy is restored right away instead of at the periodic requeue.
+	// Status-only updates from Envoy Gateway are ignored.
+	if r.AuthReconciler != nil {
+		builder = builder.Owns(&egv1alpha1.SecurityPolicy{},
+			ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	}
 
 	// Watch cert-manager Certificates so that Certificate readiness transitions
 	// trigger NebariApp reconciliation without waiting for the periodic requeue.
@@ -395,18 +737,233 @@ func (r *NebariAppReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		)
 	}
 
+	// Watch the shared Gateways so NebariApps created before their Gateway
+	// reconcile as soon as it appears or becomes Programmed, instead of waiting
+	// for the periodic requeue.
+	if r.RoutingReconciler != nil {
+		builder = builder.Watches(
+			&gatewayv1.Gateway{},
+			handler.EnqueueRequestsFromMapFunc(r.gatewayToNebariApps),
+			ctrlbuilder.WithPredicates(gatewayAvailabilityPredicate()),
+		)
+
+		// Own the HTTPRoutes the routing reconciler creates so a route deleted
+		// or edited out-of-band is recreated right away. Of the status updates
+		// from the Gateway controller, only those that change whether a Gateway
+		// accepted the route pass, so RoutingReady follows them.
+		builder = builder.Owns(&gatewayv1.HTTPRoute{},
+			ctrlbuilder.WithPredicates(predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				httpRouteAcceptancePredicate(),
+			)))
+	}
+
 	return builder.Complete(r)
 }
 
+// gatewayAvailabilityPredicate passes Gateway creations and updates that change
+// the Gateway's Programmed condition. Other updates (such as the per-app
+// listeners the TLS reconciler adds) are ignored to avoid fanning out a
+// reconcile to every NebariApp on each listener change.
+func gatewayAvailabilityPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return true },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldGateway, ok := e.ObjectOld.(*gatewayv1.Gateway)
+			if !ok {
+				return false
+			}
+			newGateway, ok := e.ObjectNew.(*gatewayv1.Gateway)
+			if !ok {
+				return false
+			}
+			return meta.IsStatusConditionTrue(oldGateway.Status.Conditions, string(gatewayv1.GatewayConditionProgrammed)) !=
+				meta.IsStatusConditionTrue(newGateway.Status.Conditions, string(gatewayv1.GatewayConditionProgrammed))
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// httpRouteAcceptancePredicate passes HTTPRoute updates that change the
+// Accepted condition a Gateway reported on the route, or the route generation
+// it was reported for.
+func httpRouteAcceptancePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldRoute, ok := e.ObjectOld.(*gatewayv1.HTTPRoute)
+			if !ok {
+				return false
+			}
+			newRoute, ok := e.ObjectNew.(*gatewayv1.HTTPRoute)
+			if !ok {
+				return false
+			}
+			return !slices.Equal(routeAcceptance(oldRoute), routeAcceptance(newRoute))
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// routeAcceptance summarizes the Accepted conditions on an HTTPRoute's parents.
+func routeAcceptance(route *gatewayv1.HTTPRoute) []string {
+	summary := make([]string, 0, len(route.Status.Parents))
+	for _, parent := range route.Status.Parents {
+		accepted := meta.FindStatusCondition(parent.Conditions, string(gatewayv1.RouteConditionAccepted))
+		if accepted == nil {
+			continue
+		}
+		namespace := ""
+		if parent.ParentRef.Namespace != nil {
+			namespace = string(*parent.ParentRef.Namespace)
+		}
+		summary = append(summary, fmt.Sprintf("%s/%s=%s@%d",
+			namespace, parent.ParentRef.Name, accepted.Status, accepted.ObservedGeneration))
+	}
+	return summary
+}
+
+// namespaceOptInPredicate passes Namespace creations and updates where the
+// namespace carries the nebari.dev/managed=true label and did not before.
+// Removing the label is left to the periodic requeue, which reports the
+// validation failure.
+func namespaceOptInPredicate() predicate.Predicate {
+	optedIn := func(obj client.Object) bool {
+		return obj != nil && obj.GetLabels()[core.ManagedNamespaceLabel] == "true"
+	}
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return optedIn(e.Object) },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !optedIn(e.ObjectOld) && optedIn(e.ObjectNew)
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// servicePortsChangedPredicate passes Service creations, deletions and updates
+// that change the Service's ports or its probe paths annotation.
+func servicePortsChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return true },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldService, ok := e.ObjectOld.(*corev1.Service)
+			if !ok {
+				return false
+			}
+			newService, ok := e.ObjectNew.(*corev1.Service)
+			if !ok {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldService.Spec.Ports, newService.Spec.Ports) ||
+				oldService.Annotations[constants.AnnotationProbePaths] != newService.Annotations[constants.AnnotationProbePaths]
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// serviceToNebariApps maps a Service to every NebariApp whose spec.service
+// references it, including references from other namespaces.
+func (r *NebariAppReconciler) serviceToNebariApps(ctx context.Context, obj client.Object) []reconcile.Request {
+	apps := &appsv1.NebariAppList{}
+	if err := r.List(ctx, apps); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list NebariApps for Service",
+			"service", obj.GetName(), "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range apps.Items {
+		ref := apps.Items[i].Spec.Service
+		if (ref.Kind != "" && ref.Kind != "Service") || ref.Group != "" || ref.Name != obj.GetName() {
+			continue
+		}
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = apps.Items[i].Namespace
+		}
+		if namespace != obj.GetNamespace() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: apps.Items[i].Name, Namespace: apps.Items[i].Namespace},
+		})
+	}
+	return requests
+}
+
+// namespaceToNebariApps maps a Namespace to every NebariApp it contains.
+func (r *NebariAppReconciler) namespaceToNebariApps(ctx context.Context, obj client.Object) []reconcile.Request {
+	apps := &appsv1.NebariAppList{}
+	if err := r.List(ctx, apps, client.InNamespace(obj.GetName())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list NebariApps for Namespace", "namespace", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(apps.Items))
+	for i := range apps.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: apps.Items[i].Name, Namespace: apps.Items[i].Namespace},
+		})
+	}
+	return requests
+}
+
+// gatewayToNebariApps maps a shared Gateway to every NebariApp exposed on it.
+func (r *NebariAppReconciler) gatewayToNebariApps(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetNamespace() != constants.GatewayNamespace {
+		return nil
+	}
+
+	apps := &appsv1.NebariAppList{}
+	if err := r.List(ctx, apps); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list NebariApps for Gateway", "gateway", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range apps.Items {
+		app := &apps.Items[i]
+		if !slices.Contains(naming.GatewayNames(app), obj.GetName()) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: app.Name, Namespace: app.Namespace},
+		})
+	}
+	return requests
+}
+
 // certificateToNebariApp maps a cert-manager Certificate to the NebariApp that owns it
-// using the labels set by the TLS reconciler.
-func (r *NebariAppReconciler) certificateToNebariApp(_ context.Context, obj client.Object) []reconcile.Request {
+// using the labels set by the TLS reconciler. The name label is truncated for long
+// app names, so a label at the maximum label length is resolved by matching the
+// namespace's apps against naming.LabelValue.
+func (r *NebariAppReconciler) certificateToNebariApp(ctx context.Context, obj client.Object) []reconcile.Request {
 	name := obj.GetLabels()["nebari.dev/nebariapp-name"]
 	namespace := obj.GetLabels()["nebari.dev/nebariapp-namespace"]
 	if name == "" || namespace == "" {
 		return nil
 	}
-	return []reconcile.Request{
-		{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}},
+	if len(name) < validation.LabelValueMaxLength {
+		return []reconcile.Request{
+			{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}},
+		}
+	}
+
+	apps := &appsv1.NebariAppList{}
+	if err := r.List(ctx, apps, client.InNamespace(namespace)); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list NebariApps for Certificate", "certificate", obj.GetName())
+		return nil
+	}
+	for i := range apps.Items {
+		if naming.LabelValue(apps.Items[i].Name) == name {
+			return []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: apps.Items[i].Name, Namespace: namespace}},
+			}
+		}
 	}
+	return nil
 }
@@ -18,21 +18,41 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	reconcilersv1 "github.com/nebari-dev/nebari-operator/api/v1"
 	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/auth"
+	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/auth/providers"
 	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/core"
 	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/routing"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
 )
 
 var _ = Describe("NebariApp Controller", func() {
@@ -252,5 +272,1317 @@ var _ = Describe("NebariApp Controller", func() {
 			By("Cleaning up the test resource")
 			Expect(k8sClient.Delete(ctx, appWithRouting)).To(Succeed())
 		})
+
+		It("should add and honor a configured finalizer name", func() {
+			const customFinalizer = "apps.nebari.dev/finalizer-tenant-a"
+			customName := types.NamespacedName{Name: "test-custom-finalizer", Namespace: "default"}
+
+			By("Creating a NebariApp")
+			app := &reconcilersv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      customName.Name,
+					Namespace: customName.Namespace,
+				},
+				Spec: reconcilersv1.NebariAppSpec{
+					Hostname: "test-custom-finalizer.nebari.local",
+					Service: reconcilersv1.ServiceReference{
+						Name: "test-service",
+						Port: 8080,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, app)).To(Succeed())
+
+			fakeRecorder3 := record.NewFakeRecorder(10)
+			controllerReconciler := &NebariAppReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: fakeRecorder3,
+				CoreReconciler: &core.CoreReconciler{
+					Client:   k8sClient,
+					Scheme:   k8sClient.Scheme(),
+					Recorder: fakeRecorder3,
+				},
+				RoutingReconciler: &routing.RoutingReconciler{
+					Client:   k8sClient,
+					Scheme:   k8sClient.Scheme(),
+					Recorder: fakeRecorder3,
+				},
+				FinalizerName: customFinalizer,
+			}
+
+			By("Reconciling adds the configured finalizer instead of the default one")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: customName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedApp := &reconcilersv1.NebariApp{}
+			Expect(k8sClient.Get(ctx, customName, updatedApp)).To(Succeed())
+			Expect(updatedApp.Finalizers).To(ContainElement(customFinalizer))
+			Expect(updatedApp.Finalizers).NotTo(ContainElement(constants.NebariAppFinalizer))
+
+			By("Deleting the resource and reconciling removes the configured finalizer")
+			Expect(k8sClient.Delete(ctx, updatedApp)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: customName})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = k8sClient.Get(ctx, customName, &reconcilersv1.NebariApp{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("Gateway watch mapping", func() {
+	ctx := context.Background()
+
+	newApp := func(name, namespace, gateway string) *reconcilersv1.NebariApp {
+		return &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: name + ".nebari.local",
+				Gateway:  gateway,
+				Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+			},
+		}
+	}
+
+	It("should map a Gateway to every NebariApp targeting it", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newApp("public-default", "team-a", ""),
+			newApp("public-explicit", "team-b", "public"),
+			newApp("internal-app", "team-a", "internal"),
+		).Build()
+		r := &NebariAppReconciler{Client: fakeClient, Scheme: scheme}
+
+		publicGateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{
+			Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace,
+		}}
+		Expect(r.gatewayToNebariApps(ctx, publicGateway)).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "public-default", Namespace: "team-a"}},
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "public-explicit", Namespace: "team-b"}},
+		))
+
+		internalGateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{
+			Name: constants.InternalGatewayName, Namespace: constants.GatewayNamespace,
+		}}
+		Expect(r.gatewayToNebariApps(ctx, internalGateway)).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "internal-app", Namespace: "team-a"}},
+		))
+
+		By("ignoring a same-named Gateway outside the gateway namespace")
+		otherGateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{
+			Name: constants.PublicGatewayName, Namespace: "other",
+		}}
+		Expect(r.gatewayToNebariApps(ctx, otherGateway)).To(BeEmpty())
+	})
+
+	It("should only pass Gateway updates that change the Programmed condition", func() {
+		notProgrammed := &gatewayv1.Gateway{}
+		programmed := &gatewayv1.Gateway{Status: gatewayv1.GatewayStatus{Conditions: []metav1.Condition{{
+			Type:   string(gatewayv1.GatewayConditionProgrammed),
+			Status: metav1.ConditionTrue,
+		}}}}
+
+		p := gatewayAvailabilityPredicate()
+		Expect(p.Create(event.CreateEvent{Object: notProgrammed})).To(BeTrue())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: notProgrammed, ObjectNew: programmed})).To(BeTrue())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: programmed, ObjectNew: programmed.DeepCopy()})).To(BeFalse())
+	})
+
+	It("should only pass HTTPRoute status updates that change acceptance", func() {
+		withAccepted := func(status metav1.ConditionStatus, observedGeneration int64) *gatewayv1.HTTPRoute {
+			return &gatewayv1.HTTPRoute{Status: gatewayv1.HTTPRouteStatus{RouteStatus: gatewayv1.RouteStatus{
+				Parents: []gatewayv1.RouteParentStatus{{
+					ParentRef: gatewayv1.ParentReference{Name: constants.PublicGatewayName},
+					Conditions: []metav1.Condition{{
+						Type:               string(gatewayv1.RouteConditionAccepted),
+						Status:             status,
+						ObservedGeneration: observedGeneration,
+					}},
+				}},
+			}}}
+		}
+		pending := &gatewayv1.HTTPRoute{}
+		accepted := withAccepted(metav1.ConditionTrue, 1)
+		resolved := accepted.DeepCopy()
+		resolved.Status.Parents[0].Conditions = append(resolved.Status.Parents[0].Conditions, metav1.Condition{
+			Type:   string(gatewayv1.RouteConditionResolvedRefs),
+			Status: metav1.ConditionTrue,
+		})
+
+		p := httpRouteAcceptancePredicate()
+		Expect(p.Update(event.UpdateEvent{ObjectOld: pending, ObjectNew: accepted})).To(BeTrue())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: accepted, ObjectNew: withAccepted(metav1.ConditionFalse, 1)})).To(BeTrue())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: accepted, ObjectNew: withAccepted(metav1.ConditionTrue, 2)})).To(BeTrue())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: accepted, ObjectNew: resolved})).To(BeFalse())
+		Expect(p.Create(event.CreateEvent{Object: accepted})).To(BeFalse())
+	})
+})
+
+var _ = Describe("Namespace watch mapping", func() {
+	ctx := context.Background()
+
+	It("should map a Namespace to every NebariApp in it", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+
+		newApp := func(name, namespace string) *reconcilersv1.NebariApp {
+			return &reconcilersv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: reconcilersv1.NebariAppSpec{
+					Hostname: name + ".nebari.local",
+					Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+				},
+			}
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newApp("app-one", "team-a"),
+			newApp("app-two", "team-a"),
+			newApp("other-app", "team-b"),
+		).Build()
+		r := &NebariAppReconciler{Client: fakeClient, Scheme: scheme}
+
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+		Expect(r.namespaceToNebariApps(ctx, namespace)).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "app-one", Namespace: "team-a"}},
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "app-two", Namespace: "team-a"}},
+		))
+
+		empty := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-c"}}
+		Expect(r.namespaceToNebariApps(ctx, empty)).To(BeEmpty())
+	})
+
+	It("should only pass Namespace events that add the managed label", func() {
+		unlabeled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+		labeled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "team-a",
+			Labels: map[string]string{core.ManagedNamespaceLabel: "true"},
+		}}
+		disabled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "team-a",
+			Labels: map[string]string{core.ManagedNamespaceLabel: "false"},
+		}}
+
+		p := namespaceOptInPredicate()
+		Expect(p.Create(event.CreateEvent{Object: labeled})).To(BeTrue())
+		Expect(p.Create(event.CreateEvent{Object: unlabeled})).To(BeFalse())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: unlabeled, ObjectNew: labeled})).To(BeTrue())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: disabled, ObjectNew: labeled})).To(BeTrue())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: labeled, ObjectNew: labeled.DeepCopy()})).To(BeFalse())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: labeled, ObjectNew: unlabeled})).To(BeFalse())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: unlabeled, ObjectNew: disabled})).To(BeFalse())
+		Expect(p.Delete(event.DeleteEvent{Object: labeled})).To(BeFalse())
+	})
+})
+
+var _ = Describe("Reconcile tracing", func() {
+	ctx := context.Background()
+
+	It("should emit a span for each reconcile phase", func() {
+		recorder := tracetest.NewSpanRecorder()
+		previous := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		DeferCleanup(func() { otel.SetTracerProvider(previous) })
+
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv1.Install(scheme)).To(Succeed())
+		Expect(egv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "traced-app", Namespace: "team-a"},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "traced-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(app).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{core.ManagedNamespaceLabel: "true"},
+			}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "team-a"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+			},
+			app,
+		).Build()
+		fakeRecorder := record.NewFakeRecorder(10)
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			CoreReconciler:    &core.CoreReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			AuthReconciler:    &auth.AuthReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+		}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: "traced-app", Namespace: "team-a"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		spans := recorder.Ended()
+		names := make([]string, 0, len(spans))
+		for _, span := range spans {
+			names = append(names, span.Name())
+		}
+		Expect(names).To(Equal([]string{"reconcile.validate", "reconcile.auth", "reconcile"}))
+
+		By("parenting every phase span to the reconcile span")
+		root := spans[len(spans)-1]
+		for _, span := range spans[:len(spans)-1] {
+			Expect(span.Parent().SpanID()).To(Equal(root.SpanContext().SpanID()))
+		}
+	})
+})
+
+// recordingProvider stands in for a provisioning OIDC provider and records
+// client deletions. Methods the cleanup path does not use panic via the nil
+// embedded interface.
+type recordingProvider struct {
+	providers.OIDCProvider
+	deleteErr error
+	steps     *[]string
+}
+
+func (p *recordingProvider) SupportsProvisioning() bool { return true }
+
+func (p *recordingProvider) DeleteClient(context.Context, *reconcilersv1.NebariApp) error {
+	if p.deleteErr != nil {
+		return p.deleteErr
+	}
+	*p.steps = append(*p.steps, "oidc-client")
+	return nil
+}
+
+var _ = Describe("Deletion cleanup", func() {
+	ctx := context.Background()
+
+	newCleanupFixture := func(deleteErr error) (*NebariAppReconciler, *reconcilersv1.NebariApp, client.Client, *[]string) {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv1.Install(scheme)).To(Succeed())
+		Expect(egv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "doomed-app", Namespace: "team-a"},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "doomed-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+				Auth:     &reconcilersv1.AuthConfig{Enabled: true, Provider: constants.ProviderKeycloak},
+			},
+		}
+		route := &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "doomed-app-route", Namespace: "team-a"},
+		}
+
+		steps := &[]string{}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, route).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					if _, ok := obj.(*gatewayv1.HTTPRoute); ok {
+						*steps = append(*steps, "httproute")
+					}
+					return c.Delete(ctx, obj, opts...)
+				},
+			}).Build()
+		fakeRecorder := record.NewFakeRecorder(20)
+
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			AuthReconciler: &auth.AuthReconciler{
+				Client:   fakeClient,
+				Scheme:   scheme,
+				Recorder: fakeRecorder,
+				Providers: map[string]providers.OIDCProvider{
+					constants.ProviderKeycloak: &recordingProvider{deleteErr: deleteErr, steps: steps},
+				},
+			},
+		}
+		return r, app, fakeClient, steps
+	}
+
+	It("should delete the OIDC client before the HTTPRoute", func() {
+		r, app, fakeClient, steps := newCleanupFixture(nil)
+
+		Expect(r.cleanup(ctx, app)).To(Succeed())
+		Expect(*steps).To(Equal([]string{"oidc-client", "httproute"}))
+
+		err := fakeClient.Get(ctx, types.NamespacedName{Name: "doomed-app-route", Namespace: "team-a"}, &gatewayv1.HTTPRoute{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should keep the HTTPRoute when OIDC client deletion fails", func() {
+		r, app, fakeClient, steps := newCleanupFixture(errors.NewServiceUnavailable("keycloak down"))
+
+		Expect(r.cleanup(ctx, app)).NotTo(Succeed())
+		Expect(*steps).To(BeEmpty())
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "doomed-app-route", Namespace: "team-a"}, &gatewayv1.HTTPRoute{})).To(Succeed())
+	})
+})
+
+var _ = Describe("Deletion grace period", func() {
+	ctx := context.Background()
+
+	It("should mark the HTTPRoute as draining before deleting it", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv1.Install(scheme)).To(Succeed())
+		Expect(egv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		deletedAt := metav1.NewTime(time.Now().Truncate(time.Second))
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "draining-app",
+				Namespace:         "team-a",
+				UID:               "draining-uid",
+				DeletionTimestamp: &deletedAt,
+				Finalizers:        []string{constants.NebariAppFinalizer},
+			},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "draining-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+				Routing:  &reconcilersv1.RoutingConfig{DeletionGracePeriod: "1h"},
+			},
+		}
+		route := &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "draining-app-route",
+				Namespace: "team-a",
+				Labels: map[string]string{
+					"app.kubernetes.io/instance":   "draining-app",
+					"app.kubernetes.io/managed-by": "nebari-operator",
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: reconcilersv1.GroupVersion.String(),
+					Kind:       "NebariApp",
+					Name:       "draining-app",
+					UID:        "draining-uid",
+					Controller: ptr.To(true),
+				}},
+			},
+		}
+
+		steps := &[]string{}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, route).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if _, ok := obj.(*gatewayv1.HTTPRoute); ok && obj.GetAnnotations()[constants.AnnotationDrainingUntil] != "" {
+						*steps = append(*steps, "drain")
+					}
+					return c.Update(ctx, obj, opts...)
+				},
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					if _, ok := obj.(*gatewayv1.HTTPRoute); ok {
+						*steps = append(*steps, "httproute")
+					}
+					return c.Delete(ctx, obj, opts...)
+				},
+			}).Build()
+		fakeRecorder := record.NewFakeRecorder(20)
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+		}
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "draining-app", Namespace: "team-a"}}
+		routeKey := types.NamespacedName{Name: "draining-app-route", Namespace: "team-a"}
+
+		By("keeping the route and requeueing during the grace period")
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 59*time.Minute))
+		Expect(*steps).To(Equal([]string{"drain"}))
+
+		drained := &gatewayv1.HTTPRoute{}
+		Expect(fakeClient.Get(ctx, routeKey, drained)).To(Succeed())
+		Expect(drained.Annotations).To(HaveKeyWithValue(constants.AnnotationDrainingUntil,
+			deletedAt.Add(time.Hour).UTC().Format(time.RFC3339)))
+
+		By("not marking the route again on the next requeue")
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*steps).To(Equal([]string{"drain"}))
+
+		By("deleting the route once the grace period is over")
+		current := &reconcilersv1.NebariApp{}
+		Expect(fakeClient.Get(ctx, req.NamespacedName, current)).To(Succeed())
+		current.Spec.Routing.DeletionGracePeriod = ""
+		Expect(fakeClient.Update(ctx, current)).To(Succeed())
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*steps).To(Equal([]string{"drain", "httproute"}))
+		Expect(errors.IsNotFound(fakeClient.Get(ctx, routeKey, &gatewayv1.HTTPRoute{}))).To(BeTrue())
+	})
+})
+
+var _ = Describe("Auth reconciliation", func() {
+	ctx := context.Background()
+
+	It("should create a SecurityPolicy for an auth-enabled NebariApp through Reconcile", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv1.Install(scheme)).To(Succeed())
+		Expect(egv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "secured-app", Namespace: "team-a"},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "secured-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+				Auth: &reconcilersv1.AuthConfig{
+					Enabled:         true,
+					Provider:        constants.ProviderGenericOIDC,
+					IssuerURL:       "https://idp.example.com/realms/test",
+					ProvisionClient: ptr.To(false),
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(app).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{core.ManagedNamespaceLabel: "true"},
+			}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "team-a"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "secured-app-oidc-client", Namespace: "team-a"},
+				Data:       map[string][]byte{constants.ClientSecretKey: []byte("s3cret")},
+			},
+			app,
+		).Build()
+		fakeRecorder := record.NewFakeRecorder(20)
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			CoreReconciler:    &core.CoreReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			AuthReconciler: &auth.AuthReconciler{
+				Client:   fakeClient,
+				Scheme:   scheme,
+				Recorder: fakeRecorder,
+				Providers: map[string]providers.OIDCProvider{
+					constants.ProviderGenericOIDC: &providers.GenericOIDCProvider{},
+				},
+			},
+		}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: "secured-app", Namespace: "team-a"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		policy := &egv1alpha1.SecurityPolicy{}
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "secured-app-security", Namespace: "team-a"}, policy)).To(Succeed())
+		Expect(policy.Spec.OIDC).NotTo(BeNil())
+		Expect(policy.Spec.OIDC.Provider.Issuer).To(Equal("https://idp.example.com/realms/test"))
+		Expect(policy.Spec.TargetRefs).To(HaveLen(1))
+		Expect(string(policy.Spec.TargetRefs[0].Name)).To(Equal("secured-app-route"))
+
+		By("reporting AuthReady on the NebariApp status")
+		updated := &reconcilersv1.NebariApp{}
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "secured-app", Namespace: "team-a"}, updated)).To(Succeed())
+		Expect(updated.Status.IssuerURL).To(Equal("https://idp.example.com/realms/test"))
+		authReady := meta.FindStatusCondition(updated.Status.Conditions, reconcilersv1.ConditionTypeAuthReady)
+		Expect(authReady).NotTo(BeNil())
+		Expect(authReady.Status).To(Equal(metav1.ConditionTrue))
+	})
+})
+
+var _ = Describe("Probe path exemption", func() {
+	ctx := context.Background()
+
+	It("should route the Service's declared probe paths as public routes", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv1.Install(scheme)).To(Succeed())
+		Expect(egv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "probed-app", Namespace: "team-a"},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "probed-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+				Routing: &reconcilersv1.RoutingConfig{
+					PublicRoutes: []reconcilersv1.RouteMatch{{PathPrefix: "/status"}},
+				},
+				Auth: &reconcilersv1.AuthConfig{
+					Enabled:          true,
+					Provider:         constants.ProviderGenericOIDC,
+					IssuerURL:        "https://idp.example.com/realms/test",
+					ProvisionClient:  ptr.To(false),
+					AutoExemptProbes: true,
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(app).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{core.ManagedNamespaceLabel: "true"},
+			}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "svc",
+					Namespace:   "team-a",
+					Annotations: map[string]string{constants.AnnotationProbePaths: "/healthz, /status"},
+				},
+				Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "probed-app-oidc-client", Namespace: "team-a"},
+				Data:       map[string][]byte{constants.ClientSecretKey: []byte("s3cret")},
+			},
+			&gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+			},
+			app,
+		).Build()
+		fakeRecorder := record.NewFakeRecorder(50)
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			CoreReconciler:    &core.CoreReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			AuthReconciler: &auth.AuthReconciler{
+				Client:   fakeClient,
+				Scheme:   scheme,
+				Recorder: fakeRecorder,
+				Providers: map[string]providers.OIDCProvider{
+					constants.ProviderGenericOIDC: &providers.GenericOIDCProvider{},
+				},
+			},
+		}
+		appKey := types.NamespacedName{Name: "probed-app", Namespace: "team-a"}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: appKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		publicRoute := &gatewayv1.HTTPRoute{}
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "probed-app-public-route", Namespace: "team-a"}, publicRoute)).To(Succeed())
+		var paths []string
+		for _, rule := range publicRoute.Spec.Rules {
+			for _, match := range rule.Matches {
+				Expect(*match.Path.Type).To(Equal(gatewayv1.PathMatchExact))
+				paths = append(paths, *match.Path.Value)
+			}
+		}
+		Expect(paths).To(ConsistOf("/status", "/healthz"))
+
+		By("leaving the stored spec as the user wrote it")
+		stored := &reconcilersv1.NebariApp{}
+		Expect(fakeClient.Get(ctx, appKey, stored)).To(Succeed())
+		Expect(stored.Spec.Routing.PublicRoutes).To(HaveLen(1))
+	})
+
+	It("should keep probe paths out of the stored spec when forcing a re-provision", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(rbacv1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv1.Install(scheme)).To(Succeed())
+		Expect(egv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "probed-app",
+				Namespace:   "team-a",
+				Annotations: map[string]string{constants.AnnotationForceReprovision: "true"},
+			},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "probed-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+				Routing: &reconcilersv1.RoutingConfig{
+					PublicRoutes: []reconcilersv1.RouteMatch{{PathPrefix: "/status"}},
+				},
+				Auth: &reconcilersv1.AuthConfig{
+					Enabled:          true,
+					Provider:         constants.ProviderGenericOIDC,
+					IssuerURL:        "https://idp.example.com/realms/test",
+					ProvisionClient:  ptr.To(true),
+					AutoExemptProbes: true,
+				},
+			},
+		}
+		wantSpec := app.Spec.DeepCopy()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(app).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{core.ManagedNamespaceLabel: "true"},
+			}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "svc",
+					Namespace:   "team-a",
+					Annotations: map[string]string{constants.AnnotationProbePaths: "/healthz"},
+				},
+				Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "probed-app-oidc-client", Namespace: "team-a"},
+				Data:       map[string][]byte{constants.ClientSecretKey: []byte("s3cret")},
+			},
+			&gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+			},
+			app,
+		).Build()
+		fakeRecorder := record.NewFakeRecorder(50)
+		provider := &provisioningProvider{}
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			CoreReconciler:    &core.CoreReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			AuthReconciler: &auth.AuthReconciler{
+				Client:    fakeClient,
+				Scheme:    scheme,
+				Recorder:  fakeRecorder,
+				Providers: map[string]providers.OIDCProvider{constants.ProviderGenericOIDC: provider},
+			},
+		}
+		appKey := types.NamespacedName{Name: "probed-app", Namespace: "team-a"}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: appKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.provisioned).To(Equal(1))
+
+		stored := &reconcilersv1.NebariApp{}
+		Expect(fakeClient.Get(ctx, appKey, stored)).To(Succeed())
+		Expect(stored.Annotations).NotTo(HaveKey(constants.AnnotationForceReprovision))
+		Expect(stored.Spec).To(Equal(*wantSpec))
+	})
+})
+
+// provisioningProvider is a generic OIDC provider that claims to provision
+// clients. ProvisionClient only counts calls; the client secret is expected to
+// exist already.
+type provisioningProvider struct {
+	providers.GenericOIDCProvider
+	provisioned int
+}
+
+func (p *provisioningProvider) SupportsProvisioning() bool { return true }
+
+func (p *provisioningProvider) ProvisionClient(context.Context, *reconcilersv1.NebariApp) error {
+	p.provisioned++
+	return nil
+}
+
+var _ = Describe("Service port changes", func() {
+	ctx := context.Background()
+
+	It("should follow a renumbered named port in the HTTPRoute backend", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv1.Install(scheme)).To(Succeed())
+
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "named-port-app", Namespace: "team-a"},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "named-port-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", PortName: "http"},
+				Routing:  &reconcilersv1.RoutingConfig{},
+			},
+		}
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "team-a"},
+			Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+				{Name: "metrics", Port: 9090},
+				{Name: "http", Port: 8080},
+			}},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(app).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{core.ManagedNamespaceLabel: "true"},
+			}},
+			service,
+			&gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+			},
+			app,
+		).Build()
+		fakeRecorder := record.NewFakeRecorder(50)
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			CoreReconciler:    &core.CoreReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+		}
+		appKey := types.NamespacedName{Name: "named-port-app", Namespace: "team-a"}
+		routeKey := types.NamespacedName{Name: "named-port-app-route", Namespace: "team-a"}
+		backendPort := func() gatewayv1.PortNumber {
+			route := &gatewayv1.HTTPRoute{}
+			Expect(fakeClient.Get(ctx, routeKey, route)).To(Succeed())
+			Expect(route.Spec.Rules).NotTo(BeEmpty())
+			Expect(route.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+			return *route.Spec.Rules[0].BackendRefs[0].Port
+		}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: appKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backendPort()).To(Equal(gatewayv1.PortNumber(8080)))
+
+		By("renumbering the named port on the Service")
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(service), service)).To(Succeed())
+		oldService := service.DeepCopy()
+		service.Spec.Ports[1].Port = 8081
+		Expect(fakeClient.Update(ctx, service)).To(Succeed())
+
+		Expect(servicePortsChangedPredicate().Update(event.UpdateEvent{ObjectOld: oldService, ObjectNew: service})).To(BeTrue())
+		Expect(r.serviceToNebariApps(ctx, service)).To(ConsistOf(reconcile.Request{NamespacedName: appKey}))
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: appKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backendPort()).To(Equal(gatewayv1.PortNumber(8081)))
+
+		By("keeping the port name in the stored spec")
+		stored := &reconcilersv1.NebariApp{}
+		Expect(fakeClient.Get(ctx, appKey, stored)).To(Succeed())
+		Expect(stored.Spec.Service.PortName).To(Equal("http"))
+		Expect(stored.Spec.Service.Port).To(BeZero())
+	})
+
+	It("should keep the resolved port out of the stored spec when forcing a re-provision", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(rbacv1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv1.Install(scheme)).To(Succeed())
+		Expect(egv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "named-port-auth-app",
+				Namespace:   "team-a",
+				Annotations: map[string]string{constants.AnnotationForceReprovision: "true"},
+			},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "named-port-auth-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", PortName: "http"},
+				Routing:  &reconcilersv1.RoutingConfig{},
+				Auth: &reconcilersv1.AuthConfig{
+					Enabled:         true,
+					Provider:        constants.ProviderGenericOIDC,
+					IssuerURL:       "https://idp.example.com/realms/test",
+					ProvisionClient: ptr.To(true),
+				},
+			},
+		}
+		wantSpec := app.Spec.DeepCopy()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(app).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{core.ManagedNamespaceLabel: "true"},
+			}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "team-a"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "named-port-auth-app-oidc-client", Namespace: "team-a"},
+				Data:       map[string][]byte{constants.ClientSecretKey: []byte("s3cret")},
+			},
+			&gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+			},
+			app,
+		).Build()
+		fakeRecorder := record.NewFakeRecorder(50)
+		provider := &provisioningProvider{}
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			CoreReconciler:    &core.CoreReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			AuthReconciler: &auth.AuthReconciler{
+				Client:    fakeClient,
+				Scheme:    scheme,
+				Recorder:  fakeRecorder,
+				Providers: map[string]providers.OIDCProvider{constants.ProviderGenericOIDC: provider},
+			},
+		}
+		appKey := types.NamespacedName{Name: "named-port-auth-app", Namespace: "team-a"}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: appKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.provisioned).To(Equal(1))
+
+		route := &gatewayv1.HTTPRoute{}
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "named-port-auth-app-route", Namespace: "team-a"}, route)).To(Succeed())
+		Expect(route.Spec.Rules).NotTo(BeEmpty())
+		Expect(route.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+		Expect(*route.Spec.Rules[0].BackendRefs[0].Port).To(Equal(gatewayv1.PortNumber(8080)))
+
+		stored := &reconcilersv1.NebariApp{}
+		Expect(fakeClient.Get(ctx, appKey, stored)).To(Succeed())
+		Expect(stored.Annotations).NotTo(HaveKey(constants.AnnotationForceReprovision))
+		Expect(stored.Spec).To(Equal(*wantSpec))
+	})
+
+	It("should map a Service to the NebariApps referencing it", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+
+		newApp := func(name, namespace string, ref reconcilersv1.ServiceReference) *reconcilersv1.NebariApp {
+			return &reconcilersv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec:       reconcilersv1.NebariAppSpec{Hostname: name + ".nebari.local", Service: ref},
+			}
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newApp("same-namespace", "team-a", reconcilersv1.ServiceReference{Name: "svc", Port: 8080}),
+			newApp("cross-namespace", "team-b", reconcilersv1.ServiceReference{Name: "svc", Namespace: "team-a", PortName: "http"}),
+			newApp("other-service", "team-a", reconcilersv1.ServiceReference{Name: "other", Port: 8080}),
+			newApp("other-namespace", "team-b", reconcilersv1.ServiceReference{Name: "svc", Port: 8080}),
+			newApp("backend-kind", "team-a", reconcilersv1.ServiceReference{
+				Name: "svc", Port: 443, Group: "gateway.envoyproxy.io", Kind: "Backend",
+			}),
+		).Build()
+		r := &NebariAppReconciler{Client: fakeClient, Scheme: scheme}
+
+		service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "team-a"}}
+		Expect(r.serviceToNebariApps(ctx, service)).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "same-namespace", Namespace: "team-a"}},
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "cross-namespace", Namespace: "team-b"}},
+		))
+	})
+
+	It("should only pass Service updates that change ports or probe paths", func() {
+		service := &corev1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}}}
+		relabeled := service.DeepCopy()
+		relabeled.Labels = map[string]string{"team": "a"}
+		reannotated := service.DeepCopy()
+		reannotated.Annotations = map[string]string{constants.AnnotationProbePaths: "/healthz"}
+
+		p := servicePortsChangedPredicate()
+		Expect(p.Create(event.CreateEvent{Object: service})).To(BeTrue())
+		Expect(p.Delete(event.DeleteEvent{Object: service})).To(BeTrue())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: service, ObjectNew: relabeled})).To(BeFalse())
+		Expect(p.Update(event.UpdateEvent{ObjectOld: service, ObjectNew: reannotated})).To(BeTrue())
+	})
+})
+
+var _ = Describe("Reconcile timing", func() {
+	ctx := context.Background()
+
+	It("should record when the last reconcile finished and how long it took", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "timed-app", Namespace: "team-a"},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "timed-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(app).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{core.ManagedNamespaceLabel: "true"},
+			}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "team-a"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+			},
+			app,
+		).Build()
+		fakeRecorder := record.NewFakeRecorder(20)
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			CoreReconciler:    &core.CoreReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			AuthReconciler:    &auth.AuthReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+		}
+
+		before := time.Now().Truncate(time.Second)
+		_, err := r.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: "timed-app", Namespace: "team-a"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &reconcilersv1.NebariApp{}
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "timed-app", Namespace: "team-a"}, updated)).To(Succeed())
+		Expect(updated.Status.LastReconcileTime).NotTo(BeNil())
+		Expect(updated.Status.LastReconcileTime.Time).NotTo(BeTemporally("<", before))
+		Expect(updated.Status.ReconcileDuration).NotTo(BeNil())
+		Expect(updated.Status.ReconcileDuration.Duration).To(BeNumerically(">", 0))
+	})
+})
+
+var _ = Describe("Disabling", func() {
+	ctx := context.Background()
+
+	It("should remove the managed resources when disabled and recreate them when re-enabled", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv1.Install(scheme)).To(Succeed())
+		Expect(egv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "paused-app", Namespace: "team-a"},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "paused-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+				Routing:  &reconcilersv1.RoutingConfig{},
+				Auth: &reconcilersv1.AuthConfig{
+					Enabled:         true,
+					Provider:        constants.ProviderGenericOIDC,
+					IssuerURL:       "https://idp.example.com/realms/test",
+					ProvisionClient: ptr.To(false),
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(app).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{core.ManagedNamespaceLabel: "true"},
+			}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "team-a"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+			},
+			&gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "paused-app-oidc-client", Namespace: "team-a"},
+				Data:       map[string][]byte{constants.ClientSecretKey: []byte("s3cret")},
+			},
+			app,
+		).Build()
+		fakeRecorder := record.NewFakeRecorder(20)
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			CoreReconciler:    &core.CoreReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			AuthReconciler: &auth.AuthReconciler{
+				Client:   fakeClient,
+				Scheme:   scheme,
+				Recorder: fakeRecorder,
+				Providers: map[string]providers.OIDCProvider{
+					constants.ProviderGenericOIDC: &providers.GenericOIDCProvider{},
+				},
+			},
+		}
+		appKey := types.NamespacedName{Name: "paused-app", Namespace: "team-a"}
+		routeKey := types.NamespacedName{Name: "paused-app-route", Namespace: "team-a"}
+		policyKey := types.NamespacedName{Name: "paused-app-security", Namespace: "team-a"}
+		setEnabled := func(enabled bool) {
+			current := &reconcilersv1.NebariApp{}
+			Expect(fakeClient.Get(ctx, appKey, current)).To(Succeed())
+			current.Spec.Enabled = ptr.To(enabled)
+			Expect(fakeClient.Update(ctx, current)).To(Succeed())
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: appKey})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: appKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeClient.Get(ctx, routeKey, &gatewayv1.HTTPRoute{})).To(Succeed())
+		Expect(fakeClient.Get(ctx, policyKey, &egv1alpha1.SecurityPolicy{})).To(Succeed())
+
+		By("removing the HTTPRoute and SecurityPolicy once disabled")
+		setEnabled(false)
+		Expect(errors.IsNotFound(fakeClient.Get(ctx, routeKey, &gatewayv1.HTTPRoute{}))).To(BeTrue())
+		Expect(errors.IsNotFound(fakeClient.Get(ctx, policyKey, &egv1alpha1.SecurityPolicy{}))).To(BeTrue())
+
+		updated := &reconcilersv1.NebariApp{}
+		Expect(fakeClient.Get(ctx, appKey, updated)).To(Succeed())
+		Expect(updated.DeletionTimestamp).To(BeNil())
+		ready := meta.FindStatusCondition(updated.Status.Conditions, reconcilersv1.ConditionTypeReady)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal(reconcilersv1.ReasonDisabled))
+
+		By("recreating them once re-enabled")
+		setEnabled(true)
+		Expect(fakeClient.Get(ctx, routeKey, &gatewayv1.HTTPRoute{})).To(Succeed())
+		Expect(fakeClient.Get(ctx, policyKey, &egv1alpha1.SecurityPolicy{})).To(Succeed())
+	})
+})
+
+var _ = Describe("SecurityPolicy ownership", func() {
+	ctx := context.Background()
+
+	It("should enqueue the owning NebariApp and recreate a deleted SecurityPolicy", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv1.Install(scheme)).To(Succeed())
+		Expect(egv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "guarded-app", Namespace: "team-a"},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "guarded-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+				Auth: &reconcilersv1.AuthConfig{
+					Enabled:         true,
+					Provider:        constants.ProviderGenericOIDC,
+					IssuerURL:       "https://idp.example.com/realms/test",
+					ProvisionClient: ptr.To(false),
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(app).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{core.ManagedNamespaceLabel: "true"},
+			}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "team-a"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "guarded-app-oidc-client", Namespace: "team-a"},
+				Data:       map[string][]byte{constants.ClientSecretKey: []byte("s3cret")},
+			},
+			app,
+		).Build()
+		fakeRecorder := record.NewFakeRecorder(20)
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			CoreReconciler:    &core.CoreReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			AuthReconciler: &auth.AuthReconciler{
+				Client:   fakeClient,
+				Scheme:   scheme,
+				Recorder: fakeRecorder,
+				Providers: map[string]providers.OIDCProvider{
+					constants.ProviderGenericOIDC: &providers.GenericOIDCProvider{},
+				},
+			},
+		}
+		appKey := types.NamespacedName{Name: "guarded-app", Namespace: "team-a"}
+		policyKey := types.NamespacedName{Name: "guarded-app-security", Namespace: "team-a"}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: appKey})
+		Expect(err).NotTo(HaveOccurred())
+		policy := &egv1alpha1.SecurityPolicy{}
+		Expect(fakeClient.Get(ctx, policyKey, policy)).To(Succeed())
+
+		By("mapping the deleted SecurityPolicy back to its NebariApp")
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(reconcilersv1.GroupVersion.WithKind("NebariApp"), meta.RESTScopeNamespace)
+		ownerHandler := handler.EnqueueRequestForOwner(scheme, mapper, &reconcilersv1.NebariApp{}, handler.OnlyControllerOwner())
+		queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer queue.ShutDown()
+
+		Expect(fakeClient.Delete(ctx, policy)).To(Succeed())
+		ownerHandler.Delete(ctx, event.DeleteEvent{Object: policy}, queue)
+		Expect(queue.Len()).To(Equal(1))
+		req, _ := queue.Get()
+		Expect(req.NamespacedName).To(Equal(appKey))
+
+		By("recreating it on the triggered reconcile")
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeClient.Get(ctx, policyKey, &egv1alpha1.SecurityPolicy{})).To(Succeed())
+	})
+})
+
+var _ = Describe("HTTPRoute ownership", func() {
+	ctx := context.Background()
+
+	It("should enqueue the owning NebariApp and recreate a deleted HTTPRoute", func() {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv1.Install(scheme)).To(Succeed())
+
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "routed-app", Namespace: "team-a"},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "routed-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+				Routing:  &reconcilersv1.RoutingConfig{},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(app).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{core.ManagedNamespaceLabel: "true"},
+			}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "team-a"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+			},
+			&gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+			},
+			app,
+		).Build()
+		fakeRecorder := record.NewFakeRecorder(20)
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			CoreReconciler:    &core.CoreReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+		}
+		appKey := types.NamespacedName{Name: "routed-app", Namespace: "team-a"}
+		routeKey := types.NamespacedName{Name: "routed-app-route", Namespace: "team-a"}
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: appKey})
+		Expect(err).NotTo(HaveOccurred())
+		route := &gatewayv1.HTTPRoute{}
+		Expect(fakeClient.Get(ctx, routeKey, route)).To(Succeed())
+
+		By("mapping the deleted HTTPRoute back to its NebariApp")
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(reconcilersv1.GroupVersion.WithKind("NebariApp"), meta.RESTScopeNamespace)
+		ownerHandler := handler.EnqueueRequestForOwner(scheme, mapper, &reconcilersv1.NebariApp{}, handler.OnlyControllerOwner())
+		queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer queue.ShutDown()
+
+		Expect(fakeClient.Delete(ctx, route)).To(Succeed())
+		ownerHandler.Delete(ctx, event.DeleteEvent{Object: route}, queue)
+		Expect(queue.Len()).To(Equal(1))
+		req, _ := queue.Get()
+		Expect(req.NamespacedName).To(Equal(appKey))
+
+		By("recreating it on the triggered reconcile")
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeClient.Get(ctx, routeKey, &gatewayv1.HTTPRoute{})).To(Succeed())
+	})
+})
+
+// unreachableProvider is a provisioning provider whose Keycloak cannot be reached.
+type unreachableProvider struct {
+	providers.GenericOIDCProvider
+}
+
+func (p *unreachableProvider) SupportsProvisioning() bool { return true }
+
+func (p *unreachableProvider) ProvisionClient(context.Context, *reconcilersv1.NebariApp) error {
+	return &providers.ProviderError{Kind: providers.ErrProviderUnreachable, Err: fmt.Errorf("connection refused")}
+}
+
+var _ = Describe("Requeue after failures", func() {
+	ctx := context.Background()
+
+	reconcileAuth := func(authConfig *reconcilersv1.AuthConfig) (reconcile.Result, error) {
+		scheme := runtime.NewScheme()
+		Expect(reconcilersv1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(gatewayv1.Install(scheme)).To(Succeed())
+		Expect(egv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		app := &reconcilersv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "requeue-app", Namespace: "team-a"},
+			Spec: reconcilersv1.NebariAppSpec{
+				Hostname: "requeue-app.nebari.local",
+				Service:  reconcilersv1.ServiceReference{Name: "svc", Port: 8080},
+				Auth:     authConfig,
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(app).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{core.ManagedNamespaceLabel: "true"},
+			}},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "team-a"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 8080}}},
+			},
+			app,
+		).Build()
+		fakeRecorder := record.NewFakeRecorder(20)
+		r := &NebariAppReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Recorder:          fakeRecorder,
+			CoreReconciler:    &core.CoreReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			RoutingReconciler: &routing.RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: fakeRecorder},
+			AuthReconciler: &auth.AuthReconciler{
+				Client:   fakeClient,
+				Scheme:   scheme,
+				Recorder: fakeRecorder,
+				Providers: map[string]providers.OIDCProvider{
+					constants.ProviderGenericOIDC: &unreachableProvider{},
+				},
+			},
+		}
+
+		return r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "requeue-app", Namespace: "team-a"}})
+	}
+
+	It("should wait longer after an invalid auth configuration", func() {
+		result, err := reconcileAuth(&reconcilersv1.AuthConfig{
+			Enabled:      true,
+			Provider:     constants.ProviderGenericOIDC,
+			IssuerURL:    "https://idp.example.com/realms/test",
+			StepUpScopes: []string{"admin"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(validationRequeueAfter))
+	})
+
+	It("should return the error when the provider is unreachable so the queue backs off", func() {
+		_, err := reconcileAuth(&reconcilersv1.AuthConfig{
+			Enabled:         true,
+			Provider:        constants.ProviderGenericOIDC,
+			IssuerURL:       "https://idp.example.com/realms/test",
+			ProvisionClient: ptr.To(true),
+		})
+		Expect(err).To(MatchError(providers.ErrProviderUnreachable))
+	})
+})
+
+var _ = Describe("resultForError", func() {
+	It("should classify errors by how soon a retry can succeed", func() {
+		gr := schema.GroupResource{Group: "gateway.networking.k8s.io", Resource: "httproutes"}
+		requeueAfter := func(err error) time.Duration {
+			result, returned := resultForError(err)
+			Expect(returned).NotTo(HaveOccurred())
+			return result.RequeueAfter
+		}
+
+		Expect(requeueAfter(fmt.Errorf("auth: %w", auth.ErrInvalidConfig))).To(Equal(validationRequeueAfter))
+		Expect(requeueAfter(&providers.ProviderError{
+			Kind: providers.ErrAuthFailed, Err: fmt.Errorf("401"),
+		})).To(Equal(defaultRequeueAfter))
+		Expect(requeueAfter(fmt.Errorf("something else"))).To(Equal(defaultRequeueAfter))
+
+		// Transient failures are handed to the rate limiter
+		for _, err := range []error{
+			&providers.ProviderError{Kind: providers.ErrProviderUnreachable, Err: fmt.Errorf("timeout")},
+			errors.NewConflict(gr, "app-route", fmt.Errorf("modified")),
+			errors.NewTooManyRequests("slow down", 1),
+		} {
+			result, returned := resultForError(err)
+			Expect(returned).To(Equal(err))
+			Expect(result).To(Equal(reconcile.Result{}))
+		}
 	})
 })
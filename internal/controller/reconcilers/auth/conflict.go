@@ -0,0 +1,65 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/auth/providers"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// conflictingSecurityPolicies returns the names of SecurityPolicies the
+// NebariApp does not control that target one of its HTTPRoutes by name. Envoy
+// Gateway does not merge two SecurityPolicies on the same route, so such a
+// policy would conflict with the one the operator manages. Policies that select
+// routes by label (targetSelectors) are not checked.
+func (r *AuthReconciler) conflictingSecurityPolicies(ctx context.Context, nebariApp *appsv1.NebariApp) ([]string, error) {
+	routeNames := naming.HTTPRouteNames(nebariApp)
+	if providers.StepUpRedirectPath(nebariApp) != "" {
+		routeNames = append(routeNames, naming.StepUpHTTPRouteNames(nebariApp)...)
+	}
+	if nebariApp.Spec.Auth != nil && nebariApp.Spec.Auth.Optional {
+		routeNames = append(routeNames, naming.LoginHTTPRouteNames(nebariApp)...)
+	}
+
+	policies := &egv1alpha1.SecurityPolicyList{}
+	if err := r.Client.List(ctx, policies, client.InNamespace(nebariApp.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list SecurityPolicies: %w", err)
+	}
+
+	var conflicts []string
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if metav1.IsControlledBy(policy, nebariApp) {
+			continue
+		}
+		for _, ref := range policy.Spec.GetTargetRefs() {
+			if ref.Kind == "HTTPRoute" && slices.Contains(routeNames, string(ref.Name)) {
+				conflicts = append(conflicts, policy.Name)
+				break
+			}
+		}
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// deprovisionClient removes the OIDC client the operator provisioned for a
+// NebariApp whose provisionClient has since been set to false, so the
+// operator-created client and Secret are not left behind once the user brings
+// their own. It deletes the client from the provider, then the client Secret
+// if it is still the one the operator wrote, and clears
+// status.clientProvisioned and status.authConfigHash so turning provisioning
+// back on provisions a fresh client.
+func (r *AuthReconciler) deprovisionClient(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	logger := log.FromContext(ctx)
+
+	provider, err := r.getProvider(nebariApp)
+	if err != nil {
+		return err
+	}
+	if provider.SupportsProvisioning() {
+		if nebariApp.Spec.Auth.TokenExchange != nil && nebariApp.Spec.Auth.TokenExchange.Enabled {
+			if err := provider.CleanupTokenExchange(ctx, nebariApp); err != nil {
+				return fmt.Errorf("failed to clean up token exchange: %w", err)
+			}
+		}
+		logger.Info("provisionClient turned off, deleting provisioned OIDC client")
+		if err := provider.DeleteClient(ctx, nebariApp); err != nil {
+			return fmt.Errorf("failed to delete provisioned OIDC client: %w", err)
+		}
+	} else {
+		// The provider changed along with provisionClient; the new one has no
+		// client to delete and the old one is no longer configured for this app.
+		logger.Info("Provider does not support provisioning, leaving the previously provisioned OIDC client in place",
+			"provider", r.providerName(nebariApp))
+	}
+
+	if err := r.deleteProvisionedClientSecret(ctx, nebariApp); err != nil {
+		return err
+	}
+
+	nebariApp.Status.ClientProvisioned = false
+	nebariApp.Status.AuthConfigHash = ""
+	r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonClientDeprovisioned,
+		"provisionClient is false; deleted the OIDC client the operator provisioned")
+	return nil
+}
+
+// deleteProvisionedClientSecret deletes the client Secret when it still
+// carries the labels the operator sets on the Secrets it writes. A Secret the
+// user created in its place is left alone.
+func (r *AuthReconciler) deleteProvisionedClientSecret(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, types.NamespacedName{
+		Name:      naming.ClientSecretName(nebariApp),
+		Namespace: nebariApp.Namespace,
+	}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get OIDC client secret: %w", err)
+	}
+
+	if secret.Labels["app.kubernetes.io/managed-by"] != naming.ManagedBy(r.ManagedBy) ||
+		secret.Labels["app.kubernetes.io/instance"] != naming.LabelValue(nebariApp.Name) {
+		log.FromContext(ctx).Info("OIDC client secret was not written by the operator, leaving it in place",
+			"secretName", secret.Name)
+		return nil
+	}
+
+	if err := client.IgnoreNotFound(r.Client.Delete(ctx, secret)); err != nil {
+		return fmt.Errorf("failed to delete provisioned OIDC client secret: %w", err)
+	}
+	log.FromContext(ctx).Info("Deleted provisioned OIDC client secret", "secretName", secret.Name)
+	return nil
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/auth/providers"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/conditions"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileAuth_ProvisionClientTurnedOff(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	newApp := func() *appsv1.NebariApp {
+		return &appsv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "app-uid"},
+			Spec: appsv1.NebariAppSpec{
+				Hostname: "test.example.com",
+				Auth: &appsv1.AuthConfig{
+					Enabled:         true,
+					Provider:        constants.ProviderKeycloak,
+					ProvisionClient: ptr.To(true),
+				},
+			},
+		}
+	}
+	// The Secret the operator writes alongside the provisioned client.
+	operatorSecret := func(app *appsv1.NebariApp) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      naming.ClientSecretName(app),
+				Namespace: app.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/name":       "nebariapp",
+					"app.kubernetes.io/instance":   app.Name,
+					"app.kubernetes.io/managed-by": constants.DefaultManagedBy,
+				},
+			},
+			Data: map[string][]byte{constants.ClientSecretKey: []byte("operator-secret")},
+		}
+	}
+	userSecret := func(app *appsv1.NebariApp) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: naming.ClientSecretName(app), Namespace: app.Namespace},
+			Data:       map[string][]byte{constants.ClientSecretKey: []byte("user-secret")},
+		}
+	}
+	setup := func(provider *mockProvider) (*AuthReconciler, *appsv1.NebariApp) {
+		app := newApp()
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, operatorSecret(app)).Build()
+		reconciler := &AuthReconciler{
+			Client:    fakeClient,
+			Scheme:    scheme,
+			Recorder:  record.NewFakeRecorder(32),
+			Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: provider},
+		}
+		if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+			t.Fatalf("initial ReconcileAuth: %v", err)
+		}
+		if !app.Status.ClientProvisioned {
+			t.Fatal("expected status.clientProvisioned after provisioning")
+		}
+		app.Spec.Auth.ProvisionClient = ptr.To(false)
+		return reconciler, app
+	}
+	secretKey := types.NamespacedName{Name: naming.ClientSecretName(newApp()), Namespace: "default"}
+
+	t.Run("deletes the provisioned client and secret once", func(t *testing.T) {
+		provider := &mockProvider{issuerURL: "https://keycloak.example.com/realms/test", clientID: "test-app", supportsProvisioning: true}
+		reconciler, app := setup(provider)
+		ctx := context.Background()
+
+		// The operator's secret goes with the client, so the user's secret is now missing.
+		if err := reconciler.ReconcileAuth(ctx, app); err == nil {
+			t.Fatal("expected a missing secret error after deprovisioning, got nil")
+		}
+		if provider.deleteCount != 1 {
+			t.Errorf("expected DeleteClient to be called once, got %d", provider.deleteCount)
+		}
+		if err := reconciler.Client.Get(ctx, secretKey, &corev1.Secret{}); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the operator's client secret to be deleted, got err=%v", err)
+		}
+		if app.Status.ClientProvisioned || app.Status.AuthConfigHash != "" {
+			t.Errorf("expected clientProvisioned and authConfigHash to be cleared, got %t/%q",
+				app.Status.ClientProvisioned, app.Status.AuthConfigHash)
+		}
+
+		// Once the user's secret exists the app is configured with it.
+		if err := reconciler.Client.Create(ctx, userSecret(app)); err != nil {
+			t.Fatalf("create user secret: %v", err)
+		}
+		if err := reconciler.ReconcileAuth(ctx, app); err != nil {
+			t.Fatalf("ReconcileAuth with the user's secret: %v", err)
+		}
+		if !conditions.IsConditionTrue(app, appsv1.ConditionTypeAuthReady) {
+			t.Errorf("expected AuthReady=True, got %+v", app.Status.Conditions)
+		}
+		if provider.deleteCount != 1 {
+			t.Errorf("expected no further DeleteClient calls, got %d", provider.deleteCount)
+		}
+	})
+
+	t.Run("leaves a user-created secret in place", func(t *testing.T) {
+		provider := &mockProvider{issuerURL: "https://keycloak.example.com/realms/test", clientID: "test-app", supportsProvisioning: true}
+		reconciler, app := setup(provider)
+		ctx := context.Background()
+
+		// The user replaced the operator's secret before turning provisioning off.
+		if err := reconciler.Client.Delete(ctx, operatorSecret(app)); err != nil {
+			t.Fatalf("delete operator secret: %v", err)
+		}
+		if err := reconciler.Client.Create(ctx, userSecret(app)); err != nil {
+			t.Fatalf("create user secret: %v", err)
+		}
+
+		if err := reconciler.ReconcileAuth(ctx, app); err != nil {
+			t.Fatalf("ReconcileAuth: %v", err)
+		}
+		if provider.deleteCount != 1 {
+			t.Errorf("expected DeleteClient to be called once, got %d", provider.deleteCount)
+		}
+		secret := &corev1.Secret{}
+		if err := reconciler.Client.Get(ctx, secretKey, secret); err != nil {
+			t.Fatalf("expected the user's secret to remain: %v", err)
+		}
+		if string(secret.Data[constants.ClientSecretKey]) != "user-secret" {
+			t.Errorf("expected the user's secret data, got %q", secret.Data[constants.ClientSecretKey])
+		}
+	})
+
+	t.Run("reports a failed client deletion", func(t *testing.T) {
+		provider := &mockProvider{issuerURL: "https://keycloak.example.com/realms/test", clientID: "test-app", supportsProvisioning: true}
+		reconciler, app := setup(provider)
+		provider.deleteError = errors.New("keycloak unavailable")
+
+		if err := reconciler.ReconcileAuth(context.Background(), app); err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+		cond := conditions.GetCondition(app, appsv1.ConditionTypeAuthReady)
+		if cond == nil || cond.Reason != appsv1.ReasonClientCleanupFailed {
+			t.Errorf("expected reason %s, got %+v", appsv1.ReasonClientCleanupFailed, cond)
+		}
+		if !app.Status.ClientProvisioned {
+			t.Error("expected status.clientProvisioned to be kept so the deletion is retried")
+		}
+		if err := reconciler.Client.Get(context.Background(), secretKey, &corev1.Secret{}); err != nil {
+			t.Errorf("expected the operator's secret to be kept until the client is deleted: %v", err)
+		}
+	})
+}
+
+func TestDisableAuth(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "app-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:         true,
+				Provider:        constants.ProviderKeycloak,
+				ProvisionClient: ptr.To(true),
+			},
+		},
+	}
+	// The mock provider does not write the Secret, so seed the one the operator would.
+	operatorSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.ClientSecretName(app),
+			Namespace: app.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/instance":   app.Name,
+				"app.kubernetes.io/managed-by": constants.DefaultManagedBy,
+			},
+		},
+		Data: map[string][]byte{constants.ClientSecretKey: []byte("operator-secret")},
+	}
+	provider := &mockProvider{issuerURL: "https://keycloak.example.com/realms/test", clientID: "test-app", supportsProvisioning: true}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, operatorSecret).Build()
+	reconciler := &AuthReconciler{
+		Client:    fakeClient,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(32),
+		Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: provider},
+	}
+	ctx := context.Background()
+
+	if err := reconciler.ReconcileAuth(ctx, app); err != nil {
+		t.Fatalf("initial ReconcileAuth: %v", err)
+	}
+	policyKey := types.NamespacedName{Name: naming.SecurityPolicyName(app), Namespace: "default"}
+	if err := fakeClient.Get(ctx, policyKey, &egv1alpha1.SecurityPolicy{}); err != nil {
+		t.Fatalf("expected a SecurityPolicy after ReconcileAuth: %v", err)
+	}
+
+	if err := reconciler.DisableAuth(ctx, app); err != nil {
+		t.Fatalf("DisableAuth: %v", err)
+	}
+	if provider.deleteCount != 1 {
+		t.Errorf("expected DeleteClient to be called once, got %d", provider.deleteCount)
+	}
+	if err := fakeClient.Get(ctx, policyKey, &egv1alpha1.SecurityPolicy{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the SecurityPolicy to be deleted, got err=%v", err)
+	}
+	secretKey := types.NamespacedName{Name: naming.ClientSecretName(app), Namespace: "default"}
+	if err := fakeClient.Get(ctx, secretKey, &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the operator's client secret to be deleted, got err=%v", err)
+	}
+	if app.Status.ClientProvisioned || app.Status.AuthConfigHash != "" {
+		t.Errorf("expected clientProvisioned and authConfigHash to be cleared, got %t/%q",
+			app.Status.ClientProvisioned, app.Status.AuthConfigHash)
+	}
+
+	// Re-enabling provisions the client and recreates the SecurityPolicy.
+	operatorSecret.ResourceVersion = ""
+	if err := fakeClient.Create(ctx, operatorSecret); err != nil {
+		t.Fatalf("recreate operator secret: %v", err)
+	}
+	if err := reconciler.ReconcileAuth(ctx, app); err != nil {
+		t.Fatalf("ReconcileAuth after re-enabling: %v", err)
+	}
+	if err := fakeClient.Get(ctx, policyKey, &egv1alpha1.SecurityPolicy{}); err != nil {
+		t.Errorf("expected the SecurityPolicy to be recreated: %v", err)
+	}
+	if !app.Status.ClientProvisioned {
+		t.Error("expected status.clientProvisioned after re-enabling")
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/auth/providers"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// validateClientTLSSecret checks that the Secret named by auth.clientTLSSecretRef
+// exists and holds both a certificate and a private key.
+func (r *AuthReconciler) validateClientTLSSecret(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	if nebariApp.Spec.Auth.ClientTLSSecretRef == nil {
+		return nil
+	}
+	secretName := *nebariApp.Spec.Auth.ClientTLSSecretRef
+
+	secret := &corev1.Secret{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: secretName, Namespace: nebariApp.Namespace}, secret)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("client TLS secret '%s' not found in namespace '%s'", secretName, nebariApp.Namespace)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get client TLS secret: %w", err)
+	}
+
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		if len(secret.Data[key]) == 0 {
+			return fmt.Errorf("client TLS secret '%s' missing required key '%s'", secretName, key)
+		}
+	}
+	return nil
+}
+
+// idpTokenEndpointAddress returns the host and port of the IdP token endpoint:
+// the provider's explicit token endpoint when it has one, otherwise the issuer,
+// which serves the token endpoint for every provider the operator supports.
+func idpTokenEndpointAddress(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) (string, int32, error) {
+	overrides, err := provider.GetEndpointOverrides(ctx, nebariApp)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get endpoint overrides: %w", err)
+	}
+	endpoint := ""
+	if overrides.Token != nil {
+		endpoint = *overrides.Token
+	} else {
+		endpoint, err = provider.GetIssuerURL(ctx, nebariApp)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to get issuer URL: %w", err)
+		}
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid token endpoint URL %q: %w", endpoint, err)
+	}
+	if u.Scheme != "https" {
+		return "", 0, fmt.Errorf("clientTLSSecretRef requires an https token endpoint, got %q", endpoint)
+	}
+	port := int32(443)
+	if p := u.Port(); p != "" {
+		parsed, err := strconv.ParseInt(p, 10, 32)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid port in token endpoint URL %q: %w", endpoint, err)
+		}
+		port = int32(parsed)
+	}
+	return u.Hostname(), port, nil
+}
+
+// reconcileIdPBackend creates or updates the Envoy Gateway Backend that the
+// SecurityPolicy uses to reach the IdP token endpoint when auth.clientTLSSecretRef
+// is set. The OIDC provider has no TLS settings of its own; the client
+// certificate is attached to the Backend instead. Without clientTLSSecretRef
+// any Backend left over from an earlier configuration is deleted.
+func (r *AuthReconciler) reconcileIdPBackend(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) error {
+	if nebariApp.Spec.Auth.ClientTLSSecretRef == nil {
+		return r.deleteIdPBackendIfExists(ctx, nebariApp)
+	}
+	logger := log.FromContext(ctx)
+
+	host, port, err := idpTokenEndpointAddress(ctx, nebariApp, provider)
+	if err != nil {
+		return err
+	}
+
+	spec := egv1alpha1.BackendSpec{
+		Endpoints: []egv1alpha1.BackendEndpoint{{
+			FQDN: &egv1alpha1.FQDNEndpoint{Hostname: host, Port: port},
+		}},
+		TLS: &egv1alpha1.BackendTLSSettings{
+			WellKnownCACertificates: ptr.To(gwapiv1.WellKnownCACertificatesSystem),
+			BackendTLSConfig: &egv1alpha1.BackendTLSConfig{
+				ClientCertificateRef: &gwapiv1.SecretObjectReference{
+					Name: gwapiv1.ObjectName(*nebariApp.Spec.Auth.ClientTLSSecretRef),
+				},
+			},
+		},
+	}
+
+	backend := &egv1alpha1.Backend{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.IdPBackendName(nebariApp),
+			Namespace: nebariApp.Namespace,
+		},
+	}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, backend, func() error {
+		if err := controllerutil.SetControllerReference(nebariApp, backend, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if !equality.Semantic.DeepEqual(backend.Spec, spec) {
+			backend.Spec = spec
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or update IdP Backend: %w", err)
+	}
+
+	logger.Info("IdP Backend reconciled", "name", backend.Name, "host", host, "port", port, "operation", op)
+	return nil
+}
+
+// deleteIdPBackendIfExists deletes the IdP Backend for a NebariApp if it exists.
+func (r *AuthReconciler) deleteIdPBackendIfExists(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	backend := &egv1alpha1.Backend{}
+	err := r.Client.Get(ctx, types.NamespacedName{
+		Name:      naming.IdPBackendName(nebariApp),
+		Namespace: nebariApp.Namespace,
+	}, backend)
+	// Clusters without the Backend CRD cannot have one to clean up.
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get IdP Backend: %w", err)
+	}
+
+	log.FromContext(ctx).Info("Deleting IdP Backend (clientTLSSecretRef not set)", "name", backend.Name)
+	if err := r.Client.Delete(ctx, backend); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete IdP Backend: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/auth/providers"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newClientTLSApp(secretRef *string) *appsv1.NebariApp {
+	return &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:            true,
+				Provider:           constants.ProviderKeycloak,
+				ClientTLSSecretRef: secretRef,
+			},
+		},
+	}
+}
+
+func TestValidateClientTLSSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name        string
+		secretRef   *string
+		secret      *corev1.Secret
+		expectError bool
+	}{
+		{
+			name: "unset skips validation",
+		},
+		{
+			name:      "secret with certificate and key is valid",
+			secretRef: ptr.To("idp-client-cert"),
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "idp-client-cert", Namespace: "default"},
+				Data: map[string][]byte{
+					corev1.TLSCertKey:       []byte("cert"),
+					corev1.TLSPrivateKeyKey: []byte("key"),
+				},
+			},
+		},
+		{
+			name:        "missing secret fails",
+			secretRef:   ptr.To("idp-client-cert"),
+			expectError: true,
+		},
+		{
+			name:      "secret without a private key fails",
+			secretRef: ptr.To("idp-client-cert"),
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "idp-client-cert", Namespace: "default"},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert")},
+			},
+			expectError: true,
+		},
+		{
+			name:      "secret with an empty certificate fails",
+			secretRef: ptr.To("idp-client-cert"),
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "idp-client-cert", Namespace: "default"},
+				Data: map[string][]byte{
+					corev1.TLSCertKey:       {},
+					corev1.TLSPrivateKeyKey: []byte("key"),
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.secret != nil {
+				builder = builder.WithObjects(tt.secret)
+			}
+			reconciler := &AuthReconciler{Client: builder.Build(), Scheme: scheme}
+
+			err := reconciler.validateClientTLSSecret(context.Background(), newClientTLSApp(tt.secretRef))
+			if tt.expectError && err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileIdPBackend(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name         string
+		issuerURL    string
+		tokenURL     *string
+		expectedHost string
+		expectedPort int32
+		expectError  bool
+	}{
+		{
+			name:         "issuer host with default https port",
+			issuerURL:    "https://keycloak.example.com/realms/test",
+			expectedHost: "keycloak.example.com",
+			expectedPort: 443,
+		},
+		{
+			name:         "explicit token endpoint wins over issuer",
+			issuerURL:    "https://keycloak.example.com/realms/test",
+			tokenURL:     ptr.To("https://token.example.com:8443/realms/test/protocol/openid-connect/token"),
+			expectedHost: "token.example.com",
+			expectedPort: 8443,
+		},
+		{
+			name:        "plain http token endpoint fails",
+			issuerURL:   "http://keycloak.keycloak.svc.cluster.local:8080/realms/test",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newClientTLSApp(ptr.To("idp-client-cert"))
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build()
+			reconciler := &AuthReconciler{Client: k8sClient, Scheme: scheme}
+			provider := &mockProvider{
+				issuerURL:         tt.issuerURL,
+				clientID:          "test-client",
+				endpointOverrides: providers.OIDCEndpointOverrides{Token: tt.tokenURL},
+			}
+
+			err := reconciler.reconcileIdPBackend(context.Background(), app, provider)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			backend := &egv1alpha1.Backend{}
+			key := types.NamespacedName{Name: naming.IdPBackendName(app), Namespace: app.Namespace}
+			if err := k8sClient.Get(context.Background(), key, backend); err != nil {
+				t.Fatalf("failed to get Backend: %v", err)
+			}
+			if len(backend.Spec.Endpoints) != 1 || backend.Spec.Endpoints[0].FQDN == nil {
+				t.Fatalf("expected a single FQDN endpoint, got %+v", backend.Spec.Endpoints)
+			}
+			fqdn := backend.Spec.Endpoints[0].FQDN
+			if fqdn.Hostname != tt.expectedHost || fqdn.Port != tt.expectedPort {
+				t.Errorf("expected endpoint %s:%d, got %s:%d", tt.expectedHost, tt.expectedPort, fqdn.Hostname, fqdn.Port)
+			}
+			tls := backend.Spec.TLS
+			if tls == nil || tls.BackendTLSConfig == nil || tls.ClientCertificateRef == nil {
+				t.Fatalf("expected a client certificate reference, got %+v", tls)
+			}
+			if tls.ClientCertificateRef.Name != "idp-client-cert" {
+				t.Errorf("expected client certificate 'idp-client-cert', got %q", tls.ClientCertificateRef.Name)
+			}
+			if len(backend.OwnerReferences) != 1 || backend.OwnerReferences[0].Name != app.Name {
+				t.Errorf("expected Backend to be owned by the NebariApp, got %+v", backend.OwnerReferences)
+			}
+
+			// Clearing clientTLSSecretRef removes the Backend.
+			app.Spec.Auth.ClientTLSSecretRef = nil
+			if err := reconciler.reconcileIdPBackend(context.Background(), app, provider); err != nil {
+				t.Fatalf("unexpected error on cleanup: %v", err)
+			}
+			if err := k8sClient.Get(context.Background(), key, backend); !apierrors.IsNotFound(err) {
+				t.Errorf("expected Backend to be deleted, got err=%v", err)
+			}
+		})
+	}
+}
+
+func TestBuildSecurityPolicySpec_ClientTLSSecretRef(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	provider := &mockProvider{issuerURL: "https://keycloak.example.com/realms/test", clientID: "test-client"}
+
+	for _, secretRef := range []*string{nil, ptr.To("idp-client-cert")} {
+		app := newClientTLSApp(secretRef)
+		reconciler := &AuthReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+			Scheme: scheme,
+		}
+
+		spec, err := reconciler.buildSecurityPolicySpec(context.Background(), app, provider)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		refs := spec.OIDC.Provider.BackendRefs
+		if secretRef == nil {
+			if len(refs) != 0 {
+				t.Errorf("expected no backendRefs without clientTLSSecretRef, got %+v", refs)
+			}
+			continue
+		}
+		if len(refs) != 1 {
+			t.Fatalf("expected one backendRef, got %+v", refs)
+		}
+		ref := refs[0].BackendObjectReference
+		if ref.Kind == nil || *ref.Kind != egv1alpha1.KindBackend ||
+			ref.Group == nil || *ref.Group != egv1alpha1.GroupName ||
+			string(ref.Name) != naming.IdPBackendName(app) {
+			t.Errorf("expected backendRef to the IdP Backend %q, got %+v", naming.IdPBackendName(app), ref)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// SecurityPolicySweeper periodically deletes operator-managed SecurityPolicies
+// whose NebariApp no longer exists. Owner references normally garbage-collect
+// them, but policies that lost their owner reference (restored from backup,
+// copied by hand) would otherwise keep enforcing auth on a route forever.
+// The HTTPRoutes such a policy protects are deleted with it, so a route is
+// never left serving without auth. It runs as a manager Runnable, so only the elected leader sweeps.
+type SecurityPolicySweeper struct {
+	Client   client.Client
+	Interval time.Duration
+
+	// ManagedBy is the app.kubernetes.io/managed-by value on the resources it
+	// creates and selects on. Empty means constants.DefaultManagedBy.
+	ManagedBy string
+}
+
+// Start sweeps once immediately and then on every Interval until ctx is done.
+// Sweep errors are logged and retried on the next tick.
+func (s *SecurityPolicySweeper) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("securitypolicy-sweeper")
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Sweep(ctx); err != nil {
+			logger.Error(err, "Failed to sweep orphaned SecurityPolicies")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection makes the sweep run on the leader only.
+func (s *SecurityPolicySweeper) NeedLeaderElection() bool {
+	return true
+}
+
+// Sweep deletes every SecurityPolicy labeled as managed by the operator whose
+// owning NebariApp (from the nebari.dev/nebariapp-* labels) is not found, and
+// returns how many were deleted. The operator-managed HTTPRoutes of the same
+// NebariApp that the policy targets are deleted first; if that fails the policy
+// is kept. Policies without the managed-by label or an owner name label are
+// never touched.
+func (s *SecurityPolicySweeper) Sweep(ctx context.Context) (int, error) {
+	logger := log.FromContext(ctx)
+
+	var policies egv1alpha1.SecurityPolicyList
+	if err := s.Client.List(ctx, &policies, client.MatchingLabels{
+		"app.kubernetes.io/managed-by": naming.ManagedBy(s.ManagedBy),
+	}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to list SecurityPolicies: %w", err)
+	}
+
+	deleted := 0
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		appName := policy.Labels["nebari.dev/nebariapp-name"]
+		if appName == "" {
+			continue
+		}
+		appNamespace := policy.Labels["nebari.dev/nebariapp-namespace"]
+		if appNamespace == "" {
+			appNamespace = policy.Namespace
+		}
+
+		exists, err := s.appExists(ctx, appName, appNamespace)
+		if err != nil {
+			return deleted, err
+		}
+		if exists {
+			continue
+		}
+
+		if err := s.deleteTargetRoutes(ctx, policy, appName); err != nil {
+			return deleted, err
+		}
+		if err := client.IgnoreNotFound(s.Client.Delete(ctx, policy)); err != nil {
+			return deleted, fmt.Errorf("failed to delete orphaned SecurityPolicy %s/%s: %w", policy.Namespace, policy.Name, err)
+		}
+		logger.Info("Deleted orphaned SecurityPolicy", "name", policy.Name, "namespace", policy.Namespace,
+			"nebariApp", appNamespace+"/"+appName)
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// appExists reports whether the NebariApp named by a nebari.dev/nebariapp-name
+// label exists. Long app names are truncated in labels, so when no app has the
+// label's exact name, the namespace's apps are matched by naming.LabelValue.
+func (s *SecurityPolicySweeper) appExists(ctx context.Context, label, namespace string) (bool, error) {
+	err := s.Client.Get(ctx, client.ObjectKey{Name: label, Namespace: namespace}, &appsv1.NebariApp{})
+	if err == nil {
+		return true, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get NebariApp %s/%s: %w", namespace, label, err)
+	}
+
+	var apps appsv1.NebariAppList
+	if err := s.Client.List(ctx, &apps, client.InNamespace(namespace)); err != nil {
+		return false, fmt.Errorf("failed to list NebariApps in namespace %s: %w", namespace, err)
+	}
+	for i := range apps.Items {
+		if naming.LabelValue(apps.Items[i].Name) == label {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deleteTargetRoutes deletes the HTTPRoutes targeted by an orphaned policy.
+// Only routes labeled as managed by the operator for the same NebariApp are
+// deleted; routes created by hand are left alone.
+func (s *SecurityPolicySweeper) deleteTargetRoutes(ctx context.Context, policy *egv1alpha1.SecurityPolicy, appName string) error {
+	logger := log.FromContext(ctx)
+
+	for _, ref := range policy.Spec.TargetRefs {
+		if ref.Kind != "HTTPRoute" {
+			continue
+		}
+		route := &gwapiv1.HTTPRoute{}
+		err := s.Client.Get(ctx, client.ObjectKey{Name: string(ref.Name), Namespace: policy.Namespace}, route)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get HTTPRoute %s/%s: %w", policy.Namespace, ref.Name, err)
+		}
+		if route.Labels["app.kubernetes.io/managed-by"] != naming.ManagedBy(s.ManagedBy) ||
+			route.Labels["app.kubernetes.io/instance"] != appName {
+			continue
+		}
+		if err := client.IgnoreNotFound(s.Client.Delete(ctx, route)); err != nil {
+			return fmt.Errorf("failed to delete HTTPRoute %s/%s of orphaned SecurityPolicy: %w", route.Namespace, route.Name, err)
+		}
+		logger.Info("Deleted HTTPRoute of orphaned SecurityPolicy", "name", route.Name, "namespace", route.Namespace,
+			"securityPolicy", policy.Name)
+	}
+	return nil
+}
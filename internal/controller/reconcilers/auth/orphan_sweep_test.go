@@ -0,0 +1,196 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestSecurityPolicySweeper_Sweep(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	policy := func(name string, labels map[string]string) *egv1alpha1.SecurityPolicy {
+		return &egv1alpha1.SecurityPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		}
+	}
+	managed := func(appName string) map[string]string {
+		return map[string]string{
+			"app.kubernetes.io/managed-by":   "nebari-operator",
+			"nebari.dev/nebariapp-name":      appName,
+			"nebari.dev/nebariapp-namespace": "default",
+		}
+	}
+
+	liveApp := &appsv1.NebariApp{ObjectMeta: metav1.ObjectMeta{Name: "live-app", Namespace: "default"}}
+	// Its name label is truncated with a hash suffix
+	longApp := &appsv1.NebariApp{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 100), Namespace: "default"}}
+	objects := []client.Object{
+		liveApp,
+		longApp,
+		policy("live-app-security", managed("live-app")),
+		policy("long-app-security", managed(naming.LabelValue(longApp.Name))),
+		policy("long-deleted-app-security", managed(naming.LabelValue(strings.Repeat("b", 100)))),
+		policy("deleted-app-security", managed("deleted-app")),
+		// Not managed by the operator: left alone even though no such app exists
+		policy("hand-made-security", map[string]string{"nebari.dev/nebariapp-name": "deleted-app"}),
+		// Managed but without an owner name: never guessed at
+		policy("unnamed-security", map[string]string{"app.kubernetes.io/managed-by": "nebari-operator"}),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	sweeper := &SecurityPolicySweeper{Client: fakeClient}
+
+	deleted, err := sweeper.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 orphaned SecurityPolicies to be deleted, got %d", deleted)
+	}
+
+	expectKept := map[string]bool{
+		"live-app-security":         true,
+		"deleted-app-security":      false,
+		"long-app-security":         true,
+		"long-deleted-app-security": false,
+		"hand-made-security":        true,
+		"unnamed-security":          true,
+	}
+	for name, kept := range expectKept {
+		err := fakeClient.Get(context.Background(), client.ObjectKey{Name: name, Namespace: "default"}, &egv1alpha1.SecurityPolicy{})
+		if kept && err != nil {
+			t.Errorf("expected SecurityPolicy %s to be kept, got err=%v", name, err)
+		}
+		if !kept && !apierrors.IsNotFound(err) {
+			t.Errorf("expected SecurityPolicy %s to be deleted, got err=%v", name, err)
+		}
+	}
+
+	// A second sweep is a no-op
+	deleted, err = sweeper.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error on second sweep: %v", err)
+	}
+	if deleted != 0 {
+		t.Errorf("expected second sweep to delete nothing, got %d", deleted)
+	}
+}
+
+func TestSecurityPolicySweeper_ManagedBy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	orphan := func(name, managedBy string) *egv1alpha1.SecurityPolicy {
+		return &egv1alpha1.SecurityPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{
+				"app.kubernetes.io/managed-by":   managedBy,
+				"nebari.dev/nebariapp-name":      name,
+				"nebari.dev/nebariapp-namespace": "default",
+			}},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(orphan("staging-app", "nebari-operator-staging"), orphan("prod-app", "nebari-operator")).
+		Build()
+	sweeper := &SecurityPolicySweeper{Client: fakeClient, ManagedBy: "nebari-operator-staging"}
+
+	deleted, err := sweeper.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected only this instance's orphan to be deleted, got %d", deleted)
+	}
+	err = fakeClient.Get(context.Background(), client.ObjectKey{Name: "staging-app", Namespace: "default"}, &egv1alpha1.SecurityPolicy{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the staging SecurityPolicy to be deleted, got err=%v", err)
+	}
+	if err := fakeClient.Get(context.Background(), client.ObjectKey{Name: "prod-app", Namespace: "default"}, &egv1alpha1.SecurityPolicy{}); err != nil {
+		t.Errorf("expected the SecurityPolicy of another instance to be kept, got err=%v", err)
+	}
+}
+
+func TestSecurityPolicySweeper_DeletesTargetRoutes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+	_ = gwapiv1.AddToScheme(scheme)
+
+	route := func(name string, labels map[string]string) *gwapiv1.HTTPRoute {
+		return &gwapiv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels}}
+	}
+	policy := &egv1alpha1.SecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "deleted-app-security", Namespace: "default", Labels: map[string]string{
+			"app.kubernetes.io/managed-by":   "nebari-operator",
+			"nebari.dev/nebariapp-name":      "deleted-app",
+			"nebari.dev/nebariapp-namespace": "default",
+		}},
+		Spec: egv1alpha1.SecurityPolicySpec{
+			PolicyTargetReferences: egv1alpha1.PolicyTargetReferences{
+				TargetRefs: httpRouteTargetRefs([]string{"deleted-app-route", "hand-made-route", "missing-route"}),
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			policy,
+			route("deleted-app-route", map[string]string{
+				"app.kubernetes.io/managed-by": "nebari-operator",
+				"app.kubernetes.io/instance":   "deleted-app",
+			}),
+			// Targeted but not created by the operator for this app: left alone
+			route("hand-made-route", map[string]string{"app.kubernetes.io/instance": "deleted-app"}),
+		).
+		Build()
+	sweeper := &SecurityPolicySweeper{Client: fakeClient}
+
+	deleted, err := sweeper.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected the orphaned SecurityPolicy to be deleted, got %d", deleted)
+	}
+
+	err = fakeClient.Get(context.Background(), client.ObjectKey{Name: "deleted-app-route", Namespace: "default"}, &gwapiv1.HTTPRoute{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the HTTPRoute of the orphaned SecurityPolicy to be deleted, got err=%v", err)
+	}
+	if err := fakeClient.Get(context.Background(), client.ObjectKey{Name: "hand-made-route", Namespace: "default"}, &gwapiv1.HTTPRoute{}); err != nil {
+		t.Errorf("expected the hand-made HTTPRoute to be kept, got err=%v", err)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/auth/providers"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// issuerPreflightTimeout bounds the discovery request so an unresponsive
+// issuer cannot stall the reconcile loop.
+const issuerPreflightTimeout = 5 * time.Second
+
+// issuerPreflight fetches <issuer>/.well-known/openid-configuration, the
+// document Envoy Gateway loads for the SecurityPolicy, and returns an error
+// naming the failure when it cannot be fetched. Discovery is tried once; the
+// error is a providers.ErrProviderUnreachable, so the reconcile is requeued
+// soon rather than blocking the worker on retries.
+//
+// It does nothing unless IssuerPreflightEnabled is set, and for bearerOnly apps,
+// whose JWT-only SecurityPolicy does not use discovery.
+func (r *AuthReconciler) issuerPreflight(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) error {
+	if !r.IssuerPreflightEnabled || nebariApp.Spec.Auth.BearerOnly {
+		return nil
+	}
+	issuerURL, err := provider.GetIssuerURL(ctx, nebariApp)
+	if err != nil {
+		return fmt.Errorf("failed to get issuer URL: %w", err)
+	}
+	discoveryURL := strings.TrimRight(issuerURL, "/") + "/.well-known/openid-configuration"
+
+	if err := r.fetchDiscovery(ctx, discoveryURL); err != nil {
+		return &providers.ProviderError{
+			Kind: providers.ErrProviderUnreachable,
+			Err:  fmt.Errorf("OIDC discovery at %s failed: %w", discoveryURL, err),
+		}
+	}
+	log.FromContext(ctx).V(1).Info("Issuer discovery reachable", "url", discoveryURL)
+	return nil
+}
+
+// fetchDiscovery GETs discoveryURL and checks the response is a discovery
+// document with an issuer.
+func (r *AuthReconciler) fetchDiscovery(ctx context.Context, discoveryURL string) error {
+	ctx, cancel := context.WithTimeout(ctx, issuerPreflightTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := r.IssuerPreflightClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	var document struct {
+		Issuer string `json:"issuer"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&document); err != nil || document.Issuer == "" {
+		return fmt.Errorf("response is not an OIDC discovery document")
+	}
+	return nil
+}
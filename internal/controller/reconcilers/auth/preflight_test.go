@@ -0,0 +1,217 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/auth/providers"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/conditions"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// stubIssuer returns a client answering every request with statusCode and body,
+// or transportErr, and records the requested URLs.
+func stubIssuer(statusCode int, body string, transportErr error, requests *[]string) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		*requests = append(*requests, req.URL.String())
+		if transportErr != nil {
+			return nil, transportErr
+		}
+		return &http.Response{
+			StatusCode: statusCode,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})}
+}
+
+func TestIssuerPreflight(t *testing.T) {
+	discovery := `{"issuer":"https://keycloak.example.com/realms/test"}`
+	tests := []struct {
+		name           string
+		enabled        bool
+		bearerOnly     bool
+		statusCode     int
+		body           string
+		transportErr   error
+		expectError    string
+		expectRequests int
+	}{
+		{name: "disabled", enabled: false, statusCode: http.StatusInternalServerError},
+		{
+			name:       "bearer-only apps skip discovery",
+			enabled:    true,
+			bearerOnly: true,
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "reachable issuer",
+			enabled:        true,
+			statusCode:     http.StatusOK,
+			body:           discovery,
+			expectRequests: 1,
+		},
+		{
+			name:           "issuer returns 500",
+			enabled:        true,
+			statusCode:     http.StatusInternalServerError,
+			expectError:    "HTTP 500",
+			expectRequests: 1,
+		},
+		{
+			name:           "issuer unreachable",
+			enabled:        true,
+			transportErr:   errors.New("connection refused"),
+			expectError:    "connection refused",
+			expectRequests: 1,
+		},
+		{
+			name:           "response is not a discovery document",
+			enabled:        true,
+			statusCode:     http.StatusOK,
+			body:           "<html>login</html>",
+			expectError:    "not an OIDC discovery document",
+			expectRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth:     &appsv1.AuthConfig{Enabled: true, BearerOnly: tt.bearerOnly},
+				},
+			}
+			var requests []string
+			reconciler := &AuthReconciler{
+				IssuerPreflightEnabled: tt.enabled,
+				IssuerPreflightClient:  stubIssuer(tt.statusCode, tt.body, tt.transportErr, &requests),
+			}
+			provider := &mockProvider{issuerURL: "https://keycloak.example.com/realms/test/"}
+
+			err := reconciler.issuerPreflight(context.Background(), app, provider)
+			if tt.expectError == "" && err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if tt.expectError != "" && (err == nil || !strings.Contains(err.Error(), tt.expectError)) {
+				t.Fatalf("expected error containing %q, got %v", tt.expectError, err)
+			}
+			if tt.expectError != "" && !errors.Is(err, providers.ErrProviderUnreachable) {
+				t.Errorf("expected the error to be transient, got %v", err)
+			}
+			if len(requests) != tt.expectRequests {
+				t.Fatalf("expected %d discovery requests, got %d", tt.expectRequests, len(requests))
+			}
+			want := "https://keycloak.example.com/realms/test/.well-known/openid-configuration"
+			for _, got := range requests {
+				if got != want {
+					t.Errorf("expected discovery URL %q, got %q", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileAuth_IssuerUnreachable(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "app-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:         true,
+				Provider:        constants.ProviderKeycloak,
+				ProvisionClient: ptr.To(false),
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: naming.ClientSecretName(app), Namespace: app.Namespace},
+		Data:       map[string][]byte{constants.ClientSecretKey: []byte("s3cr3t")},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, secret).Build()
+
+	var requests []string
+	reconciler := &AuthReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(32),
+		Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: &mockProvider{
+			issuerURL: "https://keycloak.example.com/realms/test",
+			clientID:  "test-app",
+		}},
+		IssuerPreflightEnabled: true,
+		IssuerPreflightClient:  stubIssuer(http.StatusInternalServerError, "", nil, &requests),
+	}
+
+	if err := reconciler.ReconcileAuth(context.Background(), app); err == nil {
+		t.Fatal("expected an error for an unreachable issuer, got nil")
+	}
+	cond := conditions.GetCondition(app, appsv1.ConditionTypeAuthReady)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != appsv1.ReasonIssuerUnreachable {
+		t.Fatalf("expected AuthReady=False with reason %s, got %+v", appsv1.ReasonIssuerUnreachable, cond)
+	}
+	if !strings.Contains(cond.Message, "HTTP 500") {
+		t.Errorf("expected the message to carry the HTTP error, got %q", cond.Message)
+	}
+	err := fakeClient.Get(context.Background(), types.NamespacedName{Name: naming.SecurityPolicyName(app), Namespace: "default"},
+		&egv1alpha1.SecurityPolicy{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected no SecurityPolicy while the issuer is unreachable, got err=%v", err)
+	}
+
+	// Once discovery succeeds the SecurityPolicy is written.
+	reconciler.IssuerPreflightClient = stubIssuer(http.StatusOK, `{"issuer":"https://keycloak.example.com/realms/test"}`, nil, &requests)
+	if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !conditions.IsConditionTrue(app, appsv1.ConditionTypeAuthReady) {
+		t.Errorf("expected AuthReady=True, got %+v", conditions.GetCondition(app, appsv1.ConditionTypeAuthReady))
+	}
+	if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: naming.SecurityPolicyName(app), Namespace: "default"},
+		&egv1alpha1.SecurityPolicy{}); err != nil {
+		t.Errorf("expected the SecurityPolicy to be created: %v", err)
+	}
+}
@@ -70,6 +70,18 @@ func (p *GenericOIDCProvider) CleanupTokenExchange(ctx context.Context, nebariAp
 	return nil
 }
 
+// SyncClientSecret is a no-op for generic OIDC providers; the client secret
+// is managed outside the operator.
+func (p *GenericOIDCProvider) SyncClientSecret(ctx context.Context, nebariApp *appsv1.NebariApp) (bool, error) {
+	return false, nil
+}
+
+// UnknownScopes returns nil for generic OIDC providers; the operator has no
+// way to list the scopes an arbitrary issuer defines.
+func (p *GenericOIDCProvider) UnknownScopes(ctx context.Context, nebariApp *appsv1.NebariApp) ([]string, error) {
+	return nil, nil
+}
+
 // ProvisionClient always returns an error as generic OIDC doesn't support provisioning.
 func (p *GenericOIDCProvider) ProvisionClient(ctx context.Context, nebariApp *appsv1.NebariApp) error {
 	return fmt.Errorf("generic-oidc provider does not support automatic client provisioning")
@@ -20,11 +20,19 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,10 +42,13 @@ import (
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -46,17 +57,23 @@ import (
 type KeycloakProvider struct {
 	Client client.Client
 	Config config.KeycloakConfig
+
+	// Recorder, when set, receives warning events about the admin secret.
+	Recorder record.EventRecorder
+
+	// ManagedBy is the app.kubernetes.io/managed-by value on the resources it
+	// creates and selects on. Empty means constants.DefaultManagedBy.
+	ManagedBy string
+
+	// AllowedRedirectSchemes lists the URI schemes permitted on the client's
+	// redirect URIs. Empty means constants.DefaultAllowedRedirectSchemes.
+	AllowedRedirectSchemes []string
 }
 
 // internalRealmURL returns the base internal cluster URL for the Keycloak realm.
 // This is used by both GetIssuerURL and GetEndpointOverrides to avoid duplication.
 func (p *KeycloakProvider) internalRealmURL() string {
-	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d%s/realms/%s",
-		p.Config.IssuerServiceName,
-		p.Config.IssuerServiceNamespace,
-		p.Config.IssuerServicePort,
-		p.Config.IssuerContextPath,
-		p.Config.Realm)
+	return p.Config.IssuerURL()
 }
 
 // externalRealmURL returns the publicly routable base URL for the Keycloak realm,
@@ -79,7 +96,7 @@ func (p *KeycloakProvider) GetIssuerURL(ctx context.Context, nebariApp *appsv1.N
 // GetEndpointOverrides returns OIDC endpoint URLs split by who actually hits
 // each endpoint:
 //
-//   - Token: server-side, called by the Envoy proxy in the OAuth2 back-channel.
+//   - Token, JWKS: server-side, called by the Envoy proxy in the OAuth2 back-channel.
 //     Uses the in-cluster Keycloak service URL: lower latency than the public
 //     URL, and avoids requiring Envoy to trust the public TLS chain (which can
 //     be a self-signed or staging issuer).
@@ -104,6 +121,7 @@ func (p *KeycloakProvider) GetEndpointOverrides(_ context.Context, _ *appsv1.Neb
 	internalBase := p.internalRealmURL() + "/protocol/openid-connect"
 	overrides := OIDCEndpointOverrides{
 		Token: ptr.To(internalBase + "/token"),
+		JWKS:  ptr.To(internalBase + "/certs"),
 	}
 
 	if externalBase := p.externalRealmURL(); externalBase != "" {
@@ -170,28 +188,17 @@ func (p *KeycloakProvider) loadCredentials(ctx context.Context) error {
 			p.Config.AdminSecretNamespace, p.Config.AdminSecretName, err)
 	}
 
-	// Extract credentials from secret (support both key formats)
-	var username, password []byte
-	var ok bool
-
-	// Try 'username' first, then 'admin-username'
-	username, ok = secret.Data["username"]
+	// Extract credentials from secret (support both key formats). The
+	// unprefixed key wins when both are present.
+	username, ok := p.adminSecretValue(ctx, secret, "username", "admin-username")
 	if !ok {
-		username, ok = secret.Data["admin-username"]
-		if !ok {
-			return fmt.Errorf("secret %s/%s missing 'username' or 'admin-username' field",
-				p.Config.AdminSecretNamespace, p.Config.AdminSecretName)
-		}
+		return fmt.Errorf("secret %s/%s missing 'username' or 'admin-username' field",
+			p.Config.AdminSecretNamespace, p.Config.AdminSecretName)
 	}
-
-	// Try 'password' first, then 'admin-password'
-	password, ok = secret.Data["password"]
+	password, ok := p.adminSecretValue(ctx, secret, "password", "admin-password")
 	if !ok {
-		password, ok = secret.Data["admin-password"]
-		if !ok {
-			return fmt.Errorf("secret %s/%s missing 'password' or 'admin-password' field",
-				p.Config.AdminSecretNamespace, p.Config.AdminSecretName)
-		}
+		return fmt.Errorf("secret %s/%s missing 'password' or 'admin-password' field",
+			p.Config.AdminSecretNamespace, p.Config.AdminSecretName)
 	}
 
 	p.Config.AdminUsername = string(username)
@@ -204,8 +211,36 @@ func (p *KeycloakProvider) loadCredentials(ctx context.Context) error {
 	return nil
 }
 
+// adminSecretValue returns the value of key, falling back to legacyKey. When
+// both keys are set to different values the secret is ambiguous: key still
+// wins, but a warning is logged and recorded as an event on the secret so the
+// conflict does not go unnoticed.
+func (p *KeycloakProvider) adminSecretValue(ctx context.Context, secret *corev1.Secret, key, legacyKey string) ([]byte, bool) {
+	value, ok := secret.Data[key]
+	legacyValue, legacyOK := secret.Data[legacyKey]
+	if !ok {
+		return legacyValue, legacyOK
+	}
+
+	if legacyOK && !bytes.Equal(value, legacyValue) {
+		msg := fmt.Sprintf("Keycloak admin secret sets both %q and %q to different values; using %q", key, legacyKey, key)
+		log.FromContext(ctx).Info("WARNING: "+msg,
+			"secretName", secret.Name,
+			"secretNamespace", secret.Namespace)
+		if p.Recorder != nil {
+			p.Recorder.Event(secret, corev1.EventTypeWarning, appsv1.EventReasonAmbiguousAdminSecret, msg)
+		}
+	}
+	return value, true
+}
+
 // ProvisionClient creates or updates a Keycloak OIDC client for the NebariApp.
-func (p *KeycloakProvider) ProvisionClient(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+func (p *KeycloakProvider) ProvisionClient(ctx context.Context, nebariApp *appsv1.NebariApp) (err error) {
+	ctx, span := tracing.Start(ctx, "keycloak.provision_client", attribute.String("clientID", p.GetClientID(ctx, nebariApp)))
+	defer func() {
+		err = classifyError(err)
+		tracing.End(span, err)
+	}()
 	ctx, cancel := p.withAPITimeout(ctx)
 	defer cancel()
 
@@ -247,8 +282,10 @@ func (p *KeycloakProvider) ProvisionClient(ctx context.Context, nebariApp *appsv
 		logger.Info("Created new client", "clientID", clientID)
 	}
 
-	// Sync requested OIDC scopes to the client
-	if err := p.syncClientScopes(ctx, kcClient, token, clientInternalID, nebariApp); err != nil {
+	// Sync requested OIDC scopes to the client. Scopes that cannot be
+	// provisioned are reported once everything else is in place.
+	degradedScopes, err := p.syncClientScopes(ctx, kcClient, token, clientInternalID, nebariApp)
+	if err != nil {
 		return fmt.Errorf("failed to sync client scopes: %w", err)
 	}
 
@@ -262,6 +299,13 @@ func (p *KeycloakProvider) ProvisionClient(ctx context.Context, nebariApp *appsv
 		return fmt.Errorf("failed to sync groups: %w", err)
 	}
 
+	// Ensure the app-scoped group and its client role mapping if requested
+	if nebariApp.Spec.Auth.CreateAppGroup {
+		if err := p.ensureAppGroup(ctx, kcClient, token, clientInternalID, nebariApp); err != nil {
+			return fmt.Errorf("failed to ensure app group: %w", err)
+		}
+	}
+
 	// Provision SPA client if requested
 	var spaClientID string
 	if p.shouldProvisionSPAClient(nebariApp) {
@@ -290,7 +334,22 @@ func (p *KeycloakProvider) ProvisionClient(ctx context.Context, nebariApp *appsv
 	}
 
 	// Store all credentials in Kubernetes Secret
-	return p.storeClientSecret(ctx, nebariApp, clientID, clientSecret, externalIssuerURL, spaClientID, deviceClientID)
+	if err := p.storeClientSecret(ctx, nebariApp, clientID, clientSecret, externalIssuerURL, spaClientID, deviceClientID); err != nil {
+		return err
+	}
+
+	// Publish the non-secret client configuration for review and export
+	exported := newExportedClientConfig(p.buildClientRepresentation(clientID, nebariApp), p.Config.Realm, RequestedScopes(nebariApp.Spec.Auth))
+	exported.SPAClientID = spaClientID
+	exported.DeviceClientID = deviceClientID
+	if err := p.storeClientConfig(ctx, nebariApp, exported); err != nil {
+		return fmt.Errorf("failed to store client config: %w", err)
+	}
+
+	if len(degradedScopes) > 0 {
+		return &DegradedScopesError{Scopes: degradedScopes}
+	}
+	return nil
 }
 
 // ConfigureTokenExchange enables OAuth 2.0 Token Exchange (RFC 8693) on this client
@@ -305,7 +364,12 @@ func (p *KeycloakProvider) ProvisionClient(ctx context.Context, nebariApp *appsv
 // peerClientIDs are Keycloak clientId strings (e.g. "jupyterhub-my-app"), not
 // internal UUIDs. Keycloak's policy API resolves clientId strings to UUIDs
 // server-side when creating client policies.
-func (p *KeycloakProvider) ConfigureTokenExchange(ctx context.Context, nebariApp *appsv1.NebariApp, peerClientIDs []string) error {
+func (p *KeycloakProvider) ConfigureTokenExchange(ctx context.Context, nebariApp *appsv1.NebariApp, peerClientIDs []string) (err error) {
+	ctx, span := tracing.Start(ctx, "keycloak.configure_token_exchange", attribute.String("clientID", p.GetClientID(ctx, nebariApp)))
+	defer func() {
+		err = classifyError(err)
+		tracing.End(span, err)
+	}()
 	ctx, cancel := p.withAPITimeout(ctx)
 	defer cancel()
 
@@ -327,7 +391,7 @@ func (p *KeycloakProvider) ConfigureTokenExchange(ctx context.Context, nebariApp
 		return err
 	}
 	if existingClient == nil {
-		return fmt.Errorf("client %s not found, provision it first", clientID)
+		return &ProviderError{Kind: ErrClientNotFound, Err: fmt.Errorf("client %s not found, provision it first", clientID)}
 	}
 	internalID := gocloak.PString(existingClient.ID)
 
@@ -443,7 +507,11 @@ func (p *KeycloakProvider) ConfigureTokenExchange(ctx context.Context, nebariApp
 	}
 	permReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
 	permReq.Header.Set("Content-Type", "application/json")
-	permResp, err := http.DefaultClient.Do(permReq)
+	httpClient, err := p.newHTTPClient()
+	if err != nil {
+		return fmt.Errorf("failed to configure Keycloak HTTP client: %w", err)
+	}
+	permResp, err := httpClient.Do(permReq)
 	if err != nil {
 		return fmt.Errorf("failed to update token-exchange permission: %w", err)
 	}
@@ -461,7 +529,12 @@ func (p *KeycloakProvider) ConfigureTokenExchange(ctx context.Context, nebariApp
 // causes Keycloak to automatically remove the auto-created scope permissions
 // (including token-exchange) from realm-management. Peer client policies are
 // left in place — without a referencing permission they have no effect.
-func (p *KeycloakProvider) CleanupTokenExchange(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+func (p *KeycloakProvider) CleanupTokenExchange(ctx context.Context, nebariApp *appsv1.NebariApp) (err error) {
+	ctx, span := tracing.Start(ctx, "keycloak.cleanup_token_exchange", attribute.String("clientID", p.GetClientID(ctx, nebariApp)))
+	defer func() {
+		err = classifyError(err)
+		tracing.End(span, err)
+	}()
 	ctx, cancel := p.withAPITimeout(ctx)
 	defer cancel()
 
@@ -497,7 +570,12 @@ func (p *KeycloakProvider) CleanupTokenExchange(ctx context.Context, nebariApp *
 }
 
 // DeleteClient removes the Keycloak OIDC client.
-func (p *KeycloakProvider) DeleteClient(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+func (p *KeycloakProvider) DeleteClient(ctx context.Context, nebariApp *appsv1.NebariApp) (err error) {
+	ctx, span := tracing.Start(ctx, "keycloak.delete_client", attribute.String("clientID", p.GetClientID(ctx, nebariApp)))
+	defer func() {
+		err = classifyError(err)
+		tracing.End(span, err)
+	}()
 	ctx, cancel := p.withAPITimeout(ctx)
 	defer cancel()
 
@@ -529,6 +607,13 @@ func (p *KeycloakProvider) DeleteClient(ctx context.Context, nebariApp *appsv1.N
 		logger.Info("Deleted confidential client", "clientID", clientID)
 	}
 
+	// Delete the app-scoped group. Its client role went away with the client.
+	if nebariApp.Spec.Auth != nil && nebariApp.Spec.Auth.CreateAppGroup {
+		if err := p.deleteAppGroup(ctx, kcClient, token, nebariApp); err != nil {
+			return err
+		}
+	}
+
 	// Delete SPA client if it exists
 	if p.shouldProvisionSPAClient(nebariApp) {
 		spaClientID := p.GetSPAClientID(ctx, nebariApp)
@@ -562,7 +647,77 @@ func (p *KeycloakProvider) DeleteClient(ctx context.Context, nebariApp *appsv1.N
 		logger.Info("Deleted device flow client", "clientID", deviceFlowClientID)
 	}
 
-	return nil
+	// The exported config describes a client that no longer exists
+	return p.deleteClientConfig(ctx, nebariApp)
+}
+
+// SyncClientSecret re-reads the confidential client's secret from Keycloak and
+// rewrites the client-secret key of the app's client Secret when it differs.
+// Keycloak is the source of truth: a secret regenerated in the admin console
+// would otherwise leave Envoy and the app presenting a stale secret until the
+// next full re-provision. Returns false when the client or the Secret does not
+// exist yet, since provisioning will create both.
+func (p *KeycloakProvider) SyncClientSecret(ctx context.Context, nebariApp *appsv1.NebariApp) (_ bool, err error) {
+	ctx, span := tracing.Start(ctx, "keycloak.sync_client_secret", attribute.String("clientID", p.GetClientID(ctx, nebariApp)))
+	defer func() { tracing.End(span, err) }()
+	ctx, cancel := p.withAPITimeout(ctx)
+	defer cancel()
+
+	clientID := p.GetClientID(ctx, nebariApp)
+
+	secret := &corev1.Secret{}
+	err = p.Client.Get(ctx, types.NamespacedName{Name: naming.ClientSecretName(nebariApp), Namespace: nebariApp.Namespace}, secret)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get client secret: %w", err)
+	}
+
+	if err := p.loadCredentials(ctx); err != nil {
+		return false, fmt.Errorf("failed to load Keycloak credentials: %w", err)
+	}
+
+	kcClient, token, err := p.authenticate(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	existingClient, err := p.findClient(ctx, kcClient, token, clientID)
+	if err != nil {
+		return false, err
+	}
+	if existingClient == nil {
+		return false, nil
+	}
+
+	secretResp, err := kcClient.GetClientSecret(ctx, token.AccessToken, p.Config.Realm, *existingClient.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get client secret from Keycloak: %w", err)
+	}
+	current := gocloak.PString(secretResp.Value)
+	if current == "" {
+		return false, nil
+	}
+	drifted := string(secret.Data[constants.ClientSecretKey]) != current
+	if !drifted && secret.Annotations[constants.AnnotationClientSecretHash] == ClientSecretHash([]byte(current)) {
+		return false, nil
+	}
+
+	// Restamp the Secret even when only the annotation is stale, so the next
+	// reconcile does not ask Keycloak again.
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[constants.ClientSecretKey] = []byte(current)
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, constants.AnnotationClientSecretHash, ClientSecretHash([]byte(current)))
+	if err := p.Client.Update(ctx, secret); err != nil {
+		return false, fmt.Errorf("failed to update client secret: %w", err)
+	}
+
+	if drifted {
+		log.FromContext(ctx).Info("Resynced client secret from Keycloak", "clientID", clientID)
+	}
+	return drifted, nil
 }
 
 // defaultAPITimeout is used when APITimeout is not configured.
@@ -578,9 +733,69 @@ func (p *KeycloakProvider) withAPITimeout(ctx context.Context) (context.Context,
 	return context.WithTimeout(ctx, timeout)
 }
 
-// authenticate creates a Keycloak client and obtains an admin token.
-func (p *KeycloakProvider) authenticate(ctx context.Context) (*gocloak.GoCloak, *gocloak.JWT, error) {
+// newHTTPClient builds the HTTP client used for all Keycloak admin API traffic.
+// It applies the configured proxy, additional CA bundle, TLS verification and
+// per-request timeout on top of a clone of http.DefaultTransport.
+func (p *KeycloakProvider) newHTTPClient() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if p.Config.HTTPProxy != "" {
+		proxyURL, err := url.Parse(p.Config.HTTPProxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Keycloak HTTP proxy URL %q: %w", p.Config.HTTPProxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if p.Config.CACertFile != "" || p.Config.InsecureSkipVerify {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if p.Config.CACertFile != "" {
+			pem, err := os.ReadFile(p.Config.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read Keycloak CA bundle %s: %w", p.Config.CACertFile, err)
+			}
+			pool, err := x509.SystemCertPool()
+			if err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no valid PEM certificates found in Keycloak CA bundle %s", p.Config.CACertFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		// #nosec G402 -- opt-in via KEYCLOAK_TLS_INSECURE_SKIP_VERIFY for development clusters
+		tlsConfig.InsecureSkipVerify = p.Config.InsecureSkipVerify
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   p.Config.HTTPTimeout,
+	}, nil
+}
+
+// newKeycloakClient creates a gocloak client whose underlying HTTP client
+// honours the operator's proxy, CA and timeout settings.
+func (p *KeycloakProvider) newKeycloakClient() (*gocloak.GoCloak, error) {
+	httpClient, err := p.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
 	kcClient := gocloak.NewClient(p.Config.URL)
+	kcClient.RestyClient().
+		SetTransport(httpClient.Transport).
+		SetTimeout(httpClient.Timeout)
+	return kcClient, nil
+}
+
+// authenticate creates a Keycloak client and obtains an admin token.
+func (p *KeycloakProvider) authenticate(ctx context.Context) (_ *gocloak.GoCloak, _ *gocloak.JWT, err error) {
+	ctx, span := tracing.Start(ctx, "keycloak.authenticate")
+	defer func() { tracing.End(span, err) }()
+	kcClient, err := p.newKeycloakClient()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to configure Keycloak HTTP client: %w", err)
+	}
 	token, err := kcClient.LoginAdmin(ctx, p.Config.AdminUsername, p.Config.AdminPassword, "master")
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to authenticate to Keycloak: %w", err)
@@ -588,6 +803,29 @@ func (p *KeycloakProvider) authenticate(ctx context.Context) (*gocloak.GoCloak,
 	return kcClient, token, nil
 }
 
+// classifyError wraps a Keycloak API error in a *ProviderError when its kind
+// is known: a 401 or 403 means the admin credentials were rejected, while a
+// transport failure (reported by gocloak with code 0) or a 5xx response means
+// Keycloak is unreachable. Other errors, and errors already classified, are
+// returned unchanged.
+func classifyError(err error) error {
+	var providerErr *ProviderError
+	if err == nil || errors.As(err, &providerErr) {
+		return err
+	}
+	var apiErr *gocloak.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	switch {
+	case apiErr.Code == http.StatusUnauthorized || apiErr.Code == http.StatusForbidden:
+		return &ProviderError{Kind: ErrAuthFailed, Err: err}
+	case apiErr.Code == 0 || apiErr.Code >= http.StatusInternalServerError:
+		return &ProviderError{Kind: ErrProviderUnreachable, Err: err}
+	}
+	return err
+}
+
 // findClient looks up a client by clientID, returns nil if not found.
 func (p *KeycloakProvider) findClient(ctx context.Context, kcClient *gocloak.GoCloak, token *gocloak.JWT, clientID string) (*gocloak.Client, error) {
 	clients, err := kcClient.GetClients(ctx, token.AccessToken, p.Config.Realm, gocloak.GetClientsParams{
@@ -604,93 +842,275 @@ func (p *KeycloakProvider) findClient(ctx context.Context, kcClient *gocloak.GoC
 }
 
 // updateExistingClient updates an existing client's configuration and returns its secret and internal ID.
+//
+// A client created outside the operator may be public, or confidential without
+// a generated secret, so Keycloak has no secret to return for it. The gateway
+// always authenticates with a client secret, so such a client is made
+// confidential and a new secret is generated for it instead of failing.
 func (p *KeycloakProvider) updateExistingClient(ctx context.Context, kcClient *gocloak.GoCloak, token *gocloak.JWT, existingClient *gocloak.Client, nebariApp *appsv1.NebariApp) (string, string, error) {
+	logger := log.FromContext(ctx)
+
 	// Get existing client secret
-	secretResp, err := kcClient.GetClientSecret(ctx, token.AccessToken, p.Config.Realm, *existingClient.ID)
+	clientSecret, err := p.existingClientSecret(ctx, kcClient, token, existingClient)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get client secret: %w", err)
+		return "", "", err
 	}
 
 	// Update client configuration
-	redirectURIs := p.buildRedirectURLs(nebariApp)
-	existingClient.RedirectURIs = &redirectURIs
-	existingClient.WebOrigins = &[]string{"*"}
-	existingClient.StandardFlowEnabled = gocloak.BoolP(true)
-
-	// Ensure post-logout redirect URIs are set (preserving any existing attributes)
-	postLogoutAttr := map[string]string{"post.logout.redirect.uris": p.buildPostLogoutRedirectURIs(nebariApp)}
-	if existingClient.Attributes != nil {
-		for k, v := range *existingClient.Attributes {
-			if k != "post.logout.redirect.uris" {
-				postLogoutAttr[k] = v
-			}
-		}
+	if gocloak.PBool(existingClient.PublicClient) {
+		logger.Info("Existing client is public, making it confidential", "clientID", gocloak.PString(existingClient.ClientID))
 	}
-	existingClient.Attributes = &postLogoutAttr
+	existingClient.PublicClient = gocloak.BoolP(false)
+	p.applyClientSettings(existingClient, nebariApp)
 
 	err = kcClient.UpdateClient(ctx, token.AccessToken, p.Config.Realm, *existingClient)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to update client: %w", err)
 	}
 
-	return *secretResp.Value, *existingClient.ID, nil
+	if clientSecret == "" {
+		logger.Info("Existing client has no retrievable secret, generating one", "clientID", gocloak.PString(existingClient.ClientID))
+		secretResp, err := kcClient.RegenerateClientSecret(ctx, token.AccessToken, p.Config.Realm, *existingClient.ID)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to generate client secret: %w", err)
+		}
+		clientSecret = gocloak.PString(secretResp.Value)
+		if clientSecret == "" {
+			return "", "", fmt.Errorf("keycloak generated an empty secret for client %s", gocloak.PString(existingClient.ClientID))
+		}
+	}
+
+	return clientSecret, *existingClient.ID, nil
+}
+
+// existingClientSecret returns the secret of an existing client, or "" when the
+// client has none to return: it is public, its secret was never generated, or
+// Keycloak rejects the request as invalid for the client. Other errors, such
+// as an unreachable Keycloak, are returned so a working secret is never
+// replaced because of a transient failure.
+func (p *KeycloakProvider) existingClientSecret(ctx context.Context, kcClient *gocloak.GoCloak, token *gocloak.JWT, existingClient *gocloak.Client) (string, error) {
+	if gocloak.PBool(existingClient.PublicClient) {
+		return "", nil
+	}
+	secretResp, err := kcClient.GetClientSecret(ctx, token.AccessToken, p.Config.Realm, *existingClient.ID)
+	var apiErr *gocloak.APIError
+	if errors.As(err, &apiErr) && (apiErr.Code == http.StatusBadRequest || apiErr.Code == http.StatusNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get client secret: %w", err)
+	}
+	return gocloak.PString(secretResp.Value), nil
 }
 
 // createNewClient creates a new Keycloak client and returns its secret and internal ID.
 func (p *KeycloakProvider) createNewClient(ctx context.Context, kcClient *gocloak.GoCloak, token *gocloak.JWT, clientID string, nebariApp *appsv1.NebariApp) (string, string, error) {
 	// Generate client secret
-	clientSecret, err := generateSecret(32)
+	clientSecret, err := generateSecret(p.clientSecretLength(), p.Config.ClientSecretEncoding)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to generate secret: %w", err)
 	}
 
-	// Build redirect URLs
-	redirectURIs := p.buildRedirectURLs(nebariApp)
-
 	// Create client
-	newClient := gocloak.Client{
+	newClient := p.buildClientRepresentation(clientID, nebariApp)
+	newClient.Secret = gocloak.StringP(clientSecret)
+
+	internalID, err := kcClient.CreateClient(ctx, token.AccessToken, p.Config.Realm, newClient)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return clientSecret, internalID, nil
+}
+
+// buildClientRepresentation returns the confidential client the operator
+// creates for a NebariApp, without its secret.
+func (p *KeycloakProvider) buildClientRepresentation(clientID string, nebariApp *appsv1.NebariApp) gocloak.Client {
+	client := gocloak.Client{
 		ClientID:                  gocloak.StringP(clientID),
 		Name:                      gocloak.StringP(fmt.Sprintf("%s OIDC Client", nebariApp.Name)),
-		Secret:                    gocloak.StringP(clientSecret),
-		RedirectURIs:              &redirectURIs,
-		WebOrigins:                &[]string{"*"},
-		Attributes:                &map[string]string{"post.logout.redirect.uris": p.buildPostLogoutRedirectURIs(nebariApp)},
 		PublicClient:              gocloak.BoolP(false),
-		StandardFlowEnabled:       gocloak.BoolP(true),
 		DirectAccessGrantsEnabled: gocloak.BoolP(false),
 		Protocol:                  gocloak.StringP("openid-connect"),
 		Enabled:                   gocloak.BoolP(true),
 	}
+	p.applyClientSettings(&client, nebariApp)
+	return client
+}
 
-	internalID, err := kcClient.CreateClient(ctx, token.AccessToken, p.Config.Realm, newClient)
-	if err != nil {
-		return "", "", fmt.Errorf("failed to create client: %w", err)
+// managedClientAttributes are the client attributes the operator owns. All other
+// attributes on an existing client are preserved.
+var managedClientAttributes = []string{
+	"post.logout.redirect.uris",
+	"frontchannel.logout.url",
+	"backchannel.logout.url",
+	"backchannel.logout.session.required",
+}
+
+// ValidateClientAttributes checks that auth.clientAttributes does not set an
+// attribute the operator manages.
+func ValidateClientAttributes(attributes map[string]string) error {
+	for key := range attributes {
+		if slices.Contains(managedClientAttributes, key) {
+			return fmt.Errorf("clientAttributes cannot set %q, it is managed by the operator", key)
+		}
 	}
+	return nil
+}
 
-	return clientSecret, internalID, nil
+// applyClientSettings sets the client settings the operator manages on both
+// new and existing clients. Attributes outside managedClientAttributes are
+// preserved, and auth.clientAttributes is applied over them.
+//
+// A bearerOnly app gets a bearer-only client: Keycloak never lets it start a
+// login, so it has no redirect URIs and the standard flow is off.
+func (p *KeycloakProvider) applyClientSettings(client *gocloak.Client, nebariApp *appsv1.NebariApp) {
+	bearerOnly := nebariApp.Spec.Auth != nil && nebariApp.Spec.Auth.BearerOnly
+
+	redirectURIs := []string{}
+	postLogoutRedirectURIs := ""
+	if !bearerOnly {
+		redirectURIs = p.buildRedirectURLs(nebariApp)
+		postLogoutRedirectURIs = p.buildPostLogoutRedirectURIs(nebariApp)
+	}
+	client.RedirectURIs = &redirectURIs
+	webOrigins := p.buildWebOrigins(nebariApp)
+	client.WebOrigins = &webOrigins
+	client.BearerOnly = gocloak.BoolP(bearerOnly)
+	client.StandardFlowEnabled = gocloak.BoolP(!bearerOnly)
+	client.RootURL = gocloak.StringP(p.buildRootURL(nebariApp))
+	client.BaseURL = gocloak.StringP(p.buildRootURL(nebariApp) + "/")
+
+	attributes := map[string]string{"post.logout.redirect.uris": postLogoutRedirectURIs}
+	if client.Attributes != nil {
+		for k, v := range *client.Attributes {
+			if !slices.Contains(managedClientAttributes, k) {
+				attributes[k] = v
+			}
+		}
+	}
+	if nebariApp.Spec.Auth != nil {
+		for k, v := range nebariApp.Spec.Auth.ClientAttributes {
+			if !slices.Contains(managedClientAttributes, k) {
+				attributes[k] = v
+			}
+		}
+	}
+	p.applyLogoutSettings(client, attributes, nebariApp)
+	// PKCE is only ever turned on: an admin may have required it on the client
+	// directly, so auth.pkce=false leaves the attribute as it is.
+	if nebariApp.Spec.Auth != nil && nebariApp.Spec.Auth.PKCE {
+		attributes["pkce.code.challenge.method"] = "S256"
+	}
+	client.Attributes = &attributes
 }
 
-// buildRedirectURLs constructs the OAuth2 redirect URLs for the client.
-func (p *KeycloakProvider) buildRedirectURLs(nebariApp *appsv1.NebariApp) []string {
-	redirectPath := constants.DefaultOAuthCallbackPath
-	if nebariApp.Spec.Auth != nil && nebariApp.Spec.Auth.RedirectURI != "" {
-		redirectPath = nebariApp.Spec.Auth.RedirectURI
+// applyLogoutSettings configures front- and back-channel logout from
+// auth.logout. Unset options are written as empty attributes so that turning
+// an option off clears the URL Keycloak would otherwise keep calling.
+func (p *KeycloakProvider) applyLogoutSettings(client *gocloak.Client, attributes map[string]string, nebariApp *appsv1.NebariApp) {
+	frontChannel := nebariApp.Spec.Auth != nil && nebariApp.Spec.Auth.Logout != nil && nebariApp.Spec.Auth.Logout.FrontChannel
+
+	client.FrontChannelLogout = gocloak.BoolP(frontChannel)
+	attributes["frontchannel.logout.url"] = ""
+	if frontChannel {
+		// The gateway's logout path clears the app's session cookies
+		attributes["frontchannel.logout.url"] = p.buildRootURL(nebariApp) + constants.DefaultLogoutPath
 	}
 
-	return []string{
+	attributes["backchannel.logout.url"] = BackchannelLogoutURL(nebariApp)
+	attributes["backchannel.logout.session.required"] = strconv.FormatBool(attributes["backchannel.logout.url"] != "")
+}
+
+// buildRootURL returns the app's public URL, used as the client's rootUrl so
+// Keycloak-initiated links (e.g. "Back to application" in the account console)
+// point at the app. The client's baseUrl is the same URL with a trailing slash.
+func (p *KeycloakProvider) buildRootURL(nebariApp *appsv1.NebariApp) string {
+	return fmt.Sprintf("https://%s", nebariApp.Spec.Hostname)
+}
+
+// buildRedirectURLs constructs the OAuth2 redirect URLs for the client. URLs
+// whose scheme is not in AllowedRedirectSchemes are dropped, with a warning
+// event on the NebariApp naming them.
+func (p *KeycloakProvider) buildRedirectURLs(nebariApp *appsv1.NebariApp) []string {
+	redirectPath := RedirectPath(nebariApp)
+
+	redirectURLs := []string{
 		fmt.Sprintf("https://%s%s", nebariApp.Spec.Hostname, redirectPath),
 		fmt.Sprintf("http://%s%s", nebariApp.Spec.Hostname, redirectPath),
 	}
+	if nebariApp.Spec.Auth != nil && nebariApp.Spec.Auth.RedirectURLOverride != "" {
+		redirectURLs = append(redirectURLs, nebariApp.Spec.Auth.RedirectURLOverride)
+	}
+	if stepUpPath := StepUpRedirectPath(nebariApp); stepUpPath != "" {
+		redirectURLs = append(redirectURLs,
+			fmt.Sprintf("https://%s%s", nebariApp.Spec.Hostname, stepUpPath),
+			fmt.Sprintf("http://%s%s", nebariApp.Spec.Hostname, stepUpPath),
+		)
+	}
+	return p.filterRedirectSchemes(nebariApp, "redirect URIs", redirectURLs)
+}
+
+// filterRedirectSchemes drops URLs whose scheme is not in
+// AllowedRedirectSchemes, with a warning event naming them. kind describes the
+// URLs in the event, e.g. "redirect URIs".
+func (p *KeycloakProvider) filterRedirectSchemes(nebariApp *appsv1.NebariApp, kind string, redirectURLs []string) []string {
+	allowed := p.AllowedRedirectSchemes
+	if len(allowed) == 0 {
+		allowed = constants.DefaultAllowedRedirectSchemes
+	}
+
+	kept := make([]string, 0, len(redirectURLs))
+	var dropped []string
+	for _, redirectURL := range redirectURLs {
+		scheme, _, _ := strings.Cut(redirectURL, "://")
+		if slices.Contains(allowed, strings.ToLower(scheme)) {
+			kept = append(kept, redirectURL)
+		} else {
+			dropped = append(dropped, redirectURL)
+		}
+	}
+	if len(dropped) > 0 && p.Recorder != nil {
+		p.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonRedirectURIDropped,
+			fmt.Sprintf("Dropped %s with a scheme outside the allowed %v: %s",
+				kind, allowed, strings.Join(dropped, ", ")))
+	}
+	return kept
+}
+
+// buildWebOrigins returns the origins allowed to make CORS requests with the
+// client: the app's hostname over https and http, matching the registered
+// redirect URIs, followed by auth.additionalWebOrigins. Origins whose scheme is
+// not allowed are dropped like redirect URIs.
+func (p *KeycloakProvider) buildWebOrigins(nebariApp *appsv1.NebariApp) []string {
+	origins := []string{
+		fmt.Sprintf("https://%s", nebariApp.Spec.Hostname),
+		fmt.Sprintf("http://%s", nebariApp.Spec.Hostname),
+	}
+	if nebariApp.Spec.Auth != nil {
+		for _, origin := range nebariApp.Spec.Auth.AdditionalWebOrigins {
+			if !slices.Contains(origins, origin) {
+				origins = append(origins, origin)
+			}
+		}
+	}
+	return p.filterRedirectSchemes(nebariApp, "web origins", origins)
 }
 
 // buildPostLogoutRedirectURIs constructs the Keycloak post.logout.redirect.uris attribute value.
 // Keycloak stores multiple URIs as "##"-delimited strings in this client attribute.
 // Envoy Gateway sends the app's base URL as post_logout_redirect_uri when hitting /logout.
+// An explicit auth.postLogoutRedirectURI is registered as well, since it may
+// point outside the app's hostname. URIs whose scheme is not allowed are dropped.
 func (p *KeycloakProvider) buildPostLogoutRedirectURIs(nebariApp *appsv1.NebariApp) string {
-	return strings.Join([]string{
+	uris := []string{
 		fmt.Sprintf("https://%s/*", nebariApp.Spec.Hostname),
 		fmt.Sprintf("http://%s/*", nebariApp.Spec.Hostname),
-	}, "##")
+	}
+	if postLogoutURL := PostLogoutRedirectURL(nebariApp); postLogoutURL != "" {
+		uris = append(uris, postLogoutURL)
+	}
+	return strings.Join(p.filterRedirectSchemes(nebariApp, "post-logout redirect URIs", uris), "##")
 }
 
 // storeClientSecret creates or updates the Kubernetes secret containing the OIDC client credentials.
@@ -720,8 +1140,11 @@ func (p *KeycloakProvider) storeClientSecret(ctx context.Context, nebariApp *app
 			Namespace: nebariApp.Namespace,
 			Labels: map[string]string{
 				"app.kubernetes.io/name":       "nebariapp",
-				"app.kubernetes.io/instance":   nebariApp.Name,
-				"app.kubernetes.io/managed-by": "nebari-operator",
+				"app.kubernetes.io/instance":   naming.LabelValue(nebariApp.Name),
+				"app.kubernetes.io/managed-by": naming.ManagedBy(p.ManagedBy),
+			},
+			Annotations: map[string]string{
+				constants.AnnotationClientSecretHash: ClientSecretHash([]byte(clientSecret)),
 			},
 		},
 		Type: corev1.SecretTypeOpaque,
@@ -739,24 +1162,179 @@ func (p *KeycloakProvider) storeClientSecret(ctx context.Context, nebariApp *app
 		return fmt.Errorf("failed to check for existing secret: %w", err)
 	}
 
-	// Update existing secret
+	// Update existing secret. Replacing Data also drops a legacy client secret key.
+	if _, ok := existingSecret.Data[constants.LegacyClientSecretKey]; ok {
+		log.FromContext(ctx).Info("Migrating OIDC client secret to current key",
+			"secretName", secretName, "legacyKey", constants.LegacyClientSecretKey, "key", constants.ClientSecretKey)
+	}
 	existingSecret.Data = secret.Data
+	metav1.SetMetaDataAnnotation(&existingSecret.ObjectMeta, constants.AnnotationClientSecretHash, ClientSecretHash([]byte(clientSecret)))
 	return p.Client.Update(ctx, existingSecret)
 }
 
-// syncClientScopes ensures that the OIDC scopes requested by the NebariApp
-// exist in the Keycloak realm and are assigned as default scopes to the client.
-func (p *KeycloakProvider) syncClientScopes(ctx context.Context, kcClient *gocloak.GoCloak, token *gocloak.JWT, clientInternalID string, nebariApp *appsv1.NebariApp) error {
-	if nebariApp.Spec.Auth == nil || len(nebariApp.Spec.Auth.Scopes) == 0 {
+// exportedClientConfig is the non-secret client configuration the operator
+// applied in Keycloak. It is published in a ConfigMap so teams can review it
+// or replicate it in infrastructure-as-code.
+type exportedClientConfig struct {
+	Realm                     string   `json:"realm"`
+	ClientID                  string   `json:"clientId"`
+	RootURL                   string   `json:"rootUrl,omitempty"`
+	BaseURL                   string   `json:"baseUrl,omitempty"`
+	RedirectURIs              []string `json:"redirectUris"`
+	WebOrigins                []string `json:"webOrigins"`
+	PostLogoutRedirectURIs    []string `json:"postLogoutRedirectUris,omitempty"`
+	DefaultClientScopes       []string `json:"defaultClientScopes,omitempty"`
+	PublicClient              bool     `json:"publicClient"`
+	BearerOnly                bool     `json:"bearerOnly,omitempty"`
+	StandardFlowEnabled       bool     `json:"standardFlowEnabled"`
+	DirectAccessGrantsEnabled bool     `json:"directAccessGrantsEnabled"`
+	SPAClientID               string   `json:"spaClientId,omitempty"`
+	DeviceClientID            string   `json:"deviceClientId,omitempty"`
+}
+
+// newExportedClientConfig extracts the exported fields from a client
+// representation sent to Keycloak.
+func newExportedClientConfig(client gocloak.Client, realm string, scopes []string) exportedClientConfig {
+	exported := exportedClientConfig{
+		Realm:                     realm,
+		ClientID:                  gocloak.PString(client.ClientID),
+		RootURL:                   gocloak.PString(client.RootURL),
+		BaseURL:                   gocloak.PString(client.BaseURL),
+		DefaultClientScopes:       scopes,
+		PublicClient:              gocloak.PBool(client.PublicClient),
+		BearerOnly:                gocloak.PBool(client.BearerOnly),
+		StandardFlowEnabled:       gocloak.PBool(client.StandardFlowEnabled),
+		DirectAccessGrantsEnabled: gocloak.PBool(client.DirectAccessGrantsEnabled),
+	}
+	if client.RedirectURIs != nil {
+		exported.RedirectURIs = *client.RedirectURIs
+	}
+	if client.WebOrigins != nil {
+		exported.WebOrigins = *client.WebOrigins
+	}
+	if client.Attributes != nil {
+		if uris := (*client.Attributes)["post.logout.redirect.uris"]; uris != "" {
+			exported.PostLogoutRedirectURIs = strings.Split(uris, "##")
+		}
+	}
+	return exported
+}
+
+// storeClientConfig creates or updates the ConfigMap holding the exported
+// client configuration as JSON.
+func (p *KeycloakProvider) storeClientConfig(ctx context.Context, nebariApp *appsv1.NebariApp, exported exportedClientConfig) error {
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal client config: %w", err)
+	}
+
+	configMapName := naming.ClientConfigMapName(nebariApp)
+	existing := &corev1.ConfigMap{}
+	err = p.Client.Get(ctx, types.NamespacedName{Name: configMapName, Namespace: nebariApp.Namespace}, existing)
+	if apierrors.IsNotFound(err) {
+		return p.Client.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configMapName,
+				Namespace: nebariApp.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/name":       "nebariapp",
+					"app.kubernetes.io/instance":   naming.LabelValue(nebariApp.Name),
+					"app.kubernetes.io/managed-by": naming.ManagedBy(p.ManagedBy),
+				},
+			},
+			Data: map[string]string{constants.ClientConfigKey: string(data)},
+		})
+	} else if err != nil {
+		return fmt.Errorf("failed to check for existing client config: %w", err)
+	}
+
+	if existing.Data[constants.ClientConfigKey] == string(data) {
 		return nil
 	}
+	existing.Data = map[string]string{constants.ClientConfigKey: string(data)}
+	return p.Client.Update(ctx, existing)
+}
 
-	logger := log.FromContext(ctx)
+// deleteClientConfig removes the exported client config ConfigMap if it exists.
+func (p *KeycloakProvider) deleteClientConfig(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.ClientConfigMapName(nebariApp),
+			Namespace: nebariApp.Namespace,
+		},
+	}
+	if err := p.Client.Delete(ctx, configMap); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete client config: %w", err)
+	}
+	return nil
+}
+
+// placeholderScopeDescription marks the client scopes the operator creates for
+// requested scopes the realm does not define. They carry no protocol mappers,
+// so requesting them adds nothing to the token.
+const placeholderScopeDescription = "Managed by nebari-operator"
+
+// UnknownScopes returns the requested scopes (see RequestedScopes) and
+// auth.stepUpScopes that the realm does not define. A placeholder scope the operator created during
+// provisioning counts as undefined, since the realm configures no claims for
+// it. "openid" is always implicit and never reported.
+func (p *KeycloakProvider) UnknownScopes(ctx context.Context, nebariApp *appsv1.NebariApp) (_ []string, err error) {
+	scopes := RequestedScopes(nebariApp.Spec.Auth)
+	if nebariApp.Spec.Auth == nil || (len(scopes) == 0 && len(nebariApp.Spec.Auth.StepUpScopes) == 0) {
+		return nil, nil
+	}
+
+	ctx, span := tracing.Start(ctx, "keycloak.unknown_scopes", attribute.String("clientID", p.GetClientID(ctx, nebariApp)))
+	defer func() { tracing.End(span, err) }()
+	ctx, cancel := p.withAPITimeout(ctx)
+	defer cancel()
+
+	if err := p.loadCredentials(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load Keycloak credentials: %w", err)
+	}
+	kcClient, token, err := p.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	realmScopes, err := kcClient.GetClientScopes(ctx, token.AccessToken, p.Config.Realm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get realm client scopes: %w", err)
+	}
+
+	defined := make(map[string]bool, len(realmScopes))
+	for _, s := range realmScopes {
+		if s.Name != nil && gocloak.PString(s.Description) != placeholderScopeDescription {
+			defined[*s.Name] = true
+		}
+	}
+
+	var unknown []string
+	for _, scopeName := range slices.Concat(scopes, nebariApp.Spec.Auth.StepUpScopes) {
+		if scopeName == "openid" || defined[scopeName] || slices.Contains(unknown, scopeName) {
+			continue
+		}
+		unknown = append(unknown, scopeName)
+	}
+	return unknown, nil
+}
+
+// syncClientScopes ensures that the OIDC scopes requested by the NebariApp
+// exist in the Keycloak realm and are assigned as default scopes to the client.
+// auth.stepUpScopes are assigned as optional scopes instead, so tokens only
+// carry them when the step-up login asks for them.
+// A scope that cannot be created or assigned does not fail the sync; its name
+// is returned in degraded so the caller can report partial readiness. Errors
+// reading the realm or client scopes are returned as err.
+func (p *KeycloakProvider) syncClientScopes(ctx context.Context, kcClient *gocloak.GoCloak, token *gocloak.JWT, clientInternalID string, nebariApp *appsv1.NebariApp) (degraded []string, err error) {
+	scopes := RequestedScopes(nebariApp.Spec.Auth)
+	if nebariApp.Spec.Auth == nil || (len(scopes) == 0 && len(nebariApp.Spec.Auth.StepUpScopes) == 0) {
+		return nil, nil
+	}
 
 	// Get all existing client scopes in the realm
 	realmScopes, err := kcClient.GetClientScopes(ctx, token.AccessToken, p.Config.Realm)
 	if err != nil {
-		return fmt.Errorf("failed to get realm client scopes: %w", err)
+		return nil, fmt.Errorf("failed to get realm client scopes: %w", err)
 	}
 
 	// Build name→scope lookup
@@ -767,20 +1345,55 @@ func (p *KeycloakProvider) syncClientScopes(ctx context.Context, kcClient *goclo
 		}
 	}
 
-	// Get scopes already assigned as defaults on this client
-	currentDefaults, err := kcClient.GetClientsDefaultScopes(ctx, token.AccessToken, p.Config.Realm, clientInternalID)
-	if err != nil {
-		return fmt.Errorf("failed to get client default scopes: %w", err)
+	if len(scopes) > 0 {
+		// Get scopes already assigned as defaults on this client
+		currentDefaults, err := kcClient.GetClientsDefaultScopes(ctx, token.AccessToken, p.Config.Realm, clientInternalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get client default scopes: %w", err)
+		}
+		degraded = append(degraded, p.assignClientScopes(ctx, kcClient, token, scopesByName, currentDefaults,
+			scopes, "default", func(scopeID string) error {
+				return kcClient.AddDefaultScopeToClient(ctx, token.AccessToken, p.Config.Realm, clientInternalID, scopeID)
+			})...)
+	}
+
+	var stepUpScopes []string
+	for _, scopeName := range nebariApp.Spec.Auth.StepUpScopes {
+		if !slices.Contains(scopes, scopeName) {
+			stepUpScopes = append(stepUpScopes, scopeName)
+		}
+	}
+	if len(stepUpScopes) > 0 {
+		currentOptional, err := kcClient.GetClientsOptionalScopes(ctx, token.AccessToken, p.Config.Realm, clientInternalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get client optional scopes: %w", err)
+		}
+		degraded = append(degraded, p.assignClientScopes(ctx, kcClient, token, scopesByName, currentOptional,
+			stepUpScopes, "optional", func(scopeID string) error {
+				return kcClient.AddOptionalScopeToClient(ctx, token.AccessToken, p.Config.Realm, clientInternalID, scopeID)
+			})...)
 	}
 
-	assignedIDs := make(map[string]bool, len(currentDefaults))
-	for _, s := range currentDefaults {
+	return degraded, nil
+}
+
+// assignClientScopes creates any of scopeNames missing from the realm and
+// assigns those not in current to the client with assign. kind ("default" or
+// "optional") names the assignment in logs. It returns the scopes that could not
+// be created or assigned.
+func (p *KeycloakProvider) assignClientScopes(ctx context.Context, kcClient *gocloak.GoCloak, token *gocloak.JWT,
+	scopesByName map[string]*gocloak.ClientScope, current []*gocloak.ClientScope, scopeNames []string, kind string,
+	assign func(scopeID string) error) (degraded []string) {
+	logger := log.FromContext(ctx)
+
+	assignedIDs := make(map[string]bool, len(current))
+	for _, s := range current {
 		if s.ID != nil {
 			assignedIDs[*s.ID] = true
 		}
 	}
 
-	for _, scopeName := range nebariApp.Spec.Auth.Scopes {
+	for _, scopeName := range scopeNames {
 		// "openid" is always implicit in OIDC - skip it
 		if scopeName == "openid" {
 			continue
@@ -795,15 +1408,18 @@ func (p *KeycloakProvider) syncClientScopes(ctx context.Context, kcClient *goclo
 			includeInToken := "true"
 			newScope := gocloak.ClientScope{
 				Name:        gocloak.StringP(scopeName),
-				Description: gocloak.StringP("Managed by nebari-operator"),
+				Description: gocloak.StringP(placeholderScopeDescription),
 				Protocol:    gocloak.StringP("openid-connect"),
 				ClientScopeAttributes: &gocloak.ClientScopeAttributes{
 					IncludeInTokenScope: &includeInToken,
 				},
 			}
+			var err error
 			scopeID, err = kcClient.CreateClientScope(ctx, token.AccessToken, p.Config.Realm, newScope)
 			if err != nil {
-				return fmt.Errorf("failed to create client scope %q: %w", scopeName, err)
+				logger.Error(err, "Failed to create client scope, continuing without it", "scope", scopeName)
+				degraded = append(degraded, scopeName)
+				continue
 			}
 			logger.Info("Created client scope in realm", "scope", scopeName, "id", scopeID)
 		}
@@ -811,16 +1427,18 @@ func (p *KeycloakProvider) syncClientScopes(ctx context.Context, kcClient *goclo
 		// Note: Protocol mappers are applied at client level (not scope level)
 		// via syncClientProtocolMappers, so each NebariApp is isolated.
 
-		// Assign as default scope to the client if not already assigned
+		// Assign the scope to the client if not already assigned
 		if !assignedIDs[scopeID] {
-			if err := kcClient.AddDefaultScopeToClient(ctx, token.AccessToken, p.Config.Realm, clientInternalID, scopeID); err != nil {
-				return fmt.Errorf("failed to add default scope %q to client: %w", scopeName, err)
+			if err := assign(scopeID); err != nil {
+				logger.Error(err, "Failed to add "+kind+" scope to client, continuing without it", "scope", scopeName)
+				degraded = append(degraded, scopeName)
+				continue
 			}
-			logger.Info("Assigned default scope to client", "scope", scopeName)
+			logger.Info("Assigned "+kind+" scope to client", "scope", scopeName)
 		}
 	}
 
-	return nil
+	return degraded
 }
 
 // syncClientProtocolMappers ensures protocol mappers are configured directly on the
@@ -829,7 +1447,7 @@ func (p *KeycloakProvider) syncClientScopes(ctx context.Context, kcClient *goclo
 //
 // If keycloakConfig.protocolMappers is specified, those mappers are used.
 // Otherwise, if "groups" is in the requested scopes, a default group-membership
-// mapper is created with full.path=false.
+// mapper is created with full.path=false, emitting the auth.groupsClaim claim.
 func (p *KeycloakProvider) syncClientProtocolMappers(ctx context.Context, kcClient *gocloak.GoCloak, token *gocloak.JWT, clientInternalID string, nebariApp *appsv1.NebariApp) error {
 	if nebariApp.Spec.Auth == nil {
 		return nil
@@ -849,7 +1467,7 @@ func (p *KeycloakProvider) syncClientProtocolMappers(ctx context.Context, kcClie
 				Name:           "group-membership",
 				ProtocolMapper: "oidc-group-membership-mapper",
 				Config: map[string]string{
-					"claim.name":           "groups",
+					"claim.name":           naming.GroupsClaim(nebariApp),
 					"full.path":            "false",
 					"id.token.claim":       "true",
 					"access.token.claim":   "true",
@@ -911,15 +1529,7 @@ func (p *KeycloakProvider) syncClientProtocolMappers(ctx context.Context, kcClie
 
 // hasScope returns true if the NebariApp requests the given OIDC scope.
 func hasScope(nebariApp *appsv1.NebariApp, scope string) bool {
-	if nebariApp.Spec.Auth == nil {
-		return false
-	}
-	for _, s := range nebariApp.Spec.Auth.Scopes {
-		if s == scope {
-			return true
-		}
-	}
-	return false
+	return slices.Contains(RequestedScopes(nebariApp.Spec.Auth), scope)
 }
 
 // syncGroups ensures that Keycloak groups exist in the realm and that
@@ -1017,6 +1627,83 @@ func (p *KeycloakProvider) ensureGroup(ctx context.Context, kcClient *gocloak.Go
 	return groupID, nil
 }
 
+// appAccessRoleName is the client role the app-scoped group is mapped to.
+const appAccessRoleName = "access"
+
+// ensureAppGroup ensures the app-scoped group exists and is mapped to the
+// "access" client role on the app's client, creating the role if needed.
+// Keycloak treats re-adding an existing role mapping as a no-op.
+func (p *KeycloakProvider) ensureAppGroup(ctx context.Context, kcClient *gocloak.GoCloak, token *gocloak.JWT, clientInternalID string, nebariApp *appsv1.NebariApp) error {
+	logger := log.FromContext(ctx)
+	realm := p.Config.Realm
+	groupName := naming.AppGroupName(nebariApp)
+
+	groupID, err := p.ensureGroup(ctx, kcClient, token, realm, groupName)
+	if err != nil {
+		return err
+	}
+
+	roles, err := kcClient.GetClientRoles(ctx, token.AccessToken, realm, clientInternalID, gocloak.GetRoleParams{
+		Search: gocloak.StringP(appAccessRoleName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list client roles: %w", err)
+	}
+	var role *gocloak.Role
+	for _, r := range roles {
+		if r.Name != nil && *r.Name == appAccessRoleName {
+			role = r
+			break
+		}
+	}
+	if role == nil {
+		if _, err := kcClient.CreateClientRole(ctx, token.AccessToken, realm, clientInternalID, gocloak.Role{
+			Name:        gocloak.StringP(appAccessRoleName),
+			Description: gocloak.StringP(fmt.Sprintf("Access to %s", nebariApp.Spec.Hostname)),
+		}); err != nil {
+			return fmt.Errorf("failed to create client role %q: %w", appAccessRoleName, err)
+		}
+		role, err = kcClient.GetClientRole(ctx, token.AccessToken, realm, clientInternalID, appAccessRoleName)
+		if err != nil {
+			return fmt.Errorf("failed to get client role %q: %w", appAccessRoleName, err)
+		}
+	}
+
+	if err := kcClient.AddClientRolesToGroup(ctx, token.AccessToken, realm, clientInternalID, groupID, []gocloak.Role{*role}); err != nil {
+		return fmt.Errorf("failed to map client role %q to group %q: %w", appAccessRoleName, groupName, err)
+	}
+
+	logger.Info("Ensured app group", "group", groupName, "role", appAccessRoleName)
+	return nil
+}
+
+// deleteAppGroup removes the app-scoped group if it exists.
+func (p *KeycloakProvider) deleteAppGroup(ctx context.Context, kcClient *gocloak.GoCloak, token *gocloak.JWT, nebariApp *appsv1.NebariApp) error {
+	logger := log.FromContext(ctx)
+	realm := p.Config.Realm
+	groupName := naming.AppGroupName(nebariApp)
+
+	groups, err := kcClient.GetGroups(ctx, token.AccessToken, realm, gocloak.GetGroupsParams{
+		Search: &groupName,
+		Exact:  gocloak.BoolP(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to search for group %q: %w", groupName, err)
+	}
+
+	for _, g := range groups {
+		if g.Name == nil || *g.Name != groupName || g.ID == nil {
+			continue
+		}
+		if err := kcClient.DeleteGroup(ctx, token.AccessToken, realm, *g.ID); err != nil {
+			return fmt.Errorf("failed to delete group %q: %w", groupName, err)
+		}
+		logger.Info("Deleted app group", "group", groupName)
+	}
+
+	return nil
+}
+
 // syncGroupMembers ensures the specified users are members of the given group.
 // This function is additive-only: it adds missing users to the group but does not
 // remove users who are in Keycloak but not in the members list. Manual removal
@@ -1097,12 +1784,12 @@ func (p *KeycloakProvider) provisionSPAClient(ctx context.Context, kcClient *goc
 
 	// Build wildcard redirect URLs for SPA
 	hostname := nebariApp.Spec.Hostname
-	redirectURIs := []string{
+	redirectURIs := p.filterRedirectSchemes(nebariApp, "SPA client redirect URIs", []string{
 		fmt.Sprintf("https://%s/*", hostname),
 		fmt.Sprintf("https://%s", hostname),
 		fmt.Sprintf("http://%s/*", hostname),
 		fmt.Sprintf("http://%s", hostname),
-	}
+	})
 
 	if existingSPAClient != nil {
 		// Update existing SPA client
@@ -1222,8 +1909,9 @@ func (p *KeycloakProvider) provisionDeviceFlowClient(ctx context.Context, kcClie
 		logger.Info("Created new device flow client", "clientID", deviceClientID)
 	}
 
-	// Sync scopes from spec to the device flow client
-	if err := p.syncClientScopes(ctx, kcClient, token, internalID, nebariApp); err != nil {
+	// Sync scopes from spec to the device flow client. Degraded scopes are
+	// already reported through the confidential client.
+	if _, err := p.syncClientScopes(ctx, kcClient, token, internalID, nebariApp); err != nil {
 		return "", fmt.Errorf("failed to sync device flow client scopes: %w", err)
 	}
 
@@ -1277,11 +1965,25 @@ func (p *KeycloakProvider) provisionDeviceFlowClient(ctx context.Context, kcClie
 	return deviceClientID, nil
 }
 
-// generateSecret generates a random secret string of the specified length.
-func generateSecret(length int) (string, error) {
+// clientSecretLength returns the number of random bytes in generated client
+// secrets, defaulting to constants.DefaultClientSecretLength when unset.
+func (p *KeycloakProvider) clientSecretLength() int {
+	if p.Config.ClientSecretLength > 0 {
+		return p.Config.ClientSecretLength
+	}
+	return constants.DefaultClientSecretLength
+}
+
+// generateSecret returns length random bytes encoded whole, as lowercase hex or
+// unpadded base64url (the default), so no entropy is lost to truncation and the
+// secret only uses URL-safe characters.
+func generateSecret(length int, encoding string) (string, error) {
 	randBytes := make([]byte, length)
 	if _, err := rand.Read(randBytes); err != nil {
 		return "", err
 	}
-	return base64.URLEncoding.EncodeToString(randBytes)[:length], nil
+	if encoding == constants.ClientSecretEncodingHex {
+		return hex.EncodeToString(randBytes), nil
+	}
+	return base64.RawURLEncoding.EncodeToString(randBytes), nil
 }
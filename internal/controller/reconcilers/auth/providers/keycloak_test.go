@@ -18,12 +18,20 @@ package providers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/Nerzal/gocloak/v13"
 	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
 	"github.com/nebari-dev/nebari-operator/internal/config"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
@@ -33,6 +41,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -221,6 +230,11 @@ func TestKeycloakProvider_GetEndpointOverrides(t *testing.T) {
 			if got.Token == nil || *got.Token != *tt.expected.Token {
 				t.Errorf("token: expected %q, got %v", *tt.expected.Token, got.Token)
 			}
+			// JWKS is fetched by Envoy like the token endpoint, so it stays in-cluster too
+			wantJWKS := strings.TrimSuffix(*tt.expected.Token, "/token") + "/certs"
+			if got.JWKS == nil || *got.JWKS != wantJWKS {
+				t.Errorf("jwks: expected %q, got %v", wantJWKS, got.JWKS)
+			}
 			switch {
 			case tt.expected.Authorization == nil && got.Authorization != nil:
 				t.Errorf("authorization: expected nil, got %q", *got.Authorization)
@@ -324,6 +338,70 @@ func TestKeycloakProvider_BuildRedirectURLs(t *testing.T) {
 				"http://test.example.com/custom/callback",
 			},
 		},
+		{
+			name: "Redirect URI is normalized",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:     true,
+						RedirectURI: "custom/callback/",
+					},
+				},
+			},
+			expectedURLs: []string{
+				"https://test.example.com/custom/callback",
+				"http://test.example.com/custom/callback",
+			},
+		},
+		{
+			name: "Step-up callback is registered when step-up is configured",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:      true,
+						StepUpScopes: []string{"admin"},
+						StepUpPaths:  []string{"/admin"},
+					},
+				},
+			},
+			expectedURLs: []string{
+				"https://test.example.com/oauth2/callback",
+				"http://test.example.com/oauth2/callback",
+				"https://test.example.com/admin/oauth2/callback",
+				"http://test.example.com/admin/oauth2/callback",
+			},
+		},
+		{
+			name: "Redirect URL override is registered in addition to computed URLs",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:             true,
+						RedirectURLOverride: "https://cdn.example.com/oauth2/callback",
+					},
+				},
+			},
+			expectedURLs: []string{
+				"https://test.example.com/oauth2/callback",
+				"http://test.example.com/oauth2/callback",
+				"https://cdn.example.com/oauth2/callback",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -344,6 +422,75 @@ func TestKeycloakProvider_BuildRedirectURLs(t *testing.T) {
 	}
 }
 
+func TestKeycloakProvider_OtherClientURIs_AllowedSchemes(t *testing.T) {
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:               true,
+				PostLogoutRedirectURI: "http://www.example.com/bye",
+			},
+		},
+	}
+
+	provider := &KeycloakProvider{Recorder: record.NewFakeRecorder(10), AllowedRedirectSchemes: []string{"https"}}
+
+	if origins := provider.buildWebOrigins(nebariApp); !slices.Equal(origins, []string{"https://test.example.com"}) {
+		t.Errorf("expected only the https web origin, got %v", origins)
+	}
+	if uris := provider.buildPostLogoutRedirectURIs(nebariApp); uris != "https://test.example.com/*" {
+		t.Errorf("expected only the https post-logout redirect URI, got %q", uris)
+	}
+}
+
+func TestKeycloakProvider_BuildRedirectURLs_AllowedSchemes(t *testing.T) {
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:             true,
+				RedirectURLOverride: "http://cdn.example.com/oauth2/callback",
+				StepUpScopes:        []string{"admin"},
+				StepUpPaths:         []string{"/admin"},
+			},
+		},
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	provider := &KeycloakProvider{Recorder: recorder, AllowedRedirectSchemes: []string{"https"}}
+
+	urls := provider.buildRedirectURLs(nebariApp)
+	expected := []string{
+		"https://test.example.com/oauth2/callback",
+		"https://test.example.com/admin/oauth2/callback",
+	}
+	if !slices.Equal(urls, expected) {
+		t.Errorf("expected %v, got %v", expected, urls)
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, appsv1.EventReasonRedirectURIDropped) ||
+			!strings.Contains(event, "http://cdn.example.com/oauth2/callback") {
+			t.Errorf("expected a %s event naming the dropped URIs, got %q", appsv1.EventReasonRedirectURIDropped, event)
+		}
+	default:
+		t.Errorf("expected a %s event", appsv1.EventReasonRedirectURIDropped)
+	}
+
+	// The default allows both schemes and records nothing.
+	recorder = record.NewFakeRecorder(10)
+	provider = &KeycloakProvider{Recorder: recorder}
+	if urls := provider.buildRedirectURLs(nebariApp); len(urls) != 5 {
+		t.Errorf("expected all 5 redirect URIs by default, got %v", urls)
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected no events by default, got %d", len(recorder.Events))
+	}
+}
+
 func TestKeycloakProvider_StoreClientSecret(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
@@ -406,6 +553,35 @@ func TestKeycloakProvider_StoreClientSecret(t *testing.T) {
 			expectError:       false,
 			expectedSecretLen: 3,
 		},
+		{
+			name: "Update existing secret with legacy client secret key",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+					UID:       "test-uid",
+				},
+			},
+			clientID:       "default-test-app",
+			clientSecret:   "new-secret-value",
+			externalIssuer: "https://keycloak.example.com/realms/nebari",
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: naming.ClientSecretName(&appsv1.NebariApp{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "test-app",
+							Namespace: "default",
+						},
+					}),
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					constants.LegacyClientSecretKey: []byte("old-secret-value"),
+				},
+			},
+			expectError:       false,
+			expectedSecretLen: 3, // legacy key is dropped
+		},
 		{
 			name: "Create secret with all fields including SPA and device client",
 			nebariApp: &appsv1.NebariApp{
@@ -511,6 +687,43 @@ func TestKeycloakProvider_StoreClientSecret(t *testing.T) {
 	}
 }
 
+func TestKeycloakProvider_StoreClientSecret_ManagedBy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+	}
+
+	tests := []struct {
+		name      string
+		managedBy string
+		expected  string
+	}{
+		{name: "default", expected: constants.DefaultManagedBy},
+		{name: "configured", managedBy: "nebari-operator-staging", expected: "nebari-operator-staging"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp).Build()
+			provider := &KeycloakProvider{Client: k8sClient, ManagedBy: tt.managedBy}
+			if err := provider.storeClientSecret(context.Background(), nebariApp, "default-test-app", "s3cr3t", "", "", ""); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			secret := &corev1.Secret{}
+			key := types.NamespacedName{Name: naming.ClientSecretName(nebariApp), Namespace: "default"}
+			if err := k8sClient.Get(context.Background(), key, secret); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			if got := secret.Labels["app.kubernetes.io/managed-by"]; got != tt.expected {
+				t.Errorf("expected managed-by label %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
 func TestKeycloakProvider_LoadCredentials(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
@@ -714,6 +927,86 @@ func TestKeycloakProvider_LoadCredentials_RefreshesOnSecretChange(t *testing.T)
 	}
 }
 
+func TestKeycloakProvider_LoadCredentials_AmbiguousKeys(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name             string
+		data             map[string][]byte
+		expectedUsername string
+		expectedPassword string
+		expectedEvents   int
+	}{
+		{
+			name: "both key formats with different values warn and prefer the unprefixed keys",
+			data: map[string][]byte{
+				"username":       []byte("admin"),
+				"admin-username": []byte("other-admin"),
+				"password":       []byte("secret123"),
+				"admin-password": []byte("other-secret"),
+			},
+			expectedUsername: "admin",
+			expectedPassword: "secret123",
+			expectedEvents:   2,
+		},
+		{
+			name: "both key formats with the same values are not ambiguous",
+			data: map[string][]byte{
+				"username":       []byte("admin"),
+				"admin-username": []byte("admin"),
+				"password":       []byte("secret123"),
+				"admin-password": []byte("secret123"),
+			},
+			expectedUsername: "admin",
+			expectedPassword: "secret123",
+			expectedEvents:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "kc-admin", Namespace: "keycloak"},
+				Data:       tt.data,
+			}
+			recorder := record.NewFakeRecorder(10)
+			provider := &KeycloakProvider{
+				Config: config.KeycloakConfig{
+					AdminSecretName:      "kc-admin",
+					AdminSecretNamespace: "keycloak",
+				},
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+				Recorder: recorder,
+			}
+
+			if err := provider.loadCredentials(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if provider.Config.AdminUsername != tt.expectedUsername {
+				t.Errorf("expected username %q, got %q", tt.expectedUsername, provider.Config.AdminUsername)
+			}
+			if provider.Config.AdminPassword != tt.expectedPassword {
+				t.Errorf("expected password %q, got %q", tt.expectedPassword, provider.Config.AdminPassword)
+			}
+
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			if len(events) != tt.expectedEvents {
+				t.Fatalf("expected %d events, got %d: %v", tt.expectedEvents, len(events), events)
+			}
+			for _, event := range events {
+				if !strings.HasPrefix(event, corev1.EventTypeWarning+" "+appsv1.EventReasonAmbiguousAdminSecret) {
+					t.Errorf("expected %s warning event, got %q", appsv1.EventReasonAmbiguousAdminSecret, event)
+				}
+			}
+		})
+	}
+}
+
 func TestKeycloakProvider_SyncClientScopes_NoScopes(t *testing.T) {
 	// syncClientScopes should return nil immediately when no scopes are configured
 	provider := &KeycloakProvider{
@@ -760,10 +1053,13 @@ func TestKeycloakProvider_SyncClientScopes_NoScopes(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// syncClientScopes should return nil without making any Keycloak calls
 			// (passing nil kcClient and token proves no API calls are made)
-			err := provider.syncClientScopes(context.Background(), nil, nil, "fake-id", tt.nebariApp)
+			degraded, err := provider.syncClientScopes(context.Background(), nil, nil, "fake-id", tt.nebariApp)
 			if err != nil {
 				t.Errorf("expected no error, got: %v", err)
 			}
+			if len(degraded) != 0 {
+				t.Errorf("expected no degraded scopes, got %v", degraded)
+			}
 		})
 	}
 }
@@ -943,6 +1239,19 @@ func TestHasScope(t *testing.T) {
 			scope:    "groups",
 			expected: true,
 		},
+		{
+			name: "Scope implied by groups",
+			app: &appsv1.NebariApp{
+				Spec: appsv1.NebariAppSpec{
+					Auth: &appsv1.AuthConfig{
+						Enabled: true,
+						Groups:  []string{"admins"},
+					},
+				},
+			},
+			scope:    "groups",
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -955,6 +1264,52 @@ func TestHasScope(t *testing.T) {
 	}
 }
 
+func TestRequestedScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     *appsv1.AuthConfig
+		expected []string
+	}{
+		{name: "nil auth", auth: nil, expected: nil},
+		{name: "no scopes and no groups", auth: &appsv1.AuthConfig{}, expected: nil},
+		{
+			name:     "scopes without groups are unchanged",
+			auth:     &appsv1.AuthConfig{Scopes: []string{"openid", "email"}},
+			expected: []string{"openid", "email"},
+		},
+		{
+			name:     "groups adds the groups scope to the default scopes",
+			auth:     &appsv1.AuthConfig{Groups: []string{"admins"}},
+			expected: []string{"openid", "profile", "email", "groups"},
+		},
+		{
+			name:     "groups adds the groups scope to the requested scopes",
+			auth:     &appsv1.AuthConfig{Groups: []string{"admins"}, Scopes: []string{"openid", "email"}},
+			expected: []string{"openid", "email", "groups"},
+		},
+		{
+			name:     "groups scope already requested",
+			auth:     &appsv1.AuthConfig{Groups: []string{"admins"}, Scopes: []string{"openid", "groups"}},
+			expected: []string{"openid", "groups"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var before []string
+			if tt.auth != nil {
+				before = slices.Clone(tt.auth.Scopes)
+			}
+			if got := RequestedScopes(tt.auth); !slices.Equal(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+			if tt.auth != nil && !slices.Equal(tt.auth.Scopes, before) {
+				t.Errorf("expected auth.scopes to stay %v, got %v", before, tt.auth.Scopes)
+			}
+		})
+	}
+}
+
 func TestKeycloakProvider_SyncClientProtocolMappers_NoMappers(t *testing.T) {
 	// syncClientProtocolMappers should return nil when no mappers are needed
 	provider := &KeycloakProvider{
@@ -1560,3 +1915,1153 @@ func TestKeycloakProvider_ConfigureTokenExchange(t *testing.T) {
 	// has the correct signature and handles the call path
 	_ = provider.ConfigureTokenExchange(context.Background(), nebariApp, []string{"peer-client-uuid"})
 }
+
+func TestKeycloakProvider_NewKeycloakClient(t *testing.T) {
+	tmpDir := t.TempDir()
+	invalidCA := tmpDir + "/invalid-ca.crt"
+	if err := os.WriteFile(invalidCA, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		kcConfig    config.KeycloakConfig
+		wantTimeout time.Duration
+		wantProxy   string
+		wantErr     bool
+	}{
+		{
+			name:        "defaults leave timeout unset",
+			kcConfig:    config.KeycloakConfig{URL: "http://keycloak.test"},
+			wantTimeout: 0,
+		},
+		{
+			name: "custom timeout and proxy are applied",
+			kcConfig: config.KeycloakConfig{
+				URL:         "http://keycloak.test",
+				HTTPTimeout: 7 * time.Second,
+				HTTPProxy:   "http://proxy.internal:3128",
+			},
+			wantTimeout: 7 * time.Second,
+			wantProxy:   "http://proxy.internal:3128",
+		},
+		{
+			name: "invalid proxy URL",
+			kcConfig: config.KeycloakConfig{
+				URL:       "http://keycloak.test",
+				HTTPProxy: "://bad",
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing CA bundle",
+			kcConfig: config.KeycloakConfig{
+				URL:        "http://keycloak.test",
+				CACertFile: tmpDir + "/missing.crt",
+			},
+			wantErr: true,
+		},
+		{
+			name: "CA bundle without certificates",
+			kcConfig: config.KeycloakConfig{
+				URL:        "http://keycloak.test",
+				CACertFile: invalidCA,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &KeycloakProvider{Config: tt.kcConfig}
+
+			kcClient, err := provider.newKeycloakClient()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			httpClient := kcClient.RestyClient().GetClient()
+			if httpClient.Timeout != tt.wantTimeout {
+				t.Errorf("expected timeout %v, got %v", tt.wantTimeout, httpClient.Timeout)
+			}
+
+			transport, ok := httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatalf("expected *http.Transport, got %T", httpClient.Transport)
+			}
+			if tt.wantProxy != "" {
+				req, _ := http.NewRequest(http.MethodGet, "http://keycloak.test", nil)
+				proxyURL, err := transport.Proxy(req)
+				if err != nil {
+					t.Fatalf("unexpected proxy error: %v", err)
+				}
+				if proxyURL == nil || proxyURL.String() != tt.wantProxy {
+					t.Errorf("expected proxy %s, got %v", tt.wantProxy, proxyURL)
+				}
+			}
+		})
+	}
+}
+
+func TestKeycloakProvider_NewHTTPClient_InsecureSkipVerify(t *testing.T) {
+	provider := &KeycloakProvider{Config: config.KeycloakConfig{InsecureSkipVerify: true}}
+
+	httpClient, err := provider.newHTTPClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transport := httpClient.Transport.(*http.Transport)
+	if transport.TLSClientConfig == nil || !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("expected InsecureSkipVerify to be set on the transport TLS config")
+	}
+}
+
+func TestKeycloakProvider_ClientRootAndBaseURL(t *testing.T) {
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth:     &appsv1.AuthConfig{Enabled: true},
+		},
+	}
+
+	// The fake Keycloak records the client representation it receives on
+	// create (POST) and update (PUT).
+	var received gocloak.Client
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			w.Header().Set("Location", r.URL.String()+"/internal-id")
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPut:
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"type":"secret","value":"existing-secret"}`))
+		}
+	}))
+	defer server.Close()
+
+	provider := &KeycloakProvider{Config: config.KeycloakConfig{URL: server.URL, Realm: "test"}}
+	kcClient := gocloak.NewClient(server.URL)
+	token := &gocloak.JWT{AccessToken: "token"}
+
+	assertURLs := func(t *testing.T) {
+		t.Helper()
+		if received.RootURL == nil || *received.RootURL != "https://test.example.com" {
+			t.Errorf("expected rootUrl https://test.example.com, got %v", received.RootURL)
+		}
+		if received.BaseURL == nil || *received.BaseURL != "https://test.example.com/" {
+			t.Errorf("expected baseUrl https://test.example.com/, got %v", received.BaseURL)
+		}
+	}
+
+	t.Run("createNewClient", func(t *testing.T) {
+		received = gocloak.Client{}
+		if _, _, err := provider.createNewClient(context.Background(), kcClient, token, "test-client", nebariApp); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertURLs(t)
+	})
+
+	t.Run("updateExistingClient", func(t *testing.T) {
+		received = gocloak.Client{}
+		existing := &gocloak.Client{
+			ID:       gocloak.StringP("internal-id"),
+			ClientID: gocloak.StringP("test-client"),
+			RootURL:  gocloak.StringP("https://stale.example.com"),
+		}
+		if _, _, err := provider.updateExistingClient(context.Background(), kcClient, token, existing, nebariApp); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertURLs(t)
+	})
+}
+
+func TestKeycloakProvider_UpdateExistingClient_SecretNotRetrievable(t *testing.T) {
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth:     &appsv1.AuthConfig{Enabled: true},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		publicClient     bool
+		getStatus        int
+		getBody          string
+		regenerateStatus int
+		expectedSecret   string
+		expectRegenerate bool
+		expectError      bool
+	}{
+		{
+			name:             "public client is made confidential and gets a new secret",
+			publicClient:     true,
+			regenerateStatus: http.StatusOK,
+			expectedSecret:   "regenerated-secret",
+			expectRegenerate: true,
+		},
+		{
+			name:             "secret request rejected",
+			getStatus:        http.StatusBadRequest,
+			getBody:          `{"error":"unknown_error"}`,
+			regenerateStatus: http.StatusOK,
+			expectedSecret:   "regenerated-secret",
+			expectRegenerate: true,
+		},
+		{
+			name:             "secret not generated yet",
+			getStatus:        http.StatusOK,
+			getBody:          `{"type":"secret"}`,
+			regenerateStatus: http.StatusOK,
+			expectedSecret:   "regenerated-secret",
+			expectRegenerate: true,
+		},
+		{
+			name:           "existing secret is kept",
+			getStatus:      http.StatusOK,
+			getBody:        `{"type":"secret","value":"existing-secret"}`,
+			expectedSecret: "existing-secret",
+		},
+		{
+			name:        "keycloak error is returned without regenerating",
+			getStatus:   http.StatusServiceUnavailable,
+			getBody:     `{"error":"unavailable"}`,
+			expectError: true,
+		},
+		{
+			name:             "regeneration failure is returned",
+			publicClient:     true,
+			regenerateStatus: http.StatusInternalServerError,
+			expectRegenerate: true,
+			expectError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated gocloak.Client
+			regenerated := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/client-secret"):
+					w.WriteHeader(tt.getStatus)
+					_, _ = w.Write([]byte(tt.getBody))
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/client-secret"):
+					regenerated = true
+					w.WriteHeader(tt.regenerateStatus)
+					_, _ = w.Write([]byte(`{"type":"secret","value":"regenerated-secret"}`))
+				case r.Method == http.MethodPut:
+					_ = json.NewDecoder(r.Body).Decode(&updated)
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			provider := &KeycloakProvider{Config: config.KeycloakConfig{URL: server.URL, Realm: "test"}}
+			existing := &gocloak.Client{
+				ID:           gocloak.StringP("internal-id"),
+				ClientID:     gocloak.StringP("test-client"),
+				PublicClient: gocloak.BoolP(tt.publicClient),
+			}
+
+			secret, _, err := provider.updateExistingClient(context.Background(), gocloak.NewClient(server.URL),
+				&gocloak.JWT{AccessToken: "token"}, existing, nebariApp)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if secret != tt.expectedSecret {
+				t.Errorf("expected secret %q, got %q", tt.expectedSecret, secret)
+			}
+			if regenerated != tt.expectRegenerate {
+				t.Errorf("expected regenerate=%v, got %v", tt.expectRegenerate, regenerated)
+			}
+			if !tt.expectError && gocloak.PBool(updated.PublicClient) {
+				t.Error("expected the client to be updated as confidential")
+			}
+		})
+	}
+}
+
+func TestKeycloakProvider_AppGroup(t *testing.T) {
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth:     &appsv1.AuthConfig{Enabled: true, CreateAppGroup: true},
+		},
+	}
+
+	// The fake Keycloak starts without the group or the client role and
+	// records every request it receives as "<METHOD> <path>".
+	var requests []string
+	groupExists := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/realms/test/groups":
+			if groupExists {
+				_, _ = w.Write([]byte(`[{"id":"group-id","name":"default-test-app"}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/admin/realms/test/groups":
+			w.Header().Set("Location", r.URL.String()+"/group-id")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/realms/test/clients/client-id/roles":
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/admin/realms/test/clients/client-id/roles":
+			w.Header().Set("Location", r.URL.String()+"/access")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/realms/test/clients/client-id/roles/access":
+			_, _ = w.Write([]byte(`{"id":"role-id","name":"access"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	provider := &KeycloakProvider{Config: config.KeycloakConfig{URL: server.URL, Realm: "test"}}
+	kcClient := gocloak.NewClient(server.URL)
+	token := &gocloak.JWT{AccessToken: "token"}
+
+	assertRequested := func(t *testing.T, want string) {
+		t.Helper()
+		for _, r := range requests {
+			if r == want {
+				return
+			}
+		}
+		t.Errorf("expected request %q, got %v", want, requests)
+	}
+
+	t.Run("ensureAppGroup creates group, role and mapping", func(t *testing.T) {
+		requests = nil
+		groupExists = false
+		if err := provider.ensureAppGroup(context.Background(), kcClient, token, "client-id", nebariApp); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertRequested(t, "POST /admin/realms/test/groups")
+		assertRequested(t, "POST /admin/realms/test/clients/client-id/roles")
+		assertRequested(t, "POST /admin/realms/test/groups/group-id/role-mappings/clients/client-id")
+	})
+
+	t.Run("deleteAppGroup removes existing group", func(t *testing.T) {
+		requests = nil
+		groupExists = true
+		if err := provider.deleteAppGroup(context.Background(), kcClient, token, nebariApp); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertRequested(t, "DELETE /admin/realms/test/groups/group-id")
+	})
+
+	t.Run("deleteAppGroup is a no-op when group is missing", func(t *testing.T) {
+		requests = nil
+		groupExists = false
+		if err := provider.deleteAppGroup(context.Background(), kcClient, token, nebariApp); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, r := range requests {
+			if strings.HasPrefix(r, http.MethodDelete) {
+				t.Errorf("unexpected delete request %q", r)
+			}
+		}
+	})
+}
+
+func TestKeycloakProvider_BuildPostLogoutRedirectURIs(t *testing.T) {
+	tests := []struct {
+		name       string
+		postLogout string
+		expected   string
+	}{
+		{
+			name:     "defaults to the app hostname",
+			expected: "https://test.example.com/*##http://test.example.com/*",
+		},
+		{
+			name:       "absolute URL is registered",
+			postLogout: "https://www.example.com/bye",
+			expected:   "https://test.example.com/*##http://test.example.com/*##https://www.example.com/bye",
+		},
+		{
+			name:       "root-relative path is resolved against the hostname",
+			postLogout: "/goodbye",
+			expected:   "https://test.example.com/*##http://test.example.com/*##https://test.example.com/goodbye",
+		},
+	}
+
+	provider := &KeycloakProvider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth:     &appsv1.AuthConfig{Enabled: true, PostLogoutRedirectURI: tt.postLogout},
+				},
+			}
+			if got := provider.buildPostLogoutRedirectURIs(nebariApp); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestKeycloakProvider_ApplyClientSettings_PKCE(t *testing.T) {
+	tests := []struct {
+		name         string
+		pkce         bool
+		existing     map[string]string
+		expectMethod string
+	}{
+		{
+			name:         "pkce enforces S256 on a new client",
+			pkce:         true,
+			expectMethod: "S256",
+		},
+		{
+			name:         "pkce overrides a weaker method on an existing client",
+			pkce:         true,
+			existing:     map[string]string{"pkce.code.challenge.method": "plain"},
+			expectMethod: "S256",
+		},
+		{
+			name:         "pkce disabled leaves the client without a method",
+			pkce:         false,
+			expectMethod: "",
+		},
+		{
+			name:         "pkce disabled keeps a method set directly in Keycloak",
+			pkce:         false,
+			existing:     map[string]string{"pkce.code.challenge.method": "S256"},
+			expectMethod: "S256",
+		},
+	}
+
+	provider := &KeycloakProvider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth:     &appsv1.AuthConfig{Enabled: true, PKCE: tt.pkce},
+				},
+			}
+			client := provider.buildClientRepresentation("default-test-app", nebariApp)
+			if tt.existing != nil {
+				attributes := maps.Clone(tt.existing)
+				client.Attributes = &attributes
+				provider.applyClientSettings(&client, nebariApp)
+			}
+
+			if got := (*client.Attributes)["pkce.code.challenge.method"]; got != tt.expectMethod {
+				t.Errorf("expected pkce.code.challenge.method %q, got %q", tt.expectMethod, got)
+			}
+		})
+	}
+}
+
+func TestKeycloakProvider_BuildWebOrigins(t *testing.T) {
+	tests := []struct {
+		name       string
+		additional []string
+		expected   []string
+	}{
+		{
+			name:     "hostname only",
+			expected: []string{"https://test.example.com", "http://test.example.com"},
+		},
+		{
+			name:       "additional origins are appended",
+			additional: []string{"https://frontend.example.com", "http://localhost:3000"},
+			expected: []string{
+				"https://test.example.com", "http://test.example.com",
+				"https://frontend.example.com", "http://localhost:3000",
+			},
+		},
+		{
+			name:       "duplicates of the hostname are dropped",
+			additional: []string{"https://test.example.com", "https://frontend.example.com"},
+			expected:   []string{"https://test.example.com", "http://test.example.com", "https://frontend.example.com"},
+		},
+	}
+
+	provider := &KeycloakProvider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth:     &appsv1.AuthConfig{Enabled: true, AdditionalWebOrigins: tt.additional},
+				},
+			}
+
+			client := provider.buildClientRepresentation("default-test-app", nebariApp)
+
+			if client.WebOrigins == nil || !reflect.DeepEqual(*client.WebOrigins, tt.expected) {
+				t.Errorf("expected web origins %v, got %v", tt.expected, client.WebOrigins)
+			}
+			if slices.Contains(*client.WebOrigins, "*") {
+				t.Error("expected web origins not to contain the * wildcard")
+			}
+		})
+	}
+}
+
+func TestKeycloakProvider_ApplyLogoutSettings(t *testing.T) {
+	tests := []struct {
+		name                 string
+		logout               *appsv1.LogoutConfig
+		existing             map[string]string
+		expectFrontChannel   bool
+		expectFrontURL       string
+		expectBackURL        string
+		expectSessionRequire string
+	}{
+		{
+			name:                 "no logout config disables single logout",
+			expectSessionRequire: "false",
+		},
+		{
+			name:                 "front-channel points at the gateway logout path",
+			logout:               &appsv1.LogoutConfig{FrontChannel: true},
+			expectFrontChannel:   true,
+			expectFrontURL:       "https://test.example.com" + constants.DefaultLogoutPath,
+			expectSessionRequire: "false",
+		},
+		{
+			name:                 "root-relative back-channel URI is resolved against the hostname",
+			logout:               &appsv1.LogoutConfig{BackchannelLogoutURI: "/auth/backchannel-logout"},
+			expectBackURL:        "https://test.example.com/auth/backchannel-logout",
+			expectSessionRequire: "true",
+		},
+		{
+			name:   "disabling logout clears URLs left on an existing client",
+			logout: nil,
+			existing: map[string]string{
+				"frontchannel.logout.url": "https://test.example.com/logout",
+				"backchannel.logout.url":  "https://test.example.com/old",
+			},
+			expectSessionRequire: "false",
+		},
+	}
+
+	provider := &KeycloakProvider{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth:     &appsv1.AuthConfig{Enabled: true, Logout: tt.logout},
+				},
+			}
+			attributes := map[string]string{"pkce.code.challenge.method": "S256"}
+			for k, v := range tt.existing {
+				attributes[k] = v
+			}
+			client := gocloak.Client{Attributes: &attributes}
+
+			provider.applyClientSettings(&client, nebariApp)
+
+			if client.FrontChannelLogout == nil || *client.FrontChannelLogout != tt.expectFrontChannel {
+				t.Errorf("expected frontchannelLogout=%v, got %v", tt.expectFrontChannel, client.FrontChannelLogout)
+			}
+			got := *client.Attributes
+			if got["frontchannel.logout.url"] != tt.expectFrontURL {
+				t.Errorf("expected frontchannel.logout.url %q, got %q", tt.expectFrontURL, got["frontchannel.logout.url"])
+			}
+			if got["backchannel.logout.url"] != tt.expectBackURL {
+				t.Errorf("expected backchannel.logout.url %q, got %q", tt.expectBackURL, got["backchannel.logout.url"])
+			}
+			if got["backchannel.logout.session.required"] != tt.expectSessionRequire {
+				t.Errorf("expected backchannel.logout.session.required %q, got %q",
+					tt.expectSessionRequire, got["backchannel.logout.session.required"])
+			}
+			if got["pkce.code.challenge.method"] != "S256" {
+				t.Error("expected unmanaged attributes to be preserved")
+			}
+		})
+	}
+}
+
+func TestKeycloakProvider_ClientAttributes(t *testing.T) {
+	provider := &KeycloakProvider{}
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled: true,
+				PKCE:    true,
+				Logout:  &appsv1.LogoutConfig{FrontChannel: true},
+				ClientAttributes: map[string]string{
+					"login_theme":                "nebari",
+					"display.on.consent.screen":  "true",
+					"frontchannel.logout.url":    "https://evil.example.com/logout",
+					"pkce.code.challenge.method": "plain",
+				},
+			},
+		},
+	}
+
+	// New client
+	client := provider.buildClientRepresentation("default-test-app", nebariApp)
+	got := *client.Attributes
+	if got["login_theme"] != "nebari" || got["display.on.consent.screen"] != "true" {
+		t.Errorf("expected passthrough attributes to be applied, got %v", got)
+	}
+	if want := "https://test.example.com" + constants.DefaultLogoutPath; got["frontchannel.logout.url"] != want {
+		t.Errorf("expected operator-managed frontchannel.logout.url %q, got %q", want, got["frontchannel.logout.url"])
+	}
+	if got["pkce.code.challenge.method"] != "S256" {
+		t.Errorf("expected auth.pkce to keep S256, got %q", got["pkce.code.challenge.method"])
+	}
+
+	// Existing client: passthrough attributes override what is on the client,
+	// and attributes set outside the operator are kept.
+	attributes := map[string]string{"login_theme": "keycloak", "access.token.lifespan": "600"}
+	existing := gocloak.Client{Attributes: &attributes}
+	provider.applyClientSettings(&existing, nebariApp)
+	got = *existing.Attributes
+	if got["login_theme"] != "nebari" {
+		t.Errorf("expected login_theme to be updated to %q, got %q", "nebari", got["login_theme"])
+	}
+	if got["access.token.lifespan"] != "600" {
+		t.Errorf("expected unmanaged attributes to be preserved, got %v", got)
+	}
+	if got["post.logout.redirect.uris"] == "" {
+		t.Error("expected post.logout.redirect.uris to stay operator-managed")
+	}
+}
+
+func TestValidateClientAttributes(t *testing.T) {
+	if err := ValidateClientAttributes(map[string]string{"login_theme": "nebari"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateClientAttributes(map[string]string{"backchannel.logout.url": "https://x"}); err == nil {
+		t.Error("expected an error for an operator-managed attribute")
+	}
+}
+
+func TestKeycloakProvider_BearerOnlyClient(t *testing.T) {
+	provider := &KeycloakProvider{}
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "api.example.com",
+			Auth:     &appsv1.AuthConfig{Enabled: true, BearerOnly: true},
+		},
+	}
+
+	client := provider.buildClientRepresentation("default-test-app", nebariApp)
+
+	if client.BearerOnly == nil || !*client.BearerOnly {
+		t.Errorf("expected a bearer-only client, got bearerOnly=%v", client.BearerOnly)
+	}
+	if client.StandardFlowEnabled == nil || *client.StandardFlowEnabled {
+		t.Errorf("expected the standard flow to be disabled, got %v", client.StandardFlowEnabled)
+	}
+	if client.RedirectURIs == nil || len(*client.RedirectURIs) != 0 {
+		t.Errorf("expected no redirect URIs, got %v", client.RedirectURIs)
+	}
+	if uris := (*client.Attributes)["post.logout.redirect.uris"]; uris != "" {
+		t.Errorf("expected no post-logout redirect URIs, got %q", uris)
+	}
+
+	// Turning bearerOnly off restores the browser client on the same representation.
+	nebariApp.Spec.Auth.BearerOnly = false
+	provider.applyClientSettings(&client, nebariApp)
+	if client.BearerOnly == nil || *client.BearerOnly {
+		t.Errorf("expected bearerOnly to be cleared, got %v", client.BearerOnly)
+	}
+	if client.StandardFlowEnabled == nil || !*client.StandardFlowEnabled {
+		t.Errorf("expected the standard flow to be enabled, got %v", client.StandardFlowEnabled)
+	}
+	if client.RedirectURIs == nil || !slices.Contains(*client.RedirectURIs, "https://api.example.com/oauth2/callback") {
+		t.Errorf("expected the callback redirect URI, got %v", client.RedirectURIs)
+	}
+}
+
+func TestKeycloakProvider_ExportedClientConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:               true,
+				Scopes:                []string{"openid", "groups"},
+				PostLogoutRedirectURI: "/goodbye",
+			},
+		},
+	}
+
+	// The fake Keycloak records the client representation it receives on create.
+	var received gocloak.Client
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Location", r.URL.String()+"/internal-id")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	provider := &KeycloakProvider{
+		Client: k8sClient,
+		Config: config.KeycloakConfig{URL: server.URL, Realm: "test"},
+	}
+	kcClient := gocloak.NewClient(server.URL)
+	token := &gocloak.JWT{AccessToken: "token"}
+
+	if _, _, err := provider.createNewClient(context.Background(), kcClient, token, "default-test-app", nebariApp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The exported config must describe exactly what was sent to Keycloak.
+	sent := newExportedClientConfig(received, "test", nebariApp.Spec.Auth.Scopes)
+	exported := newExportedClientConfig(provider.buildClientRepresentation("default-test-app", nebariApp), "test", nebariApp.Spec.Auth.Scopes)
+	if !reflect.DeepEqual(sent, exported) {
+		t.Errorf("exported config does not match the client sent to Keycloak:\nsent:     %+v\nexported: %+v", sent, exported)
+	}
+	if len(exported.PostLogoutRedirectURIs) != 3 || exported.PostLogoutRedirectURIs[2] != "https://test.example.com/goodbye" {
+		t.Errorf("expected post-logout redirect URIs to include the configured URI, got %v", exported.PostLogoutRedirectURIs)
+	}
+
+	// The exported config is stored as JSON in the app's client config ConfigMap.
+	if err := provider.storeClientConfig(context.Background(), nebariApp, exported); err != nil {
+		t.Fatalf("failed to store client config: %v", err)
+	}
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: naming.ClientConfigMapName(nebariApp), Namespace: nebariApp.Namespace}
+	if err := k8sClient.Get(context.Background(), key, configMap); err != nil {
+		t.Fatalf("failed to get client config ConfigMap: %v", err)
+	}
+	var stored exportedClientConfig
+	if err := json.Unmarshal([]byte(configMap.Data[constants.ClientConfigKey]), &stored); err != nil {
+		t.Fatalf("failed to decode stored client config: %v", err)
+	}
+	if !reflect.DeepEqual(stored, exported) {
+		t.Errorf("stored config does not match exported config:\nstored:   %+v\nexported: %+v", stored, exported)
+	}
+
+	// Deleting the client config is idempotent.
+	for i := 0; i < 2; i++ {
+		if err := provider.deleteClientConfig(context.Background(), nebariApp); err != nil {
+			t.Fatalf("failed to delete client config: %v", err)
+		}
+	}
+}
+
+func TestKeycloakProvider_SyncClientSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth:     &appsv1.AuthConfig{Enabled: true},
+		},
+	}
+
+	// The fake Keycloak serves the admin login, the client lookup and the
+	// client's current secret.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/protocol/openid-connect/token"):
+			_, _ = w.Write([]byte(`{"access_token":"token"}`))
+		case strings.HasSuffix(r.URL.Path, "/client-secret"):
+			_, _ = w.Write([]byte(`{"type":"secret","value":"keycloak-secret"}`))
+		case strings.HasSuffix(r.URL.Path, "/admin/realms/test/clients"):
+			_, _ = w.Write([]byte(`[{"id":"internal-id","clientId":"default-test-app"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		storedSecret   string
+		expectResynced bool
+	}{
+		{name: "drift rewrites stored secret", storedSecret: "stale-secret", expectResynced: true},
+		{name: "in sync is a no-op", storedSecret: "keycloak-secret", expectResynced: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: naming.ClientSecretName(nebariApp), Namespace: "default"},
+				Data: map[string][]byte{
+					constants.ClientIDKey:     []byte("default-test-app"),
+					constants.ClientSecretKey: []byte(tt.storedSecret),
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			provider := &KeycloakProvider{
+				Client: fakeClient,
+				Config: config.KeycloakConfig{
+					URL:           server.URL,
+					Realm:         "test",
+					AdminUsername: "admin",
+					AdminPassword: "password",
+				},
+			}
+
+			resynced, err := provider.SyncClientSecret(context.Background(), nebariApp)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resynced != tt.expectResynced {
+				t.Errorf("expected resynced=%v, got %v", tt.expectResynced, resynced)
+			}
+
+			got := &corev1.Secret{}
+			if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: secret.Name, Namespace: "default"}, got); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			if string(got.Data[constants.ClientSecretKey]) != "keycloak-secret" {
+				t.Errorf("expected stored secret to match Keycloak, got %q", got.Data[constants.ClientSecretKey])
+			}
+			if string(got.Data[constants.ClientIDKey]) != "default-test-app" {
+				t.Errorf("expected other keys to be preserved, got client-id %q", got.Data[constants.ClientIDKey])
+			}
+			if got.Annotations[constants.AnnotationClientSecretHash] != ClientSecretHash([]byte("keycloak-secret")) {
+				t.Errorf("expected the secret to be stamped with the Keycloak secret's hash, got %q",
+					got.Annotations[constants.AnnotationClientSecretHash])
+			}
+		})
+	}
+}
+
+func TestKeycloakProvider_SyncClientScopes_Degraded(t *testing.T) {
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled: true,
+				Scopes:  []string{"openid", "profile", "groups", "email"},
+			},
+		},
+	}
+
+	// The fake realm has "profile" and "email" but refuses to create "groups"
+	// and to assign "email" to the client.
+	var assigned []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/realms/test/client-scopes":
+			_ = json.NewEncoder(w).Encode([]gocloak.ClientScope{
+				{ID: gocloak.StringP("profile-id"), Name: gocloak.StringP("profile")},
+				{ID: gocloak.StringP("email-id"), Name: gocloak.StringP("email")},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/realms/test/clients/internal-id/default-client-scopes":
+			_ = json.NewEncoder(w).Encode([]gocloak.ClientScope{})
+		case r.Method == http.MethodPost && r.URL.Path == "/admin/realms/test/client-scopes":
+			w.WriteHeader(http.StatusForbidden)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/admin/realms/test/clients/internal-id/default-client-scopes/"):
+			scopeID := strings.TrimPrefix(r.URL.Path, "/admin/realms/test/clients/internal-id/default-client-scopes/")
+			if scopeID == "email-id" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			assigned = append(assigned, scopeID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := &KeycloakProvider{Config: config.KeycloakConfig{URL: server.URL, Realm: "test"}}
+	kcClient := gocloak.NewClient(server.URL)
+	token := &gocloak.JWT{AccessToken: "token"}
+
+	degraded, err := provider.syncClientScopes(context.Background(), kcClient, token, "internal-id", nebariApp)
+	if err != nil {
+		t.Fatalf("expected scope failures not to fail the sync, got: %v", err)
+	}
+	if !reflect.DeepEqual(degraded, []string{"groups", "email"}) {
+		t.Errorf("expected degraded scopes [groups email], got %v", degraded)
+	}
+	if !reflect.DeepEqual(assigned, []string{"profile-id"}) {
+		t.Errorf("expected only profile to be assigned, got %v", assigned)
+	}
+
+	degradedErr := &DegradedScopesError{Scopes: degraded}
+	if degradedErr.Error() != "requested scopes could not be provisioned: groups, email" {
+		t.Errorf("unexpected DegradedScopesError message %q", degradedErr.Error())
+	}
+}
+
+func TestKeycloakProvider_SyncClientScopes_StepUpOptional(t *testing.T) {
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:      true,
+				Scopes:       []string{"openid", "profile"},
+				StepUpScopes: []string{"profile", "admin"},
+				StepUpPaths:  []string{"/admin"},
+			},
+		},
+	}
+
+	// Step-up scopes already requested as base scopes stay defaults; the rest
+	// are assigned as optional scopes.
+	var defaults, optional []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/realms/test/client-scopes":
+			_ = json.NewEncoder(w).Encode([]gocloak.ClientScope{
+				{ID: gocloak.StringP("profile-id"), Name: gocloak.StringP("profile")},
+				{ID: gocloak.StringP("admin-id"), Name: gocloak.StringP("admin")},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/realms/test/clients/internal-id/default-client-scopes",
+			r.Method == http.MethodGet && r.URL.Path == "/admin/realms/test/clients/internal-id/optional-client-scopes":
+			_ = json.NewEncoder(w).Encode([]gocloak.ClientScope{})
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/admin/realms/test/clients/internal-id/default-client-scopes/"):
+			defaults = append(defaults, strings.TrimPrefix(r.URL.Path, "/admin/realms/test/clients/internal-id/default-client-scopes/"))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/admin/realms/test/clients/internal-id/optional-client-scopes/"):
+			optional = append(optional, strings.TrimPrefix(r.URL.Path, "/admin/realms/test/clients/internal-id/optional-client-scopes/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := &KeycloakProvider{Config: config.KeycloakConfig{URL: server.URL, Realm: "test"}}
+	kcClient := gocloak.NewClient(server.URL)
+	token := &gocloak.JWT{AccessToken: "token"}
+
+	degraded, err := provider.syncClientScopes(context.Background(), kcClient, token, "internal-id", nebariApp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(degraded) != 0 {
+		t.Errorf("expected no degraded scopes, got %v", degraded)
+	}
+	if !reflect.DeepEqual(defaults, []string{"profile-id"}) {
+		t.Errorf("expected default scopes [profile-id], got %v", defaults)
+	}
+	if !reflect.DeepEqual(optional, []string{"admin-id"}) {
+		t.Errorf("expected optional scopes [admin-id], got %v", optional)
+	}
+}
+
+func TestKeycloakProvider_UnknownScopes(t *testing.T) {
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:      true,
+				Scopes:       []string{"openid", "profile", "groups", "department"},
+				StepUpScopes: []string{"admin", "groups"},
+			},
+		},
+	}
+
+	// The fake realm defines "profile" and "admin". "groups" only exists as the
+	// placeholder the operator creates, and "department" not at all.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/protocol/openid-connect/token"):
+			_, _ = w.Write([]byte(`{"access_token":"token"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/realms/test/client-scopes":
+			_ = json.NewEncoder(w).Encode([]gocloak.ClientScope{
+				{ID: gocloak.StringP("profile-id"), Name: gocloak.StringP("profile")},
+				{ID: gocloak.StringP("admin-id"), Name: gocloak.StringP("admin"), Description: gocloak.StringP("Admin access")},
+				{ID: gocloak.StringP("groups-id"), Name: gocloak.StringP("groups"), Description: gocloak.StringP(placeholderScopeDescription)},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := &KeycloakProvider{
+		Config: config.KeycloakConfig{
+			URL:           server.URL,
+			Realm:         "test",
+			AdminUsername: "admin",
+			AdminPassword: "password",
+		},
+	}
+
+	unknown, err := provider.UnknownScopes(context.Background(), nebariApp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(unknown, []string{"groups", "department"}) {
+		t.Errorf("expected unknown scopes [groups department], got %v", unknown)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	plain := errors.New("boom")
+	tests := []struct {
+		name         string
+		err          error
+		expectedKind error
+	}{
+		{name: "nil", err: nil},
+		{name: "unauthorized", err: &gocloak.APIError{Code: http.StatusUnauthorized, Message: "401 Unauthorized"}, expectedKind: ErrAuthFailed},
+		{name: "forbidden behind a wrapped error", err: fmt.Errorf("failed to query clients: %w",
+			&gocloak.APIError{Code: http.StatusForbidden, Message: "403 Forbidden"}), expectedKind: ErrAuthFailed},
+		{name: "transport failure", err: &gocloak.APIError{Code: 0, Message: "could not login: connection refused"}, expectedKind: ErrProviderUnreachable},
+		{name: "server error", err: &gocloak.APIError{Code: http.StatusServiceUnavailable, Message: "503 Service Unavailable"}, expectedKind: ErrProviderUnreachable},
+		{name: "other API error stays untyped", err: &gocloak.APIError{Code: http.StatusConflict, Message: "409 Conflict"}},
+		{name: "non-API error stays untyped", err: plain},
+		{name: "already classified", err: &ProviderError{Kind: ErrClientNotFound, Err: plain}, expectedKind: ErrClientNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyError(tt.err)
+			if tt.err == nil {
+				if got != nil {
+					t.Fatalf("expected nil, got %v", got)
+				}
+				return
+			}
+			if got.Error() != tt.err.Error() {
+				t.Errorf("expected the message to be kept, got %q", got.Error())
+			}
+			var providerErr *ProviderError
+			isTyped := errors.As(got, &providerErr)
+			if tt.expectedKind == nil {
+				if isTyped {
+					t.Errorf("expected an untyped error, got kind %v", providerErr.Kind)
+				}
+				return
+			}
+			if !isTyped || !errors.Is(got, tt.expectedKind) {
+				t.Errorf("expected kind %v, got %v", tt.expectedKind, got)
+			}
+		})
+	}
+}
+
+func TestKeycloakProvider_ProvisionClient_AuthFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"Invalid user credentials"}`))
+	}))
+	defer server.Close()
+
+	provider := &KeycloakProvider{
+		Config: config.KeycloakConfig{
+			URL:           server.URL,
+			Realm:         "test",
+			AdminUsername: "admin",
+			AdminPassword: "wrong",
+		},
+	}
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth:     &appsv1.AuthConfig{Enabled: true},
+		},
+	}
+
+	err := provider.ProvisionClient(context.Background(), nebariApp)
+	if !errors.Is(err, ErrAuthFailed) {
+		t.Errorf("expected ErrAuthFailed, got %v", err)
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	const (
+		base64URLChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+		hexChars       = "0123456789abcdef"
+	)
+
+	tests := []struct {
+		name           string
+		length         int
+		encoding       string
+		expectedLength int
+		charset        string
+	}{
+		{name: "default base64url", length: 32, encoding: "", expectedLength: 43, charset: base64URLChars},
+		{name: "explicit base64url", length: 16, encoding: constants.ClientSecretEncodingBase64URL, expectedLength: 22, charset: base64URLChars},
+		{name: "base64url without padding", length: 64, encoding: constants.ClientSecretEncodingBase64URL, expectedLength: 86, charset: base64URLChars},
+		{name: "hex", length: 32, encoding: constants.ClientSecretEncodingHex, expectedLength: 64, charset: hexChars},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := generateSecret(tt.length, tt.encoding)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(secret) != tt.expectedLength {
+				t.Errorf("expected %d characters, got %d (%q)", tt.expectedLength, len(secret), secret)
+			}
+			for _, r := range secret {
+				if !strings.ContainsRune(tt.charset, r) {
+					t.Errorf("unexpected character %q in %q", r, secret)
+				}
+			}
+
+			other, err := generateSecret(tt.length, tt.encoding)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if other == secret {
+				t.Error("expected two generated secrets to differ")
+			}
+		})
+	}
+}
+
+func TestKeycloakProvider_ClientSecretLength(t *testing.T) {
+	if got := (&KeycloakProvider{}).clientSecretLength(); got != constants.DefaultClientSecretLength {
+		t.Errorf("unset length: expected %d, got %d", constants.DefaultClientSecretLength, got)
+	}
+	provider := &KeycloakProvider{Config: config.KeycloakConfig{ClientSecretLength: 48}}
+	if got := provider.clientSecretLength(); got != 48 {
+		t.Errorf("configured length: expected 48, got %d", got)
+	}
+}
@@ -18,10 +18,137 @@ package providers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
 	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
 )
 
+// ClientSecretHash returns the digest stored in the client Secret's
+// constants.AnnotationClientSecretHash annotation for a client secret value.
+func ClientSecretHash(clientSecret []byte) string {
+	sum := sha256.Sum256(clientSecret)
+	return hex.EncodeToString(sum[:])
+}
+
+// PostLogoutRedirectURL returns the absolute URL users are sent to after
+// logout, resolving a root-relative auth.postLogoutRedirectURI against the
+// app's hostname. Returns "" when no post-logout redirect is configured.
+func PostLogoutRedirectURL(nebariApp *appsv1.NebariApp) string {
+	if nebariApp.Spec.Auth == nil {
+		return ""
+	}
+	return resolveAppURL(nebariApp, nebariApp.Spec.Auth.PostLogoutRedirectURI)
+}
+
+// BackchannelLogoutURL returns the absolute back-channel logout URL, resolving
+// a root-relative auth.logout.backchannelLogoutURI against the app's hostname.
+// Returns "" when back-channel logout is not configured.
+func BackchannelLogoutURL(nebariApp *appsv1.NebariApp) string {
+	if nebariApp.Spec.Auth == nil || nebariApp.Spec.Auth.Logout == nil {
+		return ""
+	}
+	return resolveAppURL(nebariApp, nebariApp.Spec.Auth.Logout.BackchannelLogoutURI)
+}
+
+// NormalizeRedirectURI validates auth.redirectURI and returns it as a clean
+// callback path: empty means constants.DefaultOAuthCallbackPath, a missing
+// leading slash is added and trailing slashes are dropped. Absolute URLs,
+// protocol-relative paths, queries, fragments and whitespace are rejected. An
+// absolute URL is tolerated when redirectURLOverride is set, since the override
+// replaces it; the default callback path is returned in that case.
+func NormalizeRedirectURI(raw string, hasOverride bool) (string, error) {
+	if raw == "" {
+		return constants.DefaultOAuthCallbackPath, nil
+	}
+	if strings.Contains(raw, "://") {
+		if hasOverride {
+			return constants.DefaultOAuthCallbackPath, nil
+		}
+		return "", fmt.Errorf("redirectURI %q must be a path such as %q; use redirectURLOverride for an absolute URL",
+			raw, constants.DefaultOAuthCallbackPath)
+	}
+	if strings.ContainsAny(raw, " \t\r\n?#") {
+		return "", fmt.Errorf("redirectURI %q must be a plain path without whitespace, query or fragment", raw)
+	}
+	if strings.HasPrefix(raw, "//") {
+		return "", fmt.Errorf("redirectURI %q must not start with //", raw)
+	}
+
+	path := "/" + strings.Trim(raw, "/")
+	if path == "/" {
+		return "", fmt.Errorf("redirectURI %q must name a callback path below the app root", raw)
+	}
+	return path, nil
+}
+
+// RedirectPath returns the normalized auth.redirectURI for the app. The value
+// is validated before any OIDC resources are built, so a value that fails
+// NormalizeRedirectURI only falls back to the default path here.
+func RedirectPath(nebariApp *appsv1.NebariApp) string {
+	if nebariApp.Spec.Auth == nil {
+		return constants.DefaultOAuthCallbackPath
+	}
+	path, err := NormalizeRedirectURI(nebariApp.Spec.Auth.RedirectURI, nebariApp.Spec.Auth.RedirectURLOverride != "")
+	if err != nil {
+		return constants.DefaultOAuthCallbackPath
+	}
+	return path
+}
+
+// StepUpRedirectPath returns the callback path of the step-up login: the first
+// auth.stepUpPaths entry followed by constants.DefaultOAuthCallbackPath, so the
+// callback is served by the step-up HTTPRoute. It returns "" when
+// auth.stepUpScopes or auth.stepUpPaths is unset.
+func StepUpRedirectPath(nebariApp *appsv1.NebariApp) string {
+	auth := nebariApp.Spec.Auth
+	if auth == nil || len(auth.StepUpScopes) == 0 || len(auth.StepUpPaths) == 0 {
+		return ""
+	}
+	return strings.TrimRight(auth.StepUpPaths[0], "/") + constants.DefaultOAuthCallbackPath
+}
+
+// GroupsScope is the OIDC scope that asks the provider to put the user's group
+// memberships in the token.
+const GroupsScope = "groups"
+
+// DefaultScopes are requested when auth.scopes is empty.
+var DefaultScopes = []string{"openid", "profile", "email"}
+
+// RequestedScopes returns the OIDC scopes requested for the app: auth.scopes,
+// plus GroupsScope when auth.groups is set and the scope is missing, since
+// group-based authorization only matches when the token carries the groups
+// claim. The groups scope is then appended to DefaultScopes if auth.scopes is
+// empty. auth itself is never changed, so the stored spec keeps what the user
+// wrote.
+func RequestedScopes(auth *appsv1.AuthConfig) []string {
+	if auth == nil {
+		return nil
+	}
+	if len(auth.Groups) == 0 || slices.Contains(auth.Scopes, GroupsScope) {
+		return auth.Scopes
+	}
+	scopes := auth.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+	return append(slices.Clone(scopes), GroupsScope)
+}
+
+// resolveAppURL turns a root-relative path into an https URL on the app's
+// hostname and returns absolute URLs (and "") unchanged.
+func resolveAppURL(nebariApp *appsv1.NebariApp, uri string) string {
+	if strings.HasPrefix(uri, "/") {
+		return "https://" + nebariApp.Spec.Hostname + uri
+	}
+	return uri
+}
+
 // OIDCEndpointOverrides holds explicit OIDC endpoint URLs that override
 // the values obtained from the provider's discovery document. Nil fields
 // indicate that the discovered value should be used.
@@ -29,6 +156,52 @@ type OIDCEndpointOverrides struct {
 	Token         *string
 	Authorization *string
 	EndSession    *string
+
+	// JWKS is the key set URL used to validate bearer JWTs when auth.jwt is
+	// enabled. Envoy's JWT filter does not use discovery, so a nil value means
+	// the NebariApp must set auth.jwt.jwksURI itself.
+	JWKS *string
+}
+
+// DegradedScopesError reports that ProvisionClient completed but some requested
+// scopes could not be provisioned on the client, e.g. because the realm refused
+// to create them. The client works without them, so callers treat it as a
+// warning rather than a failure.
+type DegradedScopesError struct {
+	Scopes []string
+}
+
+func (e *DegradedScopesError) Error() string {
+	return fmt.Sprintf("requested scopes could not be provisioned: %s", strings.Join(e.Scopes, ", "))
+}
+
+// Kinds of provider failure. A *ProviderError carries one of them so callers
+// can report a precise reason instead of a generic failure.
+var (
+	// ErrAuthFailed means the provider rejected the operator's admin credentials.
+	ErrAuthFailed = errors.New("provider rejected the operator's credentials")
+
+	// ErrClientNotFound means the OIDC client an operation needs does not exist.
+	ErrClientNotFound = errors.New("OIDC client not found")
+
+	// ErrProviderUnreachable means the provider could not be reached or
+	// answered with a server error.
+	ErrProviderUnreachable = errors.New("provider unreachable")
+)
+
+// ProviderError is a provider failure of a known kind. errors.Is matches it
+// against Kind as well as anything Err wraps; its message is Err's.
+type ProviderError struct {
+	Kind error
+	Err  error
+}
+
+func (e *ProviderError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ProviderError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
 }
 
 // OIDCProvider defines the interface for OIDC provider implementations.
@@ -60,6 +233,8 @@ type OIDCProvider interface {
 	// ProvisionClient provisions an OIDC client in the provider if supported.
 	// Returns nil if provisioning is not supported or not needed.
 	// The client secret should be stored in a Kubernetes Secret.
+	// Returns a *DegradedScopesError when the client is usable but some
+	// requested scopes could not be provisioned on it.
 	ProvisionClient(ctx context.Context, nebariApp *appsv1.NebariApp) error
 
 	// DeleteClient removes the OIDC client from the provider if it was provisioned.
@@ -79,4 +254,17 @@ type OIDCProvider interface {
 	// For Keycloak, this disables management permissions on the client, which
 	// causes Keycloak to automatically remove the auto-created permission.
 	CleanupTokenExchange(ctx context.Context, nebariApp *appsv1.NebariApp) error
+
+	// SyncClientSecret compares the client secret held by the provider with
+	// the one stored in the app's client Secret and rewrites the stored copy
+	// when they differ (e.g. after the secret was regenerated in the provider's
+	// admin console). Returns true when the stored secret was updated.
+	// Providers that do not provision clients return false.
+	SyncClientSecret(ctx context.Context, nebariApp *appsv1.NebariApp) (bool, error)
+
+	// UnknownScopes returns the scopes requested in auth.scopes and
+	// auth.stepUpScopes that the provider does not define, so the caller can
+	// warn that tokens will not carry them. Providers that cannot list their
+	// scopes return nil.
+	UnknownScopes(ctx context.Context, nebariApp *appsv1.NebariApp) ([]string, error)
 }
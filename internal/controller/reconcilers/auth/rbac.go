@@ -34,7 +34,7 @@ func (r *AuthReconciler) reconcileSecretRBAC(ctx context.Context, nebariApp *app
 	logger := log.FromContext(ctx)
 
 	secretName := naming.ClientSecretName(nebariApp)
-	rbacName := fmt.Sprintf("%s-oidc-secret-reader", nebariApp.Name)
+	rbacName := naming.ResourceName(nebariApp, "oidc-secret-reader")
 
 	saName := nebariApp.Spec.ServiceAccountName
 	if saName == "" {
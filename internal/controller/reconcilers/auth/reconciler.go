@@ -21,8 +21,14 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
 	"sort"
+	"strings"
 
 	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
@@ -31,6 +37,7 @@ import (
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ssa"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -50,6 +57,36 @@ type AuthReconciler struct {
 	Scheme    *runtime.Scheme
 	Recorder  record.EventRecorder
 	Providers map[string]providers.OIDCProvider // Provider name -> provider implementation
+
+	// DefaultProvider is the provider used when spec.auth.provider is empty.
+	// When unset, Keycloak is used.
+	DefaultProvider string
+
+	// IssuerPreflightEnabled makes ReconcileAuth fetch the issuer's OIDC
+	// discovery document before writing the SecurityPolicy.
+	IssuerPreflightEnabled bool
+
+	// IssuerPreflightClient, when set, fetches discovery documents instead of
+	// the default client. Used by tests to stub the issuer.
+	IssuerPreflightClient *http.Client
+
+	// AllowedScopes lists the OIDC scopes NebariApps may request in auth.scopes
+	// and auth.stepUpScopes. Empty, or a list containing "*", allows any scope.
+	AllowedScopes []string
+
+	// AllowedRedirectSchemes is the URI scheme allowlist the Keycloak provider
+	// applies to provisioned clients. It is part of the auth config hash, so
+	// changing it reprovisions the clients. Empty means
+	// constants.DefaultAllowedRedirectSchemes.
+	AllowedRedirectSchemes []string
+
+	// ManagedBy is the app.kubernetes.io/managed-by value on the resources it
+	// creates and selects on. Empty means constants.DefaultManagedBy.
+	ManagedBy string
+
+	// ServerSideApply writes SecurityPolicies with server-side apply instead of
+	// create/update, leaving fields owned by other field managers alone.
+	ServerSideApply bool
 }
 
 // shouldProvisionClient returns true if the operator should automatically provision an OIDC client.
@@ -69,41 +106,99 @@ func shouldProvisionClient(auth *appsv1.AuthConfig) bool {
 // but do not alter what is actually provisioned inside Keycloak. SecurityPolicy
 // reconciliation runs unconditionally regardless of the hash.
 type authProvisionState struct {
-	Namespace      string                       `json:"namespace"`
-	Name           string                       `json:"name"`
-	Hostname       string                       `json:"hostname"`
-	Provider       string                       `json:"provider"`
-	RedirectURI    string                       `json:"redirectURI"`
-	IssuerURL      string                       `json:"issuerURL"`
-	Scopes         []string                     `json:"scopes"`
-	Groups         []string                     `json:"groups"`
-	SPAClient      *appsv1.SPAClientConfig      `json:"spaClient,omitempty"`
-	KeycloakConfig *appsv1.KeycloakClientConfig `json:"keycloakConfig,omitempty"`
+	Namespace           string                       `json:"namespace"`
+	Name                string                       `json:"name"`
+	Hostname            string                       `json:"hostname"`
+	Provider            string                       `json:"provider"`
+	RedirectURI         string                       `json:"redirectURI"`
+	RedirectURLOverride string                       `json:"redirectURLOverride,omitempty"`
+	PostLogoutRedirect  string                       `json:"postLogoutRedirectURI,omitempty"`
+	Logout              *appsv1.LogoutConfig         `json:"logout,omitempty"`
+	PKCE                bool                         `json:"pkce,omitempty"`
+	BearerOnly          bool                         `json:"bearerOnly,omitempty"`
+	WebOrigins          []string                     `json:"webOrigins,omitempty"`
+	IssuerURL           string                       `json:"issuerURL"`
+	Scopes              []string                     `json:"scopes"`
+	StepUpScopes        []string                     `json:"stepUpScopes,omitempty"`
+	StepUpPaths         []string                     `json:"stepUpPaths,omitempty"`
+	Groups              []string                     `json:"groups"`
+	GroupsClaim         string                       `json:"groupsClaim,omitempty"`
+	SPAClient           *appsv1.SPAClientConfig      `json:"spaClient,omitempty"`
+	CreateAppGroup      bool                         `json:"createAppGroup,omitempty"`
+	KeycloakConfig      *appsv1.KeycloakClientConfig `json:"keycloakConfig,omitempty"`
+	ClientAttributes    [][2]string                  `json:"clientAttributes,omitempty"`
+	RedirectSchemes     []string                     `json:"redirectSchemes,omitempty"`
+}
+
+// clientSecretState reports whether the app's provisioned client Secret is
+// missing, and whether its client secret no longer matches the
+// AnnotationClientSecretHash stamp the operator wrote with it.
+func (r *AuthReconciler) clientSecretState(ctx context.Context, nebariApp *appsv1.NebariApp) (missing, modified bool, err error) {
+	secret := &corev1.Secret{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: naming.ClientSecretName(nebariApp), Namespace: nebariApp.Namespace}, secret)
+	if apierrors.IsNotFound(err) {
+		return true, false, nil
+	} else if err != nil {
+		return false, false, err
+	}
+	stamp := secret.Annotations[constants.AnnotationClientSecretHash]
+	return false, stamp != providers.ClientSecretHash(secret.Data[constants.ClientSecretKey]), nil
 }
 
 // computeAuthConfigHash returns a SHA-256 hex digest of the NebariApp fields that
-// influence OIDC client provisioning. Slices are sorted before hashing to produce
-// a stable result regardless of field ordering in the spec.
-func computeAuthConfigHash(nebariApp *appsv1.NebariApp) string {
+// influence OIDC client provisioning, plus the operator's redirect scheme
+// allowlist. Slices are sorted before hashing to produce a stable result
+// regardless of field ordering in the spec.
+func computeAuthConfigHash(nebariApp *appsv1.NebariApp, redirectSchemes []string) string {
 	auth := nebariApp.Spec.Auth
 
-	scopes := append([]string(nil), auth.Scopes...)
+	// The default allowlist is left out so existing hashes stay valid.
+	schemes := append([]string(nil), redirectSchemes...)
+	sort.Strings(schemes)
+	if slices.Equal(schemes, slices.Sorted(slices.Values(constants.DefaultAllowedRedirectSchemes))) {
+		schemes = nil
+	}
+
+	scopes := slices.Clone(providers.RequestedScopes(auth))
 	sort.Strings(scopes)
 
+	stepUpScopes := append([]string(nil), auth.StepUpScopes...)
+	sort.Strings(stepUpScopes)
+
 	groups := append([]string(nil), auth.Groups...)
 	sort.Strings(groups)
 
+	webOrigins := append([]string(nil), auth.AdditionalWebOrigins...)
+	sort.Strings(webOrigins)
+
+	clientAttributes := make([][2]string, 0, len(auth.ClientAttributes))
+	for _, key := range slices.Sorted(maps.Keys(auth.ClientAttributes)) {
+		clientAttributes = append(clientAttributes, [2]string{key, auth.ClientAttributes[key]})
+	}
+
 	state := authProvisionState{
-		Namespace:      nebariApp.Namespace,
-		Name:           nebariApp.Name,
-		Hostname:       nebariApp.Spec.Hostname,
-		Provider:       auth.Provider,
-		RedirectURI:    auth.RedirectURI,
-		IssuerURL:      auth.IssuerURL,
-		Scopes:         scopes,
-		Groups:         groups,
-		SPAClient:      auth.SPAClient,
-		KeycloakConfig: auth.KeycloakConfig,
+		Namespace:           nebariApp.Namespace,
+		Name:                nebariApp.Name,
+		Hostname:            nebariApp.Spec.Hostname,
+		Provider:            auth.Provider,
+		RedirectURI:         auth.RedirectURI,
+		RedirectURLOverride: auth.RedirectURLOverride,
+		PostLogoutRedirect:  auth.PostLogoutRedirectURI,
+		Logout:              auth.Logout,
+		PKCE:                auth.PKCE,
+		BearerOnly:          auth.BearerOnly,
+		WebOrigins:          webOrigins,
+		IssuerURL:           auth.IssuerURL,
+		Scopes:              scopes,
+		StepUpScopes:        stepUpScopes,
+		StepUpPaths:         auth.StepUpPaths,
+		Groups:              groups,
+		GroupsClaim:         auth.GroupsClaim,
+		SPAClient:           auth.SPAClient,
+		CreateAppGroup:      auth.CreateAppGroup,
+		KeycloakConfig:      auth.KeycloakConfig,
+		ClientAttributes:    clientAttributes,
+		RedirectSchemes:     schemes,
 	}
 
 	data, err := json.Marshal(state)
@@ -142,80 +237,212 @@ func (r *AuthReconciler) ReconcileAuth(ctx context.Context, nebariApp *appsv1.Ne
 		logger.Info("Auth not enabled, cleaning up any existing SecurityPolicy")
 		if err := r.deleteSecurityPolicyIfExists(ctx, nebariApp); err != nil {
 			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
-				"SecurityPolicyCleanupFailed", fmt.Sprintf("Failed to delete existing SecurityPolicy: %v", err))
+				appsv1.ReasonSecurityPolicyCleanupFailed, fmt.Sprintf("Failed to delete existing SecurityPolicy: %v", err))
 			return err
 		}
+		nebariApp.Status.IssuerURL = ""
 		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
-			"AuthDisabled", "Authentication is not enabled for this app")
+			appsv1.ReasonAuthDisabled, "Authentication is not enabled for this app")
 		return nil
 	}
 
 	logger.Info("Reconciling auth",
-		"provider", nebariApp.Spec.Auth.Provider,
+		"provider", r.providerName(nebariApp),
 		"hostname", nebariApp.Spec.Hostname,
 		"provisionClient", shouldProvisionClient(nebariApp.Spec.Auth))
 
+	if err := validateBearerOnly(nebariApp.Spec.Auth); err != nil {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonAuthValidationFailed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+		return invalidConfig(err)
+	}
+	if _, err := providers.NormalizeRedirectURI(nebariApp.Spec.Auth.RedirectURI, nebariApp.Spec.Auth.RedirectURLOverride != ""); err != nil {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonAuthValidationFailed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+		return invalidConfig(err)
+	}
+	if err := validateRedirectURLOverride(nebariApp.Spec.Auth.RedirectURLOverride); err != nil {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonAuthValidationFailed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+		return invalidConfig(err)
+	}
+	if err := validatePostLogoutRedirectURI(nebariApp.Spec.Auth.PostLogoutRedirectURI); err != nil {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonAuthValidationFailed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+		return invalidConfig(err)
+	}
+	if err := validateWebOrigins(nebariApp.Spec.Auth.AdditionalWebOrigins); err != nil {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonAuthValidationFailed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+		return invalidConfig(err)
+	}
+	if err := validateExtraAuthParams(nebariApp.Spec.Auth.ExtraAuthParams); err != nil {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonAuthValidationFailed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+		return invalidConfig(err)
+	}
+	if err := providers.ValidateClientAttributes(nebariApp.Spec.Auth.ClientAttributes); err != nil {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonAuthValidationFailed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+		return invalidConfig(err)
+	}
+	if err := validateStepUp(nebariApp); err != nil {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonAuthValidationFailed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+		return invalidConfig(err)
+	}
+	if err := validateGatewayAuthorization(nebariApp.Spec.Auth); err != nil {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonAuthValidationFailed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+		return invalidConfig(err)
+	}
+	if err := validateOptional(nebariApp.Spec.Auth); err != nil {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonAuthValidationFailed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+		return invalidConfig(err)
+	}
+
+	// Group-based authorization only matches when the token carries the groups
+	// claim, so the groups scope is requested even if the user left it out (see
+	// providers.RequestedScopes).
+	var groupsScopeWarning string
+	if addsGroupsScope(nebariApp.Spec.Auth) {
+		groupsScopeWarning = fmt.Sprintf("added the %q scope to the requested scopes because groups is set", providers.GroupsScope)
+	}
+
+	// The allowlist covers every scope the gateway will request, including an
+	// added groups scope.
+	if err := validateAllowedScopes(nebariApp.Spec.Auth, r.AllowedScopes); err != nil {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonScopeNotAllowed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+		return invalidConfig(err)
+	}
+
+	// With provisionClient=false nothing creates the client secret, so a missing
+	// secret is a user error. Check it before any provider work so the condition
+	// and event name the secret instead of a later, less obvious failure.
+	// A client the operator provisioned before provisionClient was turned off
+	// is removed first, so the check applies to the user's own secret.
+	if !shouldProvisionClient(nebariApp.Spec.Auth) {
+		if nebariApp.Status.ClientProvisioned {
+			if err := r.deprovisionClient(ctx, nebariApp); err != nil {
+				msg := fmt.Sprintf("Failed to remove the provisioned OIDC client: %v", err)
+				if !setProviderFailureCondition(nebariApp, err, msg) {
+					conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+						appsv1.ReasonClientCleanupFailed, msg)
+				}
+				return err
+			}
+		}
+		if err := r.validateAuthConfig(ctx, nebariApp); err != nil {
+			msg := fmt.Sprintf("Auth configuration validation failed: %v; provisionClient is false, so create the secret "+
+				"with key '%s' in namespace '%s'", err, constants.ClientSecretKey, nebariApp.Namespace)
+			r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonClientSecretInvalid, msg)
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+				appsv1.ReasonAuthValidationFailed, msg)
+			return err
+		}
+	}
+
 	// Get the OIDC provider
 	provider, err := r.getProvider(nebariApp)
 	if err != nil {
 		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
-			"InvalidProvider", fmt.Sprintf("Invalid OIDC provider: %v", err))
-		return err
+			appsv1.ReasonInvalidProvider, fmt.Sprintf("Invalid OIDC provider: %v", err))
+		return invalidConfig(err)
 	}
 
 	// Provision OIDC client if requested and supported
+	var degradedScopes []string
+	var provisioned bool
 	if shouldProvisionClient(nebariApp.Spec.Auth) {
 		if !provider.SupportsProvisioning() {
-			err := fmt.Errorf("provider %s does not support automatic client provisioning", nebariApp.Spec.Auth.Provider)
+			err := fmt.Errorf("provider %s does not support automatic client provisioning", r.providerName(nebariApp))
 			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
-				"ProvisioningNotSupported", err.Error())
-			return err
+				appsv1.ReasonProvisioningNotSupported, err.Error())
+			return invalidConfig(err)
 		}
 
-		currentHash := computeAuthConfigHash(nebariApp)
+		currentHash := computeAuthConfigHash(nebariApp, r.AllowedRedirectSchemes)
 		forceAnnotation := nebariApp.Annotations[constants.AnnotationForceReprovision]
 		authReady := conditions.IsConditionTrue(nebariApp, appsv1.ConditionTypeAuthReady)
+		secretMissing, secretModified, err := r.clientSecretState(ctx, nebariApp)
+		if err != nil {
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+				appsv1.ReasonProvisioningFailed, fmt.Sprintf("Failed to read OIDC client secret: %v", err))
+			return err
+		}
 
-		if authReady && nebariApp.Status.AuthConfigHash == currentHash && forceAnnotation == "" {
+		if authReady && nebariApp.Status.AuthConfigHash == currentHash && forceAnnotation == "" && !secretMissing {
 			logger.Info("Auth config unchanged and AuthReady=True, skipping OIDC client provisioning")
+
+			// A Secret edited since the operator wrote it is resynced from the
+			// provider. Resyncing is best effort: a transient provider error
+			// must not flip an otherwise working AuthReady condition.
+			if secretModified {
+				resynced, err := provider.SyncClientSecret(ctx, nebariApp)
+				if err != nil {
+					logger.Error(err, "Failed to check OIDC client secret for drift")
+				} else if resynced {
+					logger.Info("OIDC client secret drifted from provider, resynced")
+					r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonClientSecretResynced,
+						"OIDC client secret differed from the provider and was resynced")
+				}
+			}
 		} else {
+			if secretMissing && authReady {
+				logger.Info("OIDC client secret missing, re-provisioning")
+			}
 			if forceAnnotation != "" {
 				logger.Info("Force re-provision annotation present, re-provisioning")
 			}
 
 			logger.Info("Provisioning OIDC client")
+			provisioned = true
 			if err := provider.ProvisionClient(ctx, nebariApp); err != nil {
-				conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
-					"ProvisioningFailed", fmt.Sprintf("Failed to provision OIDC client: %v", err))
-				return err
+				var degraded *providers.DegradedScopesError
+				if !errors.As(err, &degraded) {
+					msg := fmt.Sprintf("Failed to provision OIDC client: %v", err)
+					if !setProviderFailureCondition(nebariApp, err, msg) {
+						conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+							appsv1.ReasonProvisioningFailed, msg)
+					}
+					return err
+				}
+				degradedScopes = degraded.Scopes
+				logger.Info("OIDC client provisioned with degraded scopes", "scopes", degradedScopes)
+				r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonScopesDegraded, err.Error())
+			} else {
+				logger.Info("OIDC client provisioned successfully")
+				r.Recorder.Event(nebariApp, corev1.EventTypeNormal, "Provisioned", "OIDC client provisioned successfully")
 			}
-			logger.Info("OIDC client provisioned successfully")
-			r.Recorder.Event(nebariApp, corev1.EventTypeNormal, "Provisioned", "OIDC client provisioned successfully")
 
 			// Clear the force-reprovision annotation only after provisioning succeeds.
 			// Clearing it before would silently lose the annotation if ProvisionClient
 			// returned an error, leaving the user unaware they need to re-annotate.
-			// Note: Update() modifies the object and triggers an extra reconcile cycle;
-			// this is expected and harmless for an infrequent manual operation.
+			// Note: the patch triggers an extra reconcile cycle; this is expected and
+			// harmless for an infrequent manual operation.
 			if forceAnnotation != "" {
-				if nebariApp.Annotations != nil {
-					delete(nebariApp.Annotations, constants.AnnotationForceReprovision)
-				}
-				if err := r.Client.Update(ctx, nebariApp); err != nil {
-					return fmt.Errorf("failed to clear force-reprovision annotation: %w", err)
+				if err := r.clearForceReprovision(ctx, nebariApp); err != nil {
+					return err
 				}
 			}
 
-			// Store hash so subsequent reconciles can skip provisioning when nothing has changed
-			nebariApp.Status.AuthConfigHash = currentHash
+			// Store hash so subsequent reconciles can skip provisioning when nothing has changed.
+			// With degraded scopes the hash is left stale so the next reconcile retries them.
+			if len(degradedScopes) == 0 {
+				nebariApp.Status.AuthConfigHash = currentHash
+			}
 		}
 
+		nebariApp.Status.ClientProvisioned = true
+
 		// Reconcile RBAC for OIDC secret access (runs unconditionally so externally-deleted
 		// RBAC resources are always restored).
 		logger.Info("Reconciling Secret RBAC")
 		if err := r.reconcileSecretRBAC(ctx, nebariApp); err != nil {
 			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
-				"RBACFailed", fmt.Sprintf("Failed to reconcile Secret RBAC: %v", err))
+				appsv1.ReasonRBACFailed, fmt.Sprintf("Failed to reconcile Secret RBAC: %v", err))
 			return err
 		}
 	}
@@ -223,40 +450,151 @@ func (r *AuthReconciler) ReconcileAuth(ctx context.Context, nebariApp *appsv1.Ne
 	// Configure token exchange if requested
 	if nebariApp.Spec.Auth.TokenExchange != nil && nebariApp.Spec.Auth.TokenExchange.Enabled {
 		if err := r.reconcileTokenExchange(ctx, nebariApp, provider); err != nil {
-			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
-				"TokenExchangeFailed", fmt.Sprintf("Failed to configure token exchange: %v", err))
+			msg := fmt.Sprintf("Failed to configure token exchange: %v", err)
+			if !setProviderFailureCondition(nebariApp, err, msg) {
+				conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+					appsv1.ReasonTokenExchangeFailed, msg)
+			}
 			return err
 		}
 		logger.Info("Token exchange configured")
 	}
 
-	// Validate auth configuration (check client secret exists)
-	if err := r.validateAuthConfig(ctx, nebariApp); err != nil {
-		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
-			"ValidationFailed", fmt.Sprintf("Auth configuration validation failed: %v", err))
-		return err
+	// Validate auth configuration (check the provisioned client secret exists).
+	// Without provisioning it was already checked above.
+	if shouldProvisionClient(nebariApp.Spec.Auth) {
+		if err := r.validateAuthConfig(ctx, nebariApp); err != nil {
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+				appsv1.ReasonAuthValidationFailed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+			return err
+		}
 	}
 
 	// Reconcile SecurityPolicy (only if enforceAtGateway is enabled)
 	if shouldEnforceAtGateway(nebariApp.Spec.Auth) {
+		if err := r.validateClientTLSSecret(ctx, nebariApp); err != nil {
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+				appsv1.ReasonAuthValidationFailed, fmt.Sprintf("Auth configuration validation failed: %v", err))
+			return err
+		}
+		if err := r.reconcileIdPBackend(ctx, nebariApp, provider); err != nil {
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+				appsv1.ReasonSecurityPolicyFailed, fmt.Sprintf("Failed to reconcile IdP Backend: %v", err))
+			return err
+		}
+		conflicts, err := r.conflictingSecurityPolicies(ctx, nebariApp)
+		if err != nil {
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+				appsv1.ReasonSecurityPolicyFailed, fmt.Sprintf("Failed to check for conflicting SecurityPolicies: %v", err))
+			return err
+		}
+		if len(conflicts) > 0 {
+			err := fmt.Errorf("SecurityPolicy %s already targets the app's HTTPRoutes; Envoy Gateway applies only one SecurityPolicy per route",
+				strings.Join(conflicts, ", "))
+			r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonSecurityPolicyConflict, err.Error())
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+				appsv1.ReasonSecurityPolicyConflict, err.Error())
+			return err
+		}
+		if err := r.issuerPreflight(ctx, nebariApp, provider); err != nil {
+			r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonIssuerUnreachable, err.Error())
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+				appsv1.ReasonIssuerUnreachable, err.Error())
+			return err
+		}
 		if err := r.reconcileSecurityPolicy(ctx, nebariApp, provider); err != nil {
 			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
-				"SecurityPolicyFailed", fmt.Sprintf("Failed to reconcile SecurityPolicy: %v", err))
+				appsv1.ReasonSecurityPolicyFailed, fmt.Sprintf("Failed to reconcile SecurityPolicy: %v", err))
+			return err
+		}
+		if err := r.reconcileStepUpSecurityPolicy(ctx, nebariApp, provider); err != nil {
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+				appsv1.ReasonSecurityPolicyFailed, fmt.Sprintf("Failed to reconcile step-up SecurityPolicy: %v", err))
+			return err
+		}
+		if err := r.reconcileLoginSecurityPolicy(ctx, nebariApp, provider); err != nil {
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+				appsv1.ReasonSecurityPolicyFailed, fmt.Sprintf("Failed to reconcile login SecurityPolicy: %v", err))
 			return err
 		}
 	} else {
 		logger.Info("enforceAtGateway disabled, skipping SecurityPolicy creation")
+		nebariApp.Status.IssuerURL = ""
 		// Delete existing SecurityPolicy if transitioning from enforceAtGateway=true to false
 		if err := r.deleteSecurityPolicyIfExists(ctx, nebariApp); err != nil {
 			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
-				"SecurityPolicyCleanupFailed", fmt.Sprintf("Failed to delete existing SecurityPolicy: %v", err))
+				appsv1.ReasonSecurityPolicyCleanupFailed, fmt.Sprintf("Failed to delete existing SecurityPolicy: %v", err))
 			return err
 		}
+		if err := r.deleteIdPBackendIfExists(ctx, nebariApp); err != nil {
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+				appsv1.ReasonSecurityPolicyCleanupFailed, fmt.Sprintf("Failed to delete existing IdP Backend: %v", err))
+			return err
+		}
+	}
+
+	// Warnings are carried in the AuthReady message so each one is emitted as
+	// an event once per change rather than on every reconcile.
+	previous := conditions.GetCondition(nebariApp, appsv1.ConditionTypeAuthReady)
+	warnedBefore := func(warning string) bool {
+		return previous != nil && strings.Contains(previous.Message, warning)
+	}
+	var warnings []string
+	if groupsScopeWarning != "" {
+		warnings = append(warnings, groupsScopeWarning)
+		if !warnedBefore(groupsScopeWarning) {
+			logger.Info("Added the groups scope for group-based authorization", "scopes", providers.RequestedScopes(nebariApp.Spec.Auth))
+			r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonGroupsScopeAdded, groupsScopeWarning)
+		}
 	}
 
-	// Auth configured successfully
-	conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionTrue,
-		"AuthConfigured", fmt.Sprintf("Authentication configured with provider %s", nebariApp.Spec.Auth.Provider))
+	// Requested scopes the provider does not define are a warning only; the
+	// check is best effort like the client secret resync. It queries the
+	// provider, so it only runs when the client was just provisioned or, for
+	// clients the operator does not provision, when the spec changed. Otherwise
+	// the previous warning is kept.
+	checkScopes := provisioned || (!shouldProvisionClient(nebariApp.Spec.Auth) &&
+		(previous == nil || previous.Status != metav1.ConditionTrue || previous.ObservedGeneration != nebariApp.Generation))
+	if checkScopes {
+		unknownScopes, err := provider.UnknownScopes(ctx, nebariApp)
+		if provisioned {
+			// The operator maps the groups claim on the provisioned client
+			// itself, so a placeholder groups scope still yields the claim.
+			unknownScopes = slices.DeleteFunc(unknownScopes, func(scope string) bool { return scope == providers.GroupsScope })
+		}
+		if err != nil {
+			logger.Error(err, "Failed to check requested scopes against the provider")
+		} else if len(unknownScopes) > 0 {
+			unknownScopesWarning := fmt.Sprintf("%s %s and add no claims to tokens: %s", unknownScopesPrefix,
+				r.providerName(nebariApp), strings.Join(unknownScopes, ", "))
+			warnings = append(warnings, unknownScopesWarning)
+			if !warnedBefore(unknownScopesWarning) {
+				logger.Info("Requested scopes are not defined by the provider", "scopes", unknownScopes)
+				r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonScopesUnknown, unknownScopesWarning)
+			}
+		}
+	} else if previous != nil {
+		for _, part := range strings.Split(previous.Message, "; ") {
+			if strings.HasPrefix(part, unknownScopesPrefix) {
+				warnings = append(warnings, part)
+			}
+		}
+	}
+
+	// Auth configured successfully, possibly without some requested scopes
+	var warningSuffix string
+	if len(warnings) > 0 {
+		warningSuffix = "; " + strings.Join(warnings, "; ")
+	}
+	if len(degradedScopes) > 0 {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionTrue,
+			appsv1.ReasonAuthDegraded, fmt.Sprintf("Authentication configured with provider %s, but requested scopes could not be provisioned: %s%s",
+				r.providerName(nebariApp), strings.Join(degradedScopes, ", "), warningSuffix))
+	} else {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionTrue,
+			appsv1.ReasonAuthConfigured, fmt.Sprintf("Authentication configured with provider %s%s",
+				r.providerName(nebariApp), warningSuffix))
+	}
 	r.Recorder.Event(nebariApp, corev1.EventTypeNormal, "Configured", "Authentication configured successfully")
 
 	return nil
@@ -299,13 +637,85 @@ func (r *AuthReconciler) CleanupAuth(ctx context.Context, nebariApp *appsv1.Neba
 	return nil
 }
 
-// getProvider returns the appropriate provider for the NebariApp.
-func (r *AuthReconciler) getProvider(nebariApp *appsv1.NebariApp) (providers.OIDCProvider, error) {
-	providerName := nebariApp.Spec.Auth.Provider
-	if providerName == "" {
-		providerName = constants.ProviderKeycloak // Default
+// DisableAuth removes the authentication resources of a NebariApp whose
+// spec.enabled is false. The NebariApp stays, so the SecurityPolicies, IdP
+// Backend and operator-written client Secret are deleted explicitly rather than
+// left to garbage collection. The provisioning markers in status are cleared so re-enabling
+// the app provisions a fresh client.
+func (r *AuthReconciler) DisableAuth(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	if err := r.CleanupAuth(ctx, nebariApp); err != nil {
+		return err
+	}
+	if err := r.deleteSecurityPolicyIfExists(ctx, nebariApp); err != nil {
+		return err
+	}
+	if err := r.deleteIdPBackendIfExists(ctx, nebariApp); err != nil {
+		return err
 	}
+	if nebariApp.Status.ClientProvisioned {
+		if err := r.deleteProvisionedClientSecret(ctx, nebariApp); err != nil {
+			return err
+		}
+	}
+
+	nebariApp.Status.AuthConfigHash = ""
+	nebariApp.Status.ClientProvisioned = false
+	nebariApp.Status.IssuerURL = ""
+	return nil
+}
+
+// ErrInvalidConfig marks auth errors caused by the NebariApp's own auth
+// settings. Retrying cannot fix them until the spec changes, and a spec change
+// triggers a reconcile anyway. Errors about Secrets the spec references are not
+// marked, since the Secret can appear without the spec changing.
+var ErrInvalidConfig = errors.New("invalid auth configuration")
+
+// configError is an error in the NebariApp's auth settings. errors.Is matches
+// it against ErrInvalidConfig as well as anything err wraps; its message is err's.
+type configError struct {
+	err error
+}
+
+func (e *configError) Error() string {
+	return e.err.Error()
+}
+
+func (e *configError) Unwrap() []error {
+	return []error{ErrInvalidConfig, e.err}
+}
 
+// invalidConfig marks err as caused by the NebariApp's auth settings.
+func invalidConfig(err error) error {
+	return &configError{err: err}
+}
+
+// setProviderFailureCondition sets AuthReady=False with the reason matching a
+// typed provider error and reports whether err was one. Callers set their own
+// reason for any other error.
+func setProviderFailureCondition(nebariApp *appsv1.NebariApp, err error, message string) bool {
+	var providerErr *providers.ProviderError
+	if !errors.As(err, &providerErr) {
+		return false
+	}
+	switch providerErr.Kind {
+	case providers.ErrAuthFailed:
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonProviderAuthFailed, message)
+	case providers.ErrClientNotFound:
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonClientNotFound, message)
+	case providers.ErrProviderUnreachable:
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeAuthReady, metav1.ConditionFalse,
+			appsv1.ReasonProviderUnreachable, message)
+	default:
+		return false
+	}
+	return true
+}
+
+// getProvider returns the appropriate provider for the NebariApp.
+func (r *AuthReconciler) getProvider(nebariApp *appsv1.NebariApp) (providers.OIDCProvider, error) {
+	providerName := r.providerName(nebariApp)
 	provider, exists := r.Providers[providerName]
 	if !exists {
 		return nil, fmt.Errorf("unsupported OIDC provider: %s", providerName)
@@ -314,6 +724,18 @@ func (r *AuthReconciler) getProvider(nebariApp *appsv1.NebariApp) (providers.OID
 	return provider, nil
 }
 
+// providerName returns the name of the provider configured for the NebariApp,
+// falling back to the operator-wide default when spec.auth.provider is empty.
+func (r *AuthReconciler) providerName(nebariApp *appsv1.NebariApp) string {
+	if nebariApp.Spec.Auth != nil && nebariApp.Spec.Auth.Provider != "" {
+		return nebariApp.Spec.Auth.Provider
+	}
+	if r.DefaultProvider != "" {
+		return r.DefaultProvider
+	}
+	return constants.ProviderKeycloak
+}
+
 // validateAuthConfig validates that the OIDC client secret exists and is valid.
 func (r *AuthReconciler) validateAuthConfig(ctx context.Context, nebariApp *appsv1.NebariApp) error {
 	logger := log.FromContext(ctx)
@@ -339,6 +761,14 @@ func (r *AuthReconciler) validateAuthConfig(ctx context.Context, nebariApp *apps
 	logger.Info("OIDC client secret found", "secretName", clientSecretName)
 
 	if _, ok := secret.Data[constants.ClientSecretKey]; !ok {
+		// The Secret may belong to the user, so it is never rewritten here. Envoy
+		// Gateway only reads ClientSecretKey, so a legacy key has to be renamed.
+		if _, legacy := secret.Data[constants.LegacyClientSecretKey]; legacy {
+			logger.Info("OIDC client secret uses legacy key", "secretName", clientSecretName,
+				"legacyKey", constants.LegacyClientSecretKey, "requiredKey", constants.ClientSecretKey)
+			return fmt.Errorf("OIDC client secret '%s' stores the secret under legacy key '%s'; rename it to '%s'",
+				clientSecretName, constants.LegacyClientSecretKey, constants.ClientSecretKey)
+		}
 		logger.Info("OIDC client secret missing required key", "secretName", clientSecretName, "requiredKey", constants.ClientSecretKey)
 		return fmt.Errorf("OIDC client secret '%s' missing required key '%s'", clientSecretName, constants.ClientSecretKey)
 	}
@@ -347,14 +777,337 @@ func (r *AuthReconciler) validateAuthConfig(ctx context.Context, nebariApp *apps
 	return nil
 }
 
-// deleteSecurityPolicyIfExists deletes the SecurityPolicy for a NebariApp if it exists.
+// validateRedirectURLOverride checks that a redirectURLOverride, when set, is an
+// absolute http(s) URL with a host.
+func validateRedirectURLOverride(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("redirectURLOverride %q is not a valid URL: %w", raw, err)
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("redirectURLOverride %q must be an absolute http(s) URL", raw)
+	}
+	return nil
+}
+
+// validatePostLogoutRedirectURI checks that a postLogoutRedirectURI, when set,
+// is either an absolute http(s) URL with a host or a root-relative path.
+func validatePostLogoutRedirectURI(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("postLogoutRedirectURI %q is not a valid URL: %w", raw, err)
+	}
+	if u.IsAbs() {
+		if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("postLogoutRedirectURI %q must be an absolute http(s) URL or a root-relative path", raw)
+		}
+		return nil
+	}
+	if !strings.HasPrefix(raw, "/") || strings.HasPrefix(raw, "//") {
+		return fmt.Errorf("postLogoutRedirectURI %q must be an absolute http(s) URL or a root-relative path", raw)
+	}
+	return nil
+}
+
+// validateWebOrigins checks that each additionalWebOrigins entry is an absolute
+// http(s) origin: a scheme and host with an optional port, and nothing else.
+// Keycloak compares web origins verbatim, so a path or wildcard would either
+// never match or allow far more than intended.
+func validateWebOrigins(origins []string) error {
+	for _, raw := range origins {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return fmt.Errorf("additionalWebOrigins entry %q is not a valid URL: %w", raw, err)
+		}
+		if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || strings.Contains(u.Host, "*") ||
+			raw != u.Scheme+"://"+u.Host {
+			return fmt.Errorf("additionalWebOrigins entry %q must be an absolute http(s) origin without a path, e.g. https://app.example.com", raw)
+		}
+	}
+	return nil
+}
+
+// reservedAuthParams are authorization request parameters the Envoy OAuth2
+// filter sets itself; letting users override them would break the flow.
+var reservedAuthParams = map[string]bool{
+	"client_id":             true,
+	"redirect_uri":          true,
+	"response_type":         true,
+	"scope":                 true,
+	"state":                 true,
+	"nonce":                 true,
+	"code_challenge":        true,
+	"code_challenge_method": true,
+	"resource":              true,
+}
+
+// validateExtraAuthParams checks that extraAuthParams keys and values are
+// non-empty and do not collide with parameters the OIDC filter manages.
+func validateExtraAuthParams(params map[string]string) error {
+	for key, value := range params {
+		if key == "" || value == "" {
+			return fmt.Errorf("extraAuthParams keys and values must be non-empty (got %q=%q)", key, value)
+		}
+		if reservedAuthParams[strings.ToLower(key)] {
+			return fmt.Errorf("extraAuthParams cannot set %q, it is managed by the gateway", key)
+		}
+	}
+	return nil
+}
+
+// validateAllowedScopes checks that every scope the app requests is on the
+// operator's allowlist: the requested scopes (see providers.RequestedScopes),
+// or the default scopes when there are none, plus auth.stepUpScopes. An empty allowlist, or one containing
+// constants.AllowAnyScope, allows any scope.
+func validateAllowedScopes(auth *appsv1.AuthConfig, allowed []string) error {
+	if len(allowed) == 0 || slices.Contains(allowed, constants.AllowAnyScope) {
+		return nil
+	}
+	scopes := providers.RequestedScopes(auth)
+	if len(scopes) == 0 {
+		scopes = providers.DefaultScopes
+	}
+	var denied []string
+	for _, scope := range slices.Concat(scopes, auth.StepUpScopes) {
+		if !slices.Contains(allowed, scope) && !slices.Contains(denied, scope) {
+			denied = append(denied, scope)
+		}
+	}
+	if len(denied) > 0 {
+		return fmt.Errorf("scopes %s are not allowed by the operator (allowed: %s)",
+			strings.Join(denied, ", "), strings.Join(allowed, ", "))
+	}
+	return nil
+}
+
+// clearForceReprovision removes the force-reprovision annotation from the stored
+// NebariApp. Only the annotation is patched, on a copy fetched from the API
+// server, so nothing the reconcile changed in memory is written to the spec.
+// nebariApp takes the new resourceVersion so the closing status update does not
+// conflict.
+func (r *AuthReconciler) clearForceReprovision(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	stored := &appsv1.NebariApp{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(nebariApp), stored); err != nil {
+		return fmt.Errorf("failed to get NebariApp to clear force-reprovision annotation: %w", err)
+	}
+	patch := client.MergeFrom(stored.DeepCopy())
+	delete(stored.Annotations, constants.AnnotationForceReprovision)
+	if err := r.Client.Patch(ctx, stored, patch); err != nil {
+		return fmt.Errorf("failed to clear force-reprovision annotation: %w", err)
+	}
+	delete(nebariApp.Annotations, constants.AnnotationForceReprovision)
+	nebariApp.ResourceVersion = stored.ResourceVersion
+	return nil
+}
+
+// unknownScopesPrefix starts the AuthReady warning listing requested scopes the
+// provider does not define.
+const unknownScopesPrefix = "requested scopes are not defined by provider"
+
+// addsGroupsScope reports whether providers.RequestedScopes adds the groups
+// scope the user left out of auth.scopes.
+func addsGroupsScope(auth *appsv1.AuthConfig) bool {
+	return len(auth.Groups) > 0 && !slices.Contains(auth.Scopes, providers.GroupsScope)
+}
+
+// validateStepUp checks auth.stepUpScopes and auth.stepUpPaths. Both must be
+// set together, and they need enforceAtGateway because the step-up login is done
+// by a gateway SecurityPolicy. A step-up path cannot be "/" (raise auth.scopes
+// instead) or repeat a routing.routes or routing.publicRoutes path, since Gateway
+// API would not reliably prefer the step-up route over a route with the same path.
+// Step-up paths cannot be nested in each other, and no routing.routes path may
+// lie below one, since the longer route would take those requests.
+func validateStepUp(nebariApp *appsv1.NebariApp) error {
+	auth := nebariApp.Spec.Auth
+	if len(auth.StepUpScopes) == 0 && len(auth.StepUpPaths) == 0 {
+		return nil
+	}
+	if len(auth.StepUpScopes) == 0 || len(auth.StepUpPaths) == 0 {
+		return fmt.Errorf("stepUpScopes and stepUpPaths must be set together")
+	}
+	if !shouldEnforceAtGateway(auth) {
+		return fmt.Errorf("stepUpScopes require enforceAtGateway")
+	}
+
+	var routes, publicRoutes []appsv1.RouteMatch
+	if routing := nebariApp.Spec.Routing; routing != nil {
+		routes, publicRoutes = routing.Routes, routing.PublicRoutes
+	}
+	for i, path := range auth.StepUpPaths {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("stepUpPaths entry %q must start with /", path)
+		}
+		if strings.Trim(path, "/") == "" {
+			return fmt.Errorf("stepUpPaths entry %q covers the whole app; add the scopes to scopes instead", path)
+		}
+		for _, other := range auth.StepUpPaths[:i] {
+			if pathUnder(path, other) || pathUnder(other, path) {
+				return fmt.Errorf("stepUpPaths entries %q and %q overlap; keep only the shorter one", other, path)
+			}
+		}
+		for _, route := range slices.Concat(routes, publicRoutes) {
+			if strings.TrimRight(route.PathPrefix, "/") == strings.TrimRight(path, "/") {
+				return fmt.Errorf("stepUpPaths entry %q repeats the routing path %q", path, route.PathPrefix)
+			}
+		}
+		// A longer route path wins over the step-up prefix, so requests to it
+		// would skip the step-up login. Public routes are deliberate exemptions.
+		for _, route := range routes {
+			if route.PathPrefix != "" && pathUnder(route.PathPrefix, path) {
+				return fmt.Errorf("routing path %q is below stepUpPaths entry %q and would bypass step-up", route.PathPrefix, path)
+			}
+		}
+	}
+	return nil
+}
+
+// pathUnder reports whether path is prefix or below it, comparing whole path
+// segments as a Gateway API PathPrefix match does.
+func pathUnder(path, prefix string) bool {
+	path, prefix = strings.TrimRight(path, "/"), strings.TrimRight(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// validateOptional checks auth.optional. The gateway only lets anonymous
+// requests through when it enforces auth itself, and a bearer-only app has no
+// login to make optional. Gateway claim rules deny requests without a token,
+// which would make the login mandatory again. Authenticated request headers
+// would also reach anonymous requests, so clients could no longer rely on
+// them. The login path cannot double as the OAuth2 callback.
+func validateOptional(auth *appsv1.AuthConfig) error {
+	if !auth.Optional {
+		return nil
+	}
+	if !shouldEnforceAtGateway(auth) {
+		return fmt.Errorf("optional requires enforceAtGateway")
+	}
+	if auth.BearerOnly {
+		return fmt.Errorf("optional cannot be combined with bearerOnly")
+	}
+	if auth.EnforceGroupsAtGateway || len(auth.AllowedEmailDomains) > 0 {
+		return fmt.Errorf("optional cannot be combined with enforceGroupsAtGateway or allowedEmailDomains")
+	}
+	if len(auth.AuthenticatedRequestHeaders) > 0 {
+		return fmt.Errorf("optional cannot be combined with authenticatedRequestHeaders; anonymous requests reach the app too")
+	}
+	if path, err := providers.NormalizeRedirectURI(auth.RedirectURI, auth.RedirectURLOverride != ""); err == nil && path == constants.OptionalLoginPath {
+		return fmt.Errorf("redirectURI cannot be the optional login path %s", constants.OptionalLoginPath)
+	}
+	return nil
+}
+
+// validateGatewayAuthorization checks the settings that make the gateway
+// enforce token claims. enforceGroupsAtGateway needs groups to enforce,
+// allowedEmailDomains needs the claim the IdP puts the domain in, and browser
+// sessions can only be checked when their access token is forwarded,
+// so an explicit forwardAccessToken=false is refused rather than overridden.
+func validateGatewayAuthorization(auth *appsv1.AuthConfig) error {
+	if auth.EnforceGroupsAtGateway && len(auth.Groups) == 0 {
+		return fmt.Errorf("enforceGroupsAtGateway requires groups")
+	}
+	if len(auth.AllowedEmailDomains) > 0 && auth.EmailDomainClaim == "" {
+		return fmt.Errorf("allowedEmailDomains requires emailDomainClaim, the token claim holding the email domain alone")
+	}
+	if !hasGatewayClaimRules(auth) || auth.BearerOnly || auth.JWT == nil || !auth.JWT.Enabled {
+		return nil
+	}
+	if auth.ForwardAccessToken != nil && !*auth.ForwardAccessToken {
+		return fmt.Errorf("forwardAccessToken cannot be false with enforceGroupsAtGateway or allowedEmailDomains; " +
+			"browser sessions are authorized by forwarding their access token")
+	}
+	return nil
+}
+
+// hasGatewayClaimRules reports whether auth asks the gateway to authorize
+// requests by token claims.
+func hasGatewayClaimRules(auth *appsv1.AuthConfig) bool {
+	return (auth.EnforceGroupsAtGateway && len(auth.Groups) > 0) || len(auth.AllowedEmailDomains) > 0
+}
+
+// validateBearerOnly rejects browser OIDC settings on a bearerOnly app. A
+// bearer-only client has no redirect URIs and the gateway never starts a login,
+// so these settings would be silently ignored.
+func validateBearerOnly(auth *appsv1.AuthConfig) error {
+	if !auth.BearerOnly {
+		return nil
+	}
+	var browserFields []string
+	if auth.RedirectURI != "" {
+		browserFields = append(browserFields, "redirectURI")
+	}
+	if auth.RedirectURLOverride != "" {
+		browserFields = append(browserFields, "redirectURLOverride")
+	}
+	if auth.PostLogoutRedirectURI != "" {
+		browserFields = append(browserFields, "postLogoutRedirectURI")
+	}
+	if auth.Logout != nil {
+		browserFields = append(browserFields, "logout")
+	}
+	if auth.PKCE {
+		browserFields = append(browserFields, "pkce")
+	}
+	if len(auth.ExtraAuthParams) > 0 {
+		browserFields = append(browserFields, "extraAuthParams")
+	}
+	if len(auth.DenyRedirect) > 0 {
+		browserFields = append(browserFields, "denyRedirect")
+	}
+	if auth.ForwardAccessToken != nil && *auth.ForwardAccessToken {
+		browserFields = append(browserFields, "forwardAccessToken")
+	}
+	if len(auth.StepUpScopes) > 0 || len(auth.StepUpPaths) > 0 {
+		browserFields = append(browserFields, "stepUpScopes")
+	}
+	if len(browserFields) > 0 {
+		return fmt.Errorf("bearerOnly cannot be combined with the browser login settings %s", strings.Join(browserFields, ", "))
+	}
+	return nil
+}
+
+// withQueryParams appends params to the query string of an OIDC endpoint URL.
+// Envoy Gateway has no dedicated field for extra authorization parameters, but
+// Envoy's OAuth2 filter keeps any query parameters already on the
+// authorization endpoint when it builds the login redirect.
+func withQueryParams(endpoint string, params map[string]string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint URL %q: %w", endpoint, err)
+	}
+	query := u.Query()
+	for key, value := range params {
+		query.Set(key, value)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// deleteSecurityPolicyIfExists deletes the SecurityPolicy for a NebariApp if it
+// exists, along with the step-up and login SecurityPolicies.
 // This is used when transitioning from enforceAtGateway=true to enforceAtGateway=false.
 func (r *AuthReconciler) deleteSecurityPolicyIfExists(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	if err := r.deleteNamedSecurityPolicy(ctx, nebariApp, naming.SecurityPolicyName(nebariApp)); err != nil {
+		return err
+	}
+	if err := r.deleteNamedSecurityPolicy(ctx, nebariApp, naming.StepUpSecurityPolicyName(nebariApp)); err != nil {
+		return err
+	}
+	return r.deleteNamedSecurityPolicy(ctx, nebariApp, naming.LoginSecurityPolicyName(nebariApp))
+}
+
+// deleteNamedSecurityPolicy deletes the named SecurityPolicy in the NebariApp's namespace, if it exists.
+func (r *AuthReconciler) deleteNamedSecurityPolicy(ctx context.Context, nebariApp *appsv1.NebariApp, name string) error {
 	logger := log.FromContext(ctx)
 
 	securityPolicy := &egv1alpha1.SecurityPolicy{}
 	err := r.Client.Get(ctx, types.NamespacedName{
-		Name:      naming.SecurityPolicyName(nebariApp),
+		Name:      name,
 		Namespace: nebariApp.Namespace,
 	}, securityPolicy)
 
@@ -365,20 +1118,50 @@ func (r *AuthReconciler) deleteSecurityPolicyIfExists(ctx context.Context, nebar
 		return fmt.Errorf("failed to get SecurityPolicy: %w", err)
 	}
 
-	logger.Info("Deleting SecurityPolicy (enforceAtGateway disabled)", "name", securityPolicy.Name)
+	logger.Info("Deleting SecurityPolicy", "name", securityPolicy.Name)
 	if err := r.Client.Delete(ctx, securityPolicy); err != nil {
 		return fmt.Errorf("failed to delete SecurityPolicy: %w", err)
 	}
 
-	r.Recorder.Event(nebariApp, corev1.EventTypeNormal, "SecurityPolicyDeleted", "SecurityPolicy deleted (enforceAtGateway disabled)")
+	r.Recorder.Event(nebariApp, corev1.EventTypeNormal, "SecurityPolicyDeleted", fmt.Sprintf("SecurityPolicy %s deleted", securityPolicy.Name))
 	return nil
 }
 
 // reconcileSecurityPolicy creates or updates the Envoy SecurityPolicy for OIDC authentication.
 func (r *AuthReconciler) reconcileSecurityPolicy(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) error {
+	return r.applySecurityPolicy(ctx, nebariApp, naming.SecurityPolicyName(nebariApp), func() (egv1alpha1.SecurityPolicySpec, error) {
+		return r.buildSecurityPolicySpec(ctx, nebariApp, provider)
+	})
+}
+
+// reconcileStepUpSecurityPolicy creates or updates the SecurityPolicy guarding
+// auth.stepUpPaths, or deletes it when step-up authentication is not configured.
+func (r *AuthReconciler) reconcileStepUpSecurityPolicy(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) error {
+	if providers.StepUpRedirectPath(nebariApp) == "" {
+		return r.deleteNamedSecurityPolicy(ctx, nebariApp, naming.StepUpSecurityPolicyName(nebariApp))
+	}
+	return r.applySecurityPolicy(ctx, nebariApp, naming.StepUpSecurityPolicyName(nebariApp), func() (egv1alpha1.SecurityPolicySpec, error) {
+		return r.buildStepUpSecurityPolicySpec(ctx, nebariApp, provider)
+	})
+}
+
+// reconcileLoginSecurityPolicy creates or updates the SecurityPolicy doing the
+// login of an auth.optional app, or deletes it when auth is not optional.
+func (r *AuthReconciler) reconcileLoginSecurityPolicy(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) error {
+	if !nebariApp.Spec.Auth.Optional {
+		return r.deleteNamedSecurityPolicy(ctx, nebariApp, naming.LoginSecurityPolicyName(nebariApp))
+	}
+	return r.applySecurityPolicy(ctx, nebariApp, naming.LoginSecurityPolicyName(nebariApp), func() (egv1alpha1.SecurityPolicySpec, error) {
+		return r.buildLoginSecurityPolicySpec(ctx, nebariApp, provider)
+	})
+}
+
+// applySecurityPolicy creates or updates the named SecurityPolicy with the spec
+// returned by buildSpec, with server-side apply when r.ServerSideApply is set.
+func (r *AuthReconciler) applySecurityPolicy(ctx context.Context, nebariApp *appsv1.NebariApp, securityPolicyName string,
+	buildSpec func() (egv1alpha1.SecurityPolicySpec, error)) error {
 	logger := log.FromContext(ctx)
 
-	securityPolicyName := naming.SecurityPolicyName(nebariApp)
 	securityPolicy := &egv1alpha1.SecurityPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      securityPolicyName,
@@ -386,20 +1169,53 @@ func (r *AuthReconciler) reconcileSecurityPolicy(ctx context.Context, nebariApp
 		},
 	}
 
-	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, securityPolicy, func() error {
+	mutate := func() error {
 		if err := controllerutil.SetControllerReference(nebariApp, securityPolicy, r.Scheme); err != nil {
 			return fmt.Errorf("failed to set controller reference: %w", err)
 		}
 
-		spec, err := r.buildSecurityPolicySpec(ctx, nebariApp, provider)
+		// Ownership labels let the orphan sweep find policies whose NebariApp is gone
+		if securityPolicy.Labels == nil {
+			securityPolicy.Labels = make(map[string]string)
+		}
+		securityPolicy.Labels["app.kubernetes.io/managed-by"] = naming.ManagedBy(r.ManagedBy)
+		securityPolicy.Labels["nebari.dev/nebariapp-name"] = naming.LabelValue(nebariApp.Name)
+		securityPolicy.Labels["nebari.dev/nebariapp-namespace"] = nebariApp.Namespace
+
+		if nebariApp.Spec.Description != "" {
+			if securityPolicy.Annotations == nil {
+				securityPolicy.Annotations = map[string]string{}
+			}
+			securityPolicy.Annotations[constants.AnnotationDescription] = nebariApp.Spec.Description
+		} else {
+			delete(securityPolicy.Annotations, constants.AnnotationDescription)
+		}
+
+		spec, err := buildSpec()
 		if err != nil {
 			return fmt.Errorf("failed to build SecurityPolicy spec: %w", err)
 		}
+		// CreateOrUpdate compares the object before and after mutate, so an
+		// unchanged spec issues no update.
 		securityPolicy.Spec = spec
 
 		return nil
-	})
+	}
+
+	if r.ServerSideApply {
+		// Starting from an empty object, mutate builds the complete desired state.
+		if err := mutate(); err != nil {
+			return err
+		}
+		op, err := ssa.Apply(ctx, r.Client, securityPolicy)
+		if err != nil {
+			return fmt.Errorf("failed to apply SecurityPolicy: %w", err)
+		}
+		logger.Info("SecurityPolicy reconciled", "name", securityPolicyName, "operation", op)
+		return nil
+	}
 
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, securityPolicy, mutate)
 	if err != nil {
 		return fmt.Errorf("failed to create or update SecurityPolicy: %w", err)
 	}
@@ -408,8 +1224,117 @@ func (r *AuthReconciler) reconcileSecurityPolicy(ctx context.Context, nebariApp
 	return nil
 }
 
+// buildStepUpSecurityPolicySpec builds the SecurityPolicy for auth.stepUpPaths:
+// the main policy retargeted at the step-up HTTPRoutes, requesting the base
+// scopes plus auth.stepUpScopes and returning to the step-up callback path.
+// Envoy Gateway keeps separate session cookies per policy, so a user signed in
+// to the app still logs in again, with the extra scopes, on a sensitive path.
+func (r *AuthReconciler) buildStepUpSecurityPolicySpec(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) (egv1alpha1.SecurityPolicySpec, error) {
+	spec, err := r.buildOIDCSecurityPolicySpec(ctx, nebariApp, provider)
+	if err != nil {
+		return egv1alpha1.SecurityPolicySpec{}, err
+	}
+
+	spec.TargetRefs = httpRouteTargetRefs(naming.StepUpHTTPRouteNames(nebariApp))
+
+	scopes := append([]string(nil), spec.OIDC.Scopes...)
+	for _, scope := range nebariApp.Spec.Auth.StepUpScopes {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	spec.OIDC.Scopes = scopes
+	spec.OIDC.RedirectURL = ptr.To(fmt.Sprintf("https://%s%s", nebariApp.Spec.Hostname, providers.StepUpRedirectPath(nebariApp)))
+
+	return spec, nil
+}
+
+// buildLoginSecurityPolicySpec builds the SecurityPolicy for the login routes
+// of an auth.optional app: the OIDC policy retargeted at the login HTTPRoutes.
+// It stores the access token unencrypted in a cookie of a known name, so the
+// main policy's JWT filter can read and validate it on the rest of the app.
+func (r *AuthReconciler) buildLoginSecurityPolicySpec(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) (egv1alpha1.SecurityPolicySpec, error) {
+	spec, err := r.buildOIDCSecurityPolicySpec(ctx, nebariApp, provider)
+	if err != nil {
+		return egv1alpha1.SecurityPolicySpec{}, err
+	}
+
+	spec.TargetRefs = httpRouteTargetRefs(naming.LoginHTTPRouteNames(nebariApp))
+	spec.OIDC.CookieNames = &egv1alpha1.OIDCCookieNames{
+		AccessToken: ptr.To(naming.AccessTokenCookieName(nebariApp)),
+	}
+	spec.OIDC.DisableTokenEncryption = ptr.To(true)
+
+	return spec, nil
+}
+
+// buildOptionalSecurityPolicySpec constructs the main SecurityPolicy of an
+// auth.optional app: JWT validation that lets requests without a token
+// through. A token is taken from the Authorization header or the session
+// cookie set by the login policy; an invalid one is still rejected with 401.
+// There is no OIDC filter, so anonymous users are never redirected to log in.
+func (r *AuthReconciler) buildOptionalSecurityPolicySpec(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) (egv1alpha1.SecurityPolicySpec, error) {
+	issuerURL, err := provider.GetIssuerURL(ctx, nebariApp)
+	if err != nil {
+		return egv1alpha1.SecurityPolicySpec{}, fmt.Errorf("failed to get issuer URL: %w", err)
+	}
+	overrides, err := provider.GetEndpointOverrides(ctx, nebariApp)
+	if err != nil {
+		return egv1alpha1.SecurityPolicySpec{}, fmt.Errorf("failed to get endpoint overrides: %w", err)
+	}
+
+	jwt, err := buildJWT(ctx, nebariApp, provider, issuerURL, overrides.JWKS)
+	if err != nil {
+		return egv1alpha1.SecurityPolicySpec{}, err
+	}
+	jwt.Optional = ptr.To(true)
+	jwt.Providers[0].ExtractFrom = &egv1alpha1.JWTExtractor{
+		Headers: []egv1alpha1.JWTHeaderExtractor{{Name: "Authorization", ValuePrefix: ptr.To("Bearer ")}},
+		Cookies: []string{naming.AccessTokenCookieName(nebariApp)},
+	}
+	// Surface the issuer tokens are validated against, as the OIDC mode does
+	nebariApp.Status.IssuerURL = jwt.Providers[0].Issuer
+
+	return egv1alpha1.SecurityPolicySpec{
+		PolicyTargetReferences: egv1alpha1.PolicyTargetReferences{
+			TargetRefs: httpRouteTargetRefs(naming.HTTPRouteNames(nebariApp)),
+		},
+		JWT: jwt,
+	}, nil
+}
+
+// httpRouteTargetRefs returns SecurityPolicy target references for the named
+// HTTPRoutes.
+func httpRouteTargetRefs(routeNames []string) []gwapiv1.LocalPolicyTargetReferenceWithSectionName {
+	refs := make([]gwapiv1.LocalPolicyTargetReferenceWithSectionName, 0, len(routeNames))
+	for _, routeName := range routeNames {
+		refs = append(refs, gwapiv1.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: gwapiv1.LocalPolicyTargetReference{
+				Group: gwapiv1.Group("gateway.networking.k8s.io"),
+				Kind:  gwapiv1.Kind("HTTPRoute"),
+				Name:  gwapiv1.ObjectName(routeName),
+			},
+		})
+	}
+	return refs
+}
+
 // buildSecurityPolicySpec constructs the SecurityPolicy specification for OIDC.
+// Bearer-only apps get a JWT-only policy instead, see buildBearerOnlySecurityPolicySpec,
+// and auth.optional apps an optional one, see buildOptionalSecurityPolicySpec.
 func (r *AuthReconciler) buildSecurityPolicySpec(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) (egv1alpha1.SecurityPolicySpec, error) {
+	if nebariApp.Spec.Auth.BearerOnly {
+		return r.buildBearerOnlySecurityPolicySpec(ctx, nebariApp, provider)
+	}
+	if nebariApp.Spec.Auth.Optional {
+		return r.buildOptionalSecurityPolicySpec(ctx, nebariApp, provider)
+	}
+	return r.buildOIDCSecurityPolicySpec(ctx, nebariApp, provider)
+}
+
+// buildOIDCSecurityPolicySpec constructs the SecurityPolicy that logs users in
+// through the OIDC flow, also used for the step-up and login policies.
+func (r *AuthReconciler) buildOIDCSecurityPolicySpec(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) (egv1alpha1.SecurityPolicySpec, error) {
 	// Get provider-specific values
 	issuerURL, err := provider.GetIssuerURL(ctx, nebariApp)
 	if err != nil {
@@ -420,24 +1345,14 @@ func (r *AuthReconciler) buildSecurityPolicySpec(ctx context.Context, nebariApp
 	clientSecretName := naming.ClientSecretName(nebariApp)
 
 	// Determine redirect URL
-	redirectPath := constants.DefaultOAuthCallbackPath
-	if nebariApp.Spec.Auth.RedirectURI != "" {
-		redirectPath = nebariApp.Spec.Auth.RedirectURI
-	}
-	redirectURL := fmt.Sprintf("https://%s%s", nebariApp.Spec.Hostname, redirectPath)
-
-	// Target the HTTPRoute for this NebariApp
-	group := gwapiv1.Group("gateway.networking.k8s.io")
-	kind := gwapiv1.Kind("HTTPRoute")
-	routeName := gwapiv1.ObjectName(naming.HTTPRouteName(nebariApp))
-	httpRouteRef := gwapiv1.LocalPolicyTargetReferenceWithSectionName{
-		LocalPolicyTargetReference: gwapiv1.LocalPolicyTargetReference{
-			Group: group,
-			Kind:  kind,
-			Name:  routeName,
-		},
+	redirectURL := fmt.Sprintf("https://%s%s", nebariApp.Spec.Hostname, providers.RedirectPath(nebariApp))
+	if nebariApp.Spec.Auth.RedirectURLOverride != "" {
+		redirectURL = nebariApp.Spec.Auth.RedirectURLOverride
 	}
 
+	// Target the HTTPRoute for this NebariApp on every Gateway it is exposed on
+	httpRouteRefs := httpRouteTargetRefs(naming.HTTPRouteNames(nebariApp))
+
 	// Secret reference for OIDC client credentials
 	secretGroup := gwapiv1.Group("")
 	secretKind := gwapiv1.Kind("Secret")
@@ -459,15 +1374,55 @@ func (r *AuthReconciler) buildSecurityPolicySpec(ctx context.Context, nebariApp
 		log.FromContext(ctx).Info("Overriding OIDC endpoint from discovery", "endpoint", "token", "url", *overrides.Token)
 		oidcProvider.TokenEndpoint = overrides.Token
 	}
+	// Route token requests through the Backend carrying the client certificate
+	// when the IdP requires mutual TLS. See reconcileIdPBackend.
+	if nebariApp.Spec.Auth.ClientTLSSecretRef != nil {
+		oidcProvider.BackendRefs = []egv1alpha1.BackendRef{{
+			BackendObjectReference: gwapiv1.BackendObjectReference{
+				Group: ptr.To(gwapiv1.Group(egv1alpha1.GroupName)),
+				Kind:  ptr.To(gwapiv1.Kind(egv1alpha1.KindBackend)),
+				Name:  gwapiv1.ObjectName(naming.IdPBackendName(nebariApp)),
+			},
+		}}
+	}
 	if overrides.Authorization != nil {
 		log.FromContext(ctx).Info("Overriding OIDC endpoint from discovery", "endpoint", "authorization", "url", *overrides.Authorization)
 		oidcProvider.AuthorizationEndpoint = overrides.Authorization
 	}
-	if overrides.EndSession != nil {
+	oidcProvider.Issuer = policyIssuer(ctx, nebariApp, provider, issuerURL, oidcProvider)
+	// Surface the issuer Envoy validates against so OIDC issues can be debugged from status
+	nebariApp.Status.IssuerURL = oidcProvider.Issuer
+	if params := nebariApp.Spec.Auth.ExtraAuthParams; len(params) > 0 {
+		if oidcProvider.AuthorizationEndpoint == nil {
+			return egv1alpha1.SecurityPolicySpec{}, fmt.Errorf("extraAuthParams requires an explicit authorization endpoint, which provider %q does not supply",
+				r.providerName(nebariApp))
+		}
+		endpoint, err := withQueryParams(*oidcProvider.AuthorizationEndpoint, params)
+		if err != nil {
+			return egv1alpha1.SecurityPolicySpec{}, err
+		}
+		oidcProvider.AuthorizationEndpoint = ptr.To(endpoint)
+	}
+	// RP-initiated logout: with an end session endpoint, Envoy's logout path
+	// sends the browser there with the session's id_token_hint, ending the
+	// IdP's SSO session. Envoy adds client_id and post_logout_redirect_uri (the
+	// app's root URL) itself, so nothing is appended to the endpoint. It is only
+	// set when logout.endSSOSession asks for it; otherwise logout clears the
+	// app's cookies and returns to the app's root URL.
+	endSSOSession := nebariApp.Spec.Auth.Logout != nil && nebariApp.Spec.Auth.Logout.EndSSOSession
+	if overrides.EndSession != nil && endSSOSession {
 		log.FromContext(ctx).Info("Overriding OIDC endpoint from discovery", "endpoint", "endSession", "url", *overrides.EndSession)
 		oidcProvider.EndSessionEndpoint = overrides.EndSession
 	}
+	if endSSOSession && oidcProvider.EndSessionEndpoint == nil {
+		return egv1alpha1.SecurityPolicySpec{}, fmt.Errorf("logout.endSSOSession requires an explicit end session endpoint, which provider %q does not supply",
+			r.providerName(nebariApp))
+	}
 
+	// Envoy Gateway requires clientSecret on every OIDC SecurityPolicy (it is not
+	// optional as of v1.6), so the client the gateway uses is always confidential.
+	// Public clients are only provisioned alongside it, via spaClient and
+	// deviceFlowClient.
 	oidcConfig := &egv1alpha1.OIDC{
 		Provider: oidcProvider,
 		ClientID: ptr.To(clientID),
@@ -482,10 +1437,10 @@ func (r *AuthReconciler) buildSecurityPolicySpec(ctx context.Context, nebariApp
 	}
 
 	// Set OIDC scopes
-	if len(nebariApp.Spec.Auth.Scopes) > 0 {
-		oidcConfig.Scopes = nebariApp.Spec.Auth.Scopes
+	if scopes := providers.RequestedScopes(nebariApp.Spec.Auth); len(scopes) > 0 {
+		oidcConfig.Scopes = slices.Clone(scopes)
 	} else {
-		oidcConfig.Scopes = []string{"openid", "profile", "email"}
+		oidcConfig.Scopes = slices.Clone(providers.DefaultScopes)
 	}
 
 	// Forward the OAuth2 access token to the upstream as Authorization: Bearer
@@ -523,14 +1478,176 @@ func (r *AuthReconciler) buildSecurityPolicySpec(ctx context.Context, nebariApp
 
 	spec := egv1alpha1.SecurityPolicySpec{
 		PolicyTargetReferences: egv1alpha1.PolicyTargetReferences{
-			TargetRefs: []gwapiv1.LocalPolicyTargetReferenceWithSectionName{httpRouteRef},
+			TargetRefs: httpRouteRefs,
 		},
 		OIDC: oidcConfig,
 	}
 
+	// Combined mode: with passThroughAuthHeader the OIDC filter skips the login
+	// redirect for requests that carry a bearer token, and the JWT filter then
+	// validates that token, rejecting the request if it is invalid. Browser
+	// requests without a token still go through the OIDC flow.
+	if jwtConfig := nebariApp.Spec.Auth.JWT; jwtConfig != nil && jwtConfig.Enabled {
+		jwt, err := buildJWT(ctx, nebariApp, provider, issuerURL, overrides.JWKS)
+		if err != nil {
+			return egv1alpha1.SecurityPolicySpec{}, err
+		}
+		spec.JWT = jwt
+		oidcConfig.PassThroughAuthHeader = ptr.To(true)
+
+		// Claim rules match the validated JWT. Browser sessions only carry an
+		// encrypted cookie, so unless the user set forwardAccessToken the
+		// access token is forwarded for the JWT filter to check as well;
+		// validateGatewayAuthorization refuses an explicit false.
+		if authorization := buildAuthorization(nebariApp); authorization != nil {
+			spec.Authorization = authorization
+			if oidcConfig.ForwardAccessToken == nil {
+				oidcConfig.ForwardAccessToken = ptr.To(true)
+			}
+		}
+	}
+
 	return spec, nil
 }
 
+// buildBearerOnlySecurityPolicySpec constructs the SecurityPolicy for an
+// auth.bearerOnly app: bearer JWT validation without an OIDC filter. Requests
+// without a valid token are rejected with 401 instead of being redirected to
+// the IdP, which is what API clients expect.
+func (r *AuthReconciler) buildBearerOnlySecurityPolicySpec(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) (egv1alpha1.SecurityPolicySpec, error) {
+	issuerURL, err := provider.GetIssuerURL(ctx, nebariApp)
+	if err != nil {
+		return egv1alpha1.SecurityPolicySpec{}, fmt.Errorf("failed to get issuer URL: %w", err)
+	}
+	overrides, err := provider.GetEndpointOverrides(ctx, nebariApp)
+	if err != nil {
+		return egv1alpha1.SecurityPolicySpec{}, fmt.Errorf("failed to get endpoint overrides: %w", err)
+	}
+
+	jwt, err := buildJWT(ctx, nebariApp, provider, issuerURL, overrides.JWKS)
+	if err != nil {
+		return egv1alpha1.SecurityPolicySpec{}, err
+	}
+	// Surface the issuer tokens are validated against, as the OIDC mode does
+	nebariApp.Status.IssuerURL = jwt.Providers[0].Issuer
+
+	return egv1alpha1.SecurityPolicySpec{
+		PolicyTargetReferences: egv1alpha1.PolicyTargetReferences{
+			TargetRefs: httpRouteTargetRefs(naming.HTTPRouteNames(nebariApp)),
+		},
+		JWT:           jwt,
+		Authorization: buildAuthorization(nebariApp),
+	}, nil
+}
+
+// buildAuthorization returns deny-by-default authorization that admits requests
+// whose JWT lists one of auth.groups in the groups claim, when
+// auth.enforceGroupsAtGateway is set, or has one of auth.allowedEmailDomains in
+// the email domain claim. It returns nil when there is nothing to enforce.
+func buildAuthorization(nebariApp *appsv1.NebariApp) *egv1alpha1.Authorization {
+	auth := nebariApp.Spec.Auth
+	provider := naming.ClientID(nebariApp)
+
+	var rules []egv1alpha1.AuthorizationRule
+	claimRule := func(name, claim string, valueType egv1alpha1.JWTClaimValueType, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		rules = append(rules, egv1alpha1.AuthorizationRule{
+			Name:   ptr.To(name),
+			Action: egv1alpha1.AuthorizationActionAllow,
+			Principal: egv1alpha1.Principal{
+				JWT: &egv1alpha1.JWTPrincipal{
+					Provider: provider,
+					Claims: []egv1alpha1.JWTClaim{{
+						Name:      claim,
+						ValueType: ptr.To(valueType),
+						Values:    values,
+					}},
+				},
+			},
+		})
+	}
+	if auth.EnforceGroupsAtGateway {
+		claimRule("allow-groups", naming.GroupsClaim(nebariApp), egv1alpha1.JWTClaimValueTypeStringArray, auth.Groups)
+	}
+	claimRule("allow-email-domains", auth.EmailDomainClaim, egv1alpha1.JWTClaimValueTypeString, auth.AllowedEmailDomains)
+
+	if len(rules) == 0 {
+		return nil
+	}
+	return &egv1alpha1.Authorization{
+		DefaultAction: ptr.To(egv1alpha1.AuthorizationActionDeny),
+		Rules:         rules,
+	}
+}
+
+// policyIssuer returns the issuer to write to the SecurityPolicy. Envoy Gateway
+// only fetches the discovery document from the issuer when the authorization or
+// token endpoint is missing, so the in-cluster issuer (discoveryURL) must be kept
+// in that case. Once both endpoints are explicit no discovery happens and the
+// provider's external issuer is used instead, matching the iss claim of tokens
+// minted for browsers while token requests still go to the in-cluster endpoint.
+func policyIssuer(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider, discoveryURL string, oidcProvider egv1alpha1.OIDCProvider) string {
+	if oidcProvider.AuthorizationEndpoint == nil || oidcProvider.TokenEndpoint == nil {
+		return discoveryURL
+	}
+	external, err := provider.GetExternalIssuerURL(ctx, nebariApp)
+	if err != nil || external == "" {
+		return discoveryURL
+	}
+	if external != discoveryURL {
+		log.FromContext(ctx).V(1).Info("Using external issuer on SecurityPolicy", "issuer", external, "discoveryURL", discoveryURL)
+	}
+	return external
+}
+
+// buildJWT constructs the JWT provider used in combined OIDC+JWT mode and in
+// bearer-only mode.
+// The expected token issuer is the provider's external issuer when it has one,
+// since that is what browsers and CLIs obtain tokens from; otherwise the
+// in-cluster issuer. auth.jwt.jwksURI wins over the provider's key set URL.
+func buildJWT(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider, issuerURL string, providerJWKS *string) (*egv1alpha1.JWT, error) {
+	var jwksURI string
+	var audiences []string
+	if jwtConfig := nebariApp.Spec.Auth.JWT; jwtConfig != nil {
+		jwksURI = jwtConfig.JWKSURI
+		audiences = jwtConfig.Audiences
+	}
+	if jwksURI == "" && providerJWKS != nil {
+		jwksURI = *providerJWKS
+	}
+	if jwksURI == "" {
+		return nil, fmt.Errorf("auth.jwt.jwksURI is required for provider %q", nebariApp.Spec.Auth.Provider)
+	}
+
+	tokenIssuer := issuerURL
+	if external, err := provider.GetExternalIssuerURL(ctx, nebariApp); err == nil && external != "" {
+		tokenIssuer = external
+	}
+
+	jwtProvider := egv1alpha1.JWTProvider{
+		Name:      naming.ClientID(nebariApp),
+		Issuer:    tokenIssuer,
+		Audiences: audiences,
+		RemoteJWKS: &egv1alpha1.RemoteJWKS{
+			URI: jwksURI,
+		},
+	}
+	// Copy the claim routing.rateLimit.byClaim keys on into the header the
+	// rate limit BackendTrafficPolicy selects on.
+	if routing := nebariApp.Spec.Routing; routing != nil && routing.RateLimit != nil && routing.RateLimit.ByClaim != "" {
+		jwtProvider.ClaimToHeaders = []egv1alpha1.ClaimToHeader{{
+			Header: naming.RateLimitClaimHeader(routing.RateLimit.ByClaim),
+			Claim:  routing.RateLimit.ByClaim,
+		}}
+	}
+
+	return &egv1alpha1.JWT{
+		Providers: []egv1alpha1.JWTProvider{jwtProvider},
+	}, nil
+}
+
 // reconcileTokenExchange discovers all other NebariApp OIDC clients in the same
 // Keycloak realm and configures token exchange permissions on this client.
 func (r *AuthReconciler) reconcileTokenExchange(ctx context.Context, nebariApp *appsv1.NebariApp, provider providers.OIDCProvider) error {
@@ -556,11 +1673,7 @@ func (r *AuthReconciler) reconcileTokenExchange(ctx context.Context, nebariApp *
 		if peer.Spec.Auth == nil || !peer.Spec.Auth.Enabled {
 			continue
 		}
-		peerProvider := peer.Spec.Auth.Provider
-		if peerProvider == "" {
-			peerProvider = constants.ProviderKeycloak
-		}
-		if peerProvider != constants.ProviderKeycloak {
+		if r.providerName(peer) != constants.ProviderKeycloak {
 			continue
 		}
 
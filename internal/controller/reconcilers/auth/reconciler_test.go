@@ -20,22 +20,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
 	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
 	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/auth/providers"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/conditions"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // verifyEndpointOverrides checks that the SecurityPolicy's endpoint overrides match expectations.
@@ -61,14 +71,22 @@ func verifyOptionalEndpoint(t *testing.T, name string, actual, expected *string)
 // mockProvider implements OIDCProvider for testing
 type mockProvider struct {
 	issuerURL              string
+	externalIssuerURL      string // defaults to issuerURL when empty
 	endpointOverrides      providers.OIDCEndpointOverrides
 	endpointOverridesError error
 	clientID               string
 	supportsProvisioning   bool
 	provisionError         error
 	deleteError            error
+	deleteCount            int // tracks how many times DeleteClient was called
 	issuerError            error
 	provisionCount         int // tracks how many times ProvisionClient was called
+	secretResynced         bool
+	syncError              error
+	syncCount              int // tracks how many times SyncClientSecret was called
+	unknownScopes          []string
+	unknownScopesError     error
+	unknownScopesCount     int
 }
 
 func (m *mockProvider) GetIssuerURL(ctx context.Context, nebariApp *appsv1.NebariApp) (string, error) {
@@ -86,6 +104,9 @@ func (m *mockProvider) GetExternalIssuerURL(ctx context.Context, nebariApp *apps
 	if m.issuerError != nil {
 		return "", m.issuerError
 	}
+	if m.externalIssuerURL != "" {
+		return m.externalIssuerURL, nil
+	}
 	return m.issuerURL, nil
 }
 
@@ -99,6 +120,7 @@ func (m *mockProvider) ProvisionClient(ctx context.Context, nebariApp *appsv1.Ne
 }
 
 func (m *mockProvider) DeleteClient(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	m.deleteCount++
 	return m.deleteError
 }
 
@@ -114,6 +136,16 @@ func (m *mockProvider) CleanupTokenExchange(ctx context.Context, nebariApp *apps
 	return nil
 }
 
+func (m *mockProvider) SyncClientSecret(ctx context.Context, nebariApp *appsv1.NebariApp) (bool, error) {
+	m.syncCount++
+	return m.secretResynced, m.syncError
+}
+
+func (m *mockProvider) UnknownScopes(ctx context.Context, nebariApp *appsv1.NebariApp) ([]string, error) {
+	m.unknownScopesCount++
+	return m.unknownScopes, m.unknownScopesError
+}
+
 func TestGetProvider(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = appsv1.AddToScheme(scheme)
@@ -228,6 +260,71 @@ func TestGetProvider(t *testing.T) {
 	}
 }
 
+func TestGetProvider_ConfiguredDefault(t *testing.T) {
+	keycloakProvider := &mockProvider{issuerURL: "https://keycloak.example.com/realms/test"}
+	genericProvider := &mockProvider{issuerURL: "https://accounts.google.com"}
+	registered := map[string]providers.OIDCProvider{
+		constants.ProviderKeycloak:    keycloakProvider,
+		constants.ProviderGenericOIDC: genericProvider,
+	}
+
+	tests := []struct {
+		name             string
+		defaultProvider  string
+		appProvider      string
+		expectError      bool
+		expectedProvider providers.OIDCProvider
+	}{
+		{
+			name:             "Configured default used when provider is empty",
+			defaultProvider:  constants.ProviderGenericOIDC,
+			expectedProvider: genericProvider,
+		},
+		{
+			name:             "Explicit provider overrides configured default",
+			defaultProvider:  constants.ProviderGenericOIDC,
+			appProvider:      constants.ProviderKeycloak,
+			expectedProvider: keycloakProvider,
+		},
+		{
+			name:             "Unset default falls back to keycloak",
+			expectedProvider: keycloakProvider,
+		},
+		{
+			name:            "Unregistered default is rejected",
+			defaultProvider: "unsupported-provider",
+			expectError:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler := &AuthReconciler{
+				Providers:       registered,
+				DefaultProvider: tt.defaultProvider,
+			}
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Auth: &appsv1.AuthConfig{Enabled: true, Provider: tt.appProvider},
+				},
+			}
+
+			provider, err := reconciler.getProvider(nebariApp)
+
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+			if !tt.expectError && provider != tt.expectedProvider {
+				t.Errorf("expected provider %v, got %v", tt.expectedProvider, provider)
+			}
+		})
+	}
+}
+
 func TestValidateAuthConfig(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
@@ -330,6 +427,156 @@ func TestValidateAuthConfig(t *testing.T) {
 	}
 }
 
+func TestValidateAuthConfig_LegacyKey(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name        string
+		data        map[string][]byte
+		expectError bool
+	}{
+		{
+			name: "Legacy key only is refused",
+			data: map[string][]byte{
+				constants.ClientIDKey:           []byte("test-client"),
+				constants.LegacyClientSecretKey: []byte("legacy-secret"),
+			},
+			expectError: true,
+		},
+		{
+			name: "Configured key next to legacy key is accepted",
+			data: map[string][]byte{
+				constants.ClientSecretKey:       []byte("current-secret"),
+				constants.LegacyClientSecretKey: []byte("legacy-secret"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: naming.ClientSecretName(nebariApp), Namespace: "default"},
+				Data:       tt.data,
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp, secret).Build()
+
+			reconciler := &AuthReconciler{
+				Client:   client,
+				Scheme:   scheme,
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			err := reconciler.validateAuthConfig(context.Background(), nebariApp)
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+
+			// The Secret may be user-owned and must never be rewritten
+			updated := &corev1.Secret{}
+			if err := client.Get(context.Background(), types.NamespacedName{Name: secret.Name, Namespace: "default"}, updated); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			if !reflect.DeepEqual(updated.Data, tt.data) {
+				t.Errorf("expected secret data to be unchanged, got %v", updated.Data)
+			}
+		})
+	}
+}
+
+func TestReconcileAuth_ChecksClientSecretBeforeProvider(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name           string
+		secretData     map[string][]byte
+		expectedReason string
+		expectEvent    bool
+	}{
+		{
+			name:           "Missing secret is rejected before the provider is resolved",
+			expectedReason: appsv1.ReasonAuthValidationFailed,
+			expectEvent:    true,
+		},
+		{
+			name:           "Secret without the client secret key is rejected",
+			secretData:     map[string][]byte{constants.ClientIDKey: []byte("test-client")},
+			expectedReason: appsv1.ReasonAuthValidationFailed,
+			expectEvent:    true,
+		},
+		{
+			name:           "Valid secret passes on to provider resolution",
+			secretData:     map[string][]byte{constants.ClientSecretKey: []byte("test-secret")},
+			expectedReason: appsv1.ReasonInvalidProvider,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:         true,
+						Provider:        constants.ProviderKeycloak,
+						ProvisionClient: ptr.To(false),
+					},
+				},
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp)
+			if tt.secretData != nil {
+				builder = builder.WithObjects(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: naming.ClientSecretName(nebariApp), Namespace: "default"},
+					Data:       tt.secretData,
+				})
+			}
+
+			// No providers are registered, so getting past the secret check
+			// surfaces as InvalidProvider.
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &AuthReconciler{
+				Client:    builder.Build(),
+				Scheme:    scheme,
+				Recorder:  recorder,
+				Providers: map[string]providers.OIDCProvider{},
+			}
+
+			if err := reconciler.ReconcileAuth(context.Background(), nebariApp); err == nil {
+				t.Fatal("expected an error")
+			}
+
+			cond := conditions.GetCondition(nebariApp, appsv1.ConditionTypeAuthReady)
+			if cond == nil || cond.Reason != tt.expectedReason {
+				t.Fatalf("expected AuthReady reason %q, got %+v", tt.expectedReason, cond)
+			}
+			if tt.expectEvent && !strings.Contains(cond.Message, naming.ClientSecretName(nebariApp)) {
+				t.Errorf("expected condition message to name the secret, got %q", cond.Message)
+			}
+
+			var gotEvent bool
+			close(recorder.Events)
+			for event := range recorder.Events {
+				if strings.Contains(event, appsv1.EventReasonClientSecretInvalid) {
+					gotEvent = true
+				}
+			}
+			if gotEvent != tt.expectEvent {
+				t.Errorf("expected %s event=%v, got %v", appsv1.EventReasonClientSecretInvalid, tt.expectEvent, gotEvent)
+			}
+		})
+	}
+}
+
 func TestBuildSecurityPolicySpec(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = appsv1.AddToScheme(scheme)
@@ -601,954 +848,3499 @@ func TestBuildSecurityPolicySpec(t *testing.T) {
 	}
 }
 
-// TestBuildSecurityPolicySpec_ForwardAccessToken covers the forwardAccessToken
-// passthrough in isolation. Kept separate from TestBuildSecurityPolicySpec
-// to keep that table-driven test below the gocyclo complexity threshold.
-func TestBuildSecurityPolicySpec_ForwardAccessToken(t *testing.T) {
+// TestBuildSecurityPolicySpec_RedirectURLOverride verifies that an absolute
+// redirectURLOverride replaces the computed https://<hostname><path> URL.
+func TestBuildSecurityPolicySpec_RedirectURLOverride(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = appsv1.AddToScheme(scheme)
 	_ = egv1alpha1.AddToScheme(scheme)
-	_ = corev1.AddToScheme(scheme)
 
-	tests := []struct {
-		name             string
-		forwardAccessTok *bool
-		want             *bool
-	}{
-		{
-			name:             "true sets OIDC field to true",
-			forwardAccessTok: ptr.To(true),
-			want:             ptr.To(true),
-		},
-		{
-			name:             "false sets OIDC field to false (explicit opt-out)",
-			forwardAccessTok: ptr.To(false),
-			want:             ptr.To(false),
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:             true,
+				Provider:            constants.ProviderKeycloak,
+				RedirectURI:         "/custom/callback",
+				RedirectURLOverride: "https://cdn.example.com/oauth2/callback",
+			},
 		},
-		{
-			name:             "unset leaves OIDC field nil so Envoy default applies",
-			forwardAccessTok: nil,
-			want:             nil,
+	}
+	reconciler := &AuthReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+	provider := &mockProvider{
+		issuerURL: "https://keycloak.example.com/realms/test",
+		clientID:  "test-client",
+	}
+
+	spec, err := reconciler.buildSecurityPolicySpec(context.Background(), app, provider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.OIDC == nil || spec.OIDC.RedirectURL == nil {
+		t.Fatal("expected OIDC redirect URL to be set")
+	}
+	if *spec.OIDC.RedirectURL != "https://cdn.example.com/oauth2/callback" {
+		t.Errorf("expected redirectURL https://cdn.example.com/oauth2/callback, got %s", *spec.OIDC.RedirectURL)
+	}
+}
+
+func TestBuildSecurityPolicySpec_StatusIssuerURL(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:  true,
+				Provider: constants.ProviderKeycloak,
+			},
 		},
 	}
+	reconciler := &AuthReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+	provider := &mockProvider{
+		issuerURL: "http://keycloak.keycloak.svc.cluster.local:8080/realms/test",
+		clientID:  "test-client",
+	}
+
+	spec, err := reconciler.buildSecurityPolicySpec(context.Background(), app, provider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected, _ := provider.GetIssuerURL(context.Background(), app)
+	if app.Status.IssuerURL != expected {
+		t.Errorf("expected status issuerURL %q, got %q", expected, app.Status.IssuerURL)
+	}
+	if spec.OIDC.Provider.Issuer != app.Status.IssuerURL {
+		t.Errorf("expected status issuerURL to match SecurityPolicy issuer %q, got %q", spec.OIDC.Provider.Issuer, app.Status.IssuerURL)
+	}
+
+	// Disabling auth clears the issuer again
+	app.Spec.Auth.Enabled = false
+	if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if app.Status.IssuerURL != "" {
+		t.Errorf("expected status issuerURL to be cleared, got %q", app.Status.IssuerURL)
+	}
+}
+
+func TestValidateRedirectURLOverride(t *testing.T) {
+	tests := []struct {
+		name        string
+		override    string
+		expectError bool
+	}{
+		{name: "empty is allowed", override: "", expectError: false},
+		{name: "absolute https URL", override: "https://cdn.example.com/oauth2/callback", expectError: false},
+		{name: "absolute http URL", override: "http://localhost:8080/callback", expectError: false},
+		{name: "relative path", override: "/oauth2/callback", expectError: true},
+		{name: "missing host", override: "https:///oauth2/callback", expectError: true},
+		{name: "unsupported scheme", override: "ftp://cdn.example.com/callback", expectError: true},
+		{name: "unparseable URL", override: "https://cdn.example.com/%zz", expectError: true},
+	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := &AuthReconciler{
-				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
-				Scheme: scheme,
-			}
-			nebariApp := &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
-				Spec: appsv1.NebariAppSpec{
-					Hostname: "test.example.com",
-					Auth: &appsv1.AuthConfig{
-						Enabled:            true,
-						Provider:           constants.ProviderKeycloak,
-						ForwardAccessToken: tt.forwardAccessTok,
-					},
-				},
+			err := validateRedirectURLOverride(tt.override)
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
 			}
-			provider := &mockProvider{
-				issuerURL: "https://keycloak.example.com/realms/test",
-				clientID:  "test-client",
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
 			}
+		})
+	}
+}
 
-			spec, err := r.buildSecurityPolicySpec(context.Background(), nebariApp, provider)
-			if err != nil {
-				t.Fatalf("buildSecurityPolicySpec returned error: %v", err)
+func TestNormalizeRedirectURI(t *testing.T) {
+	tests := []struct {
+		name        string
+		redirectURI string
+		hasOverride bool
+		expected    string
+		expectError bool
+	}{
+		{name: "empty uses the default", redirectURI: "", expected: "/oauth2/callback"},
+		{name: "path kept as is", redirectURI: "/auth/callback", expected: "/auth/callback"},
+		{name: "missing leading slash added", redirectURI: "auth/callback", expected: "/auth/callback"},
+		{name: "trailing slash dropped", redirectURI: "/auth/callback/", expected: "/auth/callback"},
+		{name: "absolute URL rejected", redirectURI: "https://app.example.com/callback", expectError: true},
+		{name: "absolute URL tolerated with override", redirectURI: "https://app.example.com/callback", hasOverride: true, expected: "/oauth2/callback"},
+		{name: "protocol-relative path rejected", redirectURI: "//app.example.com/callback", expectError: true},
+		{name: "query rejected", redirectURI: "/callback?next=/", expectError: true},
+		{name: "fragment rejected", redirectURI: "/callback#top", expectError: true},
+		{name: "whitespace rejected", redirectURI: "/auth callback", expectError: true},
+		{name: "root rejected", redirectURI: "/", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := providers.NormalizeRedirectURI(tt.redirectURI, tt.hasOverride)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error, got path %q", path)
+				}
+				return
 			}
-			if spec.OIDC == nil {
-				t.Fatal("OIDC config is nil")
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
 			}
-			if !reflect.DeepEqual(spec.OIDC.ForwardAccessToken, tt.want) {
-				got := "nil"
-				if spec.OIDC.ForwardAccessToken != nil {
-					got = fmt.Sprintf("%v", *spec.OIDC.ForwardAccessToken)
-				}
-				wantStr := "nil"
-				if tt.want != nil {
-					wantStr = fmt.Sprintf("%v", *tt.want)
-				}
-				t.Errorf("ForwardAccessToken = %s, want %s", got, wantStr)
+			if path != tt.expected {
+				t.Errorf("expected path %q, got %q", tt.expected, path)
 			}
 		})
 	}
 }
 
-func TestReconcileAuth(t *testing.T) {
+// TestBuildSecurityPolicySpec_NormalizedRedirectURI verifies that the redirect
+// URL is built from the normalized redirectURI path.
+func TestBuildSecurityPolicySpec_NormalizedRedirectURI(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = appsv1.AddToScheme(scheme)
-	_ = corev1.AddToScheme(scheme)
-	_ = rbacv1.AddToScheme(scheme)
 	_ = egv1alpha1.AddToScheme(scheme)
 
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth:     &appsv1.AuthConfig{Enabled: true, Provider: constants.ProviderKeycloak, RedirectURI: "auth/callback/"},
+		},
+	}
+
+	reconciler := &AuthReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+	provider := &mockProvider{
+		issuerURL: "https://keycloak.example.com/realms/test",
+		clientID:  "test-client",
+	}
+
+	spec, err := reconciler.buildSecurityPolicySpec(context.Background(), nebariApp, provider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.OIDC == nil || spec.OIDC.RedirectURL == nil {
+		t.Fatal("expected OIDC redirect URL to be set")
+	}
+	if *spec.OIDC.RedirectURL != "https://test.example.com/auth/callback" {
+		t.Errorf("expected normalized redirect URL, got %s", *spec.OIDC.RedirectURL)
+	}
+}
+
+func TestBuildSecurityPolicySpec_ExternalIssuer(t *testing.T) {
+	const (
+		internalIssuer = "http://keycloak.keycloak.svc.cluster.local:8080/realms/test"
+		externalIssuer = "https://keycloak.example.com/realms/test"
+	)
+
 	tests := []struct {
-		name                   string
-		nebariApp              *appsv1.NebariApp
-		existingSecret         *corev1.Secret
-		existingSecurityPolicy *egv1alpha1.SecurityPolicy
-		provider               *mockProvider
-		expectedOverrides      providers.OIDCEndpointOverrides
-		expectError            bool
-		// validate runs additional assertions after reconciliation (optional)
-		validate func(*testing.T, *mockProvider, *appsv1.NebariApp)
+		name           string
+		overrides      providers.OIDCEndpointOverrides
+		externalIssuer string
+		expectIssuer   string
 	}{
 		{
-			name: "Auth not enabled",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
+			name: "explicit endpoints validate against the external issuer",
+			overrides: providers.OIDCEndpointOverrides{
+				Token:         ptr.To(internalIssuer + "/protocol/openid-connect/token"),
+				Authorization: ptr.To(externalIssuer + "/protocol/openid-connect/auth"),
+			},
+			externalIssuer: externalIssuer,
+			expectIssuer:   externalIssuer,
+		},
+		{
+			name: "missing authorization endpoint keeps the discovery issuer",
+			overrides: providers.OIDCEndpointOverrides{
+				Token: ptr.To(internalIssuer + "/protocol/openid-connect/token"),
+			},
+			externalIssuer: externalIssuer,
+			expectIssuer:   internalIssuer,
+		},
+		{
+			name:           "no overrides keeps the discovery issuer",
+			externalIssuer: externalIssuer,
+			expectIssuer:   internalIssuer,
+		},
+		{
+			name: "provider without an external issuer keeps the discovery issuer",
+			overrides: providers.OIDCEndpointOverrides{
+				Token:         ptr.To(internalIssuer + "/protocol/openid-connect/token"),
+				Authorization: ptr.To(internalIssuer + "/protocol/openid-connect/auth"),
+			},
+			expectIssuer: internalIssuer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = appsv1.AddToScheme(scheme)
+			_ = egv1alpha1.AddToScheme(scheme)
+
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
 				Spec: appsv1.NebariAppSpec{
 					Hostname: "test.example.com",
 					Auth: &appsv1.AuthConfig{
-						Enabled: false,
+						Enabled:  true,
+						Provider: constants.ProviderKeycloak,
 					},
 				},
-			},
-			expectError: false,
+			}
+			reconciler := &AuthReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+				Scheme:   scheme,
+				Recorder: record.NewFakeRecorder(10),
+			}
+			provider := &mockProvider{
+				issuerURL:         internalIssuer,
+				externalIssuerURL: tt.externalIssuer,
+				endpointOverrides: tt.overrides,
+				clientID:          "test-client",
+			}
+
+			spec, err := reconciler.buildSecurityPolicySpec(context.Background(), app, provider)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			p := spec.OIDC.Provider
+			if p.Issuer != tt.expectIssuer {
+				t.Errorf("expected issuer %q, got %q", tt.expectIssuer, p.Issuer)
+			}
+			if app.Status.IssuerURL != tt.expectIssuer {
+				t.Errorf("expected status issuerURL %q, got %q", tt.expectIssuer, app.Status.IssuerURL)
+			}
+			// Token requests stay in-cluster whichever issuer is validated.
+			verifyOptionalEndpoint(t, "token", p.TokenEndpoint, tt.overrides.Token)
+			verifyOptionalEndpoint(t, "authorization", p.AuthorizationEndpoint, tt.overrides.Authorization)
+		})
+	}
+}
+
+func TestBuildSecurityPolicySpec_JWTCombinedMode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	issuer := "https://keycloak.example.com/realms/test"
+	providerJWKS := "http://keycloak.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/certs"
+
+	tests := []struct {
+		name             string
+		jwt              *appsv1.JWTAuthConfig
+		rateLimitClaim   string
+		providerJWKS     *string
+		expectJWT        bool
+		expectedJWKSURI  string
+		expectedAudience []string
+		expectedClaims   []egv1alpha1.ClaimToHeader
+		expectError      bool
+	}{
+		{
+			name:      "JWT not configured keeps OIDC only",
+			jwt:       nil,
+			expectJWT: false,
 		},
 		{
-			name: "Auth disabled with pre-existing SecurityPolicy - deletes it",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
+			name:      "JWT disabled keeps OIDC only",
+			jwt:       &appsv1.JWTAuthConfig{Enabled: false},
+			expectJWT: false,
+		},
+		{
+			name:            "JWT enabled uses the provider key set and checks no audience",
+			jwt:             &appsv1.JWTAuthConfig{Enabled: true},
+			providerJWKS:    ptr.To(providerJWKS),
+			expectJWT:       true,
+			expectedJWKSURI: providerJWKS,
+		},
+		{
+			name:             "explicit jwksURI and audiences win",
+			jwt:              &appsv1.JWTAuthConfig{Enabled: true, JWKSURI: "https://idp.example.com/jwks", Audiences: []string{"api"}},
+			providerJWKS:     ptr.To(providerJWKS),
+			expectJWT:        true,
+			expectedJWKSURI:  "https://idp.example.com/jwks",
+			expectedAudience: []string{"api"},
+		},
+		{
+			name:             "several audiences are rendered for any-of matching",
+			jwt:              &appsv1.JWTAuthConfig{Enabled: true, Audiences: []string{"test-client", "shared-api"}},
+			providerJWKS:     ptr.To(providerJWKS),
+			expectJWT:        true,
+			expectedJWKSURI:  providerJWKS,
+			expectedAudience: []string{"test-client", "shared-api"},
+		},
+		{
+			name:            "rateLimit.byClaim copies the claim into a header",
+			jwt:             &appsv1.JWTAuthConfig{Enabled: true},
+			rateLimitClaim:  "sub",
+			providerJWKS:    ptr.To(providerJWKS),
+			expectJWT:       true,
+			expectedJWKSURI: providerJWKS,
+			expectedClaims:  []egv1alpha1.ClaimToHeader{{Header: "x-nebari-claim-sub", Claim: "sub"}},
+		},
+		{
+			name:        "no key set available",
+			jwt:         &appsv1.JWTAuthConfig{Enabled: true},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
 				Spec: appsv1.NebariAppSpec{
 					Hostname: "test.example.com",
 					Auth: &appsv1.AuthConfig{
-						Enabled: false,
+						Enabled:  true,
+						Provider: constants.ProviderKeycloak,
+						JWT:      tt.jwt,
 					},
 				},
+			}
+			if tt.rateLimitClaim != "" {
+				app.Spec.Routing = &appsv1.RoutingConfig{
+					RateLimit: &appsv1.RateLimitConfig{Requests: 10, ByClaim: tt.rateLimitClaim},
+				}
+			}
+			reconciler := &AuthReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+				Scheme: scheme,
+			}
+			provider := &mockProvider{
+				issuerURL:         issuer,
+				clientID:          "test-client",
+				endpointOverrides: providers.OIDCEndpointOverrides{JWKS: tt.providerJWKS},
+			}
+
+			spec, err := reconciler.buildSecurityPolicySpec(context.Background(), app, provider)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if spec.OIDC == nil {
+				t.Fatal("expected OIDC block to be present")
+			}
+
+			if !tt.expectJWT {
+				if spec.JWT != nil {
+					t.Errorf("expected no JWT block, got %+v", spec.JWT)
+				}
+				if spec.OIDC.PassThroughAuthHeader != nil {
+					t.Errorf("expected passThroughAuthHeader unset, got %v", *spec.OIDC.PassThroughAuthHeader)
+				}
+				return
+			}
+
+			if spec.JWT == nil || len(spec.JWT.Providers) != 1 {
+				t.Fatalf("expected JWT block with one provider, got %+v", spec.JWT)
+			}
+			jwtProvider := spec.JWT.Providers[0]
+			if jwtProvider.Issuer != issuer {
+				t.Errorf("expected JWT issuer %q, got %q", issuer, jwtProvider.Issuer)
+			}
+			if jwtProvider.RemoteJWKS == nil || jwtProvider.RemoteJWKS.URI != tt.expectedJWKSURI {
+				t.Errorf("expected JWKS URI %q, got %+v", tt.expectedJWKSURI, jwtProvider.RemoteJWKS)
+			}
+			if !reflect.DeepEqual(jwtProvider.Audiences, tt.expectedAudience) {
+				t.Errorf("expected audiences %v, got %v", tt.expectedAudience, jwtProvider.Audiences)
+			}
+			if !reflect.DeepEqual(jwtProvider.ClaimToHeaders, tt.expectedClaims) {
+				t.Errorf("expected claimToHeaders %v, got %v", tt.expectedClaims, jwtProvider.ClaimToHeaders)
+			}
+			if spec.OIDC.PassThroughAuthHeader == nil || !*spec.OIDC.PassThroughAuthHeader {
+				t.Error("expected OIDC passThroughAuthHeader=true in combined mode")
+			}
+		})
+	}
+}
+
+func TestBuildSecurityPolicySpec_BearerOnly(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	issuer := "https://keycloak.example.com/realms/test"
+	providerJWKS := "http://keycloak.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/certs"
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "api.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:                true,
+				Provider:               constants.ProviderKeycloak,
+				BearerOnly:             true,
+				Groups:                 []string{"api-users"},
+				EnforceGroupsAtGateway: true,
 			},
-			existingSecurityPolicy: &egv1alpha1.SecurityPolicy{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      naming.SecurityPolicyName(&appsv1.NebariApp{ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"}}),
-					Namespace: "default",
+		},
+	}
+	reconciler := &AuthReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+		Scheme: scheme,
+	}
+	provider := &mockProvider{
+		issuerURL:         issuer,
+		clientID:          "test-client",
+		endpointOverrides: providers.OIDCEndpointOverrides{JWKS: ptr.To(providerJWKS)},
+	}
+
+	spec, err := reconciler.buildSecurityPolicySpec(context.Background(), app, provider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.OIDC != nil {
+		t.Errorf("expected no OIDC block in bearer-only mode, got %+v", spec.OIDC)
+	}
+	if spec.JWT == nil || len(spec.JWT.Providers) != 1 {
+		t.Fatalf("expected JWT block with one provider, got %+v", spec.JWT)
+	}
+	jwtProvider := spec.JWT.Providers[0]
+	if jwtProvider.Issuer != issuer {
+		t.Errorf("expected JWT issuer %q, got %q", issuer, jwtProvider.Issuer)
+	}
+	if jwtProvider.RemoteJWKS == nil || jwtProvider.RemoteJWKS.URI != providerJWKS {
+		t.Errorf("expected JWKS URI %q, got %+v", providerJWKS, jwtProvider.RemoteJWKS)
+	}
+	if len(jwtProvider.Audiences) != 0 {
+		t.Errorf("expected no audience check without jwt.audiences, got %v", jwtProvider.Audiences)
+	}
+	if spec.JWT.Optional != nil && *spec.JWT.Optional {
+		t.Error("expected requests without a bearer token to be rejected")
+	}
+	if len(spec.TargetRefs) != 1 || string(spec.TargetRefs[0].Name) != naming.HTTPRouteName(app) {
+		t.Errorf("expected the policy to target %q, got %+v", naming.HTTPRouteName(app), spec.TargetRefs)
+	}
+	if spec.Authorization == nil {
+		t.Error("expected group authorization to apply to bearer tokens")
+	}
+	if app.Status.IssuerURL != issuer {
+		t.Errorf("expected status issuerURL %q, got %q", issuer, app.Status.IssuerURL)
+	}
+}
+
+func TestValidateBearerOnly(t *testing.T) {
+	tests := []struct {
+		name        string
+		auth        appsv1.AuthConfig
+		expectError bool
+	}{
+		{name: "browser mode is not checked", auth: appsv1.AuthConfig{RedirectURI: "/callback", PKCE: true}},
+		{name: "bearer-only alone", auth: appsv1.AuthConfig{BearerOnly: true}},
+		{
+			name: "bearer-only with jwt settings",
+			auth: appsv1.AuthConfig{BearerOnly: true, JWT: &appsv1.JWTAuthConfig{Audiences: []string{"api"}}},
+		},
+		{name: "redirectURI", auth: appsv1.AuthConfig{BearerOnly: true, RedirectURI: "/callback"}, expectError: true},
+		{name: "pkce", auth: appsv1.AuthConfig{BearerOnly: true, PKCE: true}, expectError: true},
+		{name: "logout", auth: appsv1.AuthConfig{BearerOnly: true, Logout: &appsv1.LogoutConfig{}}, expectError: true},
+		{
+			name:        "forwardAccessToken",
+			auth:        appsv1.AuthConfig{BearerOnly: true, ForwardAccessToken: ptr.To(true)},
+			expectError: true,
+		},
+		{
+			name:        "stepUpScopes",
+			auth:        appsv1.AuthConfig{BearerOnly: true, StepUpScopes: []string{"admin"}, StepUpPaths: []string{"/admin"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBearerOnly(&tt.auth)
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestBuildSecurityPolicySpec_ClaimAuthorization(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	jwksURI := "https://keycloak.example.com/realms/test/protocol/openid-connect/certs"
+
+	rule := func(name, claim string, valueType egv1alpha1.JWTClaimValueType, values ...string) egv1alpha1.AuthorizationRule {
+		return egv1alpha1.AuthorizationRule{
+			Name:   ptr.To(name),
+			Action: egv1alpha1.AuthorizationActionAllow,
+			Principal: egv1alpha1.Principal{
+				JWT: &egv1alpha1.JWTPrincipal{
+					Provider: "default-test-app",
+					Claims: []egv1alpha1.JWTClaim{{
+						Name:      claim,
+						ValueType: ptr.To(valueType),
+						Values:    values,
+					}},
 				},
 			},
-			expectError: false,
+		}
+	}
+	claimRule := func(name, claim string, values ...string) egv1alpha1.AuthorizationRule {
+		return rule(name, claim, egv1alpha1.JWTClaimValueTypeStringArray, values...)
+	}
+	domainRule := func(claim string, domains ...string) egv1alpha1.AuthorizationRule {
+		return rule("allow-email-domains", claim, egv1alpha1.JWTClaimValueTypeString, domains...)
+	}
+
+	tests := []struct {
+		name             string
+		jwtEnabled       bool
+		groups           []string
+		enforceGroups    bool
+		emailDomains     []string
+		groupsClaim      string
+		emailDomainClaim string
+		expectedRules    []egv1alpha1.AuthorizationRule
+	}{
+		{
+			name:       "no groups leaves authorization unset",
+			jwtEnabled: true,
 		},
 		{
-			name: "Auth enabled with valid config",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
+			name:       "groups are not enforced at the gateway unless opted in",
+			jwtEnabled: true,
+			groups:     []string{"admins"},
+		},
+		{
+			name:          "enforced groups without JWT leave authorization unset",
+			groups:        []string{"admins"},
+			enforceGroups: true,
+		},
+		{
+			name:          "enforced groups use the default claim",
+			jwtEnabled:    true,
+			groups:        []string{"admins", "analysts"},
+			enforceGroups: true,
+			expectedRules: []egv1alpha1.AuthorizationRule{claimRule("allow-groups", "groups", "admins", "analysts")},
+		},
+		{
+			name:          "configured groups claim is used",
+			jwtEnabled:    true,
+			groups:        []string{"admins"},
+			enforceGroups: true,
+			groupsClaim:   "memberOf",
+			expectedRules: []egv1alpha1.AuthorizationRule{claimRule("allow-groups", "memberOf", "admins")},
+		},
+		{
+			name:             "email domains match the configured claim",
+			jwtEnabled:       true,
+			emailDomains:     []string{"example.com", "example.org"},
+			emailDomainClaim: "hd",
+			expectedRules:    []egv1alpha1.AuthorizationRule{domainRule("hd", "example.com", "example.org")},
+		},
+		{
+			name:             "email domains without JWT leave authorization unset",
+			emailDomains:     []string{"example.com"},
+			emailDomainClaim: "hd",
+		},
+		{
+			name:             "email domains alongside groups with a configured claim",
+			jwtEnabled:       true,
+			groups:           []string{"admins"},
+			enforceGroups:    true,
+			emailDomains:     []string{"example.com"},
+			emailDomainClaim: "hd",
+			expectedRules: []egv1alpha1.AuthorizationRule{
+				claimRule("allow-groups", "groups", "admins"),
+				domainRule("hd", "example.com"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
 				Spec: appsv1.NebariAppSpec{
 					Hostname: "test.example.com",
 					Auth: &appsv1.AuthConfig{
-						Enabled:         true,
-						Provider:        constants.ProviderKeycloak,
-						ProvisionClient: ptr.To(false),
+						Enabled:                true,
+						Provider:               constants.ProviderKeycloak,
+						Groups:                 tt.groups,
+						EnforceGroupsAtGateway: tt.enforceGroups,
+						AllowedEmailDomains:    tt.emailDomains,
+						GroupsClaim:            tt.groupsClaim,
+						EmailDomainClaim:       tt.emailDomainClaim,
+						JWT:                    &appsv1.JWTAuthConfig{Enabled: tt.jwtEnabled, JWKSURI: jwksURI},
 					},
 				},
+			}
+			reconciler := &AuthReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+				Scheme: scheme,
+			}
+			provider := &mockProvider{issuerURL: "https://keycloak.example.com/realms/test", clientID: "test-client"}
+
+			spec, err := reconciler.buildSecurityPolicySpec(context.Background(), app, provider)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.expectedRules == nil {
+				if spec.Authorization != nil {
+					t.Errorf("expected no authorization, got %+v", spec.Authorization)
+				}
+				if spec.OIDC.ForwardAccessToken != nil {
+					t.Errorf("expected forwardAccessToken unset, got %v", *spec.OIDC.ForwardAccessToken)
+				}
+				return
+			}
+
+			if spec.Authorization == nil {
+				t.Fatal("expected authorization to be set")
+			}
+			if spec.Authorization.DefaultAction == nil || *spec.Authorization.DefaultAction != egv1alpha1.AuthorizationActionDeny {
+				t.Errorf("expected defaultAction Deny, got %v", spec.Authorization.DefaultAction)
+			}
+			if !reflect.DeepEqual(spec.Authorization.Rules, tt.expectedRules) {
+				t.Errorf("expected rules %+v, got %+v", tt.expectedRules, spec.Authorization.Rules)
+			}
+			if spec.OIDC.ForwardAccessToken == nil || !*spec.OIDC.ForwardAccessToken {
+				t.Error("expected forwardAccessToken=true so browser sessions are authorized")
+			}
+		})
+	}
+}
+
+func TestBuildSecurityPolicySpec_ExtraAuthParams(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	authEndpoint := "https://keycloak.example.com/realms/test/protocol/openid-connect/auth"
+
+	tests := []struct {
+		name             string
+		params           map[string]string
+		authorization    *string
+		expectedEndpoint *string
+		expectError      bool
+	}{
+		{
+			name:             "no params leaves the endpoint untouched",
+			authorization:    ptr.To(authEndpoint),
+			expectedEndpoint: ptr.To(authEndpoint),
+		},
+		{
+			name:             "params are appended to the authorization endpoint",
+			params:           map[string]string{"prompt": "login", "access_type": "offline"},
+			authorization:    ptr.To(authEndpoint),
+			expectedEndpoint: ptr.To(authEndpoint + "?access_type=offline&prompt=login"),
+		},
+		{
+			name:             "existing query parameters are kept",
+			params:           map[string]string{"prompt": "login"},
+			authorization:    ptr.To(authEndpoint + "?kc_idp_hint=github"),
+			expectedEndpoint: ptr.To(authEndpoint + "?kc_idp_hint=github&prompt=login"),
+		},
+		{
+			name:        "params without an explicit authorization endpoint fail",
+			params:      map[string]string{"prompt": "login"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:         true,
+						Provider:        constants.ProviderKeycloak,
+						ExtraAuthParams: tt.params,
+					},
+				},
+			}
+			reconciler := &AuthReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+				Scheme: scheme,
+			}
+			provider := &mockProvider{
+				issuerURL:         "https://keycloak.example.com/realms/test",
+				clientID:          "test-client",
+				endpointOverrides: providers.OIDCEndpointOverrides{Authorization: tt.authorization},
+			}
+
+			spec, err := reconciler.buildSecurityPolicySpec(context.Background(), app, provider)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			verifyOptionalEndpoint(t, "authorization", spec.OIDC.Provider.AuthorizationEndpoint, tt.expectedEndpoint)
+		})
+	}
+}
+
+func TestValidateExtraAuthParams(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]string
+		expectError bool
+	}{
+		{name: "nil is allowed", params: nil, expectError: false},
+		{name: "valid params", params: map[string]string{"prompt": "login", "access_type": "offline"}, expectError: false},
+		{name: "empty key", params: map[string]string{"": "login"}, expectError: true},
+		{name: "empty value", params: map[string]string{"prompt": ""}, expectError: true},
+		{name: "reserved parameter", params: map[string]string{"redirect_uri": "https://evil.example.com"}, expectError: true},
+		{name: "reserved parameter is case-insensitive", params: map[string]string{"Client_ID": "other"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExtraAuthParams(tt.params)
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestBuildSecurityPolicySpec_PostLogoutRedirectURI(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	logoutEndpoint := "https://keycloak.example.com/realms/test/protocol/openid-connect/logout"
+
+	tests := []struct {
+		name             string
+		postLogout       string
+		endSession       *string
+		expectedEndpoint *string
+	}{
+		{
+			name:       "unset leaves the end session endpoint off",
+			endSession: ptr.To(logoutEndpoint),
+		},
+		{
+			name:       "absolute URL does not set the end session endpoint",
+			postLogout: "https://www.example.com/bye",
+			endSession: ptr.To(logoutEndpoint),
+		},
+		{
+			name:       "root-relative path does not set the end session endpoint",
+			postLogout: "/goodbye",
+			endSession: ptr.To(logoutEndpoint),
+		},
+		{
+			name:       "without an explicit end session endpoint succeeds",
+			postLogout: "/goodbye",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:               true,
+						Provider:              constants.ProviderKeycloak,
+						PostLogoutRedirectURI: tt.postLogout,
+					},
+				},
+			}
+			reconciler := &AuthReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+				Scheme: scheme,
+			}
+			provider := &mockProvider{
+				issuerURL:         "https://keycloak.example.com/realms/test",
+				clientID:          "test-client",
+				endpointOverrides: providers.OIDCEndpointOverrides{EndSession: tt.endSession},
+			}
+
+			spec, err := reconciler.buildSecurityPolicySpec(context.Background(), app, provider)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			verifyOptionalEndpoint(t, "endSession", spec.OIDC.Provider.EndSessionEndpoint, tt.expectedEndpoint)
+			if spec.OIDC.LogoutPath == nil || *spec.OIDC.LogoutPath != constants.DefaultLogoutPath {
+				t.Errorf("expected logoutPath %q, got %v", constants.DefaultLogoutPath, spec.OIDC.LogoutPath)
+			}
+		})
+	}
+}
+
+func TestBuildSecurityPolicySpec_EndSSOSession(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	logoutEndpoint := "https://keycloak.example.com/realms/test/protocol/openid-connect/logout"
+
+	tests := []struct {
+		name             string
+		logout           *appsv1.LogoutConfig
+		postLogout       string
+		endSession       *string
+		expectedEndpoint *string
+		expectError      bool
+	}{
+		{
+			name:       "no logout config leaves the end session endpoint off",
+			endSession: ptr.To(logoutEndpoint),
+		},
+		{
+			name:       "front-channel only leaves the end session endpoint off",
+			logout:     &appsv1.LogoutConfig{FrontChannel: true},
+			endSession: ptr.To(logoutEndpoint),
+		},
+		{
+			name:             "endSSOSession sets the end session endpoint",
+			logout:           &appsv1.LogoutConfig{EndSSOSession: true},
+			endSession:       ptr.To(logoutEndpoint),
+			expectedEndpoint: ptr.To(logoutEndpoint),
+		},
+		{
+			name:             "endSSOSession leaves post_logout_redirect_uri to Envoy",
+			logout:           &appsv1.LogoutConfig{EndSSOSession: true},
+			postLogout:       "/goodbye",
+			endSession:       ptr.To(logoutEndpoint),
+			expectedEndpoint: ptr.To(logoutEndpoint),
+		},
+		{
+			name:        "endSSOSession without an explicit end session endpoint fails",
+			logout:      &appsv1.LogoutConfig{EndSSOSession: true},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:               true,
+						Provider:              constants.ProviderKeycloak,
+						PostLogoutRedirectURI: tt.postLogout,
+						Logout:                tt.logout,
+					},
+				},
+			}
+			reconciler := &AuthReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+				Scheme: scheme,
+			}
+			provider := &mockProvider{
+				issuerURL:         "https://keycloak.example.com/realms/test",
+				clientID:          "test-client",
+				endpointOverrides: providers.OIDCEndpointOverrides{EndSession: tt.endSession},
+			}
+
+			spec, err := reconciler.buildSecurityPolicySpec(context.Background(), app, provider)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			verifyOptionalEndpoint(t, "endSession", spec.OIDC.Provider.EndSessionEndpoint, tt.expectedEndpoint)
+		})
+	}
+}
+
+func TestValidatePostLogoutRedirectURI(t *testing.T) {
+	tests := []struct {
+		name        string
+		uri         string
+		expectError bool
+	}{
+		{name: "empty is allowed", uri: "", expectError: false},
+		{name: "absolute https URL", uri: "https://www.example.com/bye", expectError: false},
+		{name: "absolute http URL", uri: "http://localhost:8080/", expectError: false},
+		{name: "root-relative path", uri: "/goodbye?reason=logout", expectError: false},
+		{name: "root path", uri: "/", expectError: false},
+		{name: "relative path", uri: "goodbye", expectError: true},
+		{name: "protocol-relative URL", uri: "//evil.example.com", expectError: true},
+		{name: "non-http scheme", uri: "javascript:alert(1)", expectError: true},
+		{name: "absolute URL without host", uri: "https:///path", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePostLogoutRedirectURI(tt.uri)
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateWebOrigins(t *testing.T) {
+	tests := []struct {
+		name        string
+		origins     []string
+		expectError bool
+	}{
+		{name: "none is allowed", origins: nil, expectError: false},
+		{name: "https origin", origins: []string{"https://frontend.example.com"}, expectError: false},
+		{name: "http origin with port", origins: []string{"http://localhost:3000"}, expectError: false},
+		{name: "wildcard", origins: []string{"*"}, expectError: true},
+		{name: "wildcard host", origins: []string{"https://*.example.com"}, expectError: true},
+		{name: "trailing slash", origins: []string{"https://frontend.example.com/"}, expectError: true},
+		{name: "path", origins: []string{"https://frontend.example.com/app"}, expectError: true},
+		{name: "bare hostname", origins: []string{"frontend.example.com"}, expectError: true},
+		{name: "non-http scheme", origins: []string{"ftp://frontend.example.com"}, expectError: true},
+		{name: "one invalid entry fails", origins: []string{"https://ok.example.com", "https://bad.example.com/x"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWebOrigins(tt.origins)
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestBuildSecurityPolicySpec_ForwardAccessToken covers the forwardAccessToken
+// passthrough in isolation. Kept separate from TestBuildSecurityPolicySpec
+// to keep that table-driven test below the gocyclo complexity threshold.
+func TestBuildSecurityPolicySpec_ForwardAccessToken(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name             string
+		forwardAccessTok *bool
+		want             *bool
+	}{
+		{
+			name:             "true sets OIDC field to true",
+			forwardAccessTok: ptr.To(true),
+			want:             ptr.To(true),
+		},
+		{
+			name:             "false sets OIDC field to false (explicit opt-out)",
+			forwardAccessTok: ptr.To(false),
+			want:             ptr.To(false),
+		},
+		{
+			name:             "unset leaves OIDC field nil so Envoy default applies",
+			forwardAccessTok: nil,
+			want:             nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &AuthReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:            true,
+						Provider:           constants.ProviderKeycloak,
+						ForwardAccessToken: tt.forwardAccessTok,
+					},
+				},
+			}
+			provider := &mockProvider{
+				issuerURL: "https://keycloak.example.com/realms/test",
+				clientID:  "test-client",
+			}
+
+			spec, err := r.buildSecurityPolicySpec(context.Background(), nebariApp, provider)
+			if err != nil {
+				t.Fatalf("buildSecurityPolicySpec returned error: %v", err)
+			}
+			if spec.OIDC == nil {
+				t.Fatal("OIDC config is nil")
+			}
+			if !reflect.DeepEqual(spec.OIDC.ForwardAccessToken, tt.want) {
+				got := "nil"
+				if spec.OIDC.ForwardAccessToken != nil {
+					got = fmt.Sprintf("%v", *spec.OIDC.ForwardAccessToken)
+				}
+				wantStr := "nil"
+				if tt.want != nil {
+					wantStr = fmt.Sprintf("%v", *tt.want)
+				}
+				t.Errorf("ForwardAccessToken = %s, want %s", got, wantStr)
+			}
+		})
+	}
+}
+
+func TestReconcileAuth(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name                   string
+		nebariApp              *appsv1.NebariApp
+		existingSecret         *corev1.Secret
+		existingSecurityPolicy *egv1alpha1.SecurityPolicy
+		provider               *mockProvider
+		expectedOverrides      providers.OIDCEndpointOverrides
+		expectError            bool
+		// validate runs additional assertions after reconciliation (optional)
+		validate func(*testing.T, *mockProvider, *appsv1.NebariApp)
+	}{
+		{
+			name: "Auth not enabled",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled: false,
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Auth disabled with pre-existing SecurityPolicy - deletes it",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled: false,
+					},
+				},
+			},
+			existingSecurityPolicy: &egv1alpha1.SecurityPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      naming.SecurityPolicyName(&appsv1.NebariApp{ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"}}),
+					Namespace: "default",
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Auth enabled with valid config",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:         true,
+						Provider:        constants.ProviderKeycloak,
+						ProvisionClient: ptr.To(false),
+					},
+				},
+			},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app-oidc-client",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					constants.ClientSecretKey: []byte("test-secret"),
+				},
+			},
+			provider: &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
+				supportsProvisioning: true,
+			},
+			expectError: false,
+		},
+		{
+			name: "Auth enabled with explicit endpoint overrides",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:         true,
+						Provider:        constants.ProviderKeycloak,
+						ProvisionClient: ptr.To(false),
+						Logout:          &appsv1.LogoutConfig{EndSSOSession: true},
+					},
+				},
+			},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app-oidc-client",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					constants.ClientSecretKey: []byte("test-secret"),
+				},
+			},
+			provider: &mockProvider{
+				issuerURL: "https://keycloak.example.com/realms/test",
+				endpointOverrides: providers.OIDCEndpointOverrides{
+					Token:         ptr.To("http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/token"),
+					Authorization: ptr.To("http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/auth"),
+					EndSession:    ptr.To("http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/logout"),
+				},
+				clientID:             "test-client",
+				supportsProvisioning: true,
+			},
+			expectedOverrides: providers.OIDCEndpointOverrides{
+				Token:         ptr.To("http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/token"),
+				Authorization: ptr.To("http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/auth"),
+				EndSession:    ptr.To("http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/logout"),
+			},
+			expectError: false,
+		},
+		{
+			name: "Auth enabled without endpoint overrides (discovery)",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:         true,
+						Provider:        constants.ProviderKeycloak,
+						ProvisionClient: ptr.To(false),
+					},
+				},
+			},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app-oidc-client",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					constants.ClientSecretKey: []byte("test-secret"),
+				},
+			},
+			provider: &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
+				supportsProvisioning: true,
+			},
+			expectedOverrides: providers.OIDCEndpointOverrides{},
+			expectError:       false,
+		},
+		{
+			name: "Auth enabled but secret missing",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:         true,
+						Provider:        constants.ProviderKeycloak,
+						ProvisionClient: ptr.To(false),
+					},
+				},
+			},
+			existingSecret: nil,
+			provider: &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
+				supportsProvisioning: true,
+			},
+			expectError: true,
+		},
+		{
+			name: "EnforceAtGateway false - SecurityPolicy not created",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:          true,
+						Provider:         constants.ProviderKeycloak,
+						ProvisionClient:  ptr.To(false),
+						EnforceAtGateway: ptr.To(false),
+					},
+				},
+			},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app-oidc-client",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					constants.ClientSecretKey: []byte("test-secret"),
+				},
+			},
+			provider: &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
+				supportsProvisioning: true,
+			},
+			expectError: false,
+		},
+		{
+			name: "EnforceAtGateway false with pre-existing SecurityPolicy - deletes it",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:          true,
+						Provider:         constants.ProviderKeycloak,
+						ProvisionClient:  ptr.To(false),
+						EnforceAtGateway: ptr.To(false),
+					},
+				},
+			},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app-oidc-client",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					constants.ClientSecretKey: []byte("test-secret"),
+				},
+			},
+			existingSecurityPolicy: &egv1alpha1.SecurityPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app-security-policy",
+					Namespace: "default",
+				},
+			},
+			provider: &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
+				supportsProvisioning: true,
+			},
+			expectError: false,
+		},
+		// --- Hash-guard tests ---
+		{
+			name: "Skip provisioning when hash matches and AuthReady=True",
+			nebariApp: func() *appsv1.NebariApp {
+				app := &appsv1.NebariApp{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-app",
+						Namespace: "default",
+					},
+					Spec: appsv1.NebariAppSpec{
+						Hostname: "test.example.com",
+						Auth: &appsv1.AuthConfig{
+							Enabled:         true,
+							Provider:        constants.ProviderKeycloak,
+							ProvisionClient: ptr.To(true),
+						},
+					},
+				}
+				// Pre-set a matching hash and AuthReady=True to trigger the skip path
+				app.Status.AuthConfigHash = computeAuthConfigHash(app, nil)
+				app.Status.Conditions = []metav1.Condition{{
+					Type:               appsv1.ConditionTypeAuthReady,
+					Status:             metav1.ConditionTrue,
+					Reason:             "AuthConfigured",
+					LastTransitionTime: metav1.Now(),
+				}}
+				return app
+			}(),
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app-oidc-client",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					constants.ClientSecretKey: []byte("test-secret"),
+				},
+			},
+			provider: &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
+				supportsProvisioning: true,
+			},
+			expectError: false,
+			validate: func(t *testing.T, p *mockProvider, app *appsv1.NebariApp) {
+				if p.provisionCount != 0 {
+					t.Errorf("expected ProvisionClient to be skipped, called %d time(s)", p.provisionCount)
+				}
+			},
+		},
+		{
+			name: "Re-provision when spec changes (hash mismatch)",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:         true,
+						Provider:        constants.ProviderKeycloak,
+						ProvisionClient: ptr.To(true),
+					},
+				},
+				Status: appsv1.NebariAppStatus{
+					AuthConfigHash: "stale-hash-will-not-match",
+					Conditions: []metav1.Condition{{
+						Type:               appsv1.ConditionTypeAuthReady,
+						Status:             metav1.ConditionTrue,
+						Reason:             "AuthConfigured",
+						LastTransitionTime: metav1.Now(),
+					}},
+				},
+			},
+			// No secret pre-created; validateAuthConfig will fail after provisioning
+			provider: &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
+				supportsProvisioning: true,
+			},
+			expectError: true, // validateAuthConfig fails (mock doesn't create the secret)
+			validate: func(t *testing.T, p *mockProvider, app *appsv1.NebariApp) {
+				if p.provisionCount != 1 {
+					t.Errorf("expected ProvisionClient called once, got %d", p.provisionCount)
+				}
+				if app.Status.AuthConfigHash == "stale-hash-will-not-match" {
+					t.Error("expected AuthConfigHash to be updated after provisioning")
+				}
+			},
+		},
+		{
+			name: "Provision on first reconcile (no stored hash, AuthReady unset)",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:         true,
+						Provider:        constants.ProviderKeycloak,
+						ProvisionClient: ptr.To(true),
+					},
+				},
+			},
+			// No secret; validateAuthConfig will fail but provisioning must run first
+			provider: &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
+				supportsProvisioning: true,
+			},
+			expectError: true,
+			validate: func(t *testing.T, p *mockProvider, app *appsv1.NebariApp) {
+				if p.provisionCount != 1 {
+					t.Errorf("expected ProvisionClient called once on first reconcile, got %d", p.provisionCount)
+				}
+			},
+		},
+		{
+			name: "Force re-provision via annotation overrides matching hash",
+			nebariApp: func() *appsv1.NebariApp {
+				app := &appsv1.NebariApp{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-app",
+						Namespace: "default",
+						Annotations: map[string]string{
+							constants.AnnotationForceReprovision: "true",
+						},
+					},
+					Spec: appsv1.NebariAppSpec{
+						Hostname: "test.example.com",
+						Auth: &appsv1.AuthConfig{
+							Enabled:         true,
+							Provider:        constants.ProviderKeycloak,
+							ProvisionClient: ptr.To(true),
+						},
+					},
+				}
+				// Hash matches and AuthReady=True, but annotation forces re-provision
+				app.Status.AuthConfigHash = computeAuthConfigHash(app, nil)
+				app.Status.Conditions = []metav1.Condition{{
+					Type:               appsv1.ConditionTypeAuthReady,
+					Status:             metav1.ConditionTrue,
+					Reason:             "AuthConfigured",
+					LastTransitionTime: metav1.Now(),
+				}}
+				return app
+			}(),
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app-oidc-client",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{
+					constants.ClientSecretKey: []byte("test-secret"),
+				},
+			},
+			provider: &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
+				supportsProvisioning: true,
+			},
+			expectError: false,
+			validate: func(t *testing.T, p *mockProvider, app *appsv1.NebariApp) {
+				if p.provisionCount != 1 {
+					t.Errorf("expected forced ProvisionClient called once, got %d", p.provisionCount)
+				}
+				if _, ok := app.Annotations[constants.AnnotationForceReprovision]; ok {
+					t.Error("expected force-reprovision annotation to be cleared after reconcile")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.nebariApp)
+
+			if tt.existingSecret != nil {
+				builder = builder.WithObjects(tt.existingSecret)
+			}
+			if tt.existingSecurityPolicy != nil {
+				builder = builder.WithObjects(tt.existingSecurityPolicy)
+			}
+
+			client := builder.Build()
+
+			providers := map[string]providers.OIDCProvider{}
+			if tt.provider != nil {
+				providers[constants.ProviderKeycloak] = tt.provider
+			}
+
+			reconciler := &AuthReconciler{
+				Client:    client,
+				Scheme:    scheme,
+				Recorder:  record.NewFakeRecorder(10),
+				Providers: providers,
+			}
+
+			err := reconciler.ReconcileAuth(context.Background(), tt.nebariApp)
+
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+
+			// If auth is disabled and there was a pre-existing SecurityPolicy, verify it was deleted
+			if !tt.expectError && tt.nebariApp.Spec.Auth != nil && !tt.nebariApp.Spec.Auth.Enabled && tt.existingSecurityPolicy != nil {
+				securityPolicy := &egv1alpha1.SecurityPolicy{}
+				err := client.Get(context.Background(), types.NamespacedName{
+					Name:      naming.SecurityPolicyName(tt.nebariApp),
+					Namespace: tt.nebariApp.Namespace,
+				}, securityPolicy)
+				if err == nil {
+					t.Error("expected SecurityPolicy to be deleted when auth is disabled, but it still exists")
+				}
+			}
+
+			// If auth is enabled and no error, verify SecurityPolicy based on enforceAtGateway setting
+			if !tt.expectError && tt.nebariApp.Spec.Auth != nil && tt.nebariApp.Spec.Auth.Enabled {
+				securityPolicy := &egv1alpha1.SecurityPolicy{}
+				err := client.Get(context.Background(), types.NamespacedName{
+					Name:      naming.SecurityPolicyName(tt.nebariApp),
+					Namespace: tt.nebariApp.Namespace,
+				}, securityPolicy)
+
+				if shouldEnforceAtGateway(tt.nebariApp.Spec.Auth) {
+					if err != nil {
+						t.Errorf("expected SecurityPolicy to be created, got error: %v", err)
+					} else if securityPolicy.Spec.OIDC != nil {
+						verifyEndpointOverrides(t, securityPolicy, tt.expectedOverrides)
+					}
+				} else {
+					if err == nil {
+						t.Error("expected SecurityPolicy to NOT be created when enforceAtGateway=false")
+					}
+				}
+			}
+
+			// Run additional per-test assertions if provided
+			if tt.validate != nil {
+				tt.validate(t, tt.provider, tt.nebariApp)
+			}
+		})
+	}
+}
+
+func TestCleanupAuth(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name        string
+		nebariApp   *appsv1.NebariApp
+		provider    *mockProvider
+		expectError bool
+	}{
+		{
+			name: "Auth not enabled",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Auth: &appsv1.AuthConfig{
+						Enabled: false,
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Cleanup with provisioning enabled",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Auth: &appsv1.AuthConfig{
+						Enabled:         true,
+						Provider:        constants.ProviderKeycloak,
+						ProvisionClient: ptr.To(true),
+					},
+				},
+			},
+			provider: &mockProvider{
+				supportsProvisioning: true,
+				deleteError:          nil,
+			},
+			expectError: false,
+		},
+		{
+			name: "Cleanup with delete error",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Auth: &appsv1.AuthConfig{
+						Enabled:         true,
+						Provider:        constants.ProviderKeycloak,
+						ProvisionClient: ptr.To(true),
+					},
+				},
+			},
+			provider: &mockProvider{
+				supportsProvisioning: true,
+				deleteError:          errors.New("failed to delete client"),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.nebariApp).
+				Build()
+
+			providers := map[string]providers.OIDCProvider{}
+			if tt.provider != nil {
+				providers[constants.ProviderKeycloak] = tt.provider
+			}
+
+			reconciler := &AuthReconciler{
+				Client:    client,
+				Scheme:    scheme,
+				Recorder:  record.NewFakeRecorder(10),
+				Providers: providers,
+			}
+
+			err := reconciler.CleanupAuth(context.Background(), tt.nebariApp)
+
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestComputeAuthConfigHash verifies the hash function's sensitivity and stability.
+// Each relevant spec field must produce a distinct hash when changed, and the hash
+// must be stable (same input → same output regardless of slice ordering).
+func TestComputeAuthConfigHash(t *testing.T) {
+	base := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "app.example.com",
+			Auth: &appsv1.AuthConfig{
+				Provider:    constants.ProviderKeycloak,
+				RedirectURI: "/oauth2/callback",
+				Scopes:      []string{"openid", "profile"},
+				Groups:      []string{"admins"},
+			},
+		},
+	}
+
+	baseHash := computeAuthConfigHash(base, nil)
+
+	t.Run("same spec produces same hash", func(t *testing.T) {
+		same := base.DeepCopy()
+		if computeAuthConfigHash(same, nil) != baseHash {
+			t.Error("expected identical spec to produce the same hash")
+		}
+	})
+
+	t.Run("scope order does not change hash", func(t *testing.T) {
+		reordered := base.DeepCopy()
+		reordered.Spec.Auth.Scopes = []string{"profile", "openid"} // reversed
+		if computeAuthConfigHash(reordered, nil) != baseHash {
+			t.Error("expected scope reordering to produce the same hash")
+		}
+	})
+
+	t.Run("group order does not change hash", func(t *testing.T) {
+		app := base.DeepCopy()
+		app.Spec.Auth.Groups = []string{"viewers", "admins"}
+		base2 := base.DeepCopy()
+		base2.Spec.Auth.Groups = []string{"admins", "viewers"}
+		if computeAuthConfigHash(app, nil) != computeAuthConfigHash(base2, nil) {
+			t.Error("expected group reordering to produce the same hash")
+		}
+	})
+
+	t.Run("different redirect URI changes hash", func(t *testing.T) {
+		changed := base.DeepCopy()
+		changed.Spec.Auth.RedirectURI = "/auth/callback"
+		if computeAuthConfigHash(changed, nil) == baseHash {
+			t.Error("expected different redirectURI to produce a different hash")
+		}
+	})
+
+	t.Run("adding a group changes hash", func(t *testing.T) {
+		changed := base.DeepCopy()
+		changed.Spec.Auth.Groups = append(changed.Spec.Auth.Groups, "data-scientists")
+		if computeAuthConfigHash(changed, nil) == baseHash {
+			t.Error("expected added group to produce a different hash")
+		}
+	})
+
+	t.Run("adding a scope changes hash", func(t *testing.T) {
+		changed := base.DeepCopy()
+		changed.Spec.Auth.Scopes = append(changed.Spec.Auth.Scopes, "offline_access")
+		if computeAuthConfigHash(changed, nil) == baseHash {
+			t.Error("expected added scope to produce a different hash")
+		}
+	})
+
+	t.Run("writing out the groups scope added for groups keeps hash", func(t *testing.T) {
+		explicit := base.DeepCopy()
+		explicit.Spec.Auth.Scopes = append(explicit.Spec.Auth.Scopes, "groups")
+		if computeAuthConfigHash(explicit, nil) != baseHash {
+			t.Error("expected the scopes actually requested to determine the hash")
+		}
+	})
+
+	t.Run("different hostname changes hash", func(t *testing.T) {
+		changed := base.DeepCopy()
+		changed.Spec.Hostname = "other.example.com"
+		if computeAuthConfigHash(changed, nil) == baseHash {
+			t.Error("expected different hostname to produce a different hash")
+		}
+	})
+
+	t.Run("enabling front-channel logout changes hash", func(t *testing.T) {
+		changed := base.DeepCopy()
+		changed.Spec.Auth.Logout = &appsv1.LogoutConfig{FrontChannel: true}
+		if computeAuthConfigHash(changed, nil) == baseHash {
+			t.Error("expected logout config to produce a different hash")
+		}
+	})
+
+	t.Run("different namespace changes hash", func(t *testing.T) {
+		changed := base.DeepCopy()
+		changed.Namespace = "production"
+		if computeAuthConfigHash(changed, nil) == baseHash {
+			t.Error("expected different namespace to produce a different hash")
+		}
+	})
+
+	t.Run("different provider changes hash", func(t *testing.T) {
+		changed := base.DeepCopy()
+		changed.Spec.Auth.Provider = constants.ProviderGenericOIDC
+		if computeAuthConfigHash(changed, nil) == baseHash {
+			t.Error("expected different provider to produce a different hash")
+		}
+	})
+
+	t.Run("adding keycloakConfig group changes hash", func(t *testing.T) {
+		changed := base.DeepCopy()
+		changed.Spec.Auth.KeycloakConfig = &appsv1.KeycloakClientConfig{
+			Groups: []appsv1.KeycloakGroup{{Name: "admins", Members: []string{"alice"}}},
+		}
+		if computeAuthConfigHash(changed, nil) == baseHash {
+			t.Error("expected keycloakConfig change to produce a different hash")
+		}
+	})
+
+	t.Run("client attribute change changes hash", func(t *testing.T) {
+		changed := base.DeepCopy()
+		changed.Spec.Auth.ClientAttributes = map[string]string{"login_theme": "nebari"}
+		changedHash := computeAuthConfigHash(changed, nil)
+		if changedHash == baseHash {
+			t.Error("expected added client attribute to produce a different hash")
+		}
+		changed.Spec.Auth.ClientAttributes["login_theme"] = "keycloak"
+		if computeAuthConfigHash(changed, nil) == changedHash {
+			t.Error("expected changed client attribute value to produce a different hash")
+		}
+	})
+
+	t.Run("client attribute order does not change hash", func(t *testing.T) {
+		app := base.DeepCopy()
+		app.Spec.Auth.ClientAttributes = map[string]string{"login_theme": "nebari", "display.on.consent.screen": "true"}
+		first := computeAuthConfigHash(app, nil)
+		for range 10 {
+			if computeAuthConfigHash(app, nil) != first {
+				t.Fatal("expected the same client attributes to always produce the same hash")
+			}
+		}
+	})
+
+	t.Run("redirect scheme allowlist change changes hash", func(t *testing.T) {
+		if computeAuthConfigHash(base, []string{"https"}) == baseHash {
+			t.Error("expected a different redirect scheme allowlist to produce a different hash")
+		}
+		if computeAuthConfigHash(base, []string{"http", "https"}) != baseHash {
+			t.Error("expected the default redirect scheme allowlist to keep the hash")
+		}
+	})
+
+	t.Run("issuerURL change (generic-oidc) changes hash", func(t *testing.T) {
+		changed := base.DeepCopy()
+		changed.Spec.Auth.IssuerURL = "https://accounts.google.com"
+		if computeAuthConfigHash(changed, nil) == baseHash {
+			t.Error("expected different issuerURL to produce a different hash")
+		}
+	})
+}
+
+// TestReconcileAuth_SpecChangeCycle simulates a realistic reconcile lifecycle:
+//  1. First reconcile: no stored hash, provisions and records the hash.
+//  2. Second reconcile: spec unchanged, provisioning is skipped.
+//  3. Spec mutation (redirect URI / group / scope): hash mismatches, provisions again.
+//
+// This validates the end-to-end guard behaviour for the changes described in #29.
+func TestReconcileAuth_SpecChangeCycle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	// Helper: build a reconciler with a pre-populated fake client secret so
+	// validateAuthConfig passes after the mock ProvisionClient returns nil.
+	newReconcilerWithSecret := func(app *appsv1.NebariApp) (*AuthReconciler, *mockProvider) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      naming.ClientSecretName(app),
+				Namespace: app.Namespace,
+			},
+			Data: map[string][]byte{constants.ClientSecretKey: []byte("s3cr3t")},
+		}
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(app, secret).
+			WithStatusSubresource(app).
+			Build()
+		provider := &mockProvider{
+			issuerURL:            "https://keycloak.example.com/realms/test",
+			clientID:             "test-app",
+			supportsProvisioning: true,
+		}
+		return &AuthReconciler{
+			Client:    fakeClient,
+			Scheme:    scheme,
+			Recorder:  record.NewFakeRecorder(32),
+			Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: provider},
+		}, provider
+	}
+
+	tests := []struct {
+		name    string
+		initial appsv1.AuthConfig
+		mutate  func(*appsv1.AuthConfig)
+	}{
+		{
+			name: "redirect URI change triggers re-provisioning",
+			initial: appsv1.AuthConfig{
+				Enabled:         true,
+				Provider:        constants.ProviderKeycloak,
+				ProvisionClient: ptr.To(true),
+				RedirectURI:     "/oauth2/callback",
+			},
+			mutate: func(a *appsv1.AuthConfig) { a.RedirectURI = "/auth/callback" },
+		},
+		{
+			name: "adding a group triggers re-provisioning",
+			initial: appsv1.AuthConfig{
+				Enabled:         true,
+				Provider:        constants.ProviderKeycloak,
+				ProvisionClient: ptr.To(true),
+				Groups:          []string{"admins"},
+			},
+			mutate: func(a *appsv1.AuthConfig) {
+				a.Groups = append(a.Groups, "data-scientists")
+			},
+		},
+		{
+			name: "adding a scope triggers re-provisioning",
+			initial: appsv1.AuthConfig{
+				Enabled:         true,
+				Provider:        constants.ProviderKeycloak,
+				ProvisionClient: ptr.To(true),
+				Scopes:          []string{"openid", "profile"},
+			},
+			mutate: func(a *appsv1.AuthConfig) {
+				a.Scopes = append(a.Scopes, "groups")
+			},
+		},
+		{
+			name: "adding a keycloakConfig group member triggers re-provisioning",
+			initial: appsv1.AuthConfig{
+				Enabled:         true,
+				Provider:        constants.ProviderKeycloak,
+				ProvisionClient: ptr.To(true),
+				KeycloakConfig: &appsv1.KeycloakClientConfig{
+					Groups: []appsv1.KeycloakGroup{{Name: "admins", Members: []string{"alice"}}},
+				},
+			},
+			mutate: func(a *appsv1.AuthConfig) {
+				a.KeycloakConfig.Groups[0].Members = append(a.KeycloakConfig.Groups[0].Members, "bob")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth:     tt.initial.DeepCopy(),
+				},
+			}
+			reconciler, provider := newReconcilerWithSecret(app)
+
+			// --- Reconcile 1: first time, no stored hash ---
+			if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+				t.Fatalf("reconcile 1 failed: %v", err)
+			}
+			if provider.provisionCount != 1 {
+				t.Fatalf("reconcile 1: expected ProvisionClient called once, got %d", provider.provisionCount)
+			}
+			hashAfterFirst := app.Status.AuthConfigHash
+			if hashAfterFirst == "" {
+				t.Fatal("reconcile 1: expected AuthConfigHash to be set after provisioning")
+			}
+
+			// --- Reconcile 2: same spec, AuthReady=True → must skip ---
+			if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+				t.Fatalf("reconcile 2 failed: %v", err)
+			}
+			if provider.provisionCount != 1 {
+				t.Errorf("reconcile 2: expected provisioning to be skipped (count=1), got %d", provider.provisionCount)
+			}
+
+			// --- Spec mutation ---
+			tt.mutate(app.Spec.Auth)
+
+			// --- Reconcile 3: spec changed, hash mismatch → must re-provision ---
+			if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+				t.Fatalf("reconcile 3 failed: %v", err)
+			}
+			if provider.provisionCount != 2 {
+				t.Errorf("reconcile 3: expected ProvisionClient called again (count=2), got %d", provider.provisionCount)
+			}
+			if app.Status.AuthConfigHash == hashAfterFirst {
+				t.Error("reconcile 3: expected AuthConfigHash to be updated after spec change")
+			}
+
+			// --- Reconcile 4: spec stable again → skip ---
+			if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+				t.Fatalf("reconcile 4 failed: %v", err)
+			}
+			if provider.provisionCount != 2 {
+				t.Errorf("reconcile 4: expected provisioning to be skipped again (count=2), got %d", provider.provisionCount)
+			}
+		})
+	}
+}
+
+func TestReconcileAuth_ScopeChangeUpdatesSecurityPolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:         true,
+				Provider:        constants.ProviderKeycloak,
+				ProvisionClient: ptr.To(true),
+				Scopes:          []string{"openid", "profile"},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: naming.ClientSecretName(app), Namespace: app.Namespace},
+		Data:       map[string][]byte{constants.ClientSecretKey: []byte("s3cr3t")},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(app, secret).
+		WithStatusSubresource(app).
+		Build()
+	provider := &mockProvider{
+		issuerURL:            "https://keycloak.example.com/realms/test",
+		clientID:             "test-app",
+		supportsProvisioning: true,
+	}
+	reconciler := &AuthReconciler{
+		Client:    fakeClient,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(32),
+		Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: provider},
+	}
+
+	policyScopes := func() []string {
+		t.Helper()
+		sp := &egv1alpha1.SecurityPolicy{}
+		if err := fakeClient.Get(context.Background(), types.NamespacedName{
+			Name: naming.SecurityPolicyName(app), Namespace: app.Namespace,
+		}, sp); err != nil {
+			t.Fatalf("failed to get SecurityPolicy: %v", err)
+		}
+		return sp.Spec.OIDC.Scopes
+	}
+
+	if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+		t.Fatalf("initial reconcile failed: %v", err)
+	}
+	if got := policyScopes(); !slices.Equal(got, []string{"openid", "profile"}) {
+		t.Fatalf("expected initial scopes [openid profile], got %v", got)
+	}
+
+	for _, scopes := range [][]string{
+		{"openid", "profile", "groups"},
+		{"openid", "email"},
+	} {
+		app.Spec.Auth.Scopes = scopes
+		if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+			t.Fatalf("reconcile with scopes %v failed: %v", scopes, err)
+		}
+		if got := policyScopes(); !slices.Equal(got, scopes) {
+			t.Errorf("expected SecurityPolicy scopes %v, got %v", scopes, got)
+		}
+	}
+
+	// Each scope change updates the existing client in place: ProvisionClient is
+	// re-run to sync the client's scopes, but the client is never deleted.
+	if provider.provisionCount != 3 {
+		t.Errorf("expected ProvisionClient once per distinct scope list (3), got %d", provider.provisionCount)
+	}
+	if provider.deleteCount != 0 {
+		t.Errorf("expected the client not to be deleted on a scope change, got %d DeleteClient calls", provider.deleteCount)
+	}
+
+	// Reconciling the same scopes again is a no-op for provisioning.
+	if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+		t.Fatalf("steady-state reconcile failed: %v", err)
+	}
+	if provider.provisionCount != 3 {
+		t.Errorf("expected unchanged scopes to skip provisioning, got %d ProvisionClient calls", provider.provisionCount)
+	}
+}
+
+func TestReconcileSecurityPolicy_DescriptionAnnotation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname:    "test.example.com",
+			Description: "JupyterHub for the data science team",
+			Auth: &appsv1.AuthConfig{
+				Enabled:  true,
+				Provider: constants.ProviderKeycloak,
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build()
+	reconciler := &AuthReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+	provider := &mockProvider{
+		issuerURL: "https://keycloak.example.com/realms/test",
+		clientID:  "test-app",
+	}
+
+	if err := reconciler.reconcileSecurityPolicy(context.Background(), app, provider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sp := &egv1alpha1.SecurityPolicy{}
+	key := types.NamespacedName{Name: naming.SecurityPolicyName(app), Namespace: app.Namespace}
+	if err := fakeClient.Get(context.Background(), key, sp); err != nil {
+		t.Fatalf("failed to get SecurityPolicy: %v", err)
+	}
+	if got := sp.Annotations[constants.AnnotationDescription]; got != app.Spec.Description {
+		t.Errorf("expected description annotation %q, got %q", app.Spec.Description, got)
+	}
+
+	// Clearing the description removes the annotation on the next reconcile.
+	app.Spec.Description = ""
+	if err := reconciler.reconcileSecurityPolicy(context.Background(), app, provider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fakeClient.Get(context.Background(), key, sp); err != nil {
+		t.Fatalf("failed to get SecurityPolicy: %v", err)
+	}
+	if _, ok := sp.Annotations[constants.AnnotationDescription]; ok {
+		t.Error("expected description annotation to be removed")
+	}
+}
+
+func TestReconcileSecurityPolicy_ServerSideApply(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname:    "test.example.com",
+			Description: "JupyterHub for the data science team",
+			Auth: &appsv1.AuthConfig{
+				Enabled:  true,
+				Provider: constants.ProviderKeycloak,
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).WithReturnManagedFields().Build()
+	reconciler := &AuthReconciler{
+		Client:          fakeClient,
+		Scheme:          scheme,
+		Recorder:        record.NewFakeRecorder(10),
+		ServerSideApply: true,
+	}
+	provider := &mockProvider{
+		issuerURL: "https://keycloak.example.com/realms/test",
+		clientID:  "test-app",
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: naming.SecurityPolicyName(app), Namespace: app.Namespace}
+
+	if err := reconciler.reconcileSecurityPolicy(ctx, app, provider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sp := &egv1alpha1.SecurityPolicy{}
+	if err := fakeClient.Get(ctx, key, sp); err != nil {
+		t.Fatalf("failed to get SecurityPolicy: %v", err)
+	}
+	applied := false
+	for _, entry := range sp.ManagedFields {
+		if entry.Manager == constants.FieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+			applied = true
+		}
+	}
+	if !applied {
+		t.Errorf("expected field manager %s with operation Apply, got %+v", constants.FieldManager, sp.ManagedFields)
+	}
+	if !metav1.IsControlledBy(sp, app) {
+		t.Errorf("expected the SecurityPolicy to be controlled by the NebariApp, got %+v", sp.OwnerReferences)
+	}
+	if sp.Spec.OIDC == nil || sp.Spec.OIDC.ClientID == nil || *sp.Spec.OIDC.ClientID != "test-app" {
+		t.Errorf("expected OIDC client ID test-app, got %+v", sp.Spec.OIDC)
+	}
+
+	// Fields dropped from the desired state are removed by the next apply.
+	app.Spec.Description = ""
+	if err := reconciler.reconcileSecurityPolicy(ctx, app, provider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fakeClient.Get(ctx, key, sp); err != nil {
+		t.Fatalf("failed to get SecurityPolicy: %v", err)
+	}
+	if _, ok := sp.Annotations[constants.AnnotationDescription]; ok {
+		t.Error("expected description annotation to be removed")
+	}
+}
+
+func TestReconcileSecurityPolicy_ManagedByLabel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth:     &appsv1.AuthConfig{Enabled: true, Provider: constants.ProviderKeycloak},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build()
+	reconciler := &AuthReconciler{
+		Client:    fakeClient,
+		Scheme:    scheme,
+		Recorder:  record.NewFakeRecorder(10),
+		ManagedBy: "nebari-operator-staging",
+	}
+	provider := &mockProvider{
+		issuerURL: "https://keycloak.example.com/realms/test",
+		clientID:  "test-app",
+	}
+
+	if err := reconciler.reconcileSecurityPolicy(context.Background(), app, provider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sp := &egv1alpha1.SecurityPolicy{}
+	key := types.NamespacedName{Name: naming.SecurityPolicyName(app), Namespace: app.Namespace}
+	if err := fakeClient.Get(context.Background(), key, sp); err != nil {
+		t.Fatalf("failed to get SecurityPolicy: %v", err)
+	}
+	if got := sp.Labels["app.kubernetes.io/managed-by"]; got != "nebari-operator-staging" {
+		t.Errorf("expected managed-by label %q, got %q", "nebari-operator-staging", got)
+	}
+}
+
+func TestReconcileSecurityPolicy_SkipsUnchangedSpec(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:  true,
+				Provider: constants.ProviderKeycloak,
 			},
-			existingSecret: &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app-oidc-client",
-					Namespace: "default",
-				},
-				Data: map[string][]byte{
-					constants.ClientSecretKey: []byte("test-secret"),
-				},
+		},
+	}
+
+	updates := 0
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*egv1alpha1.SecurityPolicy); ok {
+					updates++
+				}
+				return c.Update(ctx, obj, opts...)
 			},
+		}).Build()
+	reconciler := &AuthReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+	provider := &mockProvider{
+		issuerURL: "https://keycloak.example.com/realms/test",
+		clientID:  "test-app",
+	}
+
+	if err := reconciler.reconcileSecurityPolicy(context.Background(), app, provider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sp := &egv1alpha1.SecurityPolicy{}
+	key := types.NamespacedName{Name: naming.SecurityPolicyName(app), Namespace: app.Namespace}
+	if err := fakeClient.Get(context.Background(), key, sp); err != nil {
+		t.Fatalf("failed to get SecurityPolicy: %v", err)
+	}
+	resourceVersion := sp.ResourceVersion
+
+	// Reconciling again with the same desired spec must not write the policy.
+	if err := reconciler.reconcileSecurityPolicy(context.Background(), app, provider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fakeClient.Get(context.Background(), key, sp); err != nil {
+		t.Fatalf("failed to get SecurityPolicy: %v", err)
+	}
+	if updates != 0 {
+		t.Errorf("expected no SecurityPolicy update for an unchanged spec, got %d", updates)
+	}
+	if sp.ResourceVersion != resourceVersion {
+		t.Errorf("expected resourceVersion %q to be unchanged, got %q", resourceVersion, sp.ResourceVersion)
+	}
+
+	// A real change is still written.
+	app.Spec.Auth.Scopes = []string{"openid", "groups"}
+	if err := reconciler.reconcileSecurityPolicy(context.Background(), app, provider); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updates != 1 {
+		t.Errorf("expected 1 SecurityPolicy update after a spec change, got %d", updates)
+	}
+}
+
+func TestReconcileAuth_ClientSecretDrift(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name            string
+		provider        *mockProvider
+		stamped         bool
+		missing         bool
+		expectSync      int
+		expectProvision int
+		expectEvent     bool
+	}{
+		{
+			name: "unchanged stamped secret is not checked",
 			provider: &mockProvider{
 				issuerURL:            "https://keycloak.example.com/realms/test",
 				clientID:             "test-client",
 				supportsProvisioning: true,
+				secretResynced:       true,
 			},
-			expectError: false,
+			stamped: true,
 		},
 		{
-			name: "Auth enabled with explicit endpoint overrides",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
-				Spec: appsv1.NebariAppSpec{
-					Hostname: "test.example.com",
-					Auth: &appsv1.AuthConfig{
-						Enabled:         true,
-						Provider:        constants.ProviderKeycloak,
-						ProvisionClient: ptr.To(false),
-					},
-				},
-			},
-			existingSecret: &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app-oidc-client",
-					Namespace: "default",
-				},
-				Data: map[string][]byte{
-					constants.ClientSecretKey: []byte("test-secret"),
-				},
-			},
+			name: "missing secret is re-provisioned",
 			provider: &mockProvider{
-				issuerURL: "https://keycloak.example.com/realms/test",
-				endpointOverrides: providers.OIDCEndpointOverrides{
-					Token:         ptr.To("http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/token"),
-					Authorization: ptr.To("http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/auth"),
-					EndSession:    ptr.To("http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/logout"),
-				},
+				issuerURL:            "https://keycloak.example.com/realms/test",
 				clientID:             "test-client",
 				supportsProvisioning: true,
 			},
-			expectedOverrides: providers.OIDCEndpointOverrides{
-				Token:         ptr.To("http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/token"),
-				Authorization: ptr.To("http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/auth"),
-				EndSession:    ptr.To("http://keycloak-keycloakx-http.keycloak.svc.cluster.local:8080/realms/test/protocol/openid-connect/logout"),
-			},
-			expectError: false,
+			missing:         true,
+			expectProvision: 1,
 		},
 		{
-			name: "Auth enabled without endpoint overrides (discovery)",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
-				Spec: appsv1.NebariAppSpec{
-					Hostname: "test.example.com",
-					Auth: &appsv1.AuthConfig{
-						Enabled:         true,
-						Provider:        constants.ProviderKeycloak,
-						ProvisionClient: ptr.To(false),
-					},
-				},
-			},
-			existingSecret: &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app-oidc-client",
-					Namespace: "default",
-				},
-				Data: map[string][]byte{
-					constants.ClientSecretKey: []byte("test-secret"),
-				},
-			},
+			name: "drift detected records resync event",
 			provider: &mockProvider{
 				issuerURL:            "https://keycloak.example.com/realms/test",
 				clientID:             "test-client",
 				supportsProvisioning: true,
+				secretResynced:       true,
 			},
-			expectedOverrides: providers.OIDCEndpointOverrides{},
-			expectError:       false,
+			expectSync:  1,
+			expectEvent: true,
 		},
 		{
-			name: "Auth enabled but secret missing",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
-				Spec: appsv1.NebariAppSpec{
-					Hostname: "test.example.com",
-					Auth: &appsv1.AuthConfig{
-						Enabled:         true,
-						Provider:        constants.ProviderKeycloak,
-						ProvisionClient: ptr.To(false),
-					},
-				},
-			},
-			existingSecret: nil,
+			name: "in sync is a no-op",
 			provider: &mockProvider{
 				issuerURL:            "https://keycloak.example.com/realms/test",
 				clientID:             "test-client",
 				supportsProvisioning: true,
 			},
-			expectError: true,
+			expectSync: 1,
 		},
 		{
-			name: "EnforceAtGateway false - SecurityPolicy not created",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
-				Spec: appsv1.NebariAppSpec{
-					Hostname: "test.example.com",
-					Auth: &appsv1.AuthConfig{
-						Enabled:          true,
-						Provider:         constants.ProviderKeycloak,
-						ProvisionClient:  ptr.To(false),
-						EnforceAtGateway: ptr.To(false),
-					},
-				},
-			},
-			existingSecret: &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app-oidc-client",
-					Namespace: "default",
-				},
-				Data: map[string][]byte{
-					constants.ClientSecretKey: []byte("test-secret"),
-				},
-			},
+			name: "sync error does not fail reconcile",
 			provider: &mockProvider{
 				issuerURL:            "https://keycloak.example.com/realms/test",
 				clientID:             "test-client",
 				supportsProvisioning: true,
+				syncError:            fmt.Errorf("keycloak unavailable"),
 			},
-			expectError: false,
+			expectSync: 1,
 		},
-		{
-			name: "EnforceAtGateway false with pre-existing SecurityPolicy - deletes it",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
 				Spec: appsv1.NebariAppSpec{
 					Hostname: "test.example.com",
 					Auth: &appsv1.AuthConfig{
-						Enabled:          true,
-						Provider:         constants.ProviderKeycloak,
-						ProvisionClient:  ptr.To(false),
-						EnforceAtGateway: ptr.To(false),
+						Enabled:         true,
+						Provider:        constants.ProviderKeycloak,
+						ProvisionClient: ptr.To(true),
 					},
 				},
-			},
-			existingSecret: &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app-oidc-client",
-					Namespace: "default",
-				},
-				Data: map[string][]byte{
-					constants.ClientSecretKey: []byte("test-secret"),
-				},
-			},
-			existingSecurityPolicy: &egv1alpha1.SecurityPolicy{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app-security-policy",
-					Namespace: "default",
-				},
-			},
-			provider: &mockProvider{
-				issuerURL:            "https://keycloak.example.com/realms/test",
-				clientID:             "test-client",
-				supportsProvisioning: true,
-			},
-			expectError: false,
-		},
-		// --- Hash-guard tests ---
-		{
-			name: "Skip provisioning when hash matches and AuthReady=True",
-			nebariApp: func() *appsv1.NebariApp {
-				app := &appsv1.NebariApp{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "test-app",
-						Namespace: "default",
-					},
-					Spec: appsv1.NebariAppSpec{
-						Hostname: "test.example.com",
-						Auth: &appsv1.AuthConfig{
-							Enabled:         true,
-							Provider:        constants.ProviderKeycloak,
-							ProvisionClient: ptr.To(true),
-						},
-					},
+			}
+			// Matching hash and AuthReady=True take the skip-provisioning path
+			app.Status.AuthConfigHash = computeAuthConfigHash(app, nil)
+			app.Status.Conditions = []metav1.Condition{{
+				Type:               appsv1.ConditionTypeAuthReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             "AuthConfigured",
+				LastTransitionTime: metav1.Now(),
+			}}
+			// Secrets written before the operator stamped them count as modified
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app-oidc-client", Namespace: "default"},
+				Data:       map[string][]byte{constants.ClientSecretKey: []byte("test-secret")},
+			}
+			if tt.stamped {
+				secret.Annotations = map[string]string{
+					constants.AnnotationClientSecretHash: providers.ClientSecretHash([]byte("test-secret")),
 				}
-				// Pre-set a matching hash and AuthReady=True to trigger the skip path
-				app.Status.AuthConfigHash = computeAuthConfigHash(app)
-				app.Status.Conditions = []metav1.Condition{{
-					Type:               appsv1.ConditionTypeAuthReady,
-					Status:             metav1.ConditionTrue,
-					Reason:             "AuthConfigured",
-					LastTransitionTime: metav1.Now(),
-				}}
-				return app
-			}(),
-			existingSecret: &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app-oidc-client",
-					Namespace: "default",
-				},
-				Data: map[string][]byte{
-					constants.ClientSecretKey: []byte("test-secret"),
-				},
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app)
+			if !tt.missing {
+				builder = builder.WithObjects(secret)
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &AuthReconciler{
+				Client:    builder.Build(),
+				Scheme:    scheme,
+				Recorder:  recorder,
+				Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: tt.provider},
+			}
+
+			err := reconciler.ReconcileAuth(context.Background(), app)
+			if tt.provider.provisionCount != tt.expectProvision {
+				t.Errorf("expected ProvisionClient called %d time(s), got %d", tt.expectProvision, tt.provider.provisionCount)
+			}
+			if tt.missing {
+				// The mock provider writes no Secret, so only the attempt is checked
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.provider.syncCount != tt.expectSync {
+				t.Errorf("expected SyncClientSecret called %d time(s), got %d", tt.expectSync, tt.provider.syncCount)
+			}
+
+			resynced := false
+			close(recorder.Events)
+			for event := range recorder.Events {
+				if strings.Contains(event, appsv1.EventReasonClientSecretResynced) {
+					resynced = true
+				}
+			}
+			if resynced != tt.expectEvent {
+				t.Errorf("expected %s event=%v, got %v", appsv1.EventReasonClientSecretResynced, tt.expectEvent, resynced)
+			}
+			if !conditions.IsConditionTrue(app, appsv1.ConditionTypeAuthReady) {
+				t.Error("expected AuthReady to remain True")
+			}
+		})
+	}
+}
+
+func TestReconcileAuth_UnknownScopes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		provider      *mockProvider
+		expectWarning bool
+	}{
+		{
+			name: "scope missing from the realm is reported",
+			provider: &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
+				supportsProvisioning: true,
+				unknownScopes:        []string{"team-data"},
 			},
+			expectWarning: true,
+		},
+		{
+			name: "groups scope mapped on the provisioned client is not reported",
 			provider: &mockProvider{
 				issuerURL:            "https://keycloak.example.com/realms/test",
 				clientID:             "test-client",
 				supportsProvisioning: true,
+				unknownScopes:        []string{"groups"},
 			},
-			expectError: false,
-			validate: func(t *testing.T, p *mockProvider, app *appsv1.NebariApp) {
-				if p.provisionCount != 0 {
-					t.Errorf("expected ProvisionClient to be skipped, called %d time(s)", p.provisionCount)
-				}
+		},
+		{
+			name: "all scopes defined",
+			provider: &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
+				supportsProvisioning: true,
 			},
 		},
 		{
-			name: "Re-provision when spec changes (hash mismatch)",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
+			name: "check error does not fail reconcile",
+			provider: &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
+				supportsProvisioning: true,
+				unknownScopesError:   fmt.Errorf("keycloak unavailable"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
 				Spec: appsv1.NebariAppSpec{
 					Hostname: "test.example.com",
 					Auth: &appsv1.AuthConfig{
 						Enabled:         true,
 						Provider:        constants.ProviderKeycloak,
 						ProvisionClient: ptr.To(true),
+						Scopes:          []string{"openid", "profile", "groups", "team-data"},
 					},
 				},
-				Status: appsv1.NebariAppStatus{
-					AuthConfigHash: "stale-hash-will-not-match",
-					Conditions: []metav1.Condition{{
-						Type:               appsv1.ConditionTypeAuthReady,
-						Status:             metav1.ConditionTrue,
-						Reason:             "AuthConfigured",
-						LastTransitionTime: metav1.Now(),
-					}},
-				},
-			},
-			// No secret pre-created; validateAuthConfig will fail after provisioning
-			provider: &mockProvider{
-				issuerURL:            "https://keycloak.example.com/realms/test",
-				clientID:             "test-client",
-				supportsProvisioning: true,
-			},
-			expectError: true, // validateAuthConfig fails (mock doesn't create the secret)
-			validate: func(t *testing.T, p *mockProvider, app *appsv1.NebariApp) {
-				if p.provisionCount != 1 {
-					t.Errorf("expected ProvisionClient called once, got %d", p.provisionCount)
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app-oidc-client", Namespace: "default"},
+				Data:       map[string][]byte{constants.ClientSecretKey: []byte("test-secret")},
+			}
+
+			recorder := record.NewFakeRecorder(20)
+			reconciler := &AuthReconciler{
+				Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, secret).Build(),
+				Scheme:    scheme,
+				Recorder:  recorder,
+				Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: tt.provider},
+			}
+
+			// A second reconcile with an unchanged config must neither query the
+			// provider again nor repeat the warning event, but keeps the warning.
+			for range 2 {
+				if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+					t.Fatalf("unexpected error: %v", err)
 				}
-				if app.Status.AuthConfigHash == "stale-hash-will-not-match" {
-					t.Error("expected AuthConfigHash to be updated after provisioning")
+			}
+			if tt.provider.unknownScopesCount != 1 {
+				t.Errorf("expected the provider to be queried once, got %d", tt.provider.unknownScopesCount)
+			}
+
+			cond := conditions.GetCondition(app, appsv1.ConditionTypeAuthReady)
+			if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != appsv1.ReasonAuthConfigured {
+				t.Fatalf("expected AuthReady=True with reason %s, got %+v", appsv1.ReasonAuthConfigured, cond)
+			}
+			if strings.Contains(cond.Message, unknownScopesPrefix) != tt.expectWarning {
+				t.Errorf("expected warning in condition message=%v, got %q", tt.expectWarning, cond.Message)
+			}
+
+			warnings := 0
+			close(recorder.Events)
+			for event := range recorder.Events {
+				if strings.Contains(event, appsv1.EventReasonScopesUnknown) {
+					warnings++
 				}
-			},
+			}
+			if tt.expectWarning && warnings != 1 {
+				t.Errorf("expected one %s event, got %d", appsv1.EventReasonScopesUnknown, warnings)
+			}
+			if !tt.expectWarning && warnings != 0 {
+				t.Errorf("expected no %s events, got %d", appsv1.EventReasonScopesUnknown, warnings)
+			}
+		})
+	}
+}
+
+func TestReconcileAuth_GroupsScope(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name           string
+		groups         []string
+		scopes         []string
+		expectedScopes []string
+		expectWarning  bool
+	}{
+		{
+			name:           "groups scope added to the default scopes",
+			groups:         []string{"admins"},
+			expectedScopes: []string{"openid", "profile", "email", "groups"},
+			expectWarning:  true,
 		},
 		{
-			name: "Provision on first reconcile (no stored hash, AuthReady unset)",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
+			name:           "groups scope added to the requested scopes",
+			groups:         []string{"admins"},
+			scopes:         []string{"openid", "email"},
+			expectedScopes: []string{"openid", "email", "groups"},
+			expectWarning:  true,
+		},
+		{
+			name:           "groups scope already requested",
+			groups:         []string{"admins"},
+			scopes:         []string{"openid", "groups"},
+			expectedScopes: []string{"openid", "groups"},
+		},
+		{
+			name:           "no groups leaves the default scopes",
+			expectedScopes: []string{"openid", "profile", "email"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
 				Spec: appsv1.NebariAppSpec{
 					Hostname: "test.example.com",
 					Auth: &appsv1.AuthConfig{
 						Enabled:         true,
 						Provider:        constants.ProviderKeycloak,
 						ProvisionClient: ptr.To(true),
+						Groups:          tt.groups,
+						Scopes:          tt.scopes,
 					},
 				},
-			},
-			// No secret; validateAuthConfig will fail but provisioning must run first
-			provider: &mockProvider{
-				issuerURL:            "https://keycloak.example.com/realms/test",
-				clientID:             "test-client",
-				supportsProvisioning: true,
-			},
-			expectError: true,
-			validate: func(t *testing.T, p *mockProvider, app *appsv1.NebariApp) {
-				if p.provisionCount != 1 {
-					t.Errorf("expected ProvisionClient called once on first reconcile, got %d", p.provisionCount)
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app-oidc-client", Namespace: "default"},
+				Data:       map[string][]byte{constants.ClientSecretKey: []byte("test-secret")},
+			}
+
+			recorder := record.NewFakeRecorder(20)
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, secret).Build()
+			reconciler := &AuthReconciler{
+				Client:   client,
+				Scheme:   scheme,
+				Recorder: recorder,
+				Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: &mockProvider{
+					issuerURL:            "https://keycloak.example.com/realms/test",
+					clientID:             "test-client",
+					supportsProvisioning: true,
+				}},
+			}
+
+			// A second reconcile must not repeat the warning event.
+			for range 2 {
+				if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+					t.Fatalf("unexpected error: %v", err)
 				}
+			}
+
+			if !reflect.DeepEqual(app.Spec.Auth.Scopes, tt.scopes) {
+				t.Errorf("expected auth.scopes to stay %v, got %v", tt.scopes, app.Spec.Auth.Scopes)
+			}
+
+			policy := &egv1alpha1.SecurityPolicy{}
+			if err := client.Get(context.Background(),
+				types.NamespacedName{Name: "test-app-security", Namespace: "default"}, policy); err != nil {
+				t.Fatalf("failed to get SecurityPolicy: %v", err)
+			}
+			if !reflect.DeepEqual(policy.Spec.OIDC.Scopes, tt.expectedScopes) {
+				t.Errorf("expected SecurityPolicy scopes %v, got %v", tt.expectedScopes, policy.Spec.OIDC.Scopes)
+			}
+
+			warnings := 0
+			close(recorder.Events)
+			for event := range recorder.Events {
+				if strings.Contains(event, appsv1.EventReasonGroupsScopeAdded) {
+					warnings++
+				}
+			}
+			if tt.expectWarning && warnings != 1 {
+				t.Errorf("expected one %s event, got %d", appsv1.EventReasonGroupsScopeAdded, warnings)
+			}
+			if !tt.expectWarning && warnings != 0 {
+				t.Errorf("expected no %s events, got %d", appsv1.EventReasonGroupsScopeAdded, warnings)
+			}
+		})
+	}
+}
+
+// A forced re-provision clears its annotation without writing the scopes added
+// for groups, or anything else changed in memory, into the stored spec.
+func TestReconcileAuth_ForceReprovisionKeepsSpec(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-app",
+			Namespace:   "default",
+			Annotations: map[string]string{constants.AnnotationForceReprovision: "true"},
+		},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:         true,
+				Provider:        constants.ProviderKeycloak,
+				ProvisionClient: ptr.To(true),
+				Groups:          []string{"admins"},
 			},
 		},
+	}
+	wantSpec := app.Spec.DeepCopy()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app-oidc-client", Namespace: "default"},
+		Data:       map[string][]byte{constants.ClientSecretKey: []byte("test-secret")},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, secret).
+		WithStatusSubresource(&appsv1.NebariApp{}).Build()
+	reconciler := &AuthReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(20),
+		Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: &mockProvider{
+			issuerURL:            "https://keycloak.example.com/realms/test",
+			clientID:             "test-client",
+			supportsProvisioning: true,
+		}},
+	}
+
+	if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(&app.Spec, wantSpec) {
+		t.Errorf("expected the in-memory spec to be unchanged, got %+v", app.Spec.Auth)
+	}
+	// The status update that ends a reconcile must not conflict with the patch
+	if err := c.Status().Update(context.Background(), app); err != nil {
+		t.Fatalf("unexpected error updating status: %v", err)
+	}
+
+	stored := &appsv1.NebariApp{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test-app", Namespace: "default"}, stored); err != nil {
+		t.Fatalf("failed to get NebariApp: %v", err)
+	}
+	if _, ok := stored.Annotations[constants.AnnotationForceReprovision]; ok {
+		t.Error("expected force-reprovision annotation to be cleared")
+	}
+	if !reflect.DeepEqual(&stored.Spec, wantSpec) {
+		t.Errorf("expected the stored spec to be unchanged, got auth %+v", stored.Spec.Auth)
+	}
+}
+
+func TestReconcileAuth_AllowedScopes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+
+	commonScopes := []string{"openid", "profile", "email", "roles", "groups", "offline_access"}
+	tests := []struct {
+		name          string
+		allowedScopes []string
+		scopes        []string
+		groups        []string
+		expectDenied  string
+	}{
 		{
-			name: "Force re-provision via annotation overrides matching hash",
-			nebariApp: func() *appsv1.NebariApp {
-				app := &appsv1.NebariApp{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "test-app",
-						Namespace: "default",
-						Annotations: map[string]string{
-							constants.AnnotationForceReprovision: "true",
-						},
-					},
-					Spec: appsv1.NebariAppSpec{
-						Hostname: "test.example.com",
-						Auth: &appsv1.AuthConfig{
-							Enabled:         true,
-							Provider:        constants.ProviderKeycloak,
-							ProvisionClient: ptr.To(true),
-						},
-					},
-				}
-				// Hash matches and AuthReady=True, but annotation forces re-provision
-				app.Status.AuthConfigHash = computeAuthConfigHash(app)
-				app.Status.Conditions = []metav1.Condition{{
-					Type:               appsv1.ConditionTypeAuthReady,
-					Status:             metav1.ConditionTrue,
-					Reason:             "AuthConfigured",
-					LastTransitionTime: metav1.Now(),
-				}}
-				return app
-			}(),
-			existingSecret: &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app-oidc-client",
-					Namespace: "default",
-				},
-				Data: map[string][]byte{
-					constants.ClientSecretKey: []byte("test-secret"),
-				},
-			},
-			provider: &mockProvider{
-				issuerURL:            "https://keycloak.example.com/realms/test",
-				clientID:             "test-client",
-				supportsProvisioning: true,
-			},
-			expectError: false,
-			validate: func(t *testing.T, p *mockProvider, app *appsv1.NebariApp) {
-				if p.provisionCount != 1 {
-					t.Errorf("expected forced ProvisionClient called once, got %d", p.provisionCount)
-				}
-				if _, ok := app.Annotations[constants.AnnotationForceReprovision]; ok {
-					t.Error("expected force-reprovision annotation to be cleared after reconcile")
-				}
-			},
+			name:          "allowed scopes are accepted",
+			allowedScopes: commonScopes,
+			scopes:        []string{"openid", "email", "offline_access"},
+		},
+		{
+			name:          "scope outside the allowlist is rejected",
+			allowedScopes: commonScopes,
+			scopes:        []string{"openid", "admin"},
+			expectDenied:  "admin",
+		},
+		{
+			name:          "default allows any scope",
+			allowedScopes: constants.DefaultAllowedScopes,
+			scopes:        []string{"openid", "admin"},
+		},
+		{
+			name:          "default scopes are checked",
+			allowedScopes: []string{"openid", "email"},
+			expectDenied:  "profile",
+		},
+		{
+			name:          "groups scope added for auth.groups is checked",
+			allowedScopes: []string{"openid", "email"},
+			scopes:        []string{"openid", "email"},
+			groups:        []string{"admins"},
+			expectDenied:  "groups",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			builder := fake.NewClientBuilder().
-				WithScheme(scheme).
-				WithObjects(tt.nebariApp)
-
-			if tt.existingSecret != nil {
-				builder = builder.WithObjects(tt.existingSecret)
-			}
-			if tt.existingSecurityPolicy != nil {
-				builder = builder.WithObjects(tt.existingSecurityPolicy)
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Auth: &appsv1.AuthConfig{
+						Enabled:         true,
+						Provider:        constants.ProviderKeycloak,
+						ProvisionClient: ptr.To(true),
+						Scopes:          tt.scopes,
+						Groups:          tt.groups,
+					},
+				},
 			}
-
-			client := builder.Build()
-
-			providers := map[string]providers.OIDCProvider{}
-			if tt.provider != nil {
-				providers[constants.ProviderKeycloak] = tt.provider
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app-oidc-client", Namespace: "default"},
+				Data:       map[string][]byte{constants.ClientSecretKey: []byte("test-secret")},
 			}
 
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, secret).Build()
 			reconciler := &AuthReconciler{
-				Client:    client,
-				Scheme:    scheme,
-				Recorder:  record.NewFakeRecorder(10),
-				Providers: providers,
+				Client:        client,
+				Scheme:        scheme,
+				Recorder:      record.NewFakeRecorder(10),
+				AllowedScopes: tt.allowedScopes,
+				Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: &mockProvider{
+					issuerURL:            "https://keycloak.example.com/realms/test",
+					clientID:             "test-client",
+					supportsProvisioning: true,
+				}},
 			}
 
-			err := reconciler.ReconcileAuth(context.Background(), tt.nebariApp)
-
-			if tt.expectError && err == nil {
-				t.Error("expected error, got nil")
-			}
-			if !tt.expectError && err != nil {
-				t.Errorf("expected no error, got: %v", err)
+			err := reconciler.ReconcileAuth(context.Background(), app)
+			condition := conditions.GetCondition(app, appsv1.ConditionTypeAuthReady)
+			if condition == nil {
+				t.Fatal("expected an AuthReady condition")
 			}
 
-			// If auth is disabled and there was a pre-existing SecurityPolicy, verify it was deleted
-			if !tt.expectError && tt.nebariApp.Spec.Auth != nil && !tt.nebariApp.Spec.Auth.Enabled && tt.existingSecurityPolicy != nil {
-				securityPolicy := &egv1alpha1.SecurityPolicy{}
-				err := client.Get(context.Background(), types.NamespacedName{
-					Name:      naming.SecurityPolicyName(tt.nebariApp),
-					Namespace: tt.nebariApp.Namespace,
-				}, securityPolicy)
-				if err == nil {
-					t.Error("expected SecurityPolicy to be deleted when auth is disabled, but it still exists")
+			if tt.expectDenied == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
 				}
-			}
-
-			// If auth is enabled and no error, verify SecurityPolicy based on enforceAtGateway setting
-			if !tt.expectError && tt.nebariApp.Spec.Auth != nil && tt.nebariApp.Spec.Auth.Enabled {
-				securityPolicy := &egv1alpha1.SecurityPolicy{}
-				err := client.Get(context.Background(), types.NamespacedName{
-					Name:      naming.SecurityPolicyName(tt.nebariApp),
-					Namespace: tt.nebariApp.Namespace,
-				}, securityPolicy)
-
-				if shouldEnforceAtGateway(tt.nebariApp.Spec.Auth) {
-					if err != nil {
-						t.Errorf("expected SecurityPolicy to be created, got error: %v", err)
-					} else if securityPolicy.Spec.OIDC != nil {
-						verifyEndpointOverrides(t, securityPolicy, tt.expectedOverrides)
-					}
-				} else {
-					if err == nil {
-						t.Error("expected SecurityPolicy to NOT be created when enforceAtGateway=false")
-					}
+				if condition.Reason != appsv1.ReasonAuthConfigured {
+					t.Errorf("expected reason %s, got %s", appsv1.ReasonAuthConfigured, condition.Reason)
 				}
+				return
 			}
 
-			// Run additional per-test assertions if provided
-			if tt.validate != nil {
-				tt.validate(t, tt.provider, tt.nebariApp)
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("expected ErrInvalidConfig, got %v", err)
+			}
+			if condition.Status != metav1.ConditionFalse || condition.Reason != appsv1.ReasonScopeNotAllowed {
+				t.Errorf("expected AuthReady False/%s, got %s/%s",
+					appsv1.ReasonScopeNotAllowed, condition.Status, condition.Reason)
+			}
+			if !strings.Contains(condition.Message, tt.expectDenied) {
+				t.Errorf("expected the message to name the rejected scope, got %q", condition.Message)
+			}
+			policy := &egv1alpha1.SecurityPolicy{}
+			if err := client.Get(context.Background(),
+				types.NamespacedName{Name: "test-app-security", Namespace: "default"}, policy); err == nil {
+				t.Error("expected no SecurityPolicy for a rejected scope")
 			}
 		})
 	}
 }
 
-func TestCleanupAuth(t *testing.T) {
+func TestReconcileAuth_DegradedScopes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:         true,
+				Provider:        constants.ProviderKeycloak,
+				ProvisionClient: ptr.To(true),
+				Scopes:          []string{"openid", "profile", "groups"},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app-oidc-client", Namespace: "default"},
+		Data:       map[string][]byte{constants.ClientSecretKey: []byte("test-secret")},
+	}
+	provider := &mockProvider{
+		issuerURL:            "https://keycloak.example.com/realms/test",
+		clientID:             "test-client",
+		supportsProvisioning: true,
+		provisionError:       &providers.DegradedScopesError{Scopes: []string{"groups"}},
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &AuthReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, secret).Build(),
+		Scheme:    scheme,
+		Recorder:  recorder,
+		Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: provider},
+	}
+
+	if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+		t.Fatalf("expected degraded scopes not to fail reconcile, got: %v", err)
+	}
+
+	cond := conditions.GetCondition(app, appsv1.ConditionTypeAuthReady)
+	if cond == nil {
+		t.Fatal("expected AuthReady condition to be set")
+	}
+	if cond.Status != metav1.ConditionTrue || cond.Reason != appsv1.ReasonAuthDegraded {
+		t.Errorf("expected AuthReady=True/%s, got %s/%s", appsv1.ReasonAuthDegraded, cond.Status, cond.Reason)
+	}
+	if !strings.Contains(cond.Message, "groups") {
+		t.Errorf("expected AuthReady message to name the degraded scope, got %q", cond.Message)
+	}
+	if app.Status.AuthConfigHash != "" {
+		t.Errorf("expected auth config hash to stay unset so degraded scopes are retried, got %q", app.Status.AuthConfigHash)
+	}
+
+	warned := false
+	close(recorder.Events)
+	for event := range recorder.Events {
+		if strings.HasPrefix(event, corev1.EventTypeWarning+" "+appsv1.EventReasonScopesDegraded) {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("expected a %s warning event", appsv1.EventReasonScopesDegraded)
+	}
+
+	// Once the scopes can be provisioned the condition recovers and the hash is stored
+	provider.provisionError = nil
+	reconciler.Recorder = record.NewFakeRecorder(10)
+	if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.provisionCount != 2 {
+		t.Errorf("expected provisioning to be retried, called %d time(s)", provider.provisionCount)
+	}
+	cond = conditions.GetCondition(app, appsv1.ConditionTypeAuthReady)
+	if cond == nil || cond.Reason != appsv1.ReasonAuthConfigured {
+		t.Errorf("expected AuthReady reason %s after recovery, got %+v", appsv1.ReasonAuthConfigured, cond)
+	}
+	if app.Status.AuthConfigHash == "" {
+		t.Error("expected auth config hash to be stored after full provisioning")
+	}
+}
+
+func TestReconcileAuth_ProviderErrorReasons(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
 
+	cause := errors.New("keycloak said no")
 	tests := []struct {
-		name        string
-		nebariApp   *appsv1.NebariApp
-		provider    *mockProvider
-		expectError bool
+		name           string
+		provisionError error
+		expectedReason string
 	}{
 		{
-			name: "Auth not enabled",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
-				Spec: appsv1.NebariAppSpec{
-					Auth: &appsv1.AuthConfig{
-						Enabled: false,
-					},
-				},
-			},
-			expectError: false,
+			name:           "rejected credentials",
+			provisionError: &providers.ProviderError{Kind: providers.ErrAuthFailed, Err: cause},
+			expectedReason: appsv1.ReasonProviderAuthFailed,
 		},
 		{
-			name: "Cleanup with provisioning enabled",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
-				Spec: appsv1.NebariAppSpec{
-					Auth: &appsv1.AuthConfig{
-						Enabled:         true,
-						Provider:        constants.ProviderKeycloak,
-						ProvisionClient: ptr.To(true),
-					},
-				},
-			},
-			provider: &mockProvider{
-				supportsProvisioning: true,
-				deleteError:          nil,
-			},
-			expectError: false,
+			name:           "missing client",
+			provisionError: &providers.ProviderError{Kind: providers.ErrClientNotFound, Err: cause},
+			expectedReason: appsv1.ReasonClientNotFound,
 		},
 		{
-			name: "Cleanup with delete error",
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
+			name:           "unreachable provider behind a wrapped error",
+			provisionError: fmt.Errorf("failed to sync client scopes: %w", &providers.ProviderError{Kind: providers.ErrProviderUnreachable, Err: cause}),
+			expectedReason: appsv1.ReasonProviderUnreachable,
+		},
+		{
+			name:           "untyped error keeps the generic reason",
+			provisionError: cause,
+			expectedReason: appsv1.ReasonProvisioningFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
 				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
 					Auth: &appsv1.AuthConfig{
 						Enabled:         true,
 						Provider:        constants.ProviderKeycloak,
 						ProvisionClient: ptr.To(true),
 					},
 				},
-			},
-			provider: &mockProvider{
+			}
+			provider := &mockProvider{
+				issuerURL:            "https://keycloak.example.com/realms/test",
+				clientID:             "test-client",
 				supportsProvisioning: true,
-				deleteError:          errors.New("failed to delete client"),
-			},
-			expectError: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			client := fake.NewClientBuilder().
-				WithScheme(scheme).
-				WithObjects(tt.nebariApp).
-				Build()
-
-			providers := map[string]providers.OIDCProvider{}
-			if tt.provider != nil {
-				providers[constants.ProviderKeycloak] = tt.provider
+				provisionError:       tt.provisionError,
 			}
-
 			reconciler := &AuthReconciler{
-				Client:    client,
+				Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
 				Scheme:    scheme,
 				Recorder:  record.NewFakeRecorder(10),
-				Providers: providers,
+				Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: provider},
 			}
 
-			err := reconciler.CleanupAuth(context.Background(), tt.nebariApp)
-
-			if tt.expectError && err == nil {
-				t.Error("expected error, got nil")
+			if err := reconciler.ReconcileAuth(context.Background(), app); err == nil {
+				t.Fatal("expected an error, got nil")
 			}
-			if !tt.expectError && err != nil {
-				t.Errorf("expected no error, got: %v", err)
+			cond := conditions.GetCondition(app, appsv1.ConditionTypeAuthReady)
+			if cond == nil || cond.Status != metav1.ConditionFalse {
+				t.Fatalf("expected AuthReady=False, got %+v", cond)
+			}
+			if cond.Reason != tt.expectedReason {
+				t.Errorf("expected reason %s, got %s", tt.expectedReason, cond.Reason)
+			}
+			if !strings.Contains(cond.Message, cause.Error()) {
+				t.Errorf("expected the provider error in the message, got %q", cond.Message)
 			}
 		})
 	}
 }
 
-// TestComputeAuthConfigHash verifies the hash function's sensitivity and stability.
-// Each relevant spec field must produce a distinct hash when changed, and the hash
-// must be stable (same input → same output regardless of slice ordering).
-func TestComputeAuthConfigHash(t *testing.T) {
-	base := &appsv1.NebariApp{
-		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
-		Spec: appsv1.NebariAppSpec{
-			Hostname: "app.example.com",
-			Auth: &appsv1.AuthConfig{
-				Provider:    constants.ProviderKeycloak,
-				RedirectURI: "/oauth2/callback",
-				Scopes:      []string{"openid", "profile"},
-				Groups:      []string{"admins"},
-			},
-		},
+// TestAuthConditionReasons pins the AuthReady condition reasons. Alerts match on
+// these strings, so renaming one is a breaking change and must update this test.
+func TestAuthConditionReasons(t *testing.T) {
+	expected := map[string]string{
+		"ReasonAuthDisabled":                appsv1.ReasonAuthDisabled,
+		"ReasonAuthConfigured":              appsv1.ReasonAuthConfigured,
+		"ReasonAuthDegraded":                appsv1.ReasonAuthDegraded,
+		"ReasonInvalidProvider":             appsv1.ReasonInvalidProvider,
+		"ReasonProvisioningNotSupported":    appsv1.ReasonProvisioningNotSupported,
+		"ReasonProvisioningFailed":          appsv1.ReasonProvisioningFailed,
+		"ReasonRBACFailed":                  appsv1.ReasonRBACFailed,
+		"ReasonTokenExchangeFailed":         appsv1.ReasonTokenExchangeFailed,
+		"ReasonAuthValidationFailed":        appsv1.ReasonAuthValidationFailed,
+		"ReasonScopeNotAllowed":             appsv1.ReasonScopeNotAllowed,
+		"ReasonSecurityPolicyFailed":        appsv1.ReasonSecurityPolicyFailed,
+		"ReasonSecurityPolicyCleanupFailed": appsv1.ReasonSecurityPolicyCleanupFailed,
+		"ReasonSecurityPolicyConflict":      appsv1.ReasonSecurityPolicyConflict,
+		"ReasonIssuerUnreachable":           appsv1.ReasonIssuerUnreachable,
+		"ReasonClientCleanupFailed":         appsv1.ReasonClientCleanupFailed,
+		"ReasonProviderAuthFailed":          appsv1.ReasonProviderAuthFailed,
+		"ReasonClientNotFound":              appsv1.ReasonClientNotFound,
+		"ReasonProviderUnreachable":         appsv1.ReasonProviderUnreachable,
 	}
-
-	baseHash := computeAuthConfigHash(base)
-
-	t.Run("same spec produces same hash", func(t *testing.T) {
-		same := base.DeepCopy()
-		if computeAuthConfigHash(same) != baseHash {
-			t.Error("expected identical spec to produce the same hash")
-		}
-	})
-
-	t.Run("scope order does not change hash", func(t *testing.T) {
-		reordered := base.DeepCopy()
-		reordered.Spec.Auth.Scopes = []string{"profile", "openid"} // reversed
-		if computeAuthConfigHash(reordered) != baseHash {
-			t.Error("expected scope reordering to produce the same hash")
-		}
-	})
-
-	t.Run("group order does not change hash", func(t *testing.T) {
-		app := base.DeepCopy()
-		app.Spec.Auth.Groups = []string{"viewers", "admins"}
-		base2 := base.DeepCopy()
-		base2.Spec.Auth.Groups = []string{"admins", "viewers"}
-		if computeAuthConfigHash(app) != computeAuthConfigHash(base2) {
-			t.Error("expected group reordering to produce the same hash")
-		}
-	})
-
-	t.Run("different redirect URI changes hash", func(t *testing.T) {
-		changed := base.DeepCopy()
-		changed.Spec.Auth.RedirectURI = "/auth/callback"
-		if computeAuthConfigHash(changed) == baseHash {
-			t.Error("expected different redirectURI to produce a different hash")
-		}
-	})
-
-	t.Run("adding a group changes hash", func(t *testing.T) {
-		changed := base.DeepCopy()
-		changed.Spec.Auth.Groups = append(changed.Spec.Auth.Groups, "data-scientists")
-		if computeAuthConfigHash(changed) == baseHash {
-			t.Error("expected added group to produce a different hash")
+	stableValues := map[string]string{
+		"ReasonAuthDisabled":                "AuthDisabled",
+		"ReasonAuthConfigured":              "AuthConfigured",
+		"ReasonAuthDegraded":                "AuthDegraded",
+		"ReasonInvalidProvider":             "InvalidProvider",
+		"ReasonProvisioningNotSupported":    "ProvisioningNotSupported",
+		"ReasonProvisioningFailed":          "ProvisioningFailed",
+		"ReasonRBACFailed":                  "RBACFailed",
+		"ReasonTokenExchangeFailed":         "TokenExchangeFailed",
+		"ReasonAuthValidationFailed":        "ValidationFailed",
+		"ReasonScopeNotAllowed":             "ScopeNotAllowed",
+		"ReasonSecurityPolicyFailed":        "SecurityPolicyFailed",
+		"ReasonSecurityPolicyCleanupFailed": "SecurityPolicyCleanupFailed",
+		"ReasonSecurityPolicyConflict":      "SecurityPolicyConflict",
+		"ReasonIssuerUnreachable":           "IssuerUnreachable",
+		"ReasonClientCleanupFailed":         "ClientCleanupFailed",
+		"ReasonProviderAuthFailed":          "ProviderAuthFailed",
+		"ReasonClientNotFound":              "ClientNotFound",
+		"ReasonProviderUnreachable":         "ProviderUnreachable",
+	}
+	for name, value := range expected {
+		if value != stableValues[name] {
+			t.Errorf("%s changed: expected %q, got %q", name, stableValues[name], value)
 		}
-	})
+	}
 
-	t.Run("adding a scope changes hash", func(t *testing.T) {
-		changed := base.DeepCopy()
-		changed.Spec.Auth.Scopes = append(changed.Spec.Auth.Scopes, "groups")
-		if computeAuthConfigHash(changed) == baseHash {
-			t.Error("expected added scope to produce a different hash")
-		}
-	})
+	// Enumerate every reason the auth reconciler sets on AuthReady and make sure
+	// each one is a known constant rather than an inline string literal.
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "reconciler.go", nil, 0)
+	if err != nil {
+		t.Fatalf("failed to parse reconciler.go: %v", err)
+	}
 
-	t.Run("different hostname changes hash", func(t *testing.T) {
-		changed := base.DeepCopy()
-		changed.Spec.Hostname = "other.example.com"
-		if computeAuthConfigHash(changed) == baseHash {
-			t.Error("expected different hostname to produce a different hash")
+	used := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) < 4 {
+			return true
 		}
-	})
-
-	t.Run("different namespace changes hash", func(t *testing.T) {
-		changed := base.DeepCopy()
-		changed.Namespace = "production"
-		if computeAuthConfigHash(changed) == baseHash {
-			t.Error("expected different namespace to produce a different hash")
+		fn, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || fn.Sel.Name != "SetCondition" {
+			return true
 		}
-	})
-
-	t.Run("different provider changes hash", func(t *testing.T) {
-		changed := base.DeepCopy()
-		changed.Spec.Auth.Provider = constants.ProviderGenericOIDC
-		if computeAuthConfigHash(changed) == baseHash {
-			t.Error("expected different provider to produce a different hash")
+		condType, ok := call.Args[1].(*ast.SelectorExpr)
+		if !ok || condType.Sel.Name != "ConditionTypeAuthReady" {
+			return true
 		}
-	})
-
-	t.Run("adding keycloakConfig group changes hash", func(t *testing.T) {
-		changed := base.DeepCopy()
-		changed.Spec.Auth.KeycloakConfig = &appsv1.KeycloakClientConfig{
-			Groups: []appsv1.KeycloakGroup{{Name: "admins", Members: []string{"alice"}}},
+		reason, ok := call.Args[3].(*ast.SelectorExpr)
+		if !ok {
+			t.Errorf("%s: AuthReady reason must be an appsv1.Reason* constant", fset.Position(call.Pos()))
+			return true
 		}
-		if computeAuthConfigHash(changed) == baseHash {
-			t.Error("expected keycloakConfig change to produce a different hash")
+		if _, known := expected[reason.Sel.Name]; !known {
+			t.Errorf("%s: unknown AuthReady reason %s; add it to this test", fset.Position(call.Pos()), reason.Sel.Name)
 		}
+		used[reason.Sel.Name] = true
+		return true
 	})
 
-	t.Run("issuerURL change (generic-oidc) changes hash", func(t *testing.T) {
-		changed := base.DeepCopy()
-		changed.Spec.Auth.IssuerURL = "https://accounts.google.com"
-		if computeAuthConfigHash(changed) == baseHash {
-			t.Error("expected different issuerURL to produce a different hash")
+	for name := range expected {
+		if !used[name] {
+			t.Errorf("reason %s is no longer set by the auth reconciler", name)
 		}
-	})
+	}
 }
 
-// TestReconcileAuth_SpecChangeCycle simulates a realistic reconcile lifecycle:
-//  1. First reconcile: no stored hash, provisions and records the hash.
-//  2. Second reconcile: spec unchanged, provisioning is skipped.
-//  3. Spec mutation (redirect URI / group / scope): hash mismatches, provisions again.
-//
-// This validates the end-to-end guard behaviour for the changes described in #29.
-func TestReconcileAuth_SpecChangeCycle(t *testing.T) {
+// TestBuildStepUpSecurityPolicySpec verifies that the step-up SecurityPolicy
+// requests the step-up scopes on the step-up route while the base policy keeps
+// the base scopes on the main route.
+func TestBuildStepUpSecurityPolicySpec(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = appsv1.AddToScheme(scheme)
-	_ = corev1.AddToScheme(scheme)
-	_ = rbacv1.AddToScheme(scheme)
 	_ = egv1alpha1.AddToScheme(scheme)
 
-	// Helper: build a reconciler with a pre-populated fake client secret so
-	// validateAuthConfig passes after the mock ProvisionClient returns nil.
-	newReconcilerWithSecret := func(app *appsv1.NebariApp) (*AuthReconciler, *mockProvider) {
-		secret := &corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      naming.ClientSecretName(app),
-				Namespace: app.Namespace,
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:      true,
+				Provider:     constants.ProviderKeycloak,
+				Scopes:       []string{"openid", "profile"},
+				StepUpScopes: []string{"profile", "admin"},
+				StepUpPaths:  []string{"/admin/", "/api/billing"},
 			},
-			Data: map[string][]byte{constants.ClientSecretKey: []byte("s3cr3t")},
-		}
-		fakeClient := fake.NewClientBuilder().
-			WithScheme(scheme).
-			WithObjects(app, secret).
-			WithStatusSubresource(app).
-			Build()
-		provider := &mockProvider{
-			issuerURL:            "https://keycloak.example.com/realms/test",
-			clientID:             "test-app",
-			supportsProvisioning: true,
-		}
-		return &AuthReconciler{
-			Client:    fakeClient,
-			Scheme:    scheme,
-			Recorder:  record.NewFakeRecorder(32),
-			Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: provider},
-		}, provider
+		},
+	}
+	reconciler := &AuthReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+	provider := &mockProvider{
+		issuerURL: "https://keycloak.example.com/realms/test",
+		clientID:  "test-client",
+	}
+
+	base, err := reconciler.buildSecurityPolicySpec(context.Background(), app, provider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stepUp, err := reconciler.buildStepUpSecurityPolicySpec(context.Background(), app, provider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(base.OIDC.Scopes, []string{"openid", "profile"}) {
+		t.Errorf("expected base scopes [openid profile], got %v", base.OIDC.Scopes)
+	}
+	if len(base.TargetRefs) != 1 || base.TargetRefs[0].Name != "test-app-route" {
+		t.Errorf("expected base policy to target test-app-route, got %+v", base.TargetRefs)
+	}
+
+	if !reflect.DeepEqual(stepUp.OIDC.Scopes, []string{"openid", "profile", "admin"}) {
+		t.Errorf("expected step-up scopes [openid profile admin], got %v", stepUp.OIDC.Scopes)
+	}
+	if len(stepUp.TargetRefs) != 1 || stepUp.TargetRefs[0].Name != "test-app-stepup-route" {
+		t.Errorf("expected step-up policy to target test-app-stepup-route, got %+v", stepUp.TargetRefs)
 	}
+	if *stepUp.OIDC.RedirectURL != "https://test.example.com/admin/oauth2/callback" {
+		t.Errorf("expected step-up redirect URL under /admin, got %s", *stepUp.OIDC.RedirectURL)
+	}
+	if *base.OIDC.RedirectURL != "https://test.example.com/oauth2/callback" {
+		t.Errorf("expected base redirect URL to be unchanged, got %s", *base.OIDC.RedirectURL)
+	}
+}
 
+func TestValidateStepUp(t *testing.T) {
 	tests := []struct {
-		name    string
-		initial appsv1.AuthConfig
-		mutate  func(*appsv1.AuthConfig)
+		name             string
+		stepUpScopes     []string
+		stepUpPaths      []string
+		enforceAtGateway *bool
+		routes           []appsv1.RouteMatch
+		expectError      bool
 	}{
+		{name: "unset is allowed"},
+		{name: "scopes and paths", stepUpScopes: []string{"admin"}, stepUpPaths: []string{"/admin"}},
+		{name: "scopes without paths", stepUpScopes: []string{"admin"}, expectError: true},
+		{name: "paths without scopes", stepUpPaths: []string{"/admin"}, expectError: true},
 		{
-			name: "redirect URI change triggers re-provisioning",
-			initial: appsv1.AuthConfig{
-				Enabled:         true,
-				Provider:        constants.ProviderKeycloak,
-				ProvisionClient: ptr.To(true),
-				RedirectURI:     "/oauth2/callback",
-			},
-			mutate: func(a *appsv1.AuthConfig) { a.RedirectURI = "/auth/callback" },
+			name:             "gateway enforcement disabled",
+			stepUpScopes:     []string{"admin"},
+			stepUpPaths:      []string{"/admin"},
+			enforceAtGateway: ptr.To(false),
+			expectError:      true,
 		},
+		{name: "root path", stepUpScopes: []string{"admin"}, stepUpPaths: []string{"/"}, expectError: true},
 		{
-			name: "adding a group triggers re-provisioning",
-			initial: appsv1.AuthConfig{
-				Enabled:         true,
-				Provider:        constants.ProviderKeycloak,
-				ProvisionClient: ptr.To(true),
-				Groups:          []string{"admins"},
-			},
-			mutate: func(a *appsv1.AuthConfig) {
-				a.Groups = append(a.Groups, "data-scientists")
-			},
+			name:         "path repeats a route",
+			stepUpScopes: []string{"admin"},
+			stepUpPaths:  []string{"/admin"},
+			routes:       []appsv1.RouteMatch{{PathPrefix: "/admin/"}},
+			expectError:  true,
 		},
 		{
-			name: "adding a scope triggers re-provisioning",
-			initial: appsv1.AuthConfig{
-				Enabled:         true,
-				Provider:        constants.ProviderKeycloak,
-				ProvisionClient: ptr.To(true),
-				Scopes:          []string{"openid", "profile"},
-			},
-			mutate: func(a *appsv1.AuthConfig) {
-				a.Scopes = append(a.Scopes, "groups")
-			},
+			name:         "path below a route",
+			stepUpScopes: []string{"admin"},
+			stepUpPaths:  []string{"/app/admin"},
+			routes:       []appsv1.RouteMatch{{PathPrefix: "/app"}},
 		},
 		{
-			name: "adding a keycloakConfig group member triggers re-provisioning",
-			initial: appsv1.AuthConfig{
-				Enabled:         true,
-				Provider:        constants.ProviderKeycloak,
-				ProvisionClient: ptr.To(true),
-				KeycloakConfig: &appsv1.KeycloakClientConfig{
-					Groups: []appsv1.KeycloakGroup{{Name: "admins", Members: []string{"alice"}}},
-				},
-			},
-			mutate: func(a *appsv1.AuthConfig) {
-				a.KeycloakConfig.Groups[0].Members = append(a.KeycloakConfig.Groups[0].Members, "bob")
-			},
+			name:         "nested paths",
+			stepUpScopes: []string{"admin"},
+			stepUpPaths:  []string{"/admin", "/admin/users"},
+			expectError:  true,
+		},
+		{
+			name:         "paths sharing only a name prefix",
+			stepUpScopes: []string{"admin"},
+			stepUpPaths:  []string{"/admin", "/administration"},
+		},
+		{
+			name:         "route below a path",
+			stepUpScopes: []string{"admin"},
+			stepUpPaths:  []string{"/admin"},
+			routes:       []appsv1.RouteMatch{{PathPrefix: "/admin/api", Backends: []appsv1.WeightedBackend{{Name: "api", Port: 8080}}}},
+			expectError:  true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			app := &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+			nebariApp := &appsv1.NebariApp{
 				Spec: appsv1.NebariAppSpec{
-					Hostname: "test.example.com",
-					Auth:     tt.initial.DeepCopy(),
+					Auth: &appsv1.AuthConfig{
+						Enabled:          true,
+						StepUpScopes:     tt.stepUpScopes,
+						StepUpPaths:      tt.stepUpPaths,
+						EnforceAtGateway: tt.enforceAtGateway,
+					},
+					Routing: &appsv1.RoutingConfig{Routes: tt.routes},
 				},
 			}
-			reconciler, provider := newReconcilerWithSecret(app)
-
-			// --- Reconcile 1: first time, no stored hash ---
-			if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
-				t.Fatalf("reconcile 1 failed: %v", err)
-			}
-			if provider.provisionCount != 1 {
-				t.Fatalf("reconcile 1: expected ProvisionClient called once, got %d", provider.provisionCount)
+			err := validateStepUp(nebariApp)
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
 			}
-			hashAfterFirst := app.Status.AuthConfigHash
-			if hashAfterFirst == "" {
-				t.Fatal("reconcile 1: expected AuthConfigHash to be set after provisioning")
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
 			}
+		})
+	}
+}
 
-			// --- Reconcile 2: same spec, AuthReady=True → must skip ---
-			if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
-				t.Fatalf("reconcile 2 failed: %v", err)
-			}
-			if provider.provisionCount != 1 {
-				t.Errorf("reconcile 2: expected provisioning to be skipped (count=1), got %d", provider.provisionCount)
-			}
+func TestBuildSecurityPolicySpec_Optional(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
 
-			// --- Spec mutation ---
-			tt.mutate(app.Spec.Auth)
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:  true,
+				Provider: constants.ProviderKeycloak,
+				Optional: true,
+			},
+		},
+	}
+	reconciler := &AuthReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+	jwksURI := "https://keycloak.example.com/realms/test/protocol/openid-connect/certs"
+	provider := &mockProvider{
+		issuerURL:         "https://keycloak.example.com/realms/test",
+		clientID:          "test-client",
+		endpointOverrides: providers.OIDCEndpointOverrides{JWKS: ptr.To(jwksURI)},
+	}
 
-			// --- Reconcile 3: spec changed, hash mismatch → must re-provision ---
-			if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
-				t.Fatalf("reconcile 3 failed: %v", err)
-			}
-			if provider.provisionCount != 2 {
-				t.Errorf("reconcile 3: expected ProvisionClient called again (count=2), got %d", provider.provisionCount)
+	main, err := reconciler.buildSecurityPolicySpec(context.Background(), app, provider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Anonymous requests are not redirected or denied: there is no OIDC
+	// filter, no authorization rules, and a missing JWT is accepted.
+	if main.OIDC != nil {
+		t.Errorf("expected no OIDC login on the main policy, got %+v", main.OIDC)
+	}
+	if main.Authorization != nil {
+		t.Errorf("expected no authorization rules, got %+v", main.Authorization)
+	}
+	if main.JWT == nil || main.JWT.Optional == nil || !*main.JWT.Optional {
+		t.Fatalf("expected an optional JWT, got %+v", main.JWT)
+	}
+	jwtProvider := main.JWT.Providers[0]
+	if jwtProvider.RemoteJWKS == nil || jwtProvider.RemoteJWKS.URI != jwksURI {
+		t.Errorf("expected JWKS %s, got %+v", jwksURI, jwtProvider.RemoteJWKS)
+	}
+	expectedExtract := &egv1alpha1.JWTExtractor{
+		Headers: []egv1alpha1.JWTHeaderExtractor{{Name: "Authorization", ValuePrefix: ptr.To("Bearer ")}},
+		Cookies: []string{"AccessToken-default-test-app"},
+	}
+	if !reflect.DeepEqual(jwtProvider.ExtractFrom, expectedExtract) {
+		t.Errorf("expected token from the Authorization header and session cookie, got %+v", jwtProvider.ExtractFrom)
+	}
+	// Keycloak access tokens from the login session do not carry the client ID
+	// as audience, so none is checked unless jwt.audiences sets one.
+	if len(jwtProvider.Audiences) != 0 {
+		t.Errorf("expected no audience check for session tokens, got %v", jwtProvider.Audiences)
+	}
+	if len(main.TargetRefs) != 1 || main.TargetRefs[0].Name != "test-app-route" {
+		t.Errorf("expected main policy to target test-app-route, got %+v", main.TargetRefs)
+	}
+
+	login, err := reconciler.buildLoginSecurityPolicySpec(context.Background(), app, provider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if login.OIDC == nil {
+		t.Fatal("expected the login policy to run the OIDC flow")
+	}
+	if len(login.TargetRefs) != 1 || login.TargetRefs[0].Name != "test-app-login-route" {
+		t.Errorf("expected login policy to target test-app-login-route, got %+v", login.TargetRefs)
+	}
+	if login.OIDC.CookieNames == nil || login.OIDC.CookieNames.AccessToken == nil ||
+		*login.OIDC.CookieNames.AccessToken != "AccessToken-default-test-app" {
+		t.Errorf("expected the access token cookie shared with the main policy, got %+v", login.OIDC.CookieNames)
+	}
+	if login.OIDC.DisableTokenEncryption == nil || !*login.OIDC.DisableTokenEncryption {
+		t.Error("expected an unencrypted access token cookie the JWT filter can read")
+	}
+	if *login.OIDC.RedirectURL != "https://test.example.com/oauth2/callback" {
+		t.Errorf("expected the default callback, got %s", *login.OIDC.RedirectURL)
+	}
+}
+
+func TestValidateOptional(t *testing.T) {
+	tests := []struct {
+		name        string
+		auth        appsv1.AuthConfig
+		expectError bool
+	}{
+		{name: "not optional", auth: appsv1.AuthConfig{Groups: []string{"admins"}, BearerOnly: true}},
+		{name: "optional", auth: appsv1.AuthConfig{Optional: true}},
+		{name: "optional with step-up", auth: appsv1.AuthConfig{Optional: true, StepUpScopes: []string{"admin"}, StepUpPaths: []string{"/admin"}}},
+		{name: "gateway enforcement disabled", auth: appsv1.AuthConfig{Optional: true, EnforceAtGateway: ptr.To(false)}, expectError: true},
+		{name: "bearer only", auth: appsv1.AuthConfig{Optional: true, BearerOnly: true}, expectError: true},
+		{name: "groups checked by the IdP", auth: appsv1.AuthConfig{Optional: true, Groups: []string{"admins"}}},
+		{name: "groups enforced at the gateway", auth: appsv1.AuthConfig{Optional: true, Groups: []string{"admins"}, EnforceGroupsAtGateway: true}, expectError: true},
+		{name: "email domains", auth: appsv1.AuthConfig{Optional: true, AllowedEmailDomains: []string{"example.com"}}, expectError: true},
+		{name: "callback on the login path", auth: appsv1.AuthConfig{Optional: true, RedirectURI: "/oauth2/login"}, expectError: true},
+		{name: "authenticated request headers", auth: appsv1.AuthConfig{Optional: true,
+			AuthenticatedRequestHeaders: []appsv1.HeaderValue{{Name: "X-Authenticated", Value: "true"}}}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := tt.auth
+			err := validateOptional(&auth)
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
 			}
-			if app.Status.AuthConfigHash == hashAfterFirst {
-				t.Error("reconcile 3: expected AuthConfigHash to be updated after spec change")
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
 			}
+		})
+	}
+}
 
-			// --- Reconcile 4: spec stable again → skip ---
-			if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
-				t.Fatalf("reconcile 4 failed: %v", err)
+func TestValidateGatewayAuthorization(t *testing.T) {
+	jwt := &appsv1.JWTAuthConfig{Enabled: true}
+	tests := []struct {
+		name        string
+		auth        appsv1.AuthConfig
+		expectError bool
+	}{
+		{name: "groups not enforced", auth: appsv1.AuthConfig{Groups: []string{"admins"}, JWT: jwt, ForwardAccessToken: ptr.To(false)}},
+		{name: "enforced groups", auth: appsv1.AuthConfig{Groups: []string{"admins"}, EnforceGroupsAtGateway: true, JWT: jwt}},
+		{name: "enforced groups forwarding the token", auth: appsv1.AuthConfig{Groups: []string{"admins"}, EnforceGroupsAtGateway: true, JWT: jwt, ForwardAccessToken: ptr.To(true)}},
+		{name: "enforcement without groups", auth: appsv1.AuthConfig{EnforceGroupsAtGateway: true, JWT: jwt}, expectError: true},
+		{name: "enforced groups with forwarding refused", auth: appsv1.AuthConfig{Groups: []string{"admins"}, EnforceGroupsAtGateway: true, JWT: jwt, ForwardAccessToken: ptr.To(false)}, expectError: true},
+		{name: "email domains", auth: appsv1.AuthConfig{AllowedEmailDomains: []string{"example.com"}, EmailDomainClaim: "hd", JWT: jwt}},
+		{name: "email domains without a claim", auth: appsv1.AuthConfig{AllowedEmailDomains: []string{"example.com"}, JWT: jwt}, expectError: true},
+		{name: "email domains with forwarding refused", auth: appsv1.AuthConfig{AllowedEmailDomains: []string{"example.com"}, EmailDomainClaim: "hd", JWT: jwt, ForwardAccessToken: ptr.To(false)}, expectError: true},
+		{name: "bearer only has no browser sessions", auth: appsv1.AuthConfig{Groups: []string{"admins"}, EnforceGroupsAtGateway: true, BearerOnly: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := tt.auth
+			err := validateGatewayAuthorization(&auth)
+			if tt.expectError && err == nil {
+				t.Error("expected error, got nil")
 			}
-			if provider.provisionCount != 2 {
-				t.Errorf("reconcile 4: expected provisioning to be skipped again (count=2), got %d", provider.provisionCount)
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error, got: %v", err)
 			}
 		})
 	}
 }
+
+func TestReconcileAuth_SecurityPolicyConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "app-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.example.com",
+			Auth: &appsv1.AuthConfig{
+				Enabled:         true,
+				Provider:        constants.ProviderKeycloak,
+				ProvisionClient: ptr.To(false),
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: naming.ClientSecretName(app), Namespace: app.Namespace},
+		Data:       map[string][]byte{constants.ClientSecretKey: []byte("s3cr3t")},
+	}
+	// A user-created policy on the app's main HTTPRoute.
+	userPolicy := &egv1alpha1.SecurityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "user-basic-auth", Namespace: "default"},
+		Spec: egv1alpha1.SecurityPolicySpec{
+			PolicyTargetReferences: egv1alpha1.PolicyTargetReferences{
+				TargetRefs: []gwapiv1.LocalPolicyTargetReferenceWithSectionName{{
+					LocalPolicyTargetReference: gwapiv1.LocalPolicyTargetReference{
+						Group: "gateway.networking.k8s.io",
+						Kind:  "HTTPRoute",
+						Name:  "test-app-route",
+					},
+				}},
+			},
+		},
+	}
+	// A policy on some other route does not conflict.
+	otherPolicy := userPolicy.DeepCopy()
+	otherPolicy.Name = "other-app-security"
+	otherPolicy.Spec.TargetRefs[0].Name = "other-app-route"
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(app, secret, userPolicy, otherPolicy).
+		Build()
+	recorder := record.NewFakeRecorder(32)
+	reconciler := &AuthReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Recorder: recorder,
+		Providers: map[string]providers.OIDCProvider{constants.ProviderKeycloak: &mockProvider{
+			issuerURL: "https://keycloak.example.com/realms/test",
+			clientID:  "test-app",
+		}},
+	}
+
+	if err := reconciler.ReconcileAuth(context.Background(), app); err == nil {
+		t.Fatal("expected a conflict error, got nil")
+	}
+
+	cond := conditions.GetCondition(app, appsv1.ConditionTypeAuthReady)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != appsv1.ReasonSecurityPolicyConflict {
+		t.Fatalf("expected AuthReady=False with reason %s, got %+v", appsv1.ReasonSecurityPolicyConflict, cond)
+	}
+	if !strings.Contains(cond.Message, "user-basic-auth") || strings.Contains(cond.Message, "other-app-security") {
+		t.Errorf("expected the message to name only the conflicting policy, got %q", cond.Message)
+	}
+
+	// The operator does not add its own policy next to the conflicting one.
+	err := fakeClient.Get(context.Background(), types.NamespacedName{Name: naming.SecurityPolicyName(app), Namespace: "default"},
+		&egv1alpha1.SecurityPolicy{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected no operator SecurityPolicy, got err=%v", err)
+	}
+
+	// Removing the user policy clears the conflict.
+	if err := fakeClient.Delete(context.Background(), userPolicy); err != nil {
+		t.Fatalf("failed to delete user policy: %v", err)
+	}
+	if err := reconciler.ReconcileAuth(context.Background(), app); err != nil {
+		t.Fatalf("expected reconcile to succeed once the conflict is gone, got: %v", err)
+	}
+	if !conditions.IsConditionTrue(app, appsv1.ConditionTypeAuthReady) {
+		t.Errorf("expected AuthReady=True, got %+v", app.Status.Conditions)
+	}
+}
@@ -19,14 +19,20 @@ package core
 import (
 	"context"
 	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
 
 	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/conditions"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -37,11 +43,33 @@ type CoreReconciler struct {
 	Client   client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// ProtectedNamespaces lists namespaces whose NebariApps are always refused,
+	// regardless of the opt-in label. Nil means constants.DefaultProtectedNamespaces.
+	ProtectedNamespaces []string
+
+	// ManagedBy is the app.kubernetes.io/managed-by value on the resources it
+	// creates and selects on. Empty means constants.DefaultManagedBy.
+	ManagedBy string
 }
 
-func (r *CoreReconciler) ValidateSpec(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+// ValidateSpec checks the NebariApp's spec and namespace, setting the Ready
+// condition and recording an event for the first failure. It returns the port
+// of spec.service, with a portName resolved on the Service, for the routing
+// phase; the spec itself is not changed.
+func (r *CoreReconciler) ValidateSpec(ctx context.Context, nebariApp *appsv1.NebariApp) (servicePort int32, err error) {
 	logger := log.FromContext(ctx)
 
+	// Refuse system namespaces before looking at the opt-in label, so a stray
+	// label on kube-system cannot hand it to the operator
+	if err := ValidateNamespaceNotProtected(nebariApp, r.protectedNamespaces()); err != nil {
+		logger.Error(err, "Namespace validation failed")
+		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonProtectedNamespace, err.Error())
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
+			appsv1.ReasonProtectedNamespace, err.Error())
+		return 0, err
+	}
+
 	// Validate namespace is opted-in
 	if err := ValidateNamespaceOptIn(ctx, r.Client, nebariApp); err != nil {
 		logger.Error(err, "Namespace validation failed")
@@ -49,7 +77,7 @@ func (r *CoreReconciler) ValidateSpec(ctx context.Context, nebariApp *appsv1.Neb
 		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonNamespaceNotOptIn, err.Error())
 		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
 			appsv1.ReasonNamespaceNotOptedIn, err.Error())
-		return err
+		return 0, err
 	}
 
 	// Validate that derived resource names fit within Kubernetes naming limits
@@ -58,17 +86,86 @@ func (r *CoreReconciler) ValidateSpec(ctx context.Context, nebariApp *appsv1.Neb
 		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, "ResourceNameTooLong", err.Error())
 		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
 			"ResourceNameTooLong", err.Error())
-		return err
+		return 0, err
+	}
+
+	// Reject routing options that contradict each other before checking them one by one
+	if err := ValidateExclusiveRoutingOptions(nebariApp); err != nil {
+		logger.Error(err, "Conflicting routing options")
+		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonValidationFailed, err.Error())
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
+			appsv1.ReasonConflictingRoutingOptions, err.Error())
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
+			appsv1.ReasonConflictingRoutingOptions, err.Error())
+		return 0, err
+	}
+
+	// Validate that routing entries do not conflict with each other
+	if err := ValidateRoutes(nebariApp); err != nil {
+		logger.Error(err, "Route validation failed")
+		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonValidationFailed, err.Error())
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
+			appsv1.ReasonInvalidRoutes, err.Error())
+		return 0, err
+	}
+
+	// Reject duplicate path entries, which point at a copy-paste config error
+	if err := ValidateUniqueRoutes(nebariApp); err != nil {
+		logger.Error(err, "Duplicate routes found")
+		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonValidationFailed, err.Error())
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
+			appsv1.ReasonDuplicateRoutes, err.Error())
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
+			appsv1.ReasonDuplicateRoutes, err.Error())
+		return 0, err
+	}
+
+	// Apps sharing a hostname must each route their own paths
+	if err := ValidateSharedHostnamePaths(ctx, r.Client, nebariApp); err != nil {
+		logger.Error(err, "Path conflict with another NebariApp")
+		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonValidationFailed, err.Error())
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
+			appsv1.ReasonPathConflict, err.Error())
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
+			appsv1.ReasonPathConflict, err.Error())
+		return 0, err
+	}
+
+	// Only route to backend kinds Envoy Gateway is known to support
+	if err := ValidateBackendKinds(nebariApp); err != nil {
+		logger.Error(err, "Backend kind validation failed")
+		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonValidationFailed, err.Error())
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
+			appsv1.ReasonUnsupportedBackendKind, err.Error())
+		return 0, err
+	}
+
+	// external-dns needs a hostname or an address to point the app at
+	if err := ValidateDNSTarget(nebariApp); err != nil {
+		logger.Error(err, "DNS target validation failed")
+		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonValidationFailed, err.Error())
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
+			appsv1.ReasonInvalidDNSTarget, err.Error())
+		return 0, err
+	}
+
+	// Stand in for a Service that has not been applied yet, when asked to
+	if err := r.ReconcileServiceStub(ctx, nebariApp); err != nil {
+		logger.Error(err, "Service stub reconciliation failed")
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
+			appsv1.ReasonFailed, err.Error())
+		return 0, err
 	}
 
 	// Validate referenced service exists and has the specified port
-	if err := ValidateService(ctx, r.Client, nebariApp); err != nil {
+	servicePort, err = ValidateService(ctx, r.Client, nebariApp)
+	if err != nil {
 		logger.Error(err, "Service validation failed")
 		// Send event and set condition
 		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonServiceNotFound, err.Error())
 		conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionFalse,
 			appsv1.ReasonServiceNotFound, err.Error())
-		return err
+		return 0, err
 	}
 
 	logger.Info("Core validation passed", "nebariapp", nebariApp.Name)
@@ -86,7 +183,7 @@ func (r *CoreReconciler) ValidateSpec(ctx context.Context, nebariApp *appsv1.Neb
 	conditions.SetCondition(nebariApp, appsv1.ConditionTypeReady, metav1.ConditionTrue,
 		appsv1.ReasonValidationSuccess, "Core validation passed")
 
-	return nil
+	return servicePort, nil
 }
 
 const (
@@ -98,6 +195,24 @@ const (
 // All validation of the required spec fields for NebariApp resources.
 // ####################################################################
 
+// protectedNamespaces returns the configured protected namespaces, falling back
+// to the defaults when none are configured.
+func (r *CoreReconciler) protectedNamespaces() []string {
+	if r.ProtectedNamespaces == nil {
+		return constants.DefaultProtectedNamespaces
+	}
+	return r.ProtectedNamespaces
+}
+
+// ValidateNamespaceNotProtected returns an error if the NebariApp lives in one of
+// the protected namespaces.
+func ValidateNamespaceNotProtected(nebariApp *appsv1.NebariApp, protected []string) error {
+	if slices.Contains(protected, nebariApp.Namespace) {
+		return fmt.Errorf("namespace %s is protected and cannot host NebariApps", nebariApp.Namespace)
+	}
+	return nil
+}
+
 // ValidateNamespaceOptIn checks if the namespace has the required label for Nebari management.
 // Returns an error if the namespace is not opted-in or cannot be accessed.
 func ValidateNamespaceOptIn(ctx context.Context, c client.Client, nebariApp *appsv1.NebariApp) error {
@@ -115,9 +230,95 @@ func ValidateNamespaceOptIn(ctx context.Context, c client.Client, nebariApp *app
 	return nil
 }
 
+// backendKind identifies a backend by API group and kind.
+type backendKind struct{ group, kind string }
+
+// supportedBackendKinds lists the backend group/kind pairs Envoy Gateway can route to.
+var supportedBackendKinds = map[backendKind]bool{
+	{group: "", kind: "Service"}:                            true,
+	{group: "gateway.envoyproxy.io", kind: "Backend"}:       true,
+	{group: "multicluster.x-k8s.io", kind: "ServiceImport"}: true,
+}
+
+// kindOf returns the group/kind of a service reference, defaulting to core Service.
+func kindOf(ref appsv1.ServiceReference) backendKind {
+	kind := ref.Kind
+	if kind == "" {
+		kind = "Service"
+	}
+	return backendKind{group: ref.Group, kind: kind}
+}
+
+// ValidateDNSTarget checks that routing.dnsTarget, when set, is an IP address
+// or a DNS hostname.
+func ValidateDNSTarget(nebariApp *appsv1.NebariApp) error {
+	if nebariApp.Spec.Routing == nil || nebariApp.Spec.Routing.DNSTarget == "" {
+		return nil
+	}
+	target := nebariApp.Spec.Routing.DNSTarget
+	if net.ParseIP(target) != nil {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(target); len(errs) > 0 {
+		return fmt.Errorf("routing.dnsTarget %q is not a hostname or IP address: %s", target, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ValidateBackendKinds checks that spec.service and every experiment's primary
+// and mirror reference use a supported group/kind pair.
+func ValidateBackendKinds(nebariApp *appsv1.NebariApp) error {
+	if err := validateBackendKind("service", nebariApp.Spec.Service); err != nil {
+		return err
+	}
+	if nebariApp.Spec.Routing == nil {
+		return nil
+	}
+	if err := validateExperimentBackendKinds("routes", nebariApp.Spec.Routing.Routes); err != nil {
+		return err
+	}
+	return validateExperimentBackendKinds("publicRoutes", nebariApp.Spec.Routing.PublicRoutes)
+}
+
+// validateExperimentBackendKinds checks the primary and mirror references of
+// every experiment in a routes list.
+func validateExperimentBackendKinds(field string, routes []appsv1.RouteMatch) error {
+	for i, route := range routes {
+		if route.Experiment == nil {
+			continue
+		}
+		if err := validateBackendKind(fmt.Sprintf("routing.%s[%d].experiment.primary", field, i), route.Experiment.Primary); err != nil {
+			return err
+		}
+		if err := validateBackendKind(fmt.Sprintf("routing.%s[%d].experiment.mirror", field, i), route.Experiment.Mirror); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateBackendKind returns an error when ref names an unsupported group/kind pair,
+// or sets a portName on a backend that is not a Service.
+func validateBackendKind(field string, ref appsv1.ServiceReference) error {
+	if k := kindOf(ref); !supportedBackendKinds[k] {
+		return fmt.Errorf("%s: unsupported backend group %q kind %q", field, k.group, k.kind)
+	}
+	if ref.PortName != "" && kindOf(ref) != (backendKind{kind: "Service"}) {
+		return fmt.Errorf("%s: portName is only supported for Service backends", field)
+	}
+	return nil
+}
+
 // ValidateService checks if the referenced service exists in the namespace and has the specified port.
-// Returns an error if the service doesn't exist or the port is not exposed.
-func ValidateService(ctx context.Context, c client.Client, nebariApp *appsv1.NebariApp) error {
+// It returns the backend port: spec.service.port, or the number of the named port when
+// spec.service.portName is set. The spec is not changed, so the stored NebariApp keeps the name.
+// Returns an error if the service doesn't exist or the port is not exposed. References to
+// other backend kinds are not checked.
+func ValidateService(ctx context.Context, c client.Client, nebariApp *appsv1.NebariApp) (int32, error) {
+	if kindOf(nebariApp.Spec.Service) != (backendKind{kind: "Service"}) {
+		return nebariApp.Spec.Service.Port, nil
+	}
+
 	service := &corev1.Service{}
 
 	// Use specified service namespace, or default to NebariApp's namespace
@@ -133,10 +334,20 @@ func ValidateService(ctx context.Context, c client.Client, nebariApp *appsv1.Neb
 
 	if err := c.Get(ctx, serviceKey, service); err != nil {
 		if errors.IsNotFound(err) {
-			return fmt.Errorf("service %s not found in namespace %s",
+			return 0, fmt.Errorf("service %s not found in namespace %s",
 				nebariApp.Spec.Service.Name, serviceNamespace)
 		}
-		return fmt.Errorf("failed to get service: %w", err)
+		return 0, fmt.Errorf("failed to get service: %w", err)
+	}
+
+	// Resolve a named port to its current number on the service
+	if name := nebariApp.Spec.Service.PortName; name != "" {
+		for _, port := range service.Spec.Ports {
+			if port.Name == name {
+				return port.Port, nil
+			}
+		}
+		return 0, fmt.Errorf("service %s has no port named %q", nebariApp.Spec.Service.Name, name)
 	}
 
 	// Validate that the specified port exists on the service
@@ -149,9 +360,461 @@ func ValidateService(ctx context.Context, c client.Client, nebariApp *appsv1.Neb
 	}
 
 	if !portFound {
-		return fmt.Errorf("service %s does not expose port %d",
+		return 0, fmt.Errorf("service %s does not expose port %d",
 			nebariApp.Spec.Service.Name, nebariApp.Spec.Service.Port)
 	}
 
+	return nebariApp.Spec.Service.Port, nil
+}
+
+// gatewayPathPattern matches the characters the Gateway API accepts in an
+// HTTPRoute path match value.
+var gatewayPathPattern = regexp.MustCompile(`^(?:[-A-Za-z0-9/._~!$&'()*+,;=:@]|[%][0-9a-fA-F]{2})+$`)
+
+// maxProbePathLength is the Gateway API limit on a path match value.
+const maxProbePathLength = 1024
+
+// validProbePath reports whether path can be exempted as a probe path: an
+// absolute path other than "/" that the Gateway API accepts as a path match.
+func validProbePath(path string) bool {
+	if path == "/" || !strings.HasPrefix(path, "/") || len(path) > maxProbePathLength ||
+		!gatewayPathPattern.MatchString(path) {
+		return false
+	}
+	for _, s := range []string{"//", "/./", "/../", "%2f", "%2F", "#"} {
+		if strings.Contains(path, s) {
+			return false
+		}
+	}
+	return !strings.HasSuffix(path, "/.") && !strings.HasSuffix(path, "/..")
+}
+
+// ProbePublicRoutes returns Exact route matches for the probe paths the app's
+// backend Service lists in its nebari.dev/probe-paths annotation. Blank entries
+// and paths routing.publicRoutes already matches exactly are skipped. Entries
+// that are not valid probe paths, including "/", are skipped and returned as
+// ignored. Apps backed by other kinds have no probe paths.
+func ProbePublicRoutes(ctx context.Context, c client.Client, nebariApp *appsv1.NebariApp) (routes []appsv1.RouteMatch, ignored []string, err error) {
+	if kindOf(nebariApp.Spec.Service) != (backendKind{kind: "Service"}) {
+		return nil, nil, nil
+	}
+
+	serviceNamespace := nebariApp.Spec.Service.Namespace
+	if serviceNamespace == "" {
+		serviceNamespace = nebariApp.Namespace
+	}
+	service := &corev1.Service{}
+	if err := c.Get(ctx, client.ObjectKey{Name: nebariApp.Spec.Service.Name, Namespace: serviceNamespace}, service); err != nil {
+		return nil, nil, fmt.Errorf("failed to get service: %w", err)
+	}
+
+	exempt := make(map[string]bool)
+	if nebariApp.Spec.Routing != nil {
+		for _, route := range nebariApp.Spec.Routing.PublicRoutes {
+			if pathTypeOrDefault(route, "Exact") == "Exact" {
+				exempt[route.PathPrefix] = true
+			}
+		}
+	}
+
+	for _, entry := range strings.Split(service.Annotations[constants.AnnotationProbePaths], ",") {
+		path := strings.TrimSpace(entry)
+		if path == "" || exempt[path] {
+			continue
+		}
+		if !validProbePath(path) {
+			ignored = append(ignored, path)
+			continue
+		}
+		exempt[path] = true
+		routes = append(routes, appsv1.RouteMatch{PathPrefix: path, PathType: "Exact"})
+	}
+	return routes, ignored, nil
+}
+
+// ValidateRoutes checks routing.routes and routing.publicRoutes for conflicting entries.
+// A redirect route must not share its path match with a route that forwards to the
+// backend service, since the same request cannot be both redirected and proxied.
+// Routes with weighted backends must not redirect and must carry a non-zero total weight,
+// and experiment routes must not also redirect or split traffic across backends.
+// A per-route timeout is only accepted on routes that get a rule of their own.
+func ValidateRoutes(nebariApp *appsv1.NebariApp) error {
+	if nebariApp.Spec.Routing == nil {
+		return nil
+	}
+
+	if err := validateRedirectConflicts("routes", nebariApp.Spec.Routing.Routes, "PathPrefix"); err != nil {
+		return err
+	}
+	if err := validateRedirectConflicts("publicRoutes", nebariApp.Spec.Routing.PublicRoutes, "Exact"); err != nil {
+		return err
+	}
+	if err := validateBackendWeights("routes", nebariApp.Spec.Routing.Routes); err != nil {
+		return err
+	}
+	if err := validateBackendWeights("publicRoutes", nebariApp.Spec.Routing.PublicRoutes); err != nil {
+		return err
+	}
+	if err := validateRouteTimeouts("routes", nebariApp.Spec.Routing.Routes); err != nil {
+		return err
+	}
+	if err := validateRouteTimeouts("publicRoutes", nebariApp.Spec.Routing.PublicRoutes); err != nil {
+		return err
+	}
+	return validateGatewayPort(nebariApp.Spec.Routing.GatewayPort)
+}
+
+// ValidateExclusiveRoutingOptions rejects routing settings that contradict each
+// other. Each route sends its traffic through at most one of redirect, backends
+// and experiment, and a redirect never reaches a backend that could time out.
+// Public routes cannot match on headers, which the client controls. routing.tls
+// uses either secretName or gatewayRef. The CRD schema does not
+// cover every combination, so these are checked at reconcile time.
+func ValidateExclusiveRoutingOptions(nebariApp *appsv1.NebariApp) error {
+	routing := nebariApp.Spec.Routing
+	if routing == nil {
+		return nil
+	}
+
+	if err := validateExclusiveRouteOptions("routes", routing.Routes); err != nil {
+		return err
+	}
+	if err := validateExclusiveRouteOptions("publicRoutes", routing.PublicRoutes); err != nil {
+		return err
+	}
+	for _, route := range routing.PublicRoutes {
+		if route.PathPrefix == "" {
+			return fmt.Errorf("routing.publicRoutes: every entry must set pathPrefix; " +
+				"an entry without one matches every path and would make the whole app public")
+		}
+		if len(route.Headers) > 0 {
+			return fmt.Errorf("routing.publicRoutes: path %q cannot set headers; an auth exemption must not depend on headers the client controls",
+				route.PathPrefix)
+		}
+	}
+	if err := validateRouteHeaders(routing); err != nil {
+		return err
+	}
+	if routing.TLS != nil && routing.TLS.SecretName != "" && routing.TLS.GatewayRef != nil {
+		return fmt.Errorf("routing.tls: secretName and gatewayRef cannot both be set; use secretName for a per-app listener " +
+			"or gatewayRef for an existing one")
+	}
+	return nil
+}
+
+// maxHeaderMatches is the Gateway API limit on header matches in one
+// HTTPRouteMatch.
+const maxHeaderMatches = 16
+
+// validateRouteHeaders checks the header matches each HTTPRoute match ends up
+// with. The gatewayRouting headers for a gateway are appended to every route
+// match on it, so the combined list must stay within the Gateway API limit and
+// must not name a header twice; the API server rejects the whole HTTPRoute
+// otherwise.
+func validateRouteHeaders(routing *appsv1.RoutingConfig) error {
+	for _, route := range routing.Routes {
+		if name := duplicateHeaderName(route.Headers); name != "" {
+			return fmt.Errorf("routing.routes: path %q matches header %q more than once", route.PathPrefix, name)
+		}
+	}
+	for _, headerRoute := range routing.GatewayRouting {
+		if name := duplicateHeaderName(headerRoute.Headers); name != "" {
+			return fmt.Errorf("routing.gatewayRouting: gateway %q matches header %q more than once", headerRoute.Gateway, name)
+		}
+		for _, route := range routing.Routes {
+			combined := append(slices.Clone(route.Headers), headerRoute.Headers...)
+			if len(combined) > maxHeaderMatches {
+				return fmt.Errorf("routing.routes: path %q has %d header matches on gateway %q with routing.gatewayRouting; at most %d are allowed",
+					route.PathPrefix, len(combined), headerRoute.Gateway, maxHeaderMatches)
+			}
+			if name := duplicateHeaderName(combined); name != "" {
+				return fmt.Errorf("routing.routes: path %q matches header %q that routing.gatewayRouting already sets for gateway %q",
+					route.PathPrefix, name, headerRoute.Gateway)
+			}
+		}
+	}
+	return nil
+}
+
+// duplicateHeaderName returns the first header name that appears more than once
+// in headers, compared case-insensitively, or "" when all names are unique.
+func duplicateHeaderName(headers []appsv1.HeaderMatch) string {
+	seen := make(map[string]bool, len(headers))
+	for _, header := range headers {
+		name := strings.ToLower(header.Name)
+		if seen[name] {
+			return header.Name
+		}
+		seen[name] = true
+	}
+	return ""
+}
+
+func validateExclusiveRouteOptions(field string, routes []appsv1.RouteMatch) error {
+	for _, route := range routes {
+		var targets []string
+		if route.Redirect != nil {
+			targets = append(targets, "redirect")
+		}
+		if len(route.Backends) > 0 {
+			targets = append(targets, "backends")
+		}
+		if route.Experiment != nil {
+			targets = append(targets, "experiment")
+		}
+		if len(targets) > 1 {
+			return fmt.Errorf("routing.%s: path %q sets %s; only one of redirect, backends and experiment can be set",
+				field, route.PathPrefix, strings.Join(targets, " and "))
+		}
+		if route.Redirect != nil && route.Timeout != "" {
+			return fmt.Errorf("routing.%s: path %q cannot set both redirect and timeout; redirected requests never reach a backend",
+				field, route.PathPrefix)
+		}
+	}
+	return nil
+}
+
+// ValidateUniqueRoutes checks that routing.routes and routing.publicRoutes do not
+// list the same (pathPrefix, pathType, headers) match twice. Duplicates render redundant
+// HTTPRoute matches and usually mean one entry was meant to be different.
+func ValidateUniqueRoutes(nebariApp *appsv1.NebariApp) error {
+	if nebariApp.Spec.Routing == nil {
+		return nil
+	}
+
+	if err := validateNoDuplicateRoutes("routes", nebariApp.Spec.Routing.Routes, "PathPrefix"); err != nil {
+		return err
+	}
+	return validateNoDuplicateRoutes("publicRoutes", nebariApp.Spec.Routing.PublicRoutes, "Exact")
+}
+
+// sharesGateway reports whether two NebariApps are exposed on at least one common Gateway.
+func sharesGateway(a, b *appsv1.NebariApp) bool {
+	gateways := naming.GatewayNames(b)
+	for _, gatewayName := range naming.GatewayNames(a) {
+		if slices.Contains(gateways, gatewayName) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateSharedHostnamePaths checks that a NebariApp sharing its hostname and
+// a Gateway with other NebariApps does not claim a path match one of them already
+// routes. Each app gets its own HTTPRoute and the Gateway merges them by path,
+// so apps on one hostname cooperate as long as their path matches differ
+// (nested prefixes like "/" and "/api" are fine, the longest match wins). For an
+// identical match Gateway API picks the oldest HTTPRoute, so the older app keeps
+// the path and the newer one is reported as conflicting.
+func ValidateSharedHostnamePaths(ctx context.Context, c client.Client, nebariApp *appsv1.NebariApp) error {
+	if nebariApp.Spec.Routing == nil {
+		return nil
+	}
+
+	apps := &appsv1.NebariAppList{}
+	if err := c.List(ctx, apps); err != nil {
+		return fmt.Errorf("failed to list NebariApps: %w", err)
+	}
+
+	own := make(map[pathKey]bool)
+	for _, key := range claimedPaths(nebariApp) {
+		own[key] = true
+	}
+
+	for i := range apps.Items {
+		other := &apps.Items[i]
+		if other.Namespace == nebariApp.Namespace && other.Name == nebariApp.Name {
+			continue
+		}
+		if other.Spec.Routing == nil || !other.DeletionTimestamp.IsZero() ||
+			other.Spec.Hostname != nebariApp.Spec.Hostname ||
+			!sharesGateway(other, nebariApp) {
+			continue
+		}
+		if !routesBefore(other, nebariApp) {
+			continue
+		}
+		for _, key := range claimedPaths(other) {
+			if own[key] {
+				return fmt.Errorf("path %q (%s)%s on hostname %q is already routed by NebariApp %s/%s",
+					key.path, key.pathType, describeMatchConditions(key), nebariApp.Spec.Hostname, other.Namespace, other.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// pathKey identifies a route match by its path type and value, its header
+// matches, canonicalized by headerKey, and its method.
+type pathKey struct{ pathType, path, headers, method string }
+
+// routeKey returns the match a route renders to. A route without a path
+// matches every path, like a "/" PathPrefix.
+func routeKey(route appsv1.RouteMatch, defaultPathType string) pathKey {
+	key := pathKey{
+		pathType: pathTypeOrDefault(route, defaultPathType),
+		path:     route.PathPrefix,
+		headers:  headerKey(route.Headers),
+		method:   route.Method,
+	}
+	if route.PathPrefix == "" {
+		key.pathType, key.path = "PathPrefix", "/"
+	}
+	return key
+}
+
+// headerKey canonicalizes header matches so the same set compares equal
+// regardless of order or header name case.
+func headerKey(headers []appsv1.HeaderMatch) string {
+	entries := make([]string, 0, len(headers))
+	for _, header := range headers {
+		matchType := header.Type
+		if matchType == "" {
+			matchType = "Exact"
+		}
+		entries = append(entries, fmt.Sprintf("%s:%s=%s", matchType, strings.ToLower(header.Name), header.Value))
+	}
+	slices.Sort(entries)
+	return strings.Join(entries, ",")
+}
+
+// claimedPaths lists the path matches a NebariApp's HTTPRoutes claim. An app
+// without routes claims the whole hostname ("/" as a PathPrefix).
+func claimedPaths(nebariApp *appsv1.NebariApp) []pathKey {
+	routing := nebariApp.Spec.Routing
+	if len(routing.Routes) == 0 && len(routing.PublicRoutes) == 0 {
+		return []pathKey{{pathType: "PathPrefix", path: "/"}}
+	}
+
+	keys := make([]pathKey, 0, len(routing.Routes)+len(routing.PublicRoutes))
+	for _, route := range routing.Routes {
+		keys = append(keys, routeKey(route, "PathPrefix"))
+	}
+	for _, route := range routing.PublicRoutes {
+		keys = append(keys, routeKey(route, "Exact"))
+	}
+	return keys
+}
+
+// routesBefore reports whether a's HTTPRoute takes precedence over b's for an
+// identical match: the older app wins, ties broken by namespace/name as in
+// Gateway API.
+func routesBefore(a, b *appsv1.NebariApp) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// describeMatchConditions formats a key's method and header matches for error
+// messages, or returns "" when it has neither.
+func describeMatchConditions(key pathKey) string {
+	var conditions []string
+	if key.method != "" {
+		conditions = append(conditions, "method "+key.method)
+	}
+	if key.headers != "" {
+		conditions = append(conditions, fmt.Sprintf("headers [%s]", key.headers))
+	}
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " with " + strings.Join(conditions, " and ")
+}
+
+func pathTypeOrDefault(route appsv1.RouteMatch, defaultPathType string) string {
+	if route.PathType == "" {
+		return defaultPathType
+	}
+	return route.PathType
+}
+
+func validateNoDuplicateRoutes(field string, routes []appsv1.RouteMatch, defaultPathType string) error {
+	seen := make(map[pathKey]bool, len(routes))
+	for _, route := range routes {
+		key := routeKey(route, defaultPathType)
+		if seen[key] {
+			return fmt.Errorf("routing.%s: path %q (%s)%s is listed more than once", field, key.path, key.pathType, describeMatchConditions(key))
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// validateBackendWeights rejects routes whose weighted backends would drop all
+// traffic (every weight zero).
+func validateBackendWeights(field string, routes []appsv1.RouteMatch) error {
+	for _, route := range routes {
+		if len(route.Backends) == 0 {
+			continue
+		}
+
+		var total int64
+		for _, backend := range route.Backends {
+			weight := int32(1)
+			if backend.Weight != nil {
+				weight = *backend.Weight
+			}
+			if weight < 0 {
+				return fmt.Errorf("routing.%s: path %q backend %q has negative weight %d",
+					field, route.PathPrefix, backend.Name, weight)
+			}
+			total += int64(weight)
+		}
+		if total == 0 {
+			return fmt.Errorf("routing.%s: path %q backend weights sum to 0, at least one backend must receive traffic",
+				field, route.PathPrefix)
+		}
+	}
+
+	return nil
+}
+
+// validateRouteTimeouts returns an error when a route sets timeout without backends
+// or an experiment. Other routes either share the default backend rule, whose
+// timeout comes from routing.requestTimeout, or redirect and never reach a backend.
+func validateRouteTimeouts(field string, routes []appsv1.RouteMatch) error {
+	for _, route := range routes {
+		if route.Timeout == "" || len(route.Backends) > 0 || route.Experiment != nil {
+			continue
+		}
+		return fmt.Errorf("routing.%s: path %q sets timeout without backends or experiment; use routing.requestTimeout for the default backend",
+			field, route.PathPrefix)
+	}
+	return nil
+}
+
+// validateGatewayPort returns an error when routing.gatewayPort is set to a
+// value outside the valid port range.
+func validateGatewayPort(port *int32) error {
+	if port == nil || (*port >= 1 && *port <= 65535) {
+		return nil
+	}
+	return fmt.Errorf("routing.gatewayPort: %d is not a valid port; must be between 1 and 65535", *port)
+}
+
+// validateRedirectConflicts returns an error when a redirect entry and a backend entry
+// in the same list resolve to the same path and path type.
+func validateRedirectConflicts(field string, routes []appsv1.RouteMatch, defaultPathType string) error {
+	redirects := map[pathKey]bool{}
+	backends := map[pathKey]bool{}
+	for _, route := range routes {
+		key := routeKey(route, defaultPathType)
+		if route.Redirect != nil {
+			redirects[key] = true
+		} else {
+			backends[key] = true
+		}
+		if redirects[key] && backends[key] {
+			return fmt.Errorf("routing.%s: path %q (%s)%s is configured both as a redirect and as a backend route",
+				field, key.path, key.pathType, describeMatchConditions(key))
+		}
+	}
+
 	return nil
 }
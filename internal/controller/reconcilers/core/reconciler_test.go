@@ -18,7 +18,10 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,6 +30,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/conditions"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
 )
 
 func TestValidateNamespaceOptIn(t *testing.T) {
@@ -107,10 +113,11 @@ func TestValidateService(t *testing.T) {
 	_ = appsv1.AddToScheme(scheme)
 
 	tests := []struct {
-		name        string
-		service     *corev1.Service
-		nebariApp   *appsv1.NebariApp
-		expectError bool
+		name         string
+		service      *corev1.Service
+		nebariApp    *appsv1.NebariApp
+		expectError  bool
+		expectedPort int32
 	}{
 		{
 			name: "Valid service with matching port",
@@ -211,6 +218,81 @@ func TestValidateService(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name:    "Non-Service backend is not looked up",
+			service: nil,
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Service: appsv1.ServiceReference{
+						Name:  "external-api",
+						Port:  443,
+						Group: "gateway.envoyproxy.io",
+						Kind:  "Backend",
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Named port resolves to its number",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-service",
+					Namespace: "default",
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{Name: "metrics", Port: 9090},
+						{Name: "http", Port: 8081},
+					},
+				},
+			},
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Service: appsv1.ServiceReference{
+						Name:     "test-service",
+						PortName: "http",
+					},
+				},
+			},
+			expectError:  false,
+			expectedPort: 8081,
+		},
+		{
+			name: "Named port missing from service",
+			service: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-service",
+					Namespace: "default",
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{
+						{Name: "metrics", Port: 9090},
+					},
+				},
+			},
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Service: appsv1.ServiceReference{
+						Name:     "test-service",
+						PortName: "http",
+					},
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -225,7 +307,109 @@ func TestValidateService(t *testing.T) {
 
 			client := builder.Build()
 
-			err := ValidateService(context.Background(), client, tt.nebariApp)
+			service := tt.nebariApp.Spec.Service
+			port, err := ValidateService(context.Background(), client, tt.nebariApp)
+			if (err != nil) != tt.expectError {
+				t.Errorf("expected error=%v, got error=%v", tt.expectError, err)
+			}
+			if tt.expectedPort != 0 && port != tt.expectedPort {
+				t.Errorf("expected resolved port %d, got %d", tt.expectedPort, port)
+			}
+			if tt.nebariApp.Spec.Service != service {
+				t.Errorf("expected spec.service to be unchanged, got %+v", tt.nebariApp.Spec.Service)
+			}
+		})
+	}
+}
+
+func TestValidateDNSTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		routing     *appsv1.RoutingConfig
+		expectError bool
+	}{
+		{name: "no routing"},
+		{name: "unset", routing: &appsv1.RoutingConfig{}},
+		{name: "hostname", routing: &appsv1.RoutingConfig{DNSTarget: "lb.example.com"}},
+		{name: "IPv4 address", routing: &appsv1.RoutingConfig{DNSTarget: "203.0.113.10"}},
+		{name: "IPv6 address", routing: &appsv1.RoutingConfig{DNSTarget: "2001:db8::1"}},
+		{name: "URL", routing: &appsv1.RoutingConfig{DNSTarget: "https://lb.example.com"}, expectError: true},
+		{name: "hostname with a port", routing: &appsv1.RoutingConfig{DNSTarget: "lb.example.com:443"}, expectError: true},
+		{name: "uppercase hostname", routing: &appsv1.RoutingConfig{DNSTarget: "LB.example.com"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{Spec: appsv1.NebariAppSpec{Routing: tt.routing}}
+			err := ValidateDNSTarget(nebariApp)
+			if (err != nil) != tt.expectError {
+				t.Errorf("expected error=%v, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestValidateBackendKinds(t *testing.T) {
+	experimentRoute := func(primary, mirror appsv1.ServiceReference) appsv1.RouteMatch {
+		return appsv1.RouteMatch{
+			PathPrefix: "/api",
+			Experiment: &appsv1.RouteExperiment{Primary: primary, Mirror: mirror},
+		}
+	}
+	service := appsv1.ServiceReference{Name: "svc", Port: 8080}
+	backend := appsv1.ServiceReference{Name: "external", Port: 443, Group: "gateway.envoyproxy.io", Kind: "Backend"}
+
+	tests := []struct {
+		name        string
+		service     appsv1.ServiceReference
+		routing     *appsv1.RoutingConfig
+		expectError bool
+	}{
+		{name: "default kind is a core Service", service: service},
+		{name: "explicit core Service", service: appsv1.ServiceReference{Name: "svc", Port: 8080, Kind: "Service"}},
+		{name: "Envoy Gateway Backend", service: backend},
+		{
+			name:    "multi-cluster ServiceImport",
+			service: appsv1.ServiceReference{Name: "svc", Port: 8080, Group: "multicluster.x-k8s.io", Kind: "ServiceImport"},
+		},
+		{
+			name:        "unsupported kind",
+			service:     appsv1.ServiceReference{Name: "svc", Port: 8080, Kind: "ConfigMap"},
+			expectError: true,
+		},
+		{
+			name:        "supported kind in the wrong group",
+			service:     appsv1.ServiceReference{Name: "external", Port: 443, Kind: "Backend"},
+			expectError: true,
+		},
+		{name: "named port on a Service", service: appsv1.ServiceReference{Name: "svc", PortName: "http"}},
+		{
+			name:        "named port on a non-Service backend",
+			service:     appsv1.ServiceReference{Name: "external", PortName: "https", Group: "gateway.envoyproxy.io", Kind: "Backend"},
+			expectError: true,
+		},
+		{
+			name:    "supported experiment references",
+			service: service,
+			routing: &appsv1.RoutingConfig{Routes: []appsv1.RouteMatch{experimentRoute(service, backend)}},
+		},
+		{
+			name:    "unsupported experiment mirror",
+			service: service,
+			routing: &appsv1.RoutingConfig{Routes: []appsv1.RouteMatch{
+				experimentRoute(service, appsv1.ServiceReference{Name: "x", Port: 80, Group: "apps", Kind: "Deployment"}),
+			}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec:       appsv1.NebariAppSpec{Service: tt.service, Routing: tt.routing},
+			}
+			err := ValidateBackendKinds(nebariApp)
 			if (err != nil) != tt.expectError {
 				t.Errorf("expected error=%v, got error=%v", tt.expectError, err)
 			}
@@ -367,10 +551,781 @@ func TestCoreReconciliationValidateSpec(t *testing.T) {
 				Recorder: eventRecorder,
 			}
 
-			err := reconciler.ValidateSpec(context.Background(), tt.nebariApp)
+			_, err := reconciler.ValidateSpec(context.Background(), tt.nebariApp)
 			if (err != nil) != tt.expectError {
 				t.Errorf("expected error=%v, got error=%v", tt.expectError, err)
 			}
 		})
 	}
 }
+
+func TestProbePublicRoutes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name         string
+		annotation   string
+		publicRoutes []appsv1.RouteMatch
+		kind         string
+		expected     []appsv1.RouteMatch
+		ignored      []string
+	}{
+		{
+			name:       "Declared probe paths become Exact public routes",
+			annotation: "/healthz, /readyz,,/healthz",
+			expected: []appsv1.RouteMatch{
+				{PathPrefix: "/healthz", PathType: "Exact"},
+				{PathPrefix: "/readyz", PathType: "Exact"},
+			},
+		},
+		{
+			name:         "Paths already exempt are skipped",
+			annotation:   "/healthz,/readyz",
+			publicRoutes: []appsv1.RouteMatch{{PathPrefix: "/healthz"}},
+			expected:     []appsv1.RouteMatch{{PathPrefix: "/readyz", PathType: "Exact"}},
+		},
+		{
+			name:       "Relative paths are ignored",
+			annotation: "healthz",
+			ignored:    []string{"healthz"},
+		},
+		{
+			name:       "The root path and paths the Gateway API rejects are ignored",
+			annotation: "/,/a b,/x//y,/x/../y,/healthz",
+			expected:   []appsv1.RouteMatch{{PathPrefix: "/healthz", PathType: "Exact"}},
+			ignored:    []string{"/", "/a b", "/x//y", "/x/../y"},
+		},
+		{
+			name: "No annotation",
+		},
+		{
+			name:       "Non-Service backends have no probe paths",
+			annotation: "/healthz",
+			kind:       "Backend",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-service",
+					Namespace:   "default",
+					Annotations: map[string]string{constants.AnnotationProbePaths: tt.annotation},
+				},
+			}
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Service: appsv1.ServiceReference{Name: "test-service", Port: 8080, Kind: tt.kind},
+					Routing: &appsv1.RoutingConfig{PublicRoutes: tt.publicRoutes},
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(service).Build()
+
+			routes, ignored, err := ProbePublicRoutes(context.Background(), client, nebariApp)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(routes, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, routes)
+			}
+			if !reflect.DeepEqual(ignored, tt.ignored) {
+				t.Errorf("expected ignored %v, got %v", tt.ignored, ignored)
+			}
+		})
+	}
+}
+
+func TestValidateRoutes(t *testing.T) {
+	redirect := &appsv1.RouteRedirect{Path: "/maintenance"}
+	weight0, weight90 := int32(0), int32(90)
+	experiment := &appsv1.RouteExperiment{
+		Primary: appsv1.ServiceReference{Name: "api-v1", Port: 8080},
+		Mirror:  appsv1.ServiceReference{Name: "api-v2", Port: 8080},
+	}
+
+	tests := []struct {
+		name        string
+		routing     *appsv1.RoutingConfig
+		expectError bool
+	}{
+		{
+			name:        "No routing config",
+			routing:     nil,
+			expectError: false,
+		},
+		{
+			name: "Redirect and backend routes on distinct paths",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/api"},
+					{PathPrefix: "/", PathType: "Exact", Redirect: redirect},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Same path with different path types does not conflict",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/docs"},
+					{PathPrefix: "/docs", PathType: "Exact", Redirect: redirect},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Redirect conflicts with backend route on the same path",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/", Redirect: redirect},
+					{PathPrefix: "/", PathType: "PathPrefix"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Redirect conflicts with backend public route on the same path",
+			routing: &appsv1.RoutingConfig{
+				PublicRoutes: []appsv1.RouteMatch{
+					{PathPrefix: "/status"},
+					{PathPrefix: "/status", PathType: "Exact", Redirect: redirect},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Weighted backends with a non-zero total",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/api", Backends: []appsv1.WeightedBackend{
+						{Name: "api-v1", Port: 8080, Weight: &weight90},
+						{Name: "api-v2", Port: 8080, Weight: &weight0},
+					}},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Weighted backends that all weigh zero",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/api", Backends: []appsv1.WeightedBackend{
+						{Name: "api-v1", Port: 8080, Weight: &weight0},
+						{Name: "api-v2", Port: 8080, Weight: &weight0},
+					}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Experiment route",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/api", Experiment: experiment},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Timeout on routes with backends and experiment",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/api", Timeout: "30s", Backends: []appsv1.WeightedBackend{{Name: "api", Port: 8080}}},
+					{PathPrefix: "/search", Timeout: "5m", Experiment: experiment},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Timeout on a default backend route",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/api", Timeout: "30s"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Timeout on a redirect route",
+			routing: &appsv1.RoutingConfig{
+				PublicRoutes: []appsv1.RouteMatch{
+					{PathPrefix: "/", PathType: "Exact", Timeout: "30s", Redirect: redirect},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "Gateway port in range",
+			routing:     &appsv1.RoutingConfig{GatewayPort: ptr.To(int32(8443))},
+			expectError: false,
+		},
+		{
+			name:        "Gateway port zero",
+			routing:     &appsv1.RoutingConfig{GatewayPort: ptr.To(int32(0))},
+			expectError: true,
+		},
+		{
+			name:        "Gateway port above range",
+			routing:     &appsv1.RoutingConfig{GatewayPort: ptr.To(int32(65536))},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "test-ns"},
+				Spec:       appsv1.NebariAppSpec{Routing: tt.routing},
+			}
+
+			err := ValidateRoutes(nebariApp)
+			if tt.expectError && err == nil {
+				t.Errorf("expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateUniqueRoutes(t *testing.T) {
+	tests := []struct {
+		name        string
+		routing     *appsv1.RoutingConfig
+		expectError bool
+	}{
+		{
+			name:        "No routing config",
+			routing:     nil,
+			expectError: false,
+		},
+		{
+			name: "Unique routes",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/api"},
+					{PathPrefix: "/app"},
+				},
+				PublicRoutes: []appsv1.RouteMatch{
+					{PathPrefix: "/api"},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Same path with different path types is not a duplicate",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/api"},
+					{PathPrefix: "/api", PathType: "Exact"},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Duplicate route with the default path type spelled out",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/api"},
+					{PathPrefix: "/api", PathType: "PathPrefix"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Same path with different headers is not a duplicate",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/api"},
+					{PathPrefix: "/api", Headers: []appsv1.HeaderMatch{{Name: "X-Canary", Value: "true"}}},
+					{Headers: []appsv1.HeaderMatch{{Name: "X-Canary", Value: "true"}}},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Duplicate headers in a different order and case",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/api", Headers: []appsv1.HeaderMatch{
+						{Name: "X-Canary", Value: "true"},
+						{Name: "Accept", Value: "application/grpc", Type: "Exact"},
+					}},
+					{PathPrefix: "/api", Headers: []appsv1.HeaderMatch{
+						{Name: "accept", Value: "application/grpc"},
+						{Name: "x-canary", Value: "true"},
+					}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Header-only route duplicates a root prefix route with the same headers",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{Headers: []appsv1.HeaderMatch{{Name: "X-Canary", Value: "true"}}},
+					{PathPrefix: "/", Headers: []appsv1.HeaderMatch{{Name: "X-Canary", Value: "true"}}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Same path with different methods is not a duplicate",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/items"},
+					{PathPrefix: "/items", Method: "GET"},
+					{PathPrefix: "/items", Method: "POST"},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Duplicate path and method",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/items", Method: "POST"},
+					{PathPrefix: "/items", Method: "POST"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "Duplicate public route",
+			routing: &appsv1.RoutingConfig{
+				PublicRoutes: []appsv1.RouteMatch{
+					{PathPrefix: "/health"},
+					{PathPrefix: "/health", PathType: "Exact"},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "test-ns"},
+				Spec:       appsv1.NebariAppSpec{Routing: tt.routing},
+			}
+
+			err := ValidateUniqueRoutes(nebariApp)
+			if tt.expectError && err == nil {
+				t.Errorf("expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateExclusiveRoutingOptions(t *testing.T) {
+	redirect := &appsv1.RouteRedirect{Path: "/maintenance"}
+	backends := []appsv1.WeightedBackend{{Name: "api-v1", Port: 8080}}
+	experiment := &appsv1.RouteExperiment{
+		Primary: appsv1.ServiceReference{Name: "api-v1", Port: 8080},
+		Mirror:  appsv1.ServiceReference{Name: "api-v2", Port: 8080},
+	}
+
+	tests := []struct {
+		name          string
+		routing       *appsv1.RoutingConfig
+		expectedError string
+	}{
+		{
+			name:    "No routing config",
+			routing: nil,
+		},
+		{
+			name: "One traffic target per route",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/", PathType: "Exact", Redirect: redirect},
+					{PathPrefix: "/api", Backends: backends, Timeout: "30s"},
+					{PathPrefix: "/search", Experiment: experiment},
+				},
+				TLS: &appsv1.RoutingTLSConfig{SecretName: "app-tls"},
+			},
+		},
+		{
+			name: "Redirect and backends",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{{PathPrefix: "/api", Redirect: redirect, Backends: backends}},
+			},
+			expectedError: `routing.routes: path "/api" sets redirect and backends; only one of redirect, backends and experiment can be set`,
+		},
+		{
+			name: "Redirect and experiment",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{{PathPrefix: "/api", Redirect: redirect, Experiment: experiment}},
+			},
+			expectedError: `routing.routes: path "/api" sets redirect and experiment; only one of redirect, backends and experiment can be set`,
+		},
+		{
+			name: "Backends and experiment",
+			routing: &appsv1.RoutingConfig{
+				PublicRoutes: []appsv1.RouteMatch{{PathPrefix: "/api", Backends: backends, Experiment: experiment}},
+			},
+			expectedError: `routing.publicRoutes: path "/api" sets backends and experiment; only one of redirect, backends and experiment can be set`,
+		},
+		{
+			name: "Redirect, backends and experiment",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{{PathPrefix: "/api", Redirect: redirect, Backends: backends, Experiment: experiment}},
+			},
+			expectedError: `routing.routes: path "/api" sets redirect and backends and experiment; only one of redirect, backends and experiment can be set`,
+		},
+		{
+			name: "Redirect and timeout",
+			routing: &appsv1.RoutingConfig{
+				PublicRoutes: []appsv1.RouteMatch{{PathPrefix: "/", PathType: "Exact", Redirect: redirect, Timeout: "30s"}},
+			},
+			expectedError: `routing.publicRoutes: path "/" cannot set both redirect and timeout; redirected requests never reach a backend`,
+		},
+		{
+			name: "Headers on a routing route",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{{PathPrefix: "/api", Headers: []appsv1.HeaderMatch{{Name: "X-Canary", Value: "true"}}}},
+			},
+		},
+		{
+			name: "Headers on a public route",
+			routing: &appsv1.RoutingConfig{
+				PublicRoutes: []appsv1.RouteMatch{{PathPrefix: "/health", Headers: []appsv1.HeaderMatch{{Name: "X-Canary", Value: "true"}}}},
+			},
+			expectedError: `routing.publicRoutes: path "/health" cannot set headers; an auth exemption must not depend on headers the client controls`,
+		},
+		{
+			name: "Public route without a path",
+			routing: &appsv1.RoutingConfig{
+				PublicRoutes: []appsv1.RouteMatch{{PathPrefix: "/health"}, {Method: "GET"}},
+			},
+			expectedError: "routing.publicRoutes: every entry must set pathPrefix; an entry without one matches every path and would make the whole app public",
+		},
+		{
+			name: "Method on a public route",
+			routing: &appsv1.RoutingConfig{
+				PublicRoutes: []appsv1.RouteMatch{{PathPrefix: "/health", Method: "GET"}},
+			},
+		},
+		{
+			name: "Duplicate header on a route",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{{PathPrefix: "/api", Headers: []appsv1.HeaderMatch{
+					{Name: "X-Canary", Value: "true"},
+					{Name: "x-canary", Value: "false"},
+				}}},
+			},
+			expectedError: `routing.routes: path "/api" matches header "x-canary" more than once`,
+		},
+		{
+			name: "Duplicate header in gatewayRouting",
+			routing: &appsv1.RoutingConfig{
+				GatewayRouting: []appsv1.GatewayHeaderRoute{{Gateway: "internal", Headers: []appsv1.HeaderMatch{
+					{Name: "X-Internal", Value: "true"},
+					{Name: "X-Internal", Value: "yes"},
+				}}},
+			},
+			expectedError: `routing.gatewayRouting: gateway "internal" matches header "X-Internal" more than once`,
+		},
+		{
+			name: "Route header also set by gatewayRouting",
+			routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{{PathPrefix: "/api", Headers: []appsv1.HeaderMatch{{Name: "X-Internal", Value: "false"}}}},
+				GatewayRouting: []appsv1.GatewayHeaderRoute{{Gateway: "internal", Headers: []appsv1.HeaderMatch{
+					{Name: "X-Internal", Value: "true"},
+				}}},
+			},
+			expectedError: `routing.routes: path "/api" matches header "X-Internal" that routing.gatewayRouting already sets for gateway "internal"`,
+		},
+		{
+			name: "Too many combined header matches",
+			routing: &appsv1.RoutingConfig{
+				Routes:         []appsv1.RouteMatch{{PathPrefix: "/api", Headers: headerMatches("X-Route", 10)}},
+				GatewayRouting: []appsv1.GatewayHeaderRoute{{Gateway: "internal", Headers: headerMatches("X-Gateway", 7)}},
+			},
+			expectedError: `routing.routes: path "/api" has 17 header matches on gateway "internal" with routing.gatewayRouting; at most 16 are allowed`,
+		},
+		{
+			name: "Combined header matches within the limit",
+			routing: &appsv1.RoutingConfig{
+				Routes:         []appsv1.RouteMatch{{PathPrefix: "/api", Headers: headerMatches("X-Route", 10)}},
+				GatewayRouting: []appsv1.GatewayHeaderRoute{{Gateway: "internal", Headers: headerMatches("X-Gateway", 6)}},
+			},
+		},
+		{
+			name: "TLS secretName and gatewayRef",
+			routing: &appsv1.RoutingConfig{
+				TLS: &appsv1.RoutingTLSConfig{
+					SecretName: "app-tls",
+					GatewayRef: &appsv1.GatewayListenerRef{Name: constants.PublicGatewayName, SectionName: "wildcard-https"},
+				},
+			},
+			expectedError: "routing.tls: secretName and gatewayRef cannot both be set; use secretName for a per-app listener " +
+				"or gatewayRef for an existing one",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "test-ns"},
+				Spec:       appsv1.NebariAppSpec{Routing: tt.routing},
+			}
+
+			err := ValidateExclusiveRoutingOptions(nebariApp)
+			if tt.expectedError == "" {
+				if err != nil {
+					t.Errorf("expected no error but got: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expectedError {
+				t.Errorf("expected error %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+// headerMatches returns count header matches named prefix-0, prefix-1, ...
+func headerMatches(prefix string, count int) []appsv1.HeaderMatch {
+	headers := make([]appsv1.HeaderMatch, 0, count)
+	for i := range count {
+		headers = append(headers, appsv1.HeaderMatch{Name: fmt.Sprintf("%s-%d", prefix, i), Value: "true"})
+	}
+	return headers
+}
+
+func TestValidateSpec_ConflictingRoutingOptionsCondition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "test-ns"},
+		Spec: appsv1.NebariAppSpec{
+			Service: appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{{
+					PathPrefix: "/api",
+					Redirect:   &appsv1.RouteRedirect{Path: "/maintenance"},
+					Backends:   []appsv1.WeightedBackend{{Name: "api-v1", Port: 8080}},
+				}},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ns", Labels: map[string]string{ManagedNamespaceLabel: "true"}},
+	}
+
+	reconciler := &CoreReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp, namespace).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	_, err := reconciler.ValidateSpec(context.Background(), nebariApp)
+	if err == nil {
+		t.Fatal("expected conflicting routing options to fail validation")
+	}
+
+	for _, condType := range []string{appsv1.ConditionTypeRoutingReady, appsv1.ConditionTypeReady} {
+		cond := conditions.GetCondition(nebariApp, condType)
+		if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != appsv1.ReasonConflictingRoutingOptions {
+			t.Errorf("expected %s=False/%s, got %+v", condType, appsv1.ReasonConflictingRoutingOptions, cond)
+			continue
+		}
+		if cond.Message != err.Error() {
+			t.Errorf("expected %s message %q, got %q", condType, err.Error(), cond.Message)
+		}
+	}
+}
+
+func TestValidateSpec_DuplicateRoutesCondition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "test-ns"},
+		Spec: appsv1.NebariAppSpec{
+			Service: appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{{PathPrefix: "/api"}, {PathPrefix: "/api"}},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ns", Labels: map[string]string{ManagedNamespaceLabel: "true"}},
+	}
+
+	reconciler := &CoreReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp, namespace).Build(),
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	if _, err := reconciler.ValidateSpec(context.Background(), nebariApp); err == nil {
+		t.Fatal("expected duplicate routes to fail validation")
+	}
+
+	cond := conditions.GetCondition(nebariApp, appsv1.ConditionTypeRoutingReady)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != appsv1.ReasonDuplicateRoutes {
+		t.Errorf("expected RoutingReady=False/%s, got %+v", appsv1.ReasonDuplicateRoutes, cond)
+	}
+}
+
+func TestValidateSpec_ProtectedNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name        string
+		namespace   string
+		protected   []string
+		expectError bool
+	}{
+		{name: "kube-system is protected by default", namespace: "kube-system", expectError: true},
+		{name: "kube-public is protected by default", namespace: "kube-public", expectError: true},
+		{name: "gateway namespace is protected by default", namespace: constants.GatewayNamespace, expectError: true},
+		{name: "configured list is protected", namespace: "platform", protected: []string{"platform"}, expectError: true},
+		{name: "configured list replaces the defaults", namespace: "kube-public", protected: []string{"platform"}},
+		{name: "regular namespace passes", namespace: "test-ns"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The namespace carries the opt-in label, which must not matter.
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.namespace, Labels: map[string]string{ManagedNamespaceLabel: "true"}},
+			}
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: tt.namespace},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 8080}}},
+			}
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: tt.namespace},
+				Spec:       appsv1.NebariAppSpec{Service: appsv1.ServiceReference{Name: "test-service", Port: 8080}},
+			}
+
+			reconciler := &CoreReconciler{
+				Client:              fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp, namespace, service).Build(),
+				Scheme:              scheme,
+				Recorder:            record.NewFakeRecorder(10),
+				ProtectedNamespaces: tt.protected,
+			}
+
+			_, err := reconciler.ValidateSpec(context.Background(), nebariApp)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error=%v, got error=%v", tt.expectError, err)
+			}
+			if !tt.expectError {
+				return
+			}
+			cond := conditions.GetCondition(nebariApp, appsv1.ConditionTypeReady)
+			if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != appsv1.ReasonProtectedNamespace {
+				t.Errorf("expected Ready=False/%s, got %+v", appsv1.ReasonProtectedNamespace, cond)
+			}
+		})
+	}
+}
+
+func TestValidateSharedHostnamePaths(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	older := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(older.Add(time.Hour))
+
+	newApp := func(name string, created metav1.Time, hostname string, routing *appsv1.RoutingConfig) *appsv1.NebariApp {
+		return &appsv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", CreationTimestamp: created},
+			Spec: appsv1.NebariAppSpec{
+				Hostname: hostname,
+				Service:  appsv1.ServiceReference{Name: name, Port: 8080},
+				Routing:  routing,
+			},
+		}
+	}
+	prefixes := func(paths ...string) *appsv1.RoutingConfig {
+		routing := &appsv1.RoutingConfig{}
+		for _, path := range paths {
+			routing.Routes = append(routing.Routes, appsv1.RouteMatch{PathPrefix: path})
+		}
+		return routing
+	}
+
+	tests := []struct {
+		name        string
+		app         *appsv1.NebariApp
+		others      []*appsv1.NebariApp
+		expectError bool
+	}{
+		{
+			name:        "Two apps on one hostname with distinct prefixes",
+			app:         newApp("app-b", newer, "app.example.com", prefixes("/b")),
+			others:      []*appsv1.NebariApp{newApp("app-a", older, "app.example.com", prefixes("/a"))},
+			expectError: false,
+		},
+		{
+			name:        "Nested prefixes are merged by longest match",
+			app:         newApp("api", newer, "app.example.com", prefixes("/api")),
+			others:      []*appsv1.NebariApp{newApp("web", older, "app.example.com", &appsv1.RoutingConfig{})},
+			expectError: false,
+		},
+		{
+			name:        "Newer app claiming an existing prefix conflicts",
+			app:         newApp("app-b", newer, "app.example.com", prefixes("/b", "/a")),
+			others:      []*appsv1.NebariApp{newApp("app-a", older, "app.example.com", prefixes("/a"))},
+			expectError: true,
+		},
+		{
+			name:        "Older app keeps its prefix",
+			app:         newApp("app-a", older, "app.example.com", prefixes("/a")),
+			others:      []*appsv1.NebariApp{newApp("app-b", newer, "app.example.com", prefixes("/a"))},
+			expectError: false,
+		},
+		{
+			name:        "Two apps without routes both claim the whole hostname",
+			app:         newApp("app-b", newer, "app.example.com", &appsv1.RoutingConfig{}),
+			others:      []*appsv1.NebariApp{newApp("app-a", older, "app.example.com", &appsv1.RoutingConfig{})},
+			expectError: true,
+		},
+		{
+			name:        "Same prefix on a different hostname",
+			app:         newApp("app-b", newer, "b.example.com", prefixes("/a")),
+			others:      []*appsv1.NebariApp{newApp("app-a", older, "a.example.com", prefixes("/a"))},
+			expectError: false,
+		},
+		{
+			name:        "Same path with a different path type",
+			app:         newApp("app-b", newer, "app.example.com", &appsv1.RoutingConfig{Routes: []appsv1.RouteMatch{{PathPrefix: "/a", PathType: "Exact"}}}),
+			others:      []*appsv1.NebariApp{newApp("app-a", older, "app.example.com", prefixes("/a"))},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.app)
+			for _, other := range tt.others {
+				builder = builder.WithObjects(other)
+			}
+
+			err := ValidateSharedHostnamePaths(context.Background(), builder.Build(), tt.app)
+			if tt.expectError && err == nil {
+				t.Errorf("expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error but got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ServiceStubLabel marks a placeholder Service created for spec.createServiceStub.
+const ServiceStubLabel = "nebari.dev/service-stub"
+
+// ReconcileServiceStub creates a selectorless placeholder for spec.service when
+// spec.createServiceStub is set and the Service does not exist. An existing stub
+// has its port kept in line with spec.service.port. A stub that has since been
+// given a selector is the real Service now: the stub label and the owner
+// reference are dropped so deleting the NebariApp no longer deletes it.
+// Services that were never stubs are left untouched.
+func (r *CoreReconciler) ReconcileServiceStub(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	ref := nebariApp.Spec.Service
+	if !nebariApp.Spec.CreateServiceStub || kindOf(ref) != (backendKind{kind: "Service"}) ||
+		(ref.Namespace != "" && ref.Namespace != nebariApp.Namespace) {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+
+	service := &corev1.Service{}
+	err := r.Client.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: nebariApp.Namespace}, service)
+	if errors.IsNotFound(err) {
+		stub := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ref.Name,
+				Namespace: nebariApp.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/instance":   naming.LabelValue(nebariApp.Name),
+					"app.kubernetes.io/managed-by": naming.ManagedBy(r.ManagedBy),
+					ServiceStubLabel:               "true",
+				},
+			},
+			Spec: corev1.ServiceSpec{Ports: stubPorts(ref.Port)},
+		}
+		if err := controllerutil.SetControllerReference(nebariApp, stub, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set owner reference on service stub: %w", err)
+		}
+		if err := r.Client.Create(ctx, stub); err != nil {
+			return fmt.Errorf("failed to create service stub %s: %w", ref.Name, err)
+		}
+		logger.Info("Created placeholder Service", "service", ref.Name)
+		r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonServiceStubCreated,
+			fmt.Sprintf("Created placeholder Service %s until the real Service is applied", ref.Name))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+
+	if service.Labels[ServiceStubLabel] != "true" || !metav1.IsControlledBy(service, nebariApp) {
+		return nil
+	}
+
+	if len(service.Spec.Selector) > 0 {
+		delete(service.Labels, ServiceStubLabel)
+		refs := service.OwnerReferences[:0]
+		for _, owner := range service.OwnerReferences {
+			if owner.UID != nebariApp.UID {
+				refs = append(refs, owner)
+			}
+		}
+		service.OwnerReferences = refs
+		if err := r.Client.Update(ctx, service); err != nil {
+			return fmt.Errorf("failed to release service stub %s: %w", ref.Name, err)
+		}
+		logger.Info("Placeholder Service replaced by the real Service", "service", ref.Name)
+		return nil
+	}
+
+	if len(service.Spec.Ports) == 1 && service.Spec.Ports[0].Port == ref.Port {
+		return nil
+	}
+	service.Spec.Ports = stubPorts(ref.Port)
+	if err := r.Client.Update(ctx, service); err != nil {
+		return fmt.Errorf("failed to update service stub %s: %w", ref.Name, err)
+	}
+	logger.Info("Updated placeholder Service port", "service", ref.Name, "port", ref.Port)
+	return nil
+}
+
+// stubPorts returns the single port a placeholder Service exposes.
+func stubPorts(port int32) []corev1.ServicePort {
+	return []corev1.ServicePort{{
+		Name:       "http",
+		Protocol:   corev1.ProtocolTCP,
+		Port:       port,
+		TargetPort: intstr.FromInt32(port),
+	}}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+)
+
+func TestValidateSpec_ServiceStub(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	existing := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "test-ns"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "test"},
+			Ports:    []corev1.ServicePort{{Port: 8080}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		createStub    bool
+		service       *corev1.Service
+		expectError   bool
+		expectStub    bool
+		expectService bool
+	}{
+		{
+			name:          "stub created when enabled and the Service is missing",
+			createStub:    true,
+			expectStub:    true,
+			expectService: true,
+		},
+		{
+			name:        "no stub when disabled",
+			expectError: true,
+		},
+		{
+			name:          "existing Service is left alone",
+			createStub:    true,
+			service:       existing,
+			expectService: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "test-ns", UID: "app-uid"},
+				Spec: appsv1.NebariAppSpec{
+					Service:           appsv1.ServiceReference{Name: "test-service", Port: 8080},
+					CreateServiceStub: tt.createStub,
+				},
+			}
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ns", Labels: map[string]string{ManagedNamespaceLabel: "true"}},
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp, namespace)
+			if tt.service != nil {
+				builder = builder.WithObjects(tt.service.DeepCopy())
+			}
+			c := builder.Build()
+			reconciler := &CoreReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+
+			_, err := reconciler.ValidateSpec(context.Background(), nebariApp)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error=%v, got error=%v", tt.expectError, err)
+			}
+
+			service := &corev1.Service{}
+			err = c.Get(context.Background(), client.ObjectKey{Name: "test-service", Namespace: "test-ns"}, service)
+			if !tt.expectService {
+				if !errors.IsNotFound(err) {
+					t.Fatalf("expected no Service, got err=%v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected Service to exist: %v", err)
+			}
+
+			isStub := service.Labels[ServiceStubLabel] == "true"
+			if isStub != tt.expectStub {
+				t.Fatalf("expected stub=%v, got labels %v", tt.expectStub, service.Labels)
+			}
+			if !tt.expectStub {
+				if len(service.OwnerReferences) != 0 {
+					t.Errorf("expected existing Service to stay unowned, got %v", service.OwnerReferences)
+				}
+				return
+			}
+			if len(service.Spec.Selector) != 0 {
+				t.Errorf("expected a selectorless stub, got selector %v", service.Spec.Selector)
+			}
+			if len(service.Spec.Ports) != 1 || service.Spec.Ports[0].Port != 8080 {
+				t.Errorf("expected stub port 8080, got %v", service.Spec.Ports)
+			}
+			if !metav1.IsControlledBy(service, nebariApp) {
+				t.Errorf("expected stub to be controlled by the NebariApp, got %v", service.OwnerReferences)
+			}
+		})
+	}
+}
+
+func TestReconcileServiceStub_FollowsSpec(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "test-ns", UID: "app-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Service:           appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			CreateServiceStub: true,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp).Build()
+	reconciler := &CoreReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+	ctx := context.Background()
+	key := client.ObjectKey{Name: "test-service", Namespace: "test-ns"}
+
+	if err := reconciler.ReconcileServiceStub(ctx, nebariApp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A port change in the spec is applied to the stub.
+	nebariApp.Spec.Service.Port = 9090
+	if err := reconciler.ReconcileServiceStub(ctx, nebariApp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	service := &corev1.Service{}
+	if err := c.Get(ctx, key, service); err != nil {
+		t.Fatalf("failed to get stub: %v", err)
+	}
+	if len(service.Spec.Ports) != 1 || service.Spec.Ports[0].Port != 9090 {
+		t.Fatalf("expected stub port 9090, got %v", service.Spec.Ports)
+	}
+
+	// Once the real manifest adds a selector, the operator lets go of it.
+	service.Spec.Selector = map[string]string{"app": "test"}
+	if err := c.Update(ctx, service); err != nil {
+		t.Fatalf("failed to update Service: %v", err)
+	}
+	if err := reconciler.ReconcileServiceStub(ctx, nebariApp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(ctx, key, service); err != nil {
+		t.Fatalf("failed to get Service: %v", err)
+	}
+	if _, ok := service.Labels[ServiceStubLabel]; ok {
+		t.Errorf("expected stub label to be removed, got %v", service.Labels)
+	}
+	if metav1.IsControlledBy(service, nebariApp) {
+		t.Errorf("expected owner reference to be removed, got %v", service.OwnerReferences)
+	}
+}
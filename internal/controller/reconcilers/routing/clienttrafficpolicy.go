@@ -0,0 +1,170 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+)
+
+// ReconcileClientTrafficPolicy creates or updates the ClientTrafficPolicy carrying
+// routing.clientTimeouts. ClientTrafficPolicies can only target Gateways, so the
+// policy lives in the Gateway namespace and is scoped to the app's per-app listener
+// via sectionName; without one (tlsListenerName empty, or a listener referenced by
+// routing.tls.gatewayRef) the timeouts would apply to every app sharing the
+// listener, so they are not applied. Any existing policy is
+// removed when clientTimeouts is unset.
+func (r *RoutingReconciler) ReconcileClientTrafficPolicy(ctx context.Context, nebariApp *appsv1.NebariApp, tlsListenerName string) error {
+	logger := log.FromContext(ctx)
+
+	if nebariApp.Spec.Routing == nil || nebariApp.Spec.Routing.ClientTimeouts == nil {
+		return r.CleanupClientTrafficPolicy(ctx, nebariApp)
+	}
+
+	if tlsListenerName == "" || tlsGatewayRef(nebariApp) != nil {
+		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonClientTimeoutsNotApplied,
+			"routing.clientTimeouts requires a per-app TLS listener; the shared listener is left unchanged")
+		return r.CleanupClientTrafficPolicy(ctx, nebariApp)
+	}
+
+	policyName := naming.ClientTrafficPolicyName(nebariApp)
+	policy := &egv1alpha1.ClientTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      policyName,
+			Namespace: constants.GatewayNamespace,
+		},
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		// Set labels (cannot use SetControllerReference since the policy is cross-namespace)
+		if policy.Labels == nil {
+			policy.Labels = make(map[string]string)
+		}
+		policy.Labels["app.kubernetes.io/managed-by"] = naming.ManagedBy(r.ManagedBy)
+		policy.Labels["nebari.dev/nebariapp-name"] = naming.LabelValue(nebariApp.Name)
+		policy.Labels["nebari.dev/nebariapp-namespace"] = nebariApp.Namespace
+
+		policy.Spec = buildClientTrafficPolicySpec(nebariApp, tlsListenerName)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or update ClientTrafficPolicy: %w", err)
+	}
+
+	logger.Info("ClientTrafficPolicy reconciled", "name", policyName, "namespace", constants.GatewayNamespace, "operation", op)
+
+	switch op {
+	case controllerutil.OperationResultCreated:
+		r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonClientTrafficPolicyCreated,
+			fmt.Sprintf("Created ClientTrafficPolicy %s/%s", constants.GatewayNamespace, policyName))
+	case controllerutil.OperationResultUpdated:
+		r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonClientTrafficPolicyUpdated,
+			fmt.Sprintf("Updated ClientTrafficPolicy %s/%s", constants.GatewayNamespace, policyName))
+	}
+
+	return nil
+}
+
+// buildClientTrafficPolicySpec targets the app's listener on each of its Gateways and maps
+// routing.clientTimeouts onto the policy's HTTP client timeouts.
+func buildClientTrafficPolicySpec(nebariApp *appsv1.NebariApp, tlsListenerName string) egv1alpha1.ClientTrafficPolicySpec {
+	timeouts := nebariApp.Spec.Routing.ClientTimeouts
+	sectionName := gatewayv1.SectionName(tlsListenerName)
+
+	var targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName
+	for _, gatewayName := range naming.GatewayNames(nebariApp) {
+		targetRefs = append(targetRefs, gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: gatewayv1.GroupName,
+				Kind:  "Gateway",
+				Name:  gatewayv1.ObjectName(gatewayName),
+			},
+			SectionName: &sectionName,
+		})
+	}
+
+	return egv1alpha1.ClientTrafficPolicySpec{
+		PolicyTargetReferences: egv1alpha1.PolicyTargetReferences{
+			TargetRefs: targetRefs,
+		},
+		Timeout: &egv1alpha1.ClientTimeout{
+			HTTP: &egv1alpha1.HTTPClientTimeout{
+				IdleTimeout:            optionalDuration(timeouts.IdleTimeout),
+				StreamIdleTimeout:      optionalDuration(timeouts.StreamIdleTimeout),
+				RequestReceivedTimeout: optionalDuration(timeouts.RequestReceivedTimeout),
+			},
+		},
+	}
+}
+
+// optionalDuration returns nil for an empty duration so Envoy Gateway keeps its default.
+func optionalDuration(value string) *gatewayv1.Duration {
+	if value == "" {
+		return nil
+	}
+	d := gatewayv1.Duration(value)
+	return &d
+}
+
+// CleanupClientTrafficPolicy deletes the ClientTrafficPolicy for this NebariApp if it
+// exists and is labeled as owned by it. Policies with mismatched ownership labels are
+// left alone, as are missing policies and clusters without the Envoy Gateway CRDs.
+func (r *RoutingReconciler) CleanupClientTrafficPolicy(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	logger := log.FromContext(ctx)
+	policyName := naming.ClientTrafficPolicyName(nebariApp)
+
+	policy := &egv1alpha1.ClientTrafficPolicy{}
+	if err := r.Client.Get(ctx, client.ObjectKey{
+		Name:      policyName,
+		Namespace: constants.GatewayNamespace,
+	}, policy); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get ClientTrafficPolicy for cleanup: %w", err)
+	}
+
+	if policy.Labels["nebari.dev/nebariapp-name"] != naming.LabelValue(nebariApp.Name) ||
+		policy.Labels["nebari.dev/nebariapp-namespace"] != nebariApp.Namespace {
+		logger.V(1).Info("ClientTrafficPolicy exists with mismatched ownership labels, leaving it alone",
+			"name", policyName, "namespace", constants.GatewayNamespace)
+		return nil
+	}
+
+	if err := client.IgnoreNotFound(r.Client.Delete(ctx, policy)); err != nil {
+		return fmt.Errorf("failed to delete ClientTrafficPolicy: %w", err)
+	}
+
+	logger.Info("Deleted ClientTrafficPolicy", "name", policyName, "namespace", constants.GatewayNamespace)
+	r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonClientTrafficPolicyDeleted,
+		fmt.Sprintf("Deleted ClientTrafficPolicy %s/%s", constants.GatewayNamespace, policyName))
+	return nil
+}
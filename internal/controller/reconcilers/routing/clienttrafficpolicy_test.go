@@ -0,0 +1,200 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+)
+
+func newClientTimeoutsApp(timeouts *appsv1.ClientTimeoutsConfig) *appsv1.NebariApp {
+	return &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Routing:  &appsv1.RoutingConfig{ClientTimeouts: timeouts},
+		},
+	}
+}
+
+func TestReconcileClientTrafficPolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	nebariApp := newClientTimeoutsApp(&appsv1.ClientTimeoutsConfig{
+		IdleTimeout:            "2h",
+		StreamIdleTimeout:      "30m",
+		RequestReceivedTimeout: "10s",
+	})
+	listenerName := naming.ListenerName(nebariApp)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp).Build()
+	reconciler := &RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+
+	if err := reconciler.ReconcileClientTrafficPolicy(context.Background(), nebariApp, listenerName); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	policy := &egv1alpha1.ClientTrafficPolicy{}
+	key := types.NamespacedName{Name: naming.ClientTrafficPolicyName(nebariApp), Namespace: constants.GatewayNamespace}
+	if err := fakeClient.Get(context.Background(), key, policy); err != nil {
+		t.Fatalf("expected ClientTrafficPolicy to be created: %v", err)
+	}
+
+	if policy.Labels["nebari.dev/nebariapp-name"] != "test-app" || policy.Labels["nebari.dev/nebariapp-namespace"] != "default" {
+		t.Errorf("expected ownership labels, got %v", policy.Labels)
+	}
+	if len(policy.Spec.TargetRefs) != 1 {
+		t.Fatalf("expected 1 targetRef, got %d", len(policy.Spec.TargetRefs))
+	}
+	ref := policy.Spec.TargetRefs[0]
+	if ref.Kind != "Gateway" || string(ref.Name) != constants.PublicGatewayName {
+		t.Errorf("expected targetRef Gateway/%s, got %s/%s", constants.PublicGatewayName, ref.Kind, ref.Name)
+	}
+	if ref.SectionName == nil || string(*ref.SectionName) != listenerName {
+		t.Errorf("expected sectionName %q, got %v", listenerName, ref.SectionName)
+	}
+
+	if policy.Spec.Timeout == nil || policy.Spec.Timeout.HTTP == nil {
+		t.Fatal("expected HTTP client timeouts to be set")
+	}
+	http := policy.Spec.Timeout.HTTP
+	if http.IdleTimeout == nil || *http.IdleTimeout != "2h" {
+		t.Errorf("expected idleTimeout 2h, got %v", http.IdleTimeout)
+	}
+	if http.StreamIdleTimeout == nil || *http.StreamIdleTimeout != "30m" {
+		t.Errorf("expected streamIdleTimeout 30m, got %v", http.StreamIdleTimeout)
+	}
+	if http.RequestReceivedTimeout == nil || *http.RequestReceivedTimeout != "10s" {
+		t.Errorf("expected requestReceivedTimeout 10s, got %v", http.RequestReceivedTimeout)
+	}
+
+	// Changing a timeout updates the policy; clearing one leaves Envoy's default.
+	nebariApp.Spec.Routing.ClientTimeouts = &appsv1.ClientTimeoutsConfig{IdleTimeout: "4h"}
+	if err := reconciler.ReconcileClientTrafficPolicy(context.Background(), nebariApp, listenerName); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fakeClient.Get(context.Background(), key, policy); err != nil {
+		t.Fatalf("failed to get ClientTrafficPolicy: %v", err)
+	}
+	http = policy.Spec.Timeout.HTTP
+	if http.IdleTimeout == nil || *http.IdleTimeout != "4h" {
+		t.Errorf("expected idleTimeout 4h after update, got %v", http.IdleTimeout)
+	}
+	if http.StreamIdleTimeout != nil || http.RequestReceivedTimeout != nil {
+		t.Errorf("expected unset timeouts to be cleared, got stream=%v request=%v",
+			http.StreamIdleTimeout, http.RequestReceivedTimeout)
+	}
+}
+
+func TestReconcileClientTrafficPolicy_Removal(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	ownedPolicy := func(app *appsv1.NebariApp, owner string) *egv1alpha1.ClientTrafficPolicy {
+		return &egv1alpha1.ClientTrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      naming.ClientTrafficPolicyName(app),
+				Namespace: constants.GatewayNamespace,
+				Labels: map[string]string{
+					"nebari.dev/nebariapp-name":      owner,
+					"nebari.dev/nebariapp-namespace": app.Namespace,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		timeouts      *appsv1.ClientTimeoutsConfig
+		listenerName  string
+		owner         string
+		expectDeleted bool
+		expectWarning bool
+	}{
+		{
+			name:          "clientTimeouts unset deletes owned policy",
+			listenerName:  "tls-test-app-default",
+			owner:         "test-app",
+			expectDeleted: true,
+		},
+		{
+			name:          "no per-app listener deletes owned policy and warns",
+			timeouts:      &appsv1.ClientTimeoutsConfig{IdleTimeout: "2h"},
+			owner:         "test-app",
+			expectDeleted: true,
+			expectWarning: true,
+		},
+		{
+			name:         "policy with foreign ownership labels is left alone",
+			listenerName: "tls-test-app-default",
+			owner:        "other-app",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := newClientTimeoutsApp(tt.timeouts)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(nebariApp, ownedPolicy(nebariApp, tt.owner)).Build()
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: recorder}
+
+			if err := reconciler.ReconcileClientTrafficPolicy(context.Background(), nebariApp, tt.listenerName); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err := fakeClient.Get(context.Background(), client.ObjectKey{
+				Name:      naming.ClientTrafficPolicyName(nebariApp),
+				Namespace: constants.GatewayNamespace,
+			}, &egv1alpha1.ClientTrafficPolicy{})
+			if tt.expectDeleted && !errors.IsNotFound(err) {
+				t.Errorf("expected ClientTrafficPolicy to be deleted, got err=%v", err)
+			}
+			if !tt.expectDeleted && err != nil {
+				t.Errorf("expected ClientTrafficPolicy to be kept, got err=%v", err)
+			}
+
+			warned := false
+			close(recorder.Events)
+			for event := range recorder.Events {
+				if strings.Contains(event, appsv1.EventReasonClientTimeoutsNotApplied) {
+					warned = true
+				}
+			}
+			if warned != tt.expectWarning {
+				t.Errorf("expected %s event=%v, got %v", appsv1.EventReasonClientTimeoutsNotApplied, tt.expectWarning, warned)
+			}
+		})
+	}
+}
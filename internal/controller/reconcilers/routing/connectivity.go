@@ -0,0 +1,209 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/conditions"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+)
+
+// connectivityProbeTimeout bounds a single probe request so a black-holed
+// Gateway address cannot stall the reconcile loop.
+const connectivityProbeTimeout = 5 * time.Second
+
+// ConnectivityProbeActive reports whether the connectivity probe runs for this
+// app: the operator must allow probing and the app must opt in.
+func (r *RoutingReconciler) ConnectivityProbeActive(nebariApp *appsv1.NebariApp) bool {
+	return r.ConnectivityProbeEnabled &&
+		nebariApp.Spec.Routing != nil &&
+		nebariApp.Spec.Routing.ConnectivityProbe != nil &&
+		nebariApp.Spec.Routing.ConnectivityProbe.Enabled
+}
+
+// ProbeConnectivity sends an HTTP GET for the app's hostname to the in-cluster
+// address of each Gateway the app is exposed on and records the outcome in the
+// ConnectivityReady condition. Requests to a Gateway with routing.gatewayRouting
+// headers carry those headers, so they match the app's routes there; a Gateway
+// that requires a RegularExpression header is skipped, since the probe cannot
+// derive a value that matches it.
+// Probe failures are reported through the condition rather than returned, since
+// they usually clear on their own once the Gateway programs the route. When the
+// probe is not active the condition is removed so a stale result does not linger.
+// tlsListenerName is the app's own TLS listener, as passed to ReconcileRouting.
+func (r *RoutingReconciler) ProbeConnectivity(ctx context.Context, nebariApp *appsv1.NebariApp, tlsListenerName string) {
+	logger := log.FromContext(ctx)
+
+	if !r.ConnectivityProbeActive(nebariApp) {
+		meta.RemoveStatusCondition(&nebariApp.Status.Conditions, appsv1.ConditionTypeConnectivityReady)
+		return
+	}
+
+	var results []string
+	for _, gatewayName := range naming.GatewayNames(nebariApp) {
+		headers, ok := probeHeaders(nebariApp, gatewayName)
+		if !ok {
+			logger.V(1).Info("Skipping connectivity probe for Gateway with a RegularExpression header match",
+				"gateway", gatewayName)
+			continue
+		}
+
+		probeURL, err := r.connectivityProbeURL(ctx, nebariApp, gatewayName, tlsListenerName)
+		if err != nil {
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeConnectivityReady, metav1.ConditionFalse,
+				appsv1.ReasonConnectivityProbeFailed, err.Error())
+			return
+		}
+
+		statusCode, err := r.sendProbe(ctx, nebariApp.Spec.Hostname, probeURL, headers)
+		if err != nil {
+			logger.V(1).Info("Connectivity probe failed", "url", probeURL, "error", err.Error())
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeConnectivityReady, metav1.ConditionFalse,
+				appsv1.ReasonConnectivityProbeFailed, fmt.Sprintf("GET %s for host %s failed: %v", probeURL, nebariApp.Spec.Hostname, err))
+			return
+		}
+
+		if statusCode < 200 || statusCode >= 400 {
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeConnectivityReady, metav1.ConditionFalse,
+				appsv1.ReasonConnectivityProbeFailed,
+				fmt.Sprintf("GET %s for host %s returned HTTP %d", probeURL, nebariApp.Spec.Hostname, statusCode))
+			return
+		}
+		results = append(results, fmt.Sprintf("GET %s for host %s returned HTTP %d", probeURL, nebariApp.Spec.Hostname, statusCode))
+	}
+
+	if len(results) == 0 {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeConnectivityReady, metav1.ConditionFalse,
+			appsv1.ReasonConnectivityProbeFailed,
+			"every Gateway of the app requires a RegularExpression header match, which the probe cannot send")
+		return
+	}
+
+	conditions.SetCondition(nebariApp, appsv1.ConditionTypeConnectivityReady, metav1.ConditionTrue,
+		appsv1.ReasonConnectivityProbeSucceeded, strings.Join(results, "; "))
+}
+
+// probeHeaders returns the routing.gatewayRouting headers a request must carry
+// to match the app's routes on gatewayName. It reports false when one of them
+// is a RegularExpression match.
+func probeHeaders(nebariApp *appsv1.NebariApp, gatewayName string) ([]appsv1.HeaderMatch, bool) {
+	for _, headerRoute := range nebariApp.Spec.Routing.GatewayRouting {
+		if naming.GatewayNameFor(headerRoute.Gateway) != gatewayName {
+			continue
+		}
+		for _, header := range headerRoute.Headers {
+			if header.Type == "RegularExpression" {
+				return nil, false
+			}
+		}
+		return headerRoute.Headers, true
+	}
+	return nil, true
+}
+
+// connectivityProbeURL builds the probe URL from the first address gatewayName
+// reports in its status. The port is the one the app's route attaches to:
+// routing.gatewayPort when set, otherwise the HTTPS port when the route
+// attaches to a TLS listener and the HTTP port otherwise.
+func (r *RoutingReconciler) connectivityProbeURL(ctx context.Context, nebariApp *appsv1.NebariApp, gatewayName string,
+	tlsListenerName string) (string, error) {
+	gateway := &gatewayv1.Gateway{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: gatewayName, Namespace: constants.GatewayNamespace}, gateway); err != nil {
+		return "", fmt.Errorf("failed to get Gateway %s/%s: %w", constants.GatewayNamespace, gatewayName, err)
+	}
+	if len(gateway.Status.Addresses) == 0 || gateway.Status.Addresses[0].Value == "" {
+		return "", fmt.Errorf("gateway %s/%s has no address in its status yet", constants.GatewayNamespace, gatewayName)
+	}
+
+	scheme, port := "http", "80"
+	if r.tlsEnabled(nebariApp) {
+		scheme, port = "https", "443"
+	}
+	if parentPort := parentRefPort(nebariApp, r.listenerSectionName(nebariApp, gatewayName, tlsListenerName)); parentPort != nil {
+		port = strconv.Itoa(int(*parentPort))
+	}
+
+	path := nebariApp.Spec.Routing.ConnectivityProbe.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(gateway.Status.Addresses[0].Value, port), path), nil
+}
+
+// sendProbe issues the GET with the app's hostname as Host header (and TLS server
+// name) and the given headers, and returns the response status without following
+// redirects.
+func (r *RoutingReconciler) sendProbe(ctx context.Context, hostname, probeURL string, headers []appsv1.HeaderMatch) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, connectivityProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Host = hostname
+	for _, header := range headers {
+		req.Header.Set(header.Name, header.Value)
+	}
+
+	httpClient := r.probeClient(hostname)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return resp.StatusCode, nil
+}
+
+// probeClient returns the client used for a probe. A configured ProbeClient is
+// copied so redirects are never followed; otherwise a client is built that sends
+// the app's hostname as SNI. Its transport does not keep connections alive, so
+// nothing is left open once the probe returns. Certificate verification is skipped
+// because the probe checks reachability; certificate readiness is reported by TLSReady.
+func (r *RoutingReconciler) probeClient(hostname string) *http.Client {
+	var httpClient http.Client
+	if r.ProbeClient != nil {
+		httpClient = *r.ProbeClient
+	} else {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DisableKeepAlives = true
+		// #nosec G402 -- the probe checks reachability, not the certificate chain
+		transport.TLSClientConfig = &tls.Config{ServerName: hostname, InsecureSkipVerify: true, MinVersion: tls.VersionTLS12}
+		httpClient = http.Client{Transport: transport}
+	}
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &httpClient
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
+)
+
+// roundTripFunc stubs the Gateway so probes never leave the test process.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestProbeConnectivity(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+
+	tests := []struct {
+		name            string
+		operatorEnabled bool
+		probe           *appsv1.ConnectivityProbeConfig
+		tlsEnabled      bool
+		gatewayPort     *int32
+		tlsListenerName string
+		gatewayAddress  string
+		statusCode      int
+		transportErr    error
+		expectURL       string
+		expectStatus    metav1.ConditionStatus // empty means no condition
+		expectReason    string
+	}{
+		{
+			name:            "2xx marks the app reachable",
+			operatorEnabled: true,
+			probe:           &appsv1.ConnectivityProbeConfig{Enabled: true},
+			gatewayAddress:  "10.0.0.10",
+			statusCode:      http.StatusOK,
+			expectURL:       "http://10.0.0.10:80/",
+			expectStatus:    metav1.ConditionTrue,
+			expectReason:    appsv1.ReasonConnectivityProbeSucceeded,
+		},
+		{
+			name:            "3xx counts as reachable and uses the HTTPS port for TLS apps",
+			operatorEnabled: true,
+			probe:           &appsv1.ConnectivityProbeConfig{Enabled: true, Path: "/healthz"},
+			tlsEnabled:      true,
+			gatewayAddress:  "10.0.0.10",
+			statusCode:      http.StatusFound,
+			expectURL:       "https://10.0.0.10:443/healthz",
+			expectStatus:    metav1.ConditionTrue,
+			expectReason:    appsv1.ReasonConnectivityProbeSucceeded,
+		},
+		{
+			name:            "gatewayPort is the probed port",
+			operatorEnabled: true,
+			probe:           &appsv1.ConnectivityProbeConfig{Enabled: true},
+			tlsEnabled:      true,
+			gatewayPort:     ptr.To(int32(8443)),
+			gatewayAddress:  "10.0.0.10",
+			statusCode:      http.StatusOK,
+			expectURL:       "https://10.0.0.10:8443/",
+			expectStatus:    metav1.ConditionTrue,
+			expectReason:    appsv1.ReasonConnectivityProbeSucceeded,
+		},
+		{
+			name:            "gatewayPort does not apply to the app's own TLS listener",
+			operatorEnabled: true,
+			probe:           &appsv1.ConnectivityProbeConfig{Enabled: true},
+			tlsEnabled:      true,
+			gatewayPort:     ptr.To(int32(8443)),
+			tlsListenerName: "tls-test-app-default",
+			gatewayAddress:  "10.0.0.10",
+			statusCode:      http.StatusOK,
+			expectURL:       "https://10.0.0.10:443/",
+			expectStatus:    metav1.ConditionTrue,
+			expectReason:    appsv1.ReasonConnectivityProbeSucceeded,
+		},
+		{
+			name:            "5xx marks the app unreachable",
+			operatorEnabled: true,
+			probe:           &appsv1.ConnectivityProbeConfig{Enabled: true},
+			gatewayAddress:  "10.0.0.10",
+			statusCode:      http.StatusServiceUnavailable,
+			expectStatus:    metav1.ConditionFalse,
+			expectReason:    appsv1.ReasonConnectivityProbeFailed,
+		},
+		{
+			name:            "transport error marks the app unreachable",
+			operatorEnabled: true,
+			probe:           &appsv1.ConnectivityProbeConfig{Enabled: true},
+			gatewayAddress:  "10.0.0.10",
+			transportErr:    fmt.Errorf("connection refused"),
+			expectStatus:    metav1.ConditionFalse,
+			expectReason:    appsv1.ReasonConnectivityProbeFailed,
+		},
+		{
+			name:            "gateway without an address fails without probing",
+			operatorEnabled: true,
+			probe:           &appsv1.ConnectivityProbeConfig{Enabled: true},
+			expectStatus:    metav1.ConditionFalse,
+			expectReason:    appsv1.ReasonConnectivityProbeFailed,
+		},
+		{
+			name:           "operator flag off skips the probe",
+			probe:          &appsv1.ConnectivityProbeConfig{Enabled: true},
+			gatewayAddress: "10.0.0.10",
+		},
+		{
+			name:            "app not opted in skips the probe",
+			operatorEnabled: true,
+			gatewayAddress:  "10.0.0.10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.nebari.local",
+					Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+					Routing: &appsv1.RoutingConfig{
+						ConnectivityProbe: tt.probe,
+						GatewayPort:       tt.gatewayPort,
+						TLS:               &appsv1.RoutingTLSConfig{Enabled: ptr.To(tt.tlsEnabled)},
+					},
+				},
+			}
+			gateway := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+			}
+			if tt.gatewayAddress != "" {
+				gateway.Status.Addresses = []gatewayv1.GatewayStatusAddress{{Value: tt.gatewayAddress}}
+			}
+
+			var requests []*http.Request
+			stub := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				requests = append(requests, req)
+				if tt.transportErr != nil {
+					return nil, tt.transportErr
+				}
+				return &http.Response{
+					StatusCode: tt.statusCode,
+					Header:     http.Header{"Location": []string{"https://idp.example.com/"}},
+					Body:       io.NopCloser(strings.NewReader("")),
+					Request:    req,
+				}, nil
+			})}
+
+			reconciler := &RoutingReconciler{
+				Client:                   fake.NewClientBuilder().WithScheme(scheme).WithObjects(gateway).Build(),
+				Scheme:                   scheme,
+				Recorder:                 record.NewFakeRecorder(10),
+				ConnectivityProbeEnabled: tt.operatorEnabled,
+				ProbeClient:              stub,
+			}
+
+			reconciler.ProbeConnectivity(context.Background(), nebariApp, tt.tlsListenerName)
+
+			cond := meta.FindStatusCondition(nebariApp.Status.Conditions, appsv1.ConditionTypeConnectivityReady)
+			if tt.expectStatus == "" {
+				if cond != nil {
+					t.Errorf("expected no ConnectivityReady condition, got %s/%s", cond.Status, cond.Reason)
+				}
+				if len(requests) != 0 {
+					t.Errorf("expected no probe request, got %d", len(requests))
+				}
+				return
+			}
+			if cond == nil {
+				t.Fatal("expected ConnectivityReady condition to be set")
+			}
+			if cond.Status != tt.expectStatus || cond.Reason != tt.expectReason {
+				t.Errorf("expected ConnectivityReady=%s/%s, got %s/%s (%s)",
+					tt.expectStatus, tt.expectReason, cond.Status, cond.Reason, cond.Message)
+			}
+
+			if tt.gatewayAddress == "" {
+				if len(requests) != 0 {
+					t.Errorf("expected no probe request without a Gateway address, got %d", len(requests))
+				}
+				return
+			}
+			// Redirects must not be followed: exactly one request reaches the Gateway.
+			if len(requests) != 1 {
+				t.Fatalf("expected 1 probe request, got %d", len(requests))
+			}
+			if requests[0].Host != "test.nebari.local" {
+				t.Errorf("expected Host header test.nebari.local, got %q", requests[0].Host)
+			}
+			if tt.expectURL != "" && requests[0].URL.String() != tt.expectURL {
+				t.Errorf("expected probe URL %q, got %q", tt.expectURL, requests[0].URL.String())
+			}
+		})
+	}
+}
+
+func TestProbeConnectivity_RemovesStaleCondition(t *testing.T) {
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Routing:  &appsv1.RoutingConfig{},
+		},
+		Status: appsv1.NebariAppStatus{
+			Conditions: []metav1.Condition{{
+				Type:   appsv1.ConditionTypeConnectivityReady,
+				Status: metav1.ConditionFalse,
+				Reason: appsv1.ReasonConnectivityProbeFailed,
+			}},
+		},
+	}
+
+	reconciler := &RoutingReconciler{ConnectivityProbeEnabled: true}
+	reconciler.ProbeConnectivity(context.Background(), nebariApp, "")
+
+	if cond := meta.FindStatusCondition(nebariApp.Status.Conditions, appsv1.ConditionTypeConnectivityReady); cond != nil {
+		t.Errorf("expected ConnectivityReady to be removed once the probe is disabled, got %s", cond.Status)
+	}
+}
+
+func TestProbeConnectivity_GatewayRouting(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+
+	tests := []struct {
+		name           string
+		gatewayRouting []appsv1.GatewayHeaderRoute
+		expectHeaders  map[string]string // Gateway address -> X-Internal header sent there
+		expectStatus   metav1.ConditionStatus
+	}{
+		{
+			name: "each gateway is probed with its headers",
+			gatewayRouting: []appsv1.GatewayHeaderRoute{{
+				Gateway: "internal",
+				Headers: []appsv1.HeaderMatch{{Name: "X-Internal", Value: "true"}},
+			}},
+			expectHeaders: map[string]string{"10.0.0.10": "", "10.0.0.20": "true"},
+			expectStatus:  metav1.ConditionTrue,
+		},
+		{
+			name: "header-routed primary gateway gets its headers",
+			gatewayRouting: []appsv1.GatewayHeaderRoute{{
+				Gateway: "public",
+				Headers: []appsv1.HeaderMatch{{Name: "X-Internal", Value: "false"}},
+			}},
+			expectHeaders: map[string]string{"10.0.0.10": "false"},
+			expectStatus:  metav1.ConditionTrue,
+		},
+		{
+			name: "gateway with a regular expression header is skipped",
+			gatewayRouting: []appsv1.GatewayHeaderRoute{{
+				Gateway: "internal",
+				Headers: []appsv1.HeaderMatch{{Name: "X-Internal", Value: "tr.*", Type: "RegularExpression"}},
+			}},
+			expectHeaders: map[string]string{"10.0.0.10": ""},
+			expectStatus:  metav1.ConditionTrue,
+		},
+		{
+			name: "no gateway left to probe",
+			gatewayRouting: []appsv1.GatewayHeaderRoute{{
+				Gateway: "public",
+				Headers: []appsv1.HeaderMatch{{Name: "X-Internal", Value: "fa.*", Type: "RegularExpression"}},
+			}},
+			expectHeaders: map[string]string{},
+			expectStatus:  metav1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.nebari.local",
+					Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+					Routing: &appsv1.RoutingConfig{
+						ConnectivityProbe: &appsv1.ConnectivityProbeConfig{Enabled: true},
+						GatewayRouting:    tt.gatewayRouting,
+						TLS:               &appsv1.RoutingTLSConfig{Enabled: ptr.To(false)},
+					},
+				},
+			}
+			publicGateway := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+				Status:     gatewayv1.GatewayStatus{Addresses: []gatewayv1.GatewayStatusAddress{{Value: "10.0.0.10"}}},
+			}
+			internalGateway := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.InternalGatewayName, Namespace: constants.GatewayNamespace},
+				Status:     gatewayv1.GatewayStatus{Addresses: []gatewayv1.GatewayStatusAddress{{Value: "10.0.0.20"}}},
+			}
+
+			sent := map[string]string{}
+			stub := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				sent[req.URL.Hostname()] = req.Header.Get("X-Internal")
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+			})}
+
+			reconciler := &RoutingReconciler{
+				Client:                   fake.NewClientBuilder().WithScheme(scheme).WithObjects(publicGateway, internalGateway).Build(),
+				Scheme:                   scheme,
+				Recorder:                 record.NewFakeRecorder(10),
+				ConnectivityProbeEnabled: true,
+				ProbeClient:              stub,
+			}
+
+			reconciler.ProbeConnectivity(context.Background(), nebariApp, "")
+
+			if len(sent) != len(tt.expectHeaders) {
+				t.Errorf("expected probes to %v, got %v", tt.expectHeaders, sent)
+			}
+			for address, value := range tt.expectHeaders {
+				got, ok := sent[address]
+				if !ok {
+					t.Errorf("expected a probe to %s", address)
+				} else if got != value {
+					t.Errorf("expected X-Internal %q on the probe to %s, got %q", value, address, got)
+				}
+			}
+			cond := meta.FindStatusCondition(nebariApp.Status.Conditions, appsv1.ConditionTypeConnectivityReady)
+			if cond == nil || cond.Status != tt.expectStatus {
+				t.Errorf("expected ConnectivityReady=%s, got %+v", tt.expectStatus, cond)
+			}
+		})
+	}
+}
+
+func TestProbeClient_DoesNotKeepConnections(t *testing.T) {
+	httpClient := (&RoutingReconciler{}).probeClient("test.nebari.local")
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected an *http.Transport, got %T", httpClient.Transport)
+	}
+	if !transport.DisableKeepAlives {
+		t.Error("expected the default probe transport to disable keep-alives")
+	}
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.ServerName != "test.nebari.local" {
+		t.Errorf("expected the app's hostname as TLS server name, got %+v", transport.TLSClientConfig)
+	}
+}
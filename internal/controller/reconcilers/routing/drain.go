@@ -0,0 +1,82 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+)
+
+// DrainDeadline returns when a deleted NebariApp's routes stop draining: its
+// deletion timestamp plus routing.deletionGracePeriod. ok is false when the app
+// is not being deleted or has no grace period.
+func DrainDeadline(nebariApp *appsv1.NebariApp) (deadline time.Time, ok bool) {
+	if nebariApp.DeletionTimestamp == nil || nebariApp.Spec.Routing == nil || nebariApp.Spec.Routing.DeletionGracePeriod == "" {
+		return time.Time{}, false
+	}
+	grace, err := time.ParseDuration(nebariApp.Spec.Routing.DeletionGracePeriod)
+	if err != nil || grace <= 0 {
+		return time.Time{}, false
+	}
+	return nebariApp.DeletionTimestamp.Add(grace), true
+}
+
+// MarkHTTPRoutesDraining annotates the main and public HTTPRoutes controlled by
+// the NebariApp with the time they will be removed. The routes keep serving
+// unchanged; the annotation tells users and tooling the removal is pending.
+// Routes that already carry the annotation are left alone. Returns whether any
+// route was newly marked.
+func (r *RoutingReconciler) MarkHTTPRoutesDraining(ctx context.Context, nebariApp *appsv1.NebariApp, until time.Time) (bool, error) {
+	routes := &gatewayv1.HTTPRouteList{}
+	if err := r.Client.List(ctx, routes, client.InNamespace(nebariApp.Namespace), client.MatchingLabels{
+		"app.kubernetes.io/instance":   naming.LabelValue(nebariApp.Name),
+		"app.kubernetes.io/managed-by": naming.ManagedBy(r.ManagedBy),
+	}); err != nil {
+		return false, fmt.Errorf("failed to list HTTPRoutes: %w", err)
+	}
+
+	marked := false
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		if !metav1.IsControlledBy(route, nebariApp) {
+			continue
+		}
+		if _, ok := route.Annotations[constants.AnnotationDrainingUntil]; ok {
+			continue
+		}
+		if route.Annotations == nil {
+			route.Annotations = map[string]string{}
+		}
+		route.Annotations[constants.AnnotationDrainingUntil] = until.UTC().Format(time.RFC3339)
+		if err := r.Client.Update(ctx, route); err != nil {
+			return marked, fmt.Errorf("failed to mark HTTPRoute %s as draining: %w", route.Name, err)
+		}
+		log.FromContext(ctx).Info("Marked HTTPRoute as draining", "name", route.Name, "until", until)
+		marked = true
+	}
+	return marked, nil
+}
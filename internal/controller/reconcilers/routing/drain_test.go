@@ -0,0 +1,131 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+func TestDrainDeadline(t *testing.T) {
+	deletedAt := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	tests := []struct {
+		name             string
+		deletionTime     *metav1.Time
+		gracePeriod      string
+		expectedOK       bool
+		expectedDeadline time.Time
+	}{
+		{name: "not being deleted", gracePeriod: "30s"},
+		{name: "no grace period", deletionTime: &deletedAt},
+		{name: "unparsable grace period", deletionTime: &deletedAt, gracePeriod: "soon"},
+		{
+			name:             "grace period added to the deletion time",
+			deletionTime:     &deletedAt,
+			gracePeriod:      "1m30s",
+			expectedOK:       true,
+			expectedDeadline: deletedAt.Add(90 * time.Second),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: tt.deletionTime},
+				Spec:       appsv1.NebariAppSpec{Routing: &appsv1.RoutingConfig{DeletionGracePeriod: tt.gracePeriod}},
+			}
+			deadline, ok := DrainDeadline(nebariApp)
+			if ok != tt.expectedOK || !deadline.Equal(tt.expectedDeadline) {
+				t.Errorf("DrainDeadline() = %v, %v; want %v, %v", deadline, ok, tt.expectedDeadline, tt.expectedOK)
+			}
+		})
+	}
+}
+
+func TestMarkHTTPRoutesDraining(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+	}
+	labels := map[string]string{
+		"app.kubernetes.io/instance":   "test-app",
+		"app.kubernetes.io/managed-by": "nebari-operator",
+	}
+	owned := func(name string) *gatewayv1.HTTPRoute {
+		route := &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		}
+		route.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(nebariApp, appsv1.GroupVersion.WithKind("NebariApp"))}
+		return route
+	}
+	foreign := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-route", Namespace: "default", Labels: labels},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nebariApp, owned("test-app-route"), owned("test-app-public-route"), foreign).
+		Build()
+	reconciler := &RoutingReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+	ctx := context.Background()
+	until := time.Date(2026, 1, 2, 3, 5, 0, 0, time.UTC)
+
+	marked, err := reconciler.MarkHTTPRoutesDraining(ctx, nebariApp, until)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !marked {
+		t.Error("expected routes to be newly marked")
+	}
+
+	for name, expected := range map[string]string{
+		"test-app-route":        "2026-01-02T03:05:00Z",
+		"test-app-public-route": "2026-01-02T03:05:00Z",
+		"other-route":           "",
+	} {
+		route := &gatewayv1.HTTPRoute{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, route); err != nil {
+			t.Fatalf("failed to get HTTPRoute %s: %v", name, err)
+		}
+		if got := route.Annotations[constants.AnnotationDrainingUntil]; got != expected {
+			t.Errorf("HTTPRoute %s: expected draining-until %q, got %q", name, expected, got)
+		}
+	}
+
+	marked, err = reconciler.MarkHTTPRoutesDraining(ctx, nebariApp, until.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if marked {
+		t.Error("expected already draining routes to be left alone")
+	}
+}
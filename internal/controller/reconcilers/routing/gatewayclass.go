@@ -0,0 +1,99 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+)
+
+// GatewayClassEnsurer creates the configured GatewayClass at operator startup
+// when it is missing. It runs once per leader, separately from the per-app
+// reconcile, and never modifies an existing GatewayClass: controllerName is
+// immutable and the platform may have set parameters the operator knows nothing about.
+type GatewayClassEnsurer struct {
+	Client         client.Client
+	Name           string
+	ControllerName string
+
+	// ManagedBy is the app.kubernetes.io/managed-by value on the resources it
+	// creates and selects on. Empty means constants.DefaultManagedBy.
+	ManagedBy string
+}
+
+// Start ensures the GatewayClass and returns. Failures are logged rather than
+// returned so a missing permission does not stop the manager; the per-app
+// reconcile then reports the Gateway as not ready as usual.
+func (e *GatewayClassEnsurer) Start(ctx context.Context) error {
+	if err := e.EnsureGatewayClass(ctx); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to ensure GatewayClass", "name", e.Name)
+	}
+	return nil
+}
+
+// NeedLeaderElection makes only the elected leader create the GatewayClass.
+func (e *GatewayClassEnsurer) NeedLeaderElection() bool {
+	return true
+}
+
+// EnsureGatewayClass creates the GatewayClass if it does not exist. An existing
+// GatewayClass is left alone; a mismatched controllerName is only logged.
+func (e *GatewayClassEnsurer) EnsureGatewayClass(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	existing := &gatewayv1.GatewayClass{}
+	err := e.Client.Get(ctx, client.ObjectKey{Name: e.Name}, existing)
+	if err == nil {
+		if string(existing.Spec.ControllerName) != e.ControllerName {
+			logger.Info("GatewayClass exists with a different controllerName, leaving it alone",
+				"name", e.Name, "controllerName", existing.Spec.ControllerName, "expected", e.ControllerName)
+		}
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get GatewayClass %s: %w", e.Name, err)
+	}
+
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: e.Name,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": naming.ManagedBy(e.ManagedBy),
+			},
+		},
+		Spec: gatewayv1.GatewayClassSpec{
+			ControllerName: gatewayv1.GatewayController(e.ControllerName),
+		},
+	}
+	if err := e.Client.Create(ctx, gatewayClass); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create GatewayClass %s: %w", e.Name, err)
+	}
+
+	logger.Info("Created GatewayClass", "name", e.Name, "controllerName", e.ControllerName)
+	return nil
+}
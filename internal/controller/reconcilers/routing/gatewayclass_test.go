@@ -0,0 +1,86 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+func TestEnsureGatewayClass(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = gatewayv1.Install(scheme)
+
+	tests := []struct {
+		name               string
+		existing           *gatewayv1.GatewayClass
+		expectedController string
+		expectManagedLabel bool
+	}{
+		{
+			name:               "missing GatewayClass is created",
+			expectedController: constants.DefaultGatewayControllerName,
+			expectManagedLabel: true,
+		},
+		{
+			name: "existing GatewayClass is left alone",
+			existing: &gatewayv1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.GatewayClassName},
+				Spec:       gatewayv1.GatewayClassSpec{ControllerName: "example.com/other-controller"},
+			},
+			expectedController: "example.com/other-controller",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.existing != nil {
+				builder = builder.WithObjects(tt.existing)
+			}
+			fakeClient := builder.Build()
+			ensurer := &GatewayClassEnsurer{
+				Client:         fakeClient,
+				Name:           constants.GatewayClassName,
+				ControllerName: constants.DefaultGatewayControllerName,
+			}
+
+			if err := ensurer.EnsureGatewayClass(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			gatewayClass := &gatewayv1.GatewayClass{}
+			if err := fakeClient.Get(context.Background(), client.ObjectKey{Name: constants.GatewayClassName}, gatewayClass); err != nil {
+				t.Fatalf("expected GatewayClass to exist: %v", err)
+			}
+			if string(gatewayClass.Spec.ControllerName) != tt.expectedController {
+				t.Errorf("expected controllerName %q, got %q", tt.expectedController, gatewayClass.Spec.ControllerName)
+			}
+			if managed := gatewayClass.Labels["app.kubernetes.io/managed-by"] == "nebari-operator"; managed != tt.expectManagedLabel {
+				t.Errorf("expected managed-by label=%v, got labels %v", tt.expectManagedLabel, gatewayClass.Labels)
+			}
+		})
+	}
+}
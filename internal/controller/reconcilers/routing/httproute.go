@@ -19,9 +19,15 @@ package routing
 import (
 	"context"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -33,7 +39,10 @@ import (
 	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/conditions"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/metrics"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ssa"
 )
 
 // RoutingReconciler handles HTTPRoute generation and management for NebariApp resources
@@ -41,37 +50,209 @@ type RoutingReconciler struct {
 	Client   client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// DefaultRequestTimeouts maps a Gateway name to the request timeout applied to
+	// HTTPRoute rules attached to it when the NebariApp does not set its own.
+	DefaultRequestTimeouts map[string]string
+
+	// TLSDisabledByDefault routes NebariApps that leave routing.tls.enabled unset
+	// to the "http" listener instead of "https".
+	TLSDisabledByDefault bool
+
+	// MaxRoutes caps the number of routing.routes entries per NebariApp.
+	// Defaults to constants.DefaultMaxRoutesPerApp when zero.
+	MaxRoutes int
+
+	// ConnectivityProbeEnabled allows NebariApps to opt into routing.connectivityProbe.
+	ConnectivityProbeEnabled bool
+
+	// ProbeClient, when set, sends connectivity probe requests instead of the
+	// default client. Used by tests to stub the Gateway.
+	ProbeClient *http.Client
+
+	// DefaultResponseHeaders are set on responses from every backend rule of the
+	// generated HTTPRoutes, unless the app sets routing.defaultResponseHeaders=false.
+	DefaultResponseHeaders map[string]string
+
+	// ManagedBy is the app.kubernetes.io/managed-by value on the resources it
+	// creates and selects on. Empty means constants.DefaultManagedBy.
+	ManagedBy string
+
+	// ServerSideApply writes HTTPRoutes with server-side apply instead of
+	// create/update, leaving fields owned by other field managers alone.
+	ServerSideApply bool
 }
 
-// ReconcileRouting creates or updates the HTTPRoute for a NebariApp.
+// validateRouteCount checks routing.routes against the configured per-app limit.
+func (r *RoutingReconciler) validateRouteCount(nebariApp *appsv1.NebariApp) error {
+	maxRoutes := r.MaxRoutes
+	if maxRoutes <= 0 {
+		maxRoutes = constants.DefaultMaxRoutesPerApp
+	}
+	if nebariApp.Spec.Routing == nil || len(nebariApp.Spec.Routing.Routes) <= maxRoutes {
+		return nil
+	}
+	return fmt.Errorf("routing.routes has %d entries, more than the limit of %d per NebariApp",
+		len(nebariApp.Spec.Routing.Routes), maxRoutes)
+}
+
+// ReconcileRouting creates or updates the HTTPRoutes for a NebariApp, one per
+// Gateway it is exposed on (see naming.GatewayNames), and removes routes for
+// Gateways it no longer selects.
 // tlsListenerName is the name of the per-app TLS listener on the Gateway,
 // provided by the TLS reconciler. When non-empty and TLS is enabled, the
 // HTTPRoute will target this listener instead of the default "https" listener.
-func (r *RoutingReconciler) ReconcileRouting(ctx context.Context, nebariApp *appsv1.NebariApp, tlsListenerName string) error {
+// servicePort is the backend port of spec.service, with a portName already
+// resolved by core.ValidateService.
+func (r *RoutingReconciler) ReconcileRouting(ctx context.Context, nebariApp *appsv1.NebariApp, tlsListenerName string,
+	servicePort int32) error {
 	logger := log.FromContext(ctx)
 
-	// Determine which gateway to use
-	gatewayName := naming.GatewayName(nebariApp)
-	logger.Info("Reconciling routing", "gateway", gatewayName, "hostname", nebariApp.Spec.Hostname)
+	// Determine which gateways to use
+	gatewayNames := naming.GatewayNames(nebariApp)
+	logger.Info("Reconciling routing", "gateways", gatewayNames, "hostname", nebariApp.Spec.Hostname)
+
+	// Record the duration per gateway whatever the outcome, so load on each
+	// gateway can be compared
+	start := time.Now()
+	defer func() {
+		metrics.ObserveRoutingReconcile(gatewayNames, time.Since(start))
+	}()
+
+	// Refuse oversized route lists before building anything. Retrying cannot
+	// fix the spec, so the condition reports it and editing the spec reconciles again.
+	if err := r.validateRouteCount(nebariApp); err != nil {
+		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.ReasonTooManyRoutes, err.Error())
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
+			appsv1.ReasonTooManyRoutes, err.Error())
+		return nil
+	}
+
+	// Verify every gateway exists before touching any route
+	for _, gatewayName := range gatewayNames {
+		if err := r.validateGateway(ctx, gatewayName); err != nil {
+			logger.Error(err, "Gateway validation failed")
+			r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonGatewayNotFound, err.Error())
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
+				appsv1.EventReasonGatewayNotFound, err.Error())
+			return err
+		}
+	}
+
+	keep := make([]string, 0, len(gatewayNames))
+	for _, gatewayName := range gatewayNames {
+		// Generate desired HTTPRoute
+		desiredRoute, err := r.buildHTTPRoute(nebariApp, gatewayName, tlsListenerName, servicePort)
+		if err != nil {
+			logger.Error(err, "Failed to build HTTPRoute")
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
+				"BuildFailed", fmt.Sprintf("Failed to build HTTPRoute: %v", err))
+			return err
+		}
+		keep = append(keep, desiredRoute.Name)
+
+		if err := r.applyHTTPRoute(ctx, nebariApp, desiredRoute); err != nil {
+			return err
+		}
+	}
 
-	// Verify gateway exists
-	if err := r.validateGateway(ctx, gatewayName); err != nil {
-		logger.Error(err, "Gateway validation failed")
-		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonGatewayNotFound, err.Error())
+	if err := r.cleanupHTTPRoutes(ctx, nebariApp, routeTypeMain, keep); err != nil {
+		logger.Error(err, "Failed to remove HTTPRoutes for deselected gateways")
+		return err
+	}
+
+	if err := r.reconcileStepUpRoutes(ctx, nebariApp, tlsListenerName, servicePort); err != nil {
+		logger.Error(err, "Failed to reconcile step-up HTTPRoutes")
 		conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
-			appsv1.EventReasonGatewayNotFound, err.Error())
+			"StepUpRouteFailed", fmt.Sprintf("Failed to reconcile step-up HTTPRoute: %v", err))
 		return err
 	}
 
-	// Generate desired HTTPRoute
-	desiredRoute, err := r.buildHTTPRoute(nebariApp, gatewayName, tlsListenerName)
-	if err != nil {
-		logger.Error(err, "Failed to build HTTPRoute")
+	if err := r.reconcileLoginRoutes(ctx, nebariApp, tlsListenerName, servicePort); err != nil {
+		logger.Error(err, "Failed to reconcile login HTTPRoutes")
 		conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
-			"BuildFailed", fmt.Sprintf("Failed to build HTTPRoute: %v", err))
+			"LoginRouteFailed", fmt.Sprintf("Failed to reconcile login HTTPRoute: %v", err))
 		return err
 	}
 
+	return r.reportRouteAcceptance(ctx, nebariApp, gatewayNames, keep)
+}
+
+// reportRouteAcceptance sets RoutingReady from the Accepted condition each
+// Gateway reports on the app's main HTTPRoute: True once every Gateway accepted
+// the route's current generation, False when one rejected it, and Unknown with
+// reason AwaitingGatewayAcceptance while any has yet to report, as right after
+// the route was created. A route the cache does not hold yet was just created
+// and counts as pending too. routeNames[i] is the route attached to
+// gatewayNames[i].
+func (r *RoutingReconciler) reportRouteAcceptance(ctx context.Context, nebariApp *appsv1.NebariApp, gatewayNames, routeNames []string) error {
+	pending := ""
+	for i, routeName := range routeNames {
+		route := &gatewayv1.HTTPRoute{}
+		err := r.Client.Get(ctx, client.ObjectKey{Name: routeName, Namespace: nebariApp.Namespace}, route)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get HTTPRoute %s: %w", routeName, err)
+		}
+
+		var accepted *metav1.Condition
+		if err == nil {
+			accepted = acceptedCondition(route, gatewayNames[i])
+		}
+		switch {
+		case accepted == nil:
+			if pending == "" {
+				pending = fmt.Sprintf("HTTPRoute %s is configured and waiting for Gateway %s to accept it", routeName, gatewayNames[i])
+			}
+		case accepted.Status != metav1.ConditionTrue:
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
+				appsv1.ReasonHTTPRouteNotAccepted, fmt.Sprintf("Gateway %s did not accept HTTPRoute %s: %s: %s",
+					gatewayNames[i], routeName, accepted.Reason, accepted.Message))
+			return nil
+		}
+	}
+
+	if pending != "" {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionUnknown,
+			appsv1.ReasonAwaitingGatewayAcceptance, pending)
+		return nil
+	}
+	conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionTrue,
+		"HTTPRouteReady", "HTTPRoute is configured and accepted by the Gateway")
+	return nil
+}
+
+// acceptedCondition returns the Accepted condition gatewayName reported on
+// route, or nil when it has not reported on the route's current generation.
+func acceptedCondition(route *gatewayv1.HTTPRoute, gatewayName string) *metav1.Condition {
+	for _, parent := range route.Status.Parents {
+		if string(parent.ParentRef.Name) != gatewayName ||
+			(parent.ParentRef.Namespace != nil && string(*parent.ParentRef.Namespace) != constants.GatewayNamespace) {
+			continue
+		}
+		accepted := meta.FindStatusCondition(parent.Conditions, string(gatewayv1.RouteConditionAccepted))
+		if accepted == nil || accepted.ObservedGeneration < route.Generation {
+			return nil
+		}
+		return accepted
+	}
+	return nil
+}
+
+// applyHTTPRoute creates desiredRoute or updates the existing route of the same
+// name.
+func (r *RoutingReconciler) applyHTTPRoute(ctx context.Context, nebariApp *appsv1.NebariApp, desiredRoute *gatewayv1.HTTPRoute) error {
+	logger := log.FromContext(ctx)
+	setHTTPRouteDefaults(&desiredRoute.Spec)
+
+	if r.ServerSideApply {
+		if _, err := r.serverSideApplyHTTPRoute(ctx, nebariApp, desiredRoute, "HTTPRoute"); err != nil {
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
+				"ApplyFailed", fmt.Sprintf("Failed to apply HTTPRoute: %v", err))
+			return err
+		}
+		return nil
+	}
+
 	// Check if HTTPRoute already exists
 	existingRoute := &gatewayv1.HTTPRoute{}
 	routeKey := client.ObjectKey{
@@ -79,7 +260,7 @@ func (r *RoutingReconciler) ReconcileRouting(ctx context.Context, nebariApp *app
 		Namespace: desiredRoute.Namespace,
 	}
 
-	err = r.Client.Get(ctx, routeKey, existingRoute)
+	err := r.Client.Get(ctx, routeKey, existingRoute)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Create new HTTPRoute
@@ -92,16 +273,16 @@ func (r *RoutingReconciler) ReconcileRouting(ctx context.Context, nebariApp *app
 			logger.Info("Created HTTPRoute", "name", desiredRoute.Name)
 			r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonHTTPRouteCreated,
 				fmt.Sprintf("Created HTTPRoute %s", desiredRoute.Name))
-
-			conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionTrue,
-				"HTTPRouteCreated", "HTTPRoute created successfully")
 			return nil
 		}
 		return err
 	}
 
-	// Update existing HTTPRoute
-	existingRoute.Spec = desiredRoute.Spec
+	// Update existing HTTPRoute: spec plus operator-managed labels/annotations
+	if !applyDesiredRoute(existingRoute, desiredRoute) {
+		logger.V(1).Info("HTTPRoute is up to date", "name", existingRoute.Name)
+		return nil
+	}
 	if err := r.Client.Update(ctx, existingRoute); err != nil {
 		// Conflict errors are expected when multiple reconciliations happen concurrently
 		// Return nil to avoid error logging - the controller will naturally retry
@@ -119,17 +300,152 @@ func (r *RoutingReconciler) ReconcileRouting(ctx context.Context, nebariApp *app
 	r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonHTTPRouteUpdated,
 		fmt.Sprintf("Updated HTTPRoute %s", existingRoute.Name))
 
-	conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionTrue,
-		"HTTPRouteReady", "HTTPRoute is configured and ready")
-
 	return nil
 }
 
-// CleanupHTTPRoute removes the HTTPRoute for a NebariApp
+// setHTTPRouteDefaults fills in the fields the Gateway API CRD defaults when the
+// operator leaves them unset: parentRef group and kind, backendRef group, kind
+// and weight, and the "/" prefix match of a rule without matches. Without them
+// a route read back from the API server never equals the built one.
+func setHTTPRouteDefaults(spec *gatewayv1.HTTPRouteSpec) {
+	for i := range spec.ParentRefs {
+		parentRef := &spec.ParentRefs[i]
+		if parentRef.Group == nil {
+			parentRef.Group = ptr.To(gatewayv1.Group(gatewayv1.GroupName))
+		}
+		if parentRef.Kind == nil {
+			parentRef.Kind = ptr.To(gatewayv1.Kind("Gateway"))
+		}
+	}
+	for i := range spec.Rules {
+		rule := &spec.Rules[i]
+		if len(rule.Matches) == 0 {
+			rule.Matches = []gatewayv1.HTTPRouteMatch{{
+				Path: &gatewayv1.HTTPPathMatch{Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/")},
+			}}
+		}
+		for j := range rule.BackendRefs {
+			backendRef := &rule.BackendRefs[j].BackendRef
+			setBackendObjectReferenceDefaults(&backendRef.BackendObjectReference)
+			if backendRef.Weight == nil {
+				backendRef.Weight = ptr.To(int32(1))
+			}
+		}
+		for j := range rule.Filters {
+			if mirror := rule.Filters[j].RequestMirror; mirror != nil {
+				setBackendObjectReferenceDefaults(&mirror.BackendRef)
+			}
+		}
+	}
+}
+
+// setBackendObjectReferenceDefaults defaults an unset group and kind to the
+// core Service, as the API server does.
+func setBackendObjectReferenceDefaults(ref *gatewayv1.BackendObjectReference) {
+	if ref.Group == nil {
+		ref.Group = ptr.To(gatewayv1.Group(""))
+	}
+	if ref.Kind == nil {
+		ref.Kind = ptr.To(gatewayv1.Kind("Service"))
+	}
+}
+
+// operatorAnnotations are annotation keys owned by the operator. They are removed from
+// an existing HTTPRoute when the desired route no longer sets them.
+var operatorAnnotations = []string{
+	"nebari.dev/tls-enabled",
+	constants.AnnotationDescription,
+	constants.AnnotationExternalDNSTarget,
+	constants.AnnotationExternalDNSHostname,
+}
+
+// serverSideApplyHTTPRoute writes desiredRoute with server-side apply and
+// records the same events as the create/update path. description names the
+// route in logs and events, e.g. "public HTTPRoute".
+func (r *RoutingReconciler) serverSideApplyHTTPRoute(ctx context.Context, nebariApp *appsv1.NebariApp,
+	desiredRoute *gatewayv1.HTTPRoute, description string) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx)
+
+	op, err := ssa.Apply(ctx, r.Client, desiredRoute)
+	if err != nil {
+		logger.Error(err, "Failed to apply "+description, "name", desiredRoute.Name)
+		return op, err
+	}
+
+	switch op {
+	case controllerutil.OperationResultCreated:
+		logger.Info("Created "+description, "name", desiredRoute.Name)
+		r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonHTTPRouteCreated,
+			fmt.Sprintf("Created %s %s", description, desiredRoute.Name))
+	case controllerutil.OperationResultUpdated:
+		logger.Info("Updated "+description, "name", desiredRoute.Name)
+		r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonHTTPRouteUpdated,
+			fmt.Sprintf("Updated %s %s", description, desiredRoute.Name))
+	default:
+		logger.V(1).Info(description+" is up to date", "name", desiredRoute.Name)
+	}
+	return op, nil
+}
+
+// applyDesiredRoute sets the desired spec and operator-managed metadata on an
+// existing HTTPRoute and reports whether anything changed. The whole desired
+// state is compared at once, so an edit touching several fields (e.g. hostname
+// and routes) costs one update, and a reconcile with nothing to change, such as
+// one triggered by the route's own update event, costs none. desired must
+// carry the API server's defaults (see setHTTPRouteDefaults).
+func applyDesiredRoute(existing, desired *gatewayv1.HTTPRoute) bool {
+	before := existing.DeepCopy()
+	existing.Spec = desired.Spec
+	mergeManagedMetadata(existing, desired)
+	return !equality.Semantic.DeepEqual(before.Spec, existing.Spec) ||
+		!equality.Semantic.DeepEqual(before.Labels, existing.Labels) ||
+		!equality.Semantic.DeepEqual(before.Annotations, existing.Annotations)
+}
+
+// mergeManagedMetadata copies the desired labels and annotations onto an existing
+// HTTPRoute so drift on operator-managed keys is corrected, while leaving keys
+// added by other tools or users untouched.
+func mergeManagedMetadata(existing, desired *gatewayv1.HTTPRoute) {
+	if len(desired.Labels) > 0 && existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	for k, v := range desired.Labels {
+		existing.Labels[k] = v
+	}
+
+	for _, k := range operatorAnnotations {
+		if _, ok := desired.Annotations[k]; !ok {
+			delete(existing.Annotations, k)
+		}
+	}
+	if len(desired.Annotations) > 0 && existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	for k, v := range desired.Annotations {
+		existing.Annotations[k] = v
+	}
+}
+
+// CleanupHTTPRoute removes the HTTPRoutes for a NebariApp on every Gateway,
+// including the step-up and login HTTPRoutes.
 func (r *RoutingReconciler) CleanupHTTPRoute(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	if err := r.deleteHTTPRoute(ctx, nebariApp, naming.HTTPRouteName(nebariApp), "HTTPRoute"); err != nil {
+		return err
+	}
+	if err := r.cleanupHTTPRoutes(ctx, nebariApp, routeTypeMain, nil); err != nil {
+		return err
+	}
+	if err := r.cleanupHTTPRoutes(ctx, nebariApp, routeTypeStepUp, nil); err != nil {
+		return err
+	}
+	return r.cleanupHTTPRoutes(ctx, nebariApp, routeTypeLogin, nil)
+}
+
+// deleteHTTPRoute deletes the named HTTPRoute in the NebariApp's namespace, if it exists.
+// kind labels the route in logs and events.
+func (r *RoutingReconciler) deleteHTTPRoute(ctx context.Context, nebariApp *appsv1.NebariApp, routeName, kind string) error {
 	logger := log.FromContext(ctx)
 
-	routeName := naming.HTTPRouteName(nebariApp)
 	route := &gatewayv1.HTTPRoute{}
 	routeKey := client.ObjectKey{
 		Name:      routeName,
@@ -145,35 +461,65 @@ func (r *RoutingReconciler) CleanupHTTPRoute(ctx context.Context, nebariApp *app
 	}
 
 	if err := r.Client.Delete(ctx, route); err != nil {
-		logger.Error(err, "Failed to delete HTTPRoute")
+		logger.Error(err, "Failed to delete "+kind)
 		return err
 	}
 
-	logger.Info("Deleted HTTPRoute", "name", routeName)
+	logger.Info("Deleted "+kind, "name", routeName)
 	r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonHTTPRouteDeleted,
-		fmt.Sprintf("Deleted HTTPRoute %s", routeName))
+		fmt.Sprintf("Deleted %s %s", kind, routeName))
 
 	return nil
 }
 
+// Values of the nebari.dev/route-type label. Main routes carry no label.
+const (
+	routeTypeMain   = ""
+	routeTypePublic = "public"
+	routeTypeStepUp = "step-up"
+	routeTypeLogin  = "login"
+)
+
+// cleanupHTTPRoutes deletes the HTTPRoutes of the given route type controlled by
+// the NebariApp whose names are not in keep. This removes the routes left on a
+// Gateway after it is dropped from spec.gateways.
+func (r *RoutingReconciler) cleanupHTTPRoutes(ctx context.Context, nebariApp *appsv1.NebariApp, routeType string, keep []string) error {
+	routes := &gatewayv1.HTTPRouteList{}
+	if err := r.Client.List(ctx, routes, client.InNamespace(nebariApp.Namespace), client.MatchingLabels{
+		"app.kubernetes.io/instance":   naming.LabelValue(nebariApp.Name),
+		"app.kubernetes.io/managed-by": naming.ManagedBy(r.ManagedBy),
+	}); err != nil {
+		return fmt.Errorf("failed to list HTTPRoutes: %w", err)
+	}
+
+	kind := "HTTPRoute"
+	if routeType != routeTypeMain {
+		kind = routeType + " HTTPRoute"
+	}
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		if !metav1.IsControlledBy(route, nebariApp) ||
+			route.Labels["nebari.dev/route-type"] != routeType ||
+			slices.Contains(keep, route.Name) {
+			continue
+		}
+		if err := r.deleteHTTPRoute(ctx, nebariApp, route.Name, kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // buildHTTPRoute generates an HTTPRoute resource from NebariApp spec.
 // tlsListenerName overrides the default "https" section name when TLS is enabled
 // and a per-app TLS listener has been created by the TLS reconciler.
-func (r *RoutingReconciler) buildHTTPRoute(nebariApp *appsv1.NebariApp, gatewayName string, tlsListenerName string) (*gatewayv1.HTTPRoute, error) {
-	routeName := naming.HTTPRouteName(nebariApp)
+func (r *RoutingReconciler) buildHTTPRoute(nebariApp *appsv1.NebariApp, gatewayName string, tlsListenerName string,
+	servicePort int32) (*gatewayv1.HTTPRoute, error) {
+	routeName := naming.GatewayHTTPRouteName(nebariApp, gatewayName)
 	namespace := gatewayv1.Namespace(constants.GatewayNamespace)
 
-	// Determine which Gateway listener to use
-	// Priority: tlsListenerName (from TLS reconciler) > TLS enabled ("https") > TLS disabled ("http")
-	sectionName := gatewayv1.SectionName("https")
-	tlsEnabled := true
-	if nebariApp.Spec.Routing != nil && nebariApp.Spec.Routing.TLS != nil && nebariApp.Spec.Routing.TLS.Enabled != nil && !*nebariApp.Spec.Routing.TLS.Enabled {
-		sectionName = gatewayv1.SectionName("http")
-		tlsEnabled = false
-	}
-	if tlsListenerName != "" && tlsEnabled {
-		sectionName = gatewayv1.SectionName(tlsListenerName)
-	}
+	sectionName := r.listenerSectionName(nebariApp, gatewayName, tlsListenerName)
+	tlsEnabled := r.tlsEnabled(nebariApp)
 
 	// Build HTTPRoute annotations: start with user-supplied annotations from the
 	// routing spec, then apply operator-managed ones so they always take precedence.
@@ -184,6 +530,10 @@ func (r *RoutingReconciler) buildHTTPRoute(nebariApp *appsv1.NebariApp, gatewayN
 		}
 	}
 	httpRouteAnnotations["nebari.dev/tls-enabled"] = fmt.Sprintf("%t", tlsEnabled)
+	if nebariApp.Spec.Description != "" {
+		httpRouteAnnotations[constants.AnnotationDescription] = nebariApp.Spec.Description
+	}
+	addExternalDNSAnnotations(nebariApp, httpRouteAnnotations)
 
 	route := &gatewayv1.HTTPRoute{
 		ObjectMeta: metav1.ObjectMeta{
@@ -191,8 +541,8 @@ func (r *RoutingReconciler) buildHTTPRoute(nebariApp *appsv1.NebariApp, gatewayN
 			Namespace: nebariApp.Namespace,
 			Labels: map[string]string{
 				"app.kubernetes.io/name":       "nebariapp",
-				"app.kubernetes.io/instance":   nebariApp.Name,
-				"app.kubernetes.io/managed-by": "nebari-operator",
+				"app.kubernetes.io/instance":   naming.LabelValue(nebariApp.Name),
+				"app.kubernetes.io/managed-by": naming.ManagedBy(r.ManagedBy),
 			},
 			Annotations: httpRouteAnnotations,
 		},
@@ -203,13 +553,14 @@ func (r *RoutingReconciler) buildHTTPRoute(nebariApp *appsv1.NebariApp, gatewayN
 						Name:        gatewayv1.ObjectName(gatewayName),
 						Namespace:   &namespace,
 						SectionName: &sectionName,
+						Port:        parentRefPort(nebariApp, sectionName),
 					},
 				},
 			},
 			Hostnames: []gatewayv1.Hostname{
 				gatewayv1.Hostname(nebariApp.Spec.Hostname),
 			},
-			Rules: r.buildHTTPRouteRules(nebariApp),
+			Rules: r.buildHTTPRouteRules(nebariApp, gatewayName, servicePort),
 		},
 	}
 
@@ -221,100 +572,503 @@ func (r *RoutingReconciler) buildHTTPRoute(nebariApp *appsv1.NebariApp, gatewayN
 	return route, nil
 }
 
-// buildHTTPRouteRules generates HTTPRoute rules based on NebariApp routes
-func (r *RoutingReconciler) buildHTTPRouteRules(nebariApp *appsv1.NebariApp) []gatewayv1.HTTPRouteRule {
+// addExternalDNSAnnotations sets the external-dns target and hostname
+// annotations when routing.dnsTarget is set, so external-dns publishes the
+// app's hostname at that address instead of the Gateway's.
+func addExternalDNSAnnotations(nebariApp *appsv1.NebariApp, annotations map[string]string) {
+	if nebariApp.Spec.Routing == nil || nebariApp.Spec.Routing.DNSTarget == "" {
+		return
+	}
+	annotations[constants.AnnotationExternalDNSTarget] = nebariApp.Spec.Routing.DNSTarget
+	annotations[constants.AnnotationExternalDNSHostname] = nebariApp.Spec.Hostname
+}
+
+// listenerSectionName returns the Gateway listener the app's route on gatewayName
+// attaches to.
+// Priority: TLS disabled ("http") > tlsListenerName (from the TLS reconciler) >
+// "https". A listener named by routing.tls.gatewayRef only exists on the
+// referenced Gateway, so routes on the app's other Gateways use "https".
+func (r *RoutingReconciler) listenerSectionName(nebariApp *appsv1.NebariApp, gatewayName string, tlsListenerName string) gatewayv1.SectionName {
+	if !r.tlsEnabled(nebariApp) {
+		return gatewayv1.SectionName("http")
+	}
+	if ref := tlsGatewayRef(nebariApp); ref != nil && ref.Name != gatewayName {
+		return gatewayv1.SectionName("https")
+	}
+	if tlsListenerName != "" {
+		return gatewayv1.SectionName(tlsListenerName)
+	}
+	return gatewayv1.SectionName("https")
+}
+
+// gatewayPort returns routing.gatewayPort as the parentRef port, or nil when it
+// is unset so the route attaches to the listener regardless of port.
+func gatewayPort(nebariApp *appsv1.NebariApp) *gatewayv1.PortNumber {
+	if nebariApp.Spec.Routing == nil || nebariApp.Spec.Routing.GatewayPort == nil {
+		return nil
+	}
+	return ptr.To(gatewayv1.PortNumber(*nebariApp.Spec.Routing.GatewayPort))
+}
+
+// parentRefPort returns the parentRef port for a route attaching to
+// sectionName. The app's own TLS listener is always created on port 443 and
+// its name is unique, so routing.gatewayPort only applies to shared listeners.
+func parentRefPort(nebariApp *appsv1.NebariApp, sectionName gatewayv1.SectionName) *gatewayv1.PortNumber {
+	if string(sectionName) == naming.ListenerName(nebariApp) {
+		return nil
+	}
+	return gatewayPort(nebariApp)
+}
+
+// tlsGatewayRef returns routing.tls.gatewayRef, or nil when it is unset.
+func tlsGatewayRef(nebariApp *appsv1.NebariApp) *appsv1.GatewayListenerRef {
+	if nebariApp.Spec.Routing == nil || nebariApp.Spec.Routing.TLS == nil {
+		return nil
+	}
+	return nebariApp.Spec.Routing.TLS.GatewayRef
+}
+
+// tlsEnabled reports whether the app's routes attach to a TLS listener. An explicit
+// routing.tls.enabled wins; otherwise the operator-wide default applies.
+func (r *RoutingReconciler) tlsEnabled(nebariApp *appsv1.NebariApp) bool {
+	if nebariApp.Spec.Routing != nil && nebariApp.Spec.Routing.TLS != nil && nebariApp.Spec.Routing.TLS.Enabled != nil {
+		return *nebariApp.Spec.Routing.TLS.Enabled
+	}
+	return !r.TLSDisabledByDefault
+}
+
+// buildHTTPRouteRules generates HTTPRoute rules based on NebariApp routes for
+// the route attached to gatewayName
+func (r *RoutingReconciler) buildHTTPRouteRules(nebariApp *appsv1.NebariApp, gatewayName string,
+	servicePort int32) []gatewayv1.HTTPRouteRule {
 	// Get routes from routing config if specified
 	var routes []appsv1.RouteMatch
 	if nebariApp.Spec.Routing != nil {
 		routes = nebariApp.Spec.Routing.Routes
 	}
 
-	// Build a single rule with multiple matches (one per route)
-	// All matches route to the same backend, so we use one rule
-	// If no routes specified, we create an empty matches array. Gateway API will automatically
-	// add a default path match of "/" (PathPrefix) when matches is empty or null.
+	rules := r.buildRules(nebariApp, gatewayName, routes, gatewayv1.PathMatchPathPrefix, servicePort)
+	if filter := buildAuthenticatedHeaderFilter(nebariApp); filter != nil {
+		for i := range rules {
+			if len(rules[i].BackendRefs) > 0 {
+				rules[i].Filters = append(rules[i].Filters, *filter)
+			}
+		}
+	}
+	return rules
+}
+
+// buildAuthenticatedHeaderFilter returns a RequestHeaderModifier filter that sets
+// auth.authenticatedRequestHeaders, or nil when there is nothing to add.
+//
+// Envoy Gateway has no header-injection hook in the SecurityPolicy itself, so the
+// headers ride on the main HTTPRoute instead. That is equivalent to "only on
+// authenticated traffic": the SecurityPolicy targets this HTTPRoute, and Envoy runs
+// the OIDC filter before the route's header modifiers, so any request that reaches
+// the backend rule has already been authenticated. Public routes live on a separate
+// HTTPRoute the SecurityPolicy does not target and never get this filter. When
+// enforceAtGateway is false nothing authenticates at the gateway, so no filter is
+// added. Set (rather than Add) overwrites any client-supplied value.
+func buildAuthenticatedHeaderFilter(nebariApp *appsv1.NebariApp) *gatewayv1.HTTPRouteFilter {
+	auth := nebariApp.Spec.Auth
+	if auth == nil || !auth.Enabled || len(auth.AuthenticatedRequestHeaders) == 0 {
+		return nil
+	}
+	if auth.EnforceAtGateway != nil && !*auth.EnforceAtGateway {
+		return nil
+	}
+
+	headers := make([]gatewayv1.HTTPHeader, 0, len(auth.AuthenticatedRequestHeaders))
+	for _, header := range auth.AuthenticatedRequestHeaders {
+		headers = append(headers, gatewayv1.HTTPHeader{
+			Name:  gatewayv1.HTTPHeaderName(header.Name),
+			Value: header.Value,
+		})
+	}
+	return &gatewayv1.HTTPRouteFilter{
+		Type:                  gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+		RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{Set: headers},
+	}
+}
+
+// buildRules turns a list of RouteMatch entries into HTTPRoute rules.
+// All non-redirect routes share a single rule that forwards to the backend
+// service. If no routes are specified, that rule has an empty matches array and
+// Gateway API will automatically add a default path match of "/" (PathPrefix).
+// Each redirect route gets its own rule carrying a RequestRedirect filter and
+// no backend refs, each route with weighted backends gets its own rule that
+// splits traffic across those Services, and each experiment route gets its own
+// rule that forwards to the primary Service and mirrors a sample of requests to
+// the mirror Service. When no route uses the default backend,
+// the shared rule is omitted so it cannot shadow the others with a catch-all match.
+func (r *RoutingReconciler) buildRules(nebariApp *appsv1.NebariApp, gatewayName string, routes []appsv1.RouteMatch,
+	defaultPathType gatewayv1.PathMatchType, servicePort int32) []gatewayv1.HTTPRouteRule {
 	matches := make([]gatewayv1.HTTPRouteMatch, 0, len(routes))
+	var weightedRules, experimentRules, redirectRules []gatewayv1.HTTPRouteRule
 	for _, route := range routes {
-		pathType := gatewayv1.PathMatchPathPrefix
-		if route.PathType == "Exact" {
-			pathType = gatewayv1.PathMatchExact
+		match := buildRouteMatch(route, defaultPathType)
+		switch {
+		case route.Redirect != nil:
+			redirectRules = append(redirectRules, gatewayv1.HTTPRouteRule{
+				Matches: []gatewayv1.HTTPRouteMatch{match},
+				Filters: []gatewayv1.HTTPRouteFilter{buildRedirectFilter(route.Redirect)},
+			})
+		case len(route.Backends) > 0:
+			weightedRules = append(weightedRules, gatewayv1.HTTPRouteRule{
+				Matches:     []gatewayv1.HTTPRouteMatch{match},
+				BackendRefs: buildWeightedBackendRefs(nebariApp, route.Backends),
+				Timeouts:    r.buildRouteTimeouts(nebariApp, gatewayName, route),
+			})
+		case route.Experiment != nil:
+			experimentRules = append(experimentRules, gatewayv1.HTTPRouteRule{
+				Matches: []gatewayv1.HTTPRouteMatch{match},
+				Filters: []gatewayv1.HTTPRouteFilter{buildMirrorFilter(nebariApp, route.Experiment)},
+				BackendRefs: []gatewayv1.HTTPBackendRef{{
+					BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: buildServiceBackendObjectRef(nebariApp, route.Experiment.Primary),
+					},
+				}},
+				Timeouts: r.buildRouteTimeouts(nebariApp, gatewayName, route),
+			})
+		default:
+			matches = append(matches, match)
 		}
+	}
 
-		pathValue := route.PathPrefix
-		match := gatewayv1.HTTPRouteMatch{
-			Path: &gatewayv1.HTTPPathMatch{
-				Type:  &pathType,
-				Value: &pathValue,
-			},
+	rules := make([]gatewayv1.HTTPRouteRule, 0, len(weightedRules)+len(experimentRules)+len(redirectRules)+1)
+	if len(matches) > 0 || len(routes) == 0 {
+		rules = append(rules, gatewayv1.HTTPRouteRule{
+			Matches:     matches,
+			BackendRefs: r.buildBackendRefs(nebariApp, servicePort),
+			Timeouts:    r.buildTimeouts(nebariApp, gatewayName),
+		})
+	}
+	rules = append(rules, weightedRules...)
+	rules = append(rules, experimentRules...)
+	rules = append(rules, redirectRules...)
+
+	if filter := r.buildDefaultResponseHeaderFilter(nebariApp); filter != nil {
+		for i := range rules {
+			if len(rules[i].BackendRefs) > 0 {
+				rules[i].Filters = append(rules[i].Filters, *filter)
+			}
 		}
-		matches = append(matches, match)
 	}
+	addGatewayHeaderMatches(nebariApp, gatewayName, rules)
+	return rules
+}
 
-	return []gatewayv1.HTTPRouteRule{
-		{
-			Matches:     matches,
-			BackendRefs: r.buildBackendRefs(nebariApp),
+// addGatewayHeaderMatches adds the routing.gatewayRouting headers for gatewayName
+// to every match in rules, so the routes on that gateway only take requests that
+// carry them. A rule without matches gets an explicit "/" prefix match, which is
+// what Gateway API would have defaulted it to.
+func addGatewayHeaderMatches(nebariApp *appsv1.NebariApp, gatewayName string, rules []gatewayv1.HTTPRouteRule) {
+	var headers []appsv1.HeaderMatch
+	if routing := nebariApp.Spec.Routing; routing != nil {
+		for _, headerRoute := range routing.GatewayRouting {
+			if naming.GatewayNameFor(headerRoute.Gateway) == gatewayName {
+				headers = headerRoute.Headers
+			}
+		}
+	}
+	if len(headers) == 0 {
+		return
+	}
+
+	headerMatches := buildHeaderMatches(headers)
+	for i := range rules {
+		if len(rules[i].Matches) == 0 {
+			pathType := gatewayv1.PathMatchPathPrefix
+			rules[i].Matches = []gatewayv1.HTTPRouteMatch{{
+				Path: &gatewayv1.HTTPPathMatch{Type: &pathType, Value: ptr.To("/")},
+			}}
+		}
+		for j := range rules[i].Matches {
+			rules[i].Matches[j].Headers = append(rules[i].Matches[j].Headers, headerMatches...)
+		}
+	}
+}
+
+// buildHeaderMatches converts HeaderMatch entries into Gateway API header
+// matches. An unset type means Exact.
+func buildHeaderMatches(headers []appsv1.HeaderMatch) []gatewayv1.HTTPHeaderMatch {
+	headerMatches := make([]gatewayv1.HTTPHeaderMatch, 0, len(headers))
+	for _, header := range headers {
+		matchType := gatewayv1.HeaderMatchExact
+		if header.Type == "RegularExpression" {
+			matchType = gatewayv1.HeaderMatchRegularExpression
+		}
+		headerMatches = append(headerMatches, gatewayv1.HTTPHeaderMatch{
+			Type:  &matchType,
+			Name:  gatewayv1.HTTPHeaderName(header.Name),
+			Value: header.Value,
+		})
+	}
+	return headerMatches
+}
+
+// buildDefaultResponseHeaderFilter returns a ResponseHeaderModifier filter that sets
+// the operator-wide default response headers, or nil when none are configured or
+// the app opted out. Set (rather than Add) overrides any value from the backend,
+// so platform hardening headers cannot be weakened by an app. Headers are sorted
+// by name to keep the generated route stable across reconciles.
+func (r *RoutingReconciler) buildDefaultResponseHeaderFilter(nebariApp *appsv1.NebariApp) *gatewayv1.HTTPRouteFilter {
+	if len(r.DefaultResponseHeaders) == 0 {
+		return nil
+	}
+	if routing := nebariApp.Spec.Routing; routing != nil && routing.DefaultResponseHeaders != nil && !*routing.DefaultResponseHeaders {
+		return nil
+	}
+
+	headers := make([]gatewayv1.HTTPHeader, 0, len(r.DefaultResponseHeaders))
+	for _, name := range slices.Sorted(maps.Keys(r.DefaultResponseHeaders)) {
+		headers = append(headers, gatewayv1.HTTPHeader{
+			Name:  gatewayv1.HTTPHeaderName(name),
+			Value: r.DefaultResponseHeaders[name],
+		})
+	}
+	return &gatewayv1.HTTPRouteFilter{
+		Type:                   gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+		ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{Set: headers},
+	}
+}
+
+// buildTimeouts returns the request timeout for backend rules. The app-level
+// routing.requestTimeout wins; otherwise the operator default for gatewayName
+// is used. Returns nil when neither is set.
+func (r *RoutingReconciler) buildTimeouts(nebariApp *appsv1.NebariApp, gatewayName string) *gatewayv1.HTTPRouteTimeouts {
+	timeout := r.DefaultRequestTimeouts[gatewayName]
+	if nebariApp.Spec.Routing != nil && nebariApp.Spec.Routing.RequestTimeout != "" {
+		timeout = nebariApp.Spec.Routing.RequestTimeout
+	}
+	if timeout == "" {
+		return nil
+	}
+
+	request := gatewayv1.Duration(timeout)
+	return &gatewayv1.HTTPRouteTimeouts{Request: &request}
+}
+
+// buildRouteTimeouts returns the request timeout for the rule generated for a
+// single route. The route's own timeout wins; otherwise buildTimeouts applies.
+func (r *RoutingReconciler) buildRouteTimeouts(nebariApp *appsv1.NebariApp, gatewayName string, route appsv1.RouteMatch) *gatewayv1.HTTPRouteTimeouts {
+	if route.Timeout == "" {
+		return r.buildTimeouts(nebariApp, gatewayName)
+	}
+
+	request := gatewayv1.Duration(route.Timeout)
+	return &gatewayv1.HTTPRouteTimeouts{Request: &request}
+}
+
+// buildRouteMatch converts a RouteMatch into a Gateway API match, using
+// defaultPathType when the route does not set pathType explicitly. The route's
+// headers and method go on the same match, so a request must satisfy all of
+// them. A route without a path gets an explicit "/" prefix match, which is what
+// Gateway API would have defaulted it to.
+func buildRouteMatch(route appsv1.RouteMatch, defaultPathType gatewayv1.PathMatchType) gatewayv1.HTTPRouteMatch {
+	pathType := defaultPathType
+	switch route.PathType {
+	case "Exact":
+		pathType = gatewayv1.PathMatchExact
+	case "PathPrefix":
+		pathType = gatewayv1.PathMatchPathPrefix
+	}
+
+	pathValue := route.PathPrefix
+	if pathValue == "" {
+		pathType = gatewayv1.PathMatchPathPrefix
+		pathValue = "/"
+	}
+	match := gatewayv1.HTTPRouteMatch{
+		Path: &gatewayv1.HTTPPathMatch{
+			Type:  &pathType,
+			Value: &pathValue,
 		},
 	}
+	if len(route.Headers) > 0 {
+		match.Headers = buildHeaderMatches(route.Headers)
+	}
+	if route.Method != "" {
+		method := gatewayv1.HTTPMethod(route.Method)
+		match.Method = &method
+	}
+	return match
 }
 
-// buildBackendRefs generates backend references for the HTTPRoute
-func (r *RoutingReconciler) buildBackendRefs(nebariApp *appsv1.NebariApp) []gatewayv1.HTTPBackendRef {
-	// weight := int32(100)
-	// if nebariApp.Spec.Service.Weight != nil {
-	// 	weight = *nebariApp.Spec.Service.Weight
-	// }
+// buildRedirectFilter converts a RouteRedirect into a RequestRedirect filter.
+func buildRedirectFilter(redirect *appsv1.RouteRedirect) gatewayv1.HTTPRouteFilter {
+	statusCode := 302
+	if redirect.StatusCode != nil {
+		statusCode = *redirect.StatusCode
+	}
+
+	requestRedirect := &gatewayv1.HTTPRequestRedirectFilter{
+		Scheme:     redirect.Scheme,
+		StatusCode: &statusCode,
+	}
+	if redirect.Hostname != "" {
+		hostname := gatewayv1.PreciseHostname(redirect.Hostname)
+		requestRedirect.Hostname = &hostname
+	}
+	if redirect.Path != "" {
+		path := redirect.Path
+		requestRedirect.Path = &gatewayv1.HTTPPathModifier{
+			Type:            gatewayv1.FullPathHTTPPathModifier,
+			ReplaceFullPath: &path,
+		}
+	}
 
-	port := nebariApp.Spec.Service.Port
+	return gatewayv1.HTTPRouteFilter{
+		Type:            gatewayv1.HTTPRouteFilterRequestRedirect,
+		RequestRedirect: requestRedirect,
+	}
+}
 
-	// Use specified service namespace, or default to NebariApp's namespace
-	serviceNamespace := nebariApp.Spec.Service.Namespace
-	if serviceNamespace == "" {
-		serviceNamespace = nebariApp.Namespace
+// buildMirrorFilter converts a route experiment into a RequestMirror filter that
+// copies experiment.percent of requests (100 when unset) to the mirror Service.
+func buildMirrorFilter(nebariApp *appsv1.NebariApp, experiment *appsv1.RouteExperiment) gatewayv1.HTTPRouteFilter {
+	percent := int32(100)
+	if experiment.Percent != nil {
+		percent = *experiment.Percent
 	}
 
-	backendRef := gatewayv1.BackendObjectReference{
-		Name: gatewayv1.ObjectName(nebariApp.Spec.Service.Name),
+	return gatewayv1.HTTPRouteFilter{
+		Type: gatewayv1.HTTPRouteFilterRequestMirror,
+		RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+			BackendRef: buildServiceBackendObjectRef(nebariApp, experiment.Mirror),
+			Percent:    &percent,
+		},
+	}
+}
+
+// buildServiceBackendObjectRef references a Service, leaving the namespace unset
+// when it matches the NebariApp's namespace.
+func buildServiceBackendObjectRef(nebariApp *appsv1.NebariApp, service appsv1.ServiceReference) gatewayv1.BackendObjectReference {
+	port := service.Port
+	ref := gatewayv1.BackendObjectReference{
+		Name: gatewayv1.ObjectName(service.Name),
 		Port: &port,
 	}
+	if service.Namespace != "" && service.Namespace != nebariApp.Namespace {
+		ns := gatewayv1.Namespace(service.Namespace)
+		ref.Namespace = &ns
+	}
+	// Group and Kind default to core Service when left unset
+	if service.Group != "" {
+		group := gatewayv1.Group(service.Group)
+		ref.Group = &group
+	}
+	if service.Kind != "" {
+		kind := gatewayv1.Kind(service.Kind)
+		ref.Kind = &kind
+	}
+	return ref
+}
 
-	// Only set namespace if it's different from the HTTPRoute's namespace
-	// to support cross-namespace service references
-	if serviceNamespace != nebariApp.Namespace {
-		ns := gatewayv1.Namespace(serviceNamespace)
-		backendRef.Namespace = &ns
+// buildWeightedBackendRefs generates one weighted backend reference per entry in
+// a route's backends list. Unset weights default to 1, matching Gateway API.
+func buildWeightedBackendRefs(nebariApp *appsv1.NebariApp, backends []appsv1.WeightedBackend) []gatewayv1.HTTPBackendRef {
+	refs := make([]gatewayv1.HTTPBackendRef, 0, len(backends))
+	for _, backend := range backends {
+		port := backend.Port
+		weight := int32(1)
+		if backend.Weight != nil {
+			weight = *backend.Weight
+		}
+
+		backendRef := gatewayv1.BackendObjectReference{
+			Name: gatewayv1.ObjectName(backend.Name),
+			Port: &port,
+		}
+		if backend.Namespace != "" && backend.Namespace != nebariApp.Namespace {
+			ns := gatewayv1.Namespace(backend.Namespace)
+			backendRef.Namespace = &ns
+		}
+
+		refs = append(refs, gatewayv1.HTTPBackendRef{
+			BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: backendRef,
+				Weight:                 &weight,
+			},
+		})
 	}
+	return refs
+}
+
+// buildBackendRefs generates backend references for the HTTPRoute. The
+// backendRef points at servicePort on spec.service and carries
+// spec.service.weight, or constants.DefaultBackendWeight.
+func (r *RoutingReconciler) buildBackendRefs(nebariApp *appsv1.NebariApp, servicePort int32) []gatewayv1.HTTPBackendRef {
+	weight := int32(constants.DefaultBackendWeight)
+	if nebariApp.Spec.Service.Weight != nil {
+		weight = *nebariApp.Spec.Service.Weight
+	}
+
+	// Namespace is only set when it differs from the HTTPRoute's namespace
+	// to support cross-namespace service references
+	service := nebariApp.Spec.Service
+	service.Port = servicePort
+	backendRef := buildServiceBackendObjectRef(nebariApp, service)
 
 	return []gatewayv1.HTTPBackendRef{
 		{
 			BackendRef: gatewayv1.BackendRef{
 				BackendObjectReference: backendRef,
-				// Weight: &weight,
+				Weight:                 &weight,
 			},
 		},
 	}
 }
 
-// ReconcilePublicRoute creates or updates the public (unauthenticated) HTTPRoute for a NebariApp.
-// This route handles paths listed in routing.publicRoutes that should bypass OIDC authentication.
-func (r *RoutingReconciler) ReconcilePublicRoute(ctx context.Context, nebariApp *appsv1.NebariApp, tlsListenerName string) error {
+// ReconcilePublicRoute creates or updates the public (unauthenticated) HTTPRoutes for a NebariApp,
+// one per Gateway it is exposed on.
+// These routes handle paths listed in routing.publicRoutes that should bypass OIDC authentication,
+// followed by probeRoutes, the probe paths exempted from auth (see core.ProbePublicRoutes).
+func (r *RoutingReconciler) ReconcilePublicRoute(ctx context.Context, nebariApp *appsv1.NebariApp, tlsListenerName string,
+	probeRoutes []appsv1.RouteMatch, servicePort int32) error {
 	logger := log.FromContext(ctx)
 
+	var publicRoutes []appsv1.RouteMatch
+	if nebariApp.Spec.Routing != nil {
+		publicRoutes = slices.Concat(nebariApp.Spec.Routing.PublicRoutes, probeRoutes)
+	}
+
 	// Only create public route if there are public routes configured
-	if nebariApp.Spec.Routing == nil || len(nebariApp.Spec.Routing.PublicRoutes) == 0 {
+	if len(publicRoutes) == 0 {
 		// No public paths - clean up any existing public route
 		return r.CleanupPublicHTTPRoute(ctx, nebariApp)
 	}
 
-	gatewayName := naming.GatewayName(nebariApp)
-	logger.Info("Reconciling public route", "gateway", gatewayName, "hostname", nebariApp.Spec.Hostname,
-		"publicRoutes", nebariApp.Spec.Routing.PublicRoutes)
+	gatewayNames := naming.GatewayNames(nebariApp)
+	logger.Info("Reconciling public route", "gateways", gatewayNames, "hostname", nebariApp.Spec.Hostname,
+		"publicRoutes", publicRoutes)
+
+	keep := make([]string, 0, len(gatewayNames))
+	for _, gatewayName := range gatewayNames {
+		desiredRoute, err := r.buildPublicHTTPRoute(nebariApp, gatewayName, tlsListenerName, publicRoutes, servicePort)
+		if err != nil {
+			logger.Error(err, "Failed to build public HTTPRoute")
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
+				"BuildFailed", fmt.Sprintf("Failed to build public HTTPRoute: %v", err))
+			return err
+		}
+		keep = append(keep, desiredRoute.Name)
 
-	desiredRoute, err := r.buildPublicHTTPRoute(nebariApp, gatewayName, tlsListenerName)
-	if err != nil {
-		logger.Error(err, "Failed to build public HTTPRoute")
-		conditions.SetCondition(nebariApp, appsv1.ConditionTypeRoutingReady, metav1.ConditionFalse,
-			"BuildFailed", fmt.Sprintf("Failed to build public HTTPRoute: %v", err))
+		if err := r.applyPublicHTTPRoute(ctx, nebariApp, desiredRoute); err != nil {
+			return err
+		}
+	}
+
+	return r.cleanupHTTPRoutes(ctx, nebariApp, routeTypePublic, keep)
+}
+
+// applyPublicHTTPRoute creates desiredRoute or updates the existing public route of the same name.
+func (r *RoutingReconciler) applyPublicHTTPRoute(ctx context.Context, nebariApp *appsv1.NebariApp, desiredRoute *gatewayv1.HTTPRoute) error {
+	logger := log.FromContext(ctx)
+	setHTTPRouteDefaults(&desiredRoute.Spec)
+
+	if r.ServerSideApply {
+		_, err := r.serverSideApplyHTTPRoute(ctx, nebariApp, desiredRoute, "public HTTPRoute")
 		return err
 	}
 
@@ -324,7 +1078,7 @@ func (r *RoutingReconciler) ReconcilePublicRoute(ctx context.Context, nebariApp
 		Namespace: desiredRoute.Namespace,
 	}
 
-	err = r.Client.Get(ctx, routeKey, existingRoute)
+	err := r.Client.Get(ctx, routeKey, existingRoute)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			if err := r.Client.Create(ctx, desiredRoute); err != nil {
@@ -340,7 +1094,10 @@ func (r *RoutingReconciler) ReconcilePublicRoute(ctx context.Context, nebariApp
 	}
 
 	// Update existing public HTTPRoute
-	existingRoute.Spec = desiredRoute.Spec
+	if !applyDesiredRoute(existingRoute, desiredRoute) {
+		logger.V(1).Info("Public HTTPRoute is up to date", "name", existingRoute.Name)
+		return nil
+	}
 	if err := r.Client.Update(ctx, existingRoute); err != nil {
 		if errors.IsConflict(err) {
 			logger.V(1).Info("Public HTTPRoute update conflict, will retry", "name", existingRoute.Name)
@@ -357,67 +1114,22 @@ func (r *RoutingReconciler) ReconcilePublicRoute(ctx context.Context, nebariApp
 	return nil
 }
 
-// CleanupPublicHTTPRoute removes the public HTTPRoute for a NebariApp
+// CleanupPublicHTTPRoute removes the public HTTPRoutes for a NebariApp on every Gateway
 func (r *RoutingReconciler) CleanupPublicHTTPRoute(ctx context.Context, nebariApp *appsv1.NebariApp) error {
-	logger := log.FromContext(ctx)
-
-	routeName := naming.PublicHTTPRouteName(nebariApp)
-	route := &gatewayv1.HTTPRoute{}
-	routeKey := client.ObjectKey{
-		Name:      routeName,
-		Namespace: nebariApp.Namespace,
-	}
-
-	if err := r.Client.Get(ctx, routeKey, route); err != nil {
-		if errors.IsNotFound(err) {
-			return nil
-		}
+	if err := r.deleteHTTPRoute(ctx, nebariApp, naming.PublicHTTPRouteName(nebariApp), "public HTTPRoute"); err != nil {
 		return err
 	}
-
-	if err := r.Client.Delete(ctx, route); err != nil {
-		logger.Error(err, "Failed to delete public HTTPRoute")
-		return err
-	}
-
-	logger.Info("Deleted public HTTPRoute", "name", routeName)
-	r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonHTTPRouteDeleted,
-		fmt.Sprintf("Deleted public HTTPRoute %s", routeName))
-
-	return nil
+	return r.cleanupHTTPRoutes(ctx, nebariApp, routeTypePublic, nil)
 }
 
-// buildPublicHTTPRoute generates an HTTPRoute for public routes that bypass OIDC authentication.
+// buildPublicHTTPRoute generates an HTTPRoute for publicRoutes, the routes that bypass OIDC authentication.
 // This route is separate from the main route so the SecurityPolicy only targets the main route.
-func (r *RoutingReconciler) buildPublicHTTPRoute(nebariApp *appsv1.NebariApp, gatewayName string, tlsListenerName string) (*gatewayv1.HTTPRoute, error) {
-	routeName := naming.PublicHTTPRouteName(nebariApp)
+func (r *RoutingReconciler) buildPublicHTTPRoute(nebariApp *appsv1.NebariApp, gatewayName string, tlsListenerName string,
+	publicRoutes []appsv1.RouteMatch, servicePort int32) (*gatewayv1.HTTPRoute, error) {
+	routeName := naming.GatewayPublicHTTPRouteName(nebariApp, gatewayName)
 	namespace := gatewayv1.Namespace(constants.GatewayNamespace)
 
-	sectionName := gatewayv1.SectionName("https")
-	tlsEnabled := true
-	if nebariApp.Spec.Routing != nil && nebariApp.Spec.Routing.TLS != nil && nebariApp.Spec.Routing.TLS.Enabled != nil && !*nebariApp.Spec.Routing.TLS.Enabled {
-		sectionName = gatewayv1.SectionName("http")
-		tlsEnabled = false
-	}
-	if tlsListenerName != "" && tlsEnabled {
-		sectionName = gatewayv1.SectionName(tlsListenerName)
-	}
-
-	// Build matches for each public route (default to Exact for safer auth bypass)
-	matches := make([]gatewayv1.HTTPRouteMatch, 0, len(nebariApp.Spec.Routing.PublicRoutes))
-	for _, route := range nebariApp.Spec.Routing.PublicRoutes {
-		pathType := gatewayv1.PathMatchExact
-		if route.PathType == "PathPrefix" {
-			pathType = gatewayv1.PathMatchPathPrefix
-		}
-		pathValue := route.PathPrefix
-		matches = append(matches, gatewayv1.HTTPRouteMatch{
-			Path: &gatewayv1.HTTPPathMatch{
-				Type:  &pathType,
-				Value: &pathValue,
-			},
-		})
-	}
+	sectionName := r.listenerSectionName(nebariApp, gatewayName, tlsListenerName)
 
 	route := &gatewayv1.HTTPRoute{
 		ObjectMeta: metav1.ObjectMeta{
@@ -425,12 +1137,12 @@ func (r *RoutingReconciler) buildPublicHTTPRoute(nebariApp *appsv1.NebariApp, ga
 			Namespace: nebariApp.Namespace,
 			Labels: map[string]string{
 				"app.kubernetes.io/name":       "nebariapp",
-				"app.kubernetes.io/instance":   nebariApp.Name,
-				"app.kubernetes.io/managed-by": "nebari-operator",
-				"nebari.dev/route-type":        "public",
+				"app.kubernetes.io/instance":   naming.LabelValue(nebariApp.Name),
+				"app.kubernetes.io/managed-by": naming.ManagedBy(r.ManagedBy),
+				"nebari.dev/route-type":        routeTypePublic,
 			},
 			Annotations: map[string]string{
-				"nebari.dev/tls-enabled": fmt.Sprintf("%t", tlsEnabled),
+				"nebari.dev/tls-enabled": fmt.Sprintf("%t", r.tlsEnabled(nebariApp)),
 			},
 		},
 		Spec: gatewayv1.HTTPRouteSpec{
@@ -440,21 +1152,20 @@ func (r *RoutingReconciler) buildPublicHTTPRoute(nebariApp *appsv1.NebariApp, ga
 						Name:        gatewayv1.ObjectName(gatewayName),
 						Namespace:   &namespace,
 						SectionName: &sectionName,
+						Port:        parentRefPort(nebariApp, sectionName),
 					},
 				},
 			},
 			Hostnames: []gatewayv1.Hostname{
 				gatewayv1.Hostname(nebariApp.Spec.Hostname),
 			},
-			Rules: []gatewayv1.HTTPRouteRule{
-				{
-					Matches:     matches,
-					BackendRefs: r.buildBackendRefs(nebariApp),
-				},
-			},
+			// Public routes default to Exact matching for safer auth bypass
+			Rules: r.buildRules(nebariApp, gatewayName, publicRoutes, gatewayv1.PathMatchExact, servicePort),
 		},
 	}
 
+	addExternalDNSAnnotations(nebariApp, route.Annotations)
+
 	if err := controllerutil.SetControllerReference(nebariApp, route, r.Scheme); err != nil {
 		return nil, fmt.Errorf("failed to set controller reference on public HTTPRoute: %w", err)
 	}
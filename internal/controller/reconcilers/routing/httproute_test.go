@@ -18,18 +18,32 @@ package routing
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/metrics"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
 )
 
 func TestValidateGateway(t *testing.T) {
@@ -202,7 +216,7 @@ func TestBuildHTTPRoute(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			route, err := reconciler.buildHTTPRoute(tt.nebariApp, tt.gatewayName, "")
+			route, err := reconciler.buildHTTPRoute(tt.nebariApp, tt.gatewayName, "", tt.nebariApp.Spec.Service.Port)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -259,6 +273,136 @@ func TestBuildHTTPRoute(t *testing.T) {
 	}
 }
 
+func TestBuildHTTPRoute_ManagedBy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		managedBy string
+		expected  string
+	}{
+		{name: "default", managedBy: "", expected: "nebari-operator"},
+		{name: "configured", managedBy: "nebari-operator-staging", expected: "nebari-operator-staging"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler := &RoutingReconciler{
+				Scheme:    scheme,
+				Recorder:  record.NewFakeRecorder(10),
+				ManagedBy: tt.managedBy,
+			}
+
+			route, err := reconciler.buildHTTPRoute(nebariApp, "nebari-gateway", "", nebariApp.Spec.Service.Port)
+			if err != nil {
+				t.Fatalf("buildHTTPRoute: %v", err)
+			}
+			if got := route.Labels["app.kubernetes.io/managed-by"]; got != tt.expected {
+				t.Errorf("expected managed-by %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBuildHTTPRoute_TLSGatewayRef(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Gateways: []string{"public", "internal"},
+			Routing: &appsv1.RoutingConfig{
+				TLS: &appsv1.RoutingTLSConfig{
+					GatewayRef: &appsv1.GatewayListenerRef{Name: constants.PublicGatewayName, SectionName: "wildcard-https"},
+				},
+			},
+		},
+	}
+	reconciler := &RoutingReconciler{Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+
+	tests := []struct {
+		gatewayName string
+		expected    gatewayv1.SectionName
+	}{
+		{gatewayName: constants.PublicGatewayName, expected: "wildcard-https"},
+		{gatewayName: constants.InternalGatewayName, expected: "https"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.gatewayName, func(t *testing.T) {
+			route, err := reconciler.buildHTTPRoute(nebariApp, tt.gatewayName, "wildcard-https", nebariApp.Spec.Service.Port)
+			if err != nil {
+				t.Fatalf("buildHTTPRoute: %v", err)
+			}
+			parentRef := route.Spec.ParentRefs[0]
+			if parentRef.SectionName == nil || *parentRef.SectionName != tt.expected {
+				t.Errorf("expected sectionName %q, got %v", tt.expected, parentRef.SectionName)
+			}
+		})
+	}
+}
+
+func TestBuildHTTPRoute_GatewayPort(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+
+	tests := []struct {
+		name            string
+		port            *int32
+		tlsListenerName string
+		expected        *gatewayv1.PortNumber
+	}{
+		{name: "port unset", port: nil, expected: nil},
+		{name: "port set", port: ptr.To(int32(8443)), expected: ptr.To(gatewayv1.PortNumber(8443))},
+		// The per-app TLS listener is always on 443, so the port is left off
+		{name: "port set with per-app TLS listener", port: ptr.To(int32(8443)), tlsListenerName: "tls-test-app-default", expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.nebari.local",
+					Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+					Routing: &appsv1.RoutingConfig{
+						GatewayPort:  tt.port,
+						PublicRoutes: []appsv1.RouteMatch{{PathPrefix: "/healthz"}},
+					},
+				},
+			}
+			reconciler := &RoutingReconciler{Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+
+			route, err := reconciler.buildHTTPRoute(nebariApp, constants.PublicGatewayName, tt.tlsListenerName, nebariApp.Spec.Service.Port)
+			if err != nil {
+				t.Fatalf("buildHTTPRoute: %v", err)
+			}
+			publicRoute, err := reconciler.buildPublicHTTPRoute(nebariApp, constants.PublicGatewayName, tt.tlsListenerName, nebariApp.Spec.Routing.PublicRoutes, nebariApp.Spec.Service.Port)
+			if err != nil {
+				t.Fatalf("buildPublicHTTPRoute: %v", err)
+			}
+			for _, r := range []*gatewayv1.HTTPRoute{route, publicRoute} {
+				if got := r.Spec.ParentRefs[0].Port; !reflect.DeepEqual(got, tt.expected) {
+					t.Errorf("%s: expected parentRef port %v, got %v", r.Name, tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
 func TestBuildHTTPRoute_SetControllerReferenceError(t *testing.T) {
 	// An empty scheme has no types registered, so SetControllerReference will
 	// fail because it cannot look up the GVK for NebariApp.
@@ -277,7 +421,7 @@ func TestBuildHTTPRoute_SetControllerReferenceError(t *testing.T) {
 		},
 	}
 
-	route, err := reconciler.buildHTTPRoute(nebariApp, "nebari-gateway", "")
+	route, err := reconciler.buildHTTPRoute(nebariApp, "nebari-gateway", "", nebariApp.Spec.Service.Port)
 	if err == nil {
 		t.Error("expected error when scheme has no types registered, got nil")
 	}
@@ -305,7 +449,7 @@ func TestBuildPublicHTTPRoute_SetControllerReferenceError(t *testing.T) {
 		},
 	}
 
-	route, err := reconciler.buildPublicHTTPRoute(nebariApp, "nebari-gateway", "")
+	route, err := reconciler.buildPublicHTTPRoute(nebariApp, "nebari-gateway", "", nebariApp.Spec.Routing.PublicRoutes, nebariApp.Spec.Service.Port)
 	if err == nil {
 		t.Error("expected error when scheme has no types registered, got nil")
 	}
@@ -375,7 +519,7 @@ func TestBuildHTTPRouteRules(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			rules := reconciler.buildHTTPRouteRules(tt.nebariApp)
+			rules := reconciler.buildHTTPRouteRules(tt.nebariApp, naming.GatewayName(tt.nebariApp), tt.nebariApp.Spec.Service.Port)
 
 			if len(rules) != tt.expectedRulesCount {
 				t.Errorf("expected %d rules, got %d", tt.expectedRulesCount, len(rules))
@@ -406,162 +550,1558 @@ func TestBuildHTTPRouteRules(t *testing.T) {
 	}
 }
 
-func TestReconcileRouting(t *testing.T) {
-	scheme := runtime.NewScheme()
-	_ = appsv1.AddToScheme(scheme)
-	_ = gatewayv1.Install(scheme)
-	_ = corev1.AddToScheme(scheme)
+func TestBuildHTTPRouteRules_HeaderMatches(t *testing.T) {
+	reconciler := &RoutingReconciler{}
+	exact := gatewayv1.HeaderMatchExact
+	regex := gatewayv1.HeaderMatchRegularExpression
 
 	tests := []struct {
-		name              string
-		gateway           *gatewayv1.Gateway
-		existingRoute     *gatewayv1.HTTPRoute
-		nebariApp         *appsv1.NebariApp
-		expectError       bool
-		expectRouteCreate bool
-		expectRouteUpdate bool
+		name            string
+		route           appsv1.RouteMatch
+		expectedPath    gatewayv1.HTTPPathMatch
+		expectedHeaders []gatewayv1.HTTPHeaderMatch
 	}{
 		{
-			name: "Create new HTTPRoute",
-			gateway: &gatewayv1.Gateway{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      constants.PublicGatewayName,
-					Namespace: constants.GatewayNamespace,
+			name:  "path only",
+			route: appsv1.RouteMatch{PathPrefix: "/api"},
+			expectedPath: gatewayv1.HTTPPathMatch{
+				Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/api"),
+			},
+		},
+		{
+			name: "headers only match every path",
+			route: appsv1.RouteMatch{Headers: []appsv1.HeaderMatch{
+				{Name: "X-Canary", Value: "true"},
+			}},
+			expectedPath: gatewayv1.HTTPPathMatch{
+				Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/"),
+			},
+			expectedHeaders: []gatewayv1.HTTPHeaderMatch{
+				{Type: &exact, Name: "X-Canary", Value: "true"},
+			},
+		},
+		{
+			name: "path and headers combine into one match",
+			route: appsv1.RouteMatch{
+				PathPrefix: "/rpc",
+				PathType:   "Exact",
+				Headers: []appsv1.HeaderMatch{
+					{Name: "Accept", Value: "application/grpc.*", Type: "RegularExpression"},
+					{Name: "X-Canary", Value: "true", Type: "Exact"},
 				},
 			},
-			existingRoute: nil,
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
+			expectedPath: gatewayv1.HTTPPathMatch{
+				Type: ptr.To(gatewayv1.PathMatchExact), Value: ptr.To("/rpc"),
+			},
+			expectedHeaders: []gatewayv1.HTTPHeaderMatch{
+				{Type: &regex, Name: "Accept", Value: "application/grpc.*"},
+				{Type: &exact, Name: "X-Canary", Value: "true"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				Spec: appsv1.NebariAppSpec{
+					Service: appsv1.ServiceReference{Name: "test-service", Port: 8080},
+					Routing: &appsv1.RoutingConfig{Routes: []appsv1.RouteMatch{tt.route}},
 				},
+			}
+
+			rules := reconciler.buildHTTPRouteRules(nebariApp, naming.GatewayName(nebariApp), nebariApp.Spec.Service.Port)
+			if len(rules) != 1 || len(rules[0].Matches) != 1 {
+				t.Fatalf("expected one rule with one match, got %+v", rules)
+			}
+			match := rules[0].Matches[0]
+			if !reflect.DeepEqual(*match.Path, tt.expectedPath) {
+				t.Errorf("expected path %+v, got %+v", tt.expectedPath, *match.Path)
+			}
+			if !reflect.DeepEqual(match.Headers, tt.expectedHeaders) {
+				t.Errorf("expected headers %+v, got %+v", tt.expectedHeaders, match.Headers)
+			}
+		})
+	}
+}
+
+func TestBuildHTTPRouteRules_MethodMatches(t *testing.T) {
+	reconciler := &RoutingReconciler{}
+
+	tests := []struct {
+		name           string
+		route          appsv1.RouteMatch
+		expectedMethod *gatewayv1.HTTPMethod
+		expectedPath   string
+	}{
+		{
+			name:         "no method matches every method",
+			route:        appsv1.RouteMatch{PathPrefix: "/items"},
+			expectedPath: "/items",
+		},
+		{
+			name:           "method restricts the match",
+			route:          appsv1.RouteMatch{PathPrefix: "/items", Method: "POST"},
+			expectedMethod: ptr.To(gatewayv1.HTTPMethodPost),
+			expectedPath:   "/items",
+		},
+		{
+			name:           "method only matches every path",
+			route:          appsv1.RouteMatch{Method: "DELETE"},
+			expectedMethod: ptr.To(gatewayv1.HTTPMethodDelete),
+			expectedPath:   "/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
 				Spec: appsv1.NebariAppSpec{
-					Hostname: "test.nebari.local",
-					Service: appsv1.ServiceReference{
-						Name: "test-service",
-						Port: 8080,
+					Service: appsv1.ServiceReference{Name: "test-service", Port: 8080},
+					Routing: &appsv1.RoutingConfig{Routes: []appsv1.RouteMatch{tt.route}},
+				},
+			}
+
+			rules := reconciler.buildHTTPRouteRules(nebariApp, naming.GatewayName(nebariApp), nebariApp.Spec.Service.Port)
+			if len(rules) != 1 || len(rules[0].Matches) != 1 {
+				t.Fatalf("expected one rule with one match, got %+v", rules)
+			}
+			match := rules[0].Matches[0]
+			if !reflect.DeepEqual(match.Method, tt.expectedMethod) {
+				t.Errorf("expected method %v, got %v", tt.expectedMethod, match.Method)
+			}
+			if *match.Path.Value != tt.expectedPath {
+				t.Errorf("expected path %q, got %q", tt.expectedPath, *match.Path.Value)
+			}
+		})
+	}
+}
+
+func TestBuildHTTPRouteRules_Redirect(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	reconciler := &RoutingReconciler{
+		Scheme: scheme,
+	}
+
+	tests := []struct {
+		name               string
+		routes             []appsv1.RouteMatch
+		expectedRulesCount int
+		redirectRuleIndex  int
+		expectedScheme     *string
+		expectedHostname   string
+		expectedPath       string
+		expectedStatusCode int
+	}{
+		{
+			name: "maintenance redirect of / only omits backend rule",
+			routes: []appsv1.RouteMatch{
+				{
+					PathPrefix: "/",
+					Redirect: &appsv1.RouteRedirect{
+						Scheme:   ptr.To("https"),
+						Hostname: "status.example.com",
+						Path:     "/maintenance",
 					},
 				},
 			},
-			expectError:       false,
-			expectRouteCreate: true,
-			expectRouteUpdate: false,
+			expectedRulesCount: 1,
+			redirectRuleIndex:  0,
+			expectedScheme:     ptr.To("https"),
+			expectedHostname:   "status.example.com",
+			expectedPath:       "/maintenance",
+			expectedStatusCode: 302,
 		},
 		{
-			name:    "Gateway not found",
-			gateway: nil,
-			nebariApp: &appsv1.NebariApp{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-app",
-					Namespace: "default",
-				},
-				Spec: appsv1.NebariAppSpec{
-					Hostname: "test.nebari.local",
-					Service: appsv1.ServiceReference{
-						Name: "test-service",
-						Port: 8080,
+			name: "redirect alongside backend routes gets its own rule",
+			routes: []appsv1.RouteMatch{
+				{PathPrefix: "/api"},
+				{
+					PathPrefix: "/old",
+					PathType:   "Exact",
+					Redirect: &appsv1.RouteRedirect{
+						Path:       "/new",
+						StatusCode: ptr.To(301),
 					},
 				},
 			},
-			expectError:       true,
-			expectRouteCreate: false,
-			expectRouteUpdate: false,
+			expectedRulesCount: 2,
+			redirectRuleIndex:  1,
+			expectedPath:       "/new",
+			expectedStatusCode: 301,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			builder := fake.NewClientBuilder().
-				WithScheme(scheme).
-				WithObjects(tt.nebariApp)
+			nebariApp := &appsv1.NebariApp{
+				Spec: appsv1.NebariAppSpec{
+					Service: appsv1.ServiceReference{Name: "test-service", Port: 8080},
+					Routing: &appsv1.RoutingConfig{Routes: tt.routes},
+				},
+			}
 
-			if tt.gateway != nil {
-				builder = builder.WithObjects(tt.gateway)
+			rules := reconciler.buildHTTPRouteRules(nebariApp, naming.GatewayName(nebariApp), nebariApp.Spec.Service.Port)
+			if len(rules) != tt.expectedRulesCount {
+				t.Fatalf("expected %d rules, got %d", tt.expectedRulesCount, len(rules))
 			}
-			if tt.existingRoute != nil {
-				builder = builder.WithObjects(tt.existingRoute)
+
+			for i, rule := range rules {
+				if i != tt.redirectRuleIndex && len(rule.BackendRefs) == 0 {
+					t.Errorf("rule %d: expected backend refs on non-redirect rule", i)
+				}
+			}
+
+			rule := rules[tt.redirectRuleIndex]
+			if len(rule.BackendRefs) != 0 {
+				t.Errorf("expected no backend refs on redirect rule, got %d", len(rule.BackendRefs))
+			}
+			if len(rule.Matches) != 1 {
+				t.Fatalf("expected 1 match on redirect rule, got %d", len(rule.Matches))
+			}
+			if len(rule.Filters) != 1 || rule.Filters[0].Type != gatewayv1.HTTPRouteFilterRequestRedirect {
+				t.Fatalf("expected a single RequestRedirect filter, got %+v", rule.Filters)
+			}
+
+			redirect := rule.Filters[0].RequestRedirect
+			if redirect == nil {
+				t.Fatal("expected RequestRedirect to be set")
+			}
+			if (tt.expectedScheme == nil) != (redirect.Scheme == nil) ||
+				(tt.expectedScheme != nil && *redirect.Scheme != *tt.expectedScheme) {
+				t.Errorf("expected scheme %v, got %v", tt.expectedScheme, redirect.Scheme)
+			}
+			if tt.expectedHostname == "" {
+				if redirect.Hostname != nil {
+					t.Errorf("expected no hostname, got %q", *redirect.Hostname)
+				}
+			} else if redirect.Hostname == nil || string(*redirect.Hostname) != tt.expectedHostname {
+				t.Errorf("expected hostname %q, got %v", tt.expectedHostname, redirect.Hostname)
+			}
+			if redirect.Path == nil || redirect.Path.Type != gatewayv1.FullPathHTTPPathModifier ||
+				redirect.Path.ReplaceFullPath == nil || *redirect.Path.ReplaceFullPath != tt.expectedPath {
+				t.Errorf("expected full path replacement %q, got %+v", tt.expectedPath, redirect.Path)
+			}
+			if redirect.StatusCode == nil || *redirect.StatusCode != tt.expectedStatusCode {
+				t.Errorf("expected status code %d, got %v", tt.expectedStatusCode, redirect.StatusCode)
+			}
+		})
+	}
+}
+
+func TestBuildHTTPRouteRules_Experiment(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	reconciler := &RoutingReconciler{Scheme: scheme}
+
+	tests := []struct {
+		name            string
+		percent         *int32
+		expectedPercent int32
+	}{
+		{name: "configured percentage", percent: ptr.To(int32(25)), expectedPercent: 25},
+		{name: "unset percentage mirrors everything", percent: nil, expectedPercent: 100},
+		{name: "zero percentage keeps the mirror configured", percent: ptr.To(int32(0)), expectedPercent: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Service: appsv1.ServiceReference{Name: "web", Port: 8080},
+					Routing: &appsv1.RoutingConfig{
+						Routes: []appsv1.RouteMatch{
+							{PathPrefix: "/"},
+							{PathPrefix: "/api", Experiment: &appsv1.RouteExperiment{
+								Primary: appsv1.ServiceReference{Name: "api-v1", Port: 9000},
+								Mirror:  appsv1.ServiceReference{Name: "api-v2", Port: 9000, Namespace: "canary"},
+								Percent: tt.percent,
+							}},
+						},
+					},
+				},
+			}
+
+			rules := reconciler.buildHTTPRouteRules(nebariApp, naming.GatewayName(nebariApp), nebariApp.Spec.Service.Port)
+			if len(rules) != 2 {
+				t.Fatalf("expected 2 rules, got %d", len(rules))
+			}
+
+			rule := rules[1]
+			if len(rule.Matches) != 1 || *rule.Matches[0].Path.Value != "/api" {
+				t.Errorf("expected experiment rule to match \"/api\", got %+v", rule.Matches)
+			}
+			if len(rule.BackendRefs) != 1 || rule.BackendRefs[0].Name != "api-v1" || *rule.BackendRefs[0].Port != 9000 {
+				t.Errorf("expected experiment rule to forward to api-v1:9000, got %+v", rule.BackendRefs)
+			}
+			if len(rule.Filters) != 1 || rule.Filters[0].Type != gatewayv1.HTTPRouteFilterRequestMirror {
+				t.Fatalf("expected a single RequestMirror filter, got %+v", rule.Filters)
 			}
+			mirror := rule.Filters[0].RequestMirror
+			if mirror.BackendRef.Name != "api-v2" || mirror.BackendRef.Namespace == nil || *mirror.BackendRef.Namespace != "canary" {
+				t.Errorf("expected mirror to canary/api-v2, got %+v", mirror.BackendRef)
+			}
+			if mirror.Percent == nil || *mirror.Percent != tt.expectedPercent {
+				t.Errorf("expected mirror percent %d, got %v", tt.expectedPercent, mirror.Percent)
+			}
+		})
+	}
+}
+
+func TestBuildBackendRefs_Weight(t *testing.T) {
+	tests := []struct {
+		name           string
+		weight         *int32
+		expectedWeight int32
+	}{
+		{name: "defaults to 100", expectedWeight: 100},
+		{name: "explicit weight", weight: ptr.To(int32(25)), expectedWeight: 25},
+		{name: "zero weight", weight: ptr.To(int32(0)), expectedWeight: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Service: appsv1.ServiceReference{Name: "web", Port: 8080, Weight: tt.weight},
+				},
+			}
+			refs := (&RoutingReconciler{}).buildBackendRefs(nebariApp, nebariApp.Spec.Service.Port)
+			if len(refs) != 1 {
+				t.Fatalf("expected 1 backend ref, got %d", len(refs))
+			}
+			if refs[0].Weight == nil || *refs[0].Weight != tt.expectedWeight {
+				t.Errorf("expected weight %d, got %v", tt.expectedWeight, refs[0].Weight)
+			}
+		})
+	}
+}
+
+func TestBuildHTTPRouteRules_WeightedBackends(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	reconciler := &RoutingReconciler{Scheme: scheme}
+
+	weight90, weight10 := int32(90), int32(10)
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Service: appsv1.ServiceReference{Name: "web", Port: 8080},
+			Routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/"},
+					{PathPrefix: "/api", Backends: []appsv1.WeightedBackend{
+						{Name: "api-v1", Port: 9000, Weight: &weight90},
+						{Name: "api-v2", Port: 9000, Namespace: "canary", Weight: &weight10},
+					}},
+				},
+			},
+		},
+	}
+
+	rules := reconciler.buildHTTPRouteRules(nebariApp, naming.GatewayName(nebariApp), nebariApp.Spec.Service.Port)
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+
+	// Other routes keep the single default backend
+	defaultRule := rules[0]
+	if len(defaultRule.Matches) != 1 || *defaultRule.Matches[0].Path.Value != "/" {
+		t.Errorf("expected default rule to match only \"/\", got %+v", defaultRule.Matches)
+	}
+	if len(defaultRule.BackendRefs) != 1 || defaultRule.BackendRefs[0].Name != "web" {
+		t.Errorf("expected default rule to use the web service, got %+v", defaultRule.BackendRefs)
+	}
+
+	weightedRule := rules[1]
+	if len(weightedRule.Matches) != 1 || *weightedRule.Matches[0].Path.Value != "/api" {
+		t.Errorf("expected weighted rule to match \"/api\", got %+v", weightedRule.Matches)
+	}
+	if len(weightedRule.BackendRefs) != 2 {
+		t.Fatalf("expected 2 backend refs, got %d", len(weightedRule.BackendRefs))
+	}
+	expected := []struct {
+		name      string
+		namespace string
+		weight    int32
+	}{
+		{name: "api-v1", weight: 90},
+		{name: "api-v2", namespace: "canary", weight: 10},
+	}
+	for i, want := range expected {
+		ref := weightedRule.BackendRefs[i]
+		if string(ref.Name) != want.name {
+			t.Errorf("backend %d: expected name %q, got %q", i, want.name, ref.Name)
+		}
+		if ref.Weight == nil || *ref.Weight != want.weight {
+			t.Errorf("backend %d: expected weight %d, got %v", i, want.weight, ref.Weight)
+		}
+		if want.namespace == "" && ref.Namespace != nil {
+			t.Errorf("backend %d: expected no namespace, got %q", i, *ref.Namespace)
+		}
+		if want.namespace != "" && (ref.Namespace == nil || string(*ref.Namespace) != want.namespace) {
+			t.Errorf("backend %d: expected namespace %q, got %v", i, want.namespace, ref.Namespace)
+		}
+	}
+
+	t.Run("only weighted routes omit the catch-all default rule", func(t *testing.T) {
+		app := nebariApp.DeepCopy()
+		app.Spec.Routing.Routes = app.Spec.Routing.Routes[1:]
+		rules := reconciler.buildHTTPRouteRules(app, naming.GatewayName(app), app.Spec.Service.Port)
+		if len(rules) != 1 {
+			t.Fatalf("expected 1 rule, got %d", len(rules))
+		}
+		if len(rules[0].BackendRefs) != 2 {
+			t.Errorf("expected the weighted rule only, got %+v", rules[0])
+		}
+	})
+}
+
+func TestBuildHTTPRouteRules_Timeouts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	reconciler := &RoutingReconciler{
+		Scheme: scheme,
+		DefaultRequestTimeouts: map[string]string{
+			constants.PublicGatewayName:   "30s",
+			constants.InternalGatewayName: "5m",
+		},
+	}
+
+	tests := []struct {
+		name            string
+		gateway         string
+		requestTimeout  string
+		expectedTimeout string
+	}{
+		{
+			name:            "public gateway default",
+			gateway:         "public",
+			expectedTimeout: "30s",
+		},
+		{
+			name:            "internal gateway default differs from public",
+			gateway:         "internal",
+			expectedTimeout: "5m",
+		},
+		{
+			name:            "app-level timeout overrides gateway default",
+			gateway:         "internal",
+			requestTimeout:  "10s",
+			expectedTimeout: "10s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				Spec: appsv1.NebariAppSpec{
+					Gateway: tt.gateway,
+					Service: appsv1.ServiceReference{Name: "test-service", Port: 8080},
+					Routing: &appsv1.RoutingConfig{RequestTimeout: tt.requestTimeout},
+				},
+			}
+
+			rules := reconciler.buildHTTPRouteRules(nebariApp, naming.GatewayName(nebariApp), nebariApp.Spec.Service.Port)
+			if len(rules) != 1 {
+				t.Fatalf("expected 1 rule, got %d", len(rules))
+			}
+			timeouts := rules[0].Timeouts
+			if timeouts == nil || timeouts.Request == nil {
+				t.Fatalf("expected request timeout %q, got none", tt.expectedTimeout)
+			}
+			if string(*timeouts.Request) != tt.expectedTimeout {
+				t.Errorf("expected request timeout %q, got %q", tt.expectedTimeout, *timeouts.Request)
+			}
+		})
+	}
+
+	t.Run("no default and no app timeout leaves timeouts unset", func(t *testing.T) {
+		plain := &RoutingReconciler{Scheme: scheme}
+		rules := plain.buildHTTPRouteRules(&appsv1.NebariApp{
+			Spec: appsv1.NebariAppSpec{
+				Service: appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			},
+		}, constants.PublicGatewayName, 8080)
+		if rules[0].Timeouts != nil {
+			t.Errorf("expected no timeouts, got %+v", rules[0].Timeouts)
+		}
+	})
+}
+
+func TestBuildHTTPRouteRules_RouteTimeouts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	reconciler := &RoutingReconciler{
+		Scheme:                 scheme,
+		DefaultRequestTimeouts: map[string]string{constants.PublicGatewayName: "30s"},
+	}
+	nebariApp := &appsv1.NebariApp{
+		Spec: appsv1.NebariAppSpec{
+			Service: appsv1.ServiceReference{Name: "web", Port: 8080},
+			Routing: &appsv1.RoutingConfig{
+				RequestTimeout: "1m",
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/"},
+					{PathPrefix: "/api", Timeout: "10s", Backends: []appsv1.WeightedBackend{{Name: "api", Port: 8080}}},
+					{PathPrefix: "/reports", Timeout: "15m", Backends: []appsv1.WeightedBackend{{Name: "reports", Port: 8080}}},
+					{PathPrefix: "/search", Backends: []appsv1.WeightedBackend{{Name: "search", Port: 8080}}},
+				},
+			},
+		},
+	}
+
+	rules := reconciler.buildHTTPRouteRules(nebariApp, constants.PublicGatewayName, nebariApp.Spec.Service.Port)
+	if len(rules) != 4 {
+		t.Fatalf("expected 4 rules, got %d", len(rules))
+	}
+
+	// The shared rule and the route without its own timeout keep routing.requestTimeout.
+	expected := map[string]string{"web": "1m", "api": "10s", "reports": "15m", "search": "1m"}
+	for _, rule := range rules {
+		backend := string(rule.BackendRefs[0].Name)
+		if rule.Timeouts == nil || rule.Timeouts.Request == nil {
+			t.Errorf("expected a request timeout on the %s rule, got none", backend)
+			continue
+		}
+		if got := string(*rule.Timeouts.Request); got != expected[backend] {
+			t.Errorf("expected request timeout %q on the %s rule, got %q", expected[backend], backend, got)
+		}
+	}
+}
+
+func TestBuildHTTPRouteRules_AuthenticatedRequestHeaders(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	reconciler := &RoutingReconciler{Scheme: scheme}
+
+	headers := []appsv1.HeaderValue{{Name: "X-Authenticated", Value: "true"}}
+	enforceOff := false
+
+	tests := []struct {
+		name         string
+		auth         *appsv1.AuthConfig
+		expectFilter bool
+	}{
+		{
+			name:         "auth enabled with headers",
+			auth:         &appsv1.AuthConfig{Enabled: true, AuthenticatedRequestHeaders: headers},
+			expectFilter: true,
+		},
+		{
+			name: "auth disabled",
+			auth: &appsv1.AuthConfig{Enabled: false, AuthenticatedRequestHeaders: headers},
+		},
+		{
+			name: "not enforced at gateway",
+			auth: &appsv1.AuthConfig{Enabled: true, EnforceAtGateway: &enforceOff, AuthenticatedRequestHeaders: headers},
+		},
+		{
+			name: "no headers configured",
+			auth: &appsv1.AuthConfig{Enabled: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				Spec: appsv1.NebariAppSpec{
+					Service: appsv1.ServiceReference{Name: "test-service", Port: 8080},
+					Routing: &appsv1.RoutingConfig{
+						Routes: []appsv1.RouteMatch{
+							{PathPrefix: "/app"},
+							{PathPrefix: "/old", Redirect: &appsv1.RouteRedirect{Path: "/app"}},
+						},
+					},
+					Auth: tt.auth,
+				},
+			}
+
+			rules := reconciler.buildHTTPRouteRules(nebariApp, naming.GatewayName(nebariApp), nebariApp.Spec.Service.Port)
+			if len(rules) != 2 {
+				t.Fatalf("expected 2 rules, got %d", len(rules))
+			}
+
+			backendFilters := rules[0].Filters
+			if !tt.expectFilter {
+				if len(backendFilters) != 0 {
+					t.Errorf("expected no filters on backend rule, got %+v", backendFilters)
+				}
+				return
+			}
+			if len(backendFilters) != 1 || backendFilters[0].Type != gatewayv1.HTTPRouteFilterRequestHeaderModifier {
+				t.Fatalf("expected a RequestHeaderModifier filter, got %+v", backendFilters)
+			}
+			set := backendFilters[0].RequestHeaderModifier.Set
+			if len(set) != 1 || set[0].Name != "X-Authenticated" || set[0].Value != "true" {
+				t.Errorf("unexpected header set: %+v", set)
+			}
+
+			// Redirect rules never reach the backend and keep only their redirect filter
+			if len(rules[1].Filters) != 1 || rules[1].Filters[0].Type != gatewayv1.HTTPRouteFilterRequestRedirect {
+				t.Errorf("expected redirect rule to keep only its redirect filter, got %+v", rules[1].Filters)
+			}
+		})
+	}
+
+	t.Run("public route never receives the headers", func(t *testing.T) {
+		nebariApp := &appsv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+			Spec: appsv1.NebariAppSpec{
+				Hostname: "test.example.com",
+				Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+				Routing: &appsv1.RoutingConfig{
+					PublicRoutes: []appsv1.RouteMatch{{PathPrefix: "/health"}},
+				},
+				Auth: &appsv1.AuthConfig{Enabled: true, AuthenticatedRequestHeaders: headers},
+			},
+		}
+
+		route, err := reconciler.buildPublicHTTPRoute(nebariApp, constants.PublicGatewayName, "", nebariApp.Spec.Routing.PublicRoutes, nebariApp.Spec.Service.Port)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, rule := range route.Spec.Rules {
+			if len(rule.Filters) != 0 {
+				t.Errorf("expected no filters on public route, got %+v", rule.Filters)
+			}
+		}
+	})
+}
+
+func TestBuildHTTPRouteRules_DefaultResponseHeaders(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	reconciler := &RoutingReconciler{
+		Scheme: scheme,
+		DefaultResponseHeaders: map[string]string{
+			"X-Content-Type-Options":    "nosniff",
+			"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		optIn        *bool
+		expectFilter bool
+	}{
+		{name: "defaults apply when unset", expectFilter: true},
+		{name: "defaults apply when enabled", optIn: ptr.To(true), expectFilter: true},
+		{name: "app opts out", optIn: ptr.To(false)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				Spec: appsv1.NebariAppSpec{
+					Service: appsv1.ServiceReference{Name: "test-service", Port: 8080},
+					Routing: &appsv1.RoutingConfig{
+						Routes: []appsv1.RouteMatch{
+							{PathPrefix: "/app"},
+							{PathPrefix: "/old", Redirect: &appsv1.RouteRedirect{Path: "/app"}},
+						},
+						DefaultResponseHeaders: tt.optIn,
+					},
+				},
+			}
+
+			rules := reconciler.buildHTTPRouteRules(nebariApp, naming.GatewayName(nebariApp), nebariApp.Spec.Service.Port)
+			if len(rules) != 2 {
+				t.Fatalf("expected 2 rules, got %d", len(rules))
+			}
+
+			backendFilters := rules[0].Filters
+			if !tt.expectFilter {
+				if len(backendFilters) != 0 {
+					t.Errorf("expected no filters on backend rule, got %+v", backendFilters)
+				}
+				return
+			}
+			if len(backendFilters) != 1 || backendFilters[0].Type != gatewayv1.HTTPRouteFilterResponseHeaderModifier {
+				t.Fatalf("expected a ResponseHeaderModifier filter, got %+v", backendFilters)
+			}
+			set := backendFilters[0].ResponseHeaderModifier.Set
+			expected := []gatewayv1.HTTPHeader{
+				{Name: "Strict-Transport-Security", Value: "max-age=31536000; includeSubDomains"},
+				{Name: "X-Content-Type-Options", Value: "nosniff"},
+			}
+			if !slices.Equal(set, expected) {
+				t.Errorf("expected headers %+v sorted by name, got %+v", expected, set)
+			}
+
+			// Redirect rules never reach the backend and keep only their redirect filter
+			if len(rules[1].Filters) != 1 || rules[1].Filters[0].Type != gatewayv1.HTTPRouteFilterRequestRedirect {
+				t.Errorf("expected redirect rule to keep only its redirect filter, got %+v", rules[1].Filters)
+			}
+		})
+	}
+
+	t.Run("no defaults configured", func(t *testing.T) {
+		plain := &RoutingReconciler{Scheme: scheme}
+		rules := plain.buildHTTPRouteRules(&appsv1.NebariApp{
+			Spec: appsv1.NebariAppSpec{
+				Service: appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			},
+		}, constants.PublicGatewayName, 8080)
+		if len(rules[0].Filters) != 0 {
+			t.Errorf("expected no filters, got %+v", rules[0].Filters)
+		}
+	})
+}
+
+func TestReconcileRouting(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name              string
+		gateway           *gatewayv1.Gateway
+		existingRoute     *gatewayv1.HTTPRoute
+		nebariApp         *appsv1.NebariApp
+		expectError       bool
+		expectRouteCreate bool
+		expectRouteUpdate bool
+	}{
+		{
+			name: "Create new HTTPRoute",
+			gateway: &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.PublicGatewayName,
+					Namespace: constants.GatewayNamespace,
+				},
+			},
+			existingRoute: nil,
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.nebari.local",
+					Service: appsv1.ServiceReference{
+						Name: "test-service",
+						Port: 8080,
+					},
+				},
+			},
+			expectError:       false,
+			expectRouteCreate: true,
+			expectRouteUpdate: false,
+		},
+		{
+			name:    "Gateway not found",
+			gateway: nil,
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.nebari.local",
+					Service: appsv1.ServiceReference{
+						Name: "test-service",
+						Port: 8080,
+					},
+				},
+			},
+			expectError:       true,
+			expectRouteCreate: false,
+			expectRouteUpdate: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.nebariApp)
+
+			if tt.gateway != nil {
+				builder = builder.WithObjects(tt.gateway)
+			}
+			if tt.existingRoute != nil {
+				builder = builder.WithObjects(tt.existingRoute)
+			}
+
+			client := builder.Build()
+			reconciler := &RoutingReconciler{
+				Client:   client,
+				Scheme:   scheme,
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			err := reconciler.ReconcileRouting(context.Background(), tt.nebariApp, "", tt.nebariApp.Spec.Service.Port)
+			if (err != nil) != tt.expectError {
+				t.Errorf("expected error=%v, got error=%v", tt.expectError, err)
+			}
+
+			if !tt.expectError {
+				// Verify condition was set
+				var foundCondition bool
+				for _, cond := range tt.nebariApp.Status.Conditions {
+					if cond.Type == appsv1.ConditionTypeRoutingReady {
+						foundCondition = true
+						break
+					}
+				}
+				if !foundCondition {
+					t.Error("expected RoutingReady condition to be set")
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileRouting_BuildError(t *testing.T) {
+	// Use a properly initialised scheme for the fake client so validateGateway
+	// passes, but give the reconciler an empty scheme so SetControllerReference
+	// fails — confirming ReconcileRouting surfaces the error and sets the
+	// RoutingReady condition to False.
+	clientScheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(clientScheme)
+	_ = gatewayv1.Install(clientScheme)
+	_ = corev1.AddToScheme(clientScheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+		},
+	}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.PublicGatewayName,
+			Namespace: constants.GatewayNamespace,
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(clientScheme).
+		WithObjects(nebariApp, gateway).
+		Build()
+
+	reconciler := &RoutingReconciler{
+		Client:   fakeClient,
+		Scheme:   runtime.NewScheme(), // empty — causes SetControllerReference to fail
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	err := reconciler.ReconcileRouting(context.Background(), nebariApp, "", nebariApp.Spec.Service.Port)
+	if err == nil {
+		t.Fatal("expected error from ReconcileRouting when buildHTTPRoute fails, got nil")
+	}
+
+	// Confirm the condition is set so the failure is observable on the resource
+	var foundFalse bool
+	for _, cond := range nebariApp.Status.Conditions {
+		if cond.Type == appsv1.ConditionTypeRoutingReady && cond.Status == metav1.ConditionFalse {
+			foundFalse = true
+			break
+		}
+	}
+	if !foundFalse {
+		t.Error("expected RoutingReady condition to be set to False on build error")
+	}
+}
+
+func TestReconcileRouting_MaxRoutes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	makeRoutes := func(n int) []appsv1.RouteMatch {
+		routes := make([]appsv1.RouteMatch, n)
+		for i := range routes {
+			routes[i] = appsv1.RouteMatch{PathPrefix: fmt.Sprintf("/path-%d", i)}
+		}
+		return routes
+	}
+
+	tests := []struct {
+		name           string
+		maxRoutes      int
+		routeCount     int
+		expectRejected bool
+	}{
+		{name: "under the default limit", routeCount: 3},
+		{name: "at the default limit", routeCount: constants.DefaultMaxRoutesPerApp},
+		{name: "over the default limit", routeCount: constants.DefaultMaxRoutesPerApp + 1, expectRejected: true},
+		{name: "under a configured limit", maxRoutes: 5, routeCount: 5},
+		{name: "over a configured limit", maxRoutes: 5, routeCount: 6, expectRejected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.nebari.local",
+					Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+					Routing:  &appsv1.RoutingConfig{Routes: makeRoutes(tt.routeCount)},
+				},
+			}
+			gateway := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp, gateway).Build()
+			reconciler := &RoutingReconciler{
+				Client:    fakeClient,
+				Scheme:    scheme,
+				Recorder:  record.NewFakeRecorder(10),
+				MaxRoutes: tt.maxRoutes,
+			}
+
+			// An oversized list is a spec problem reported on the condition, not
+			// an error that would be retried
+			if err := reconciler.ReconcileRouting(context.Background(), nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cond := meta.FindStatusCondition(nebariApp.Status.Conditions, appsv1.ConditionTypeRoutingReady)
+			if cond == nil {
+				t.Fatal("expected RoutingReady condition to be set")
+			}
+
+			route := &gatewayv1.HTTPRoute{}
+			getErr := fakeClient.Get(context.Background(),
+				types.NamespacedName{Name: naming.HTTPRouteName(nebariApp), Namespace: nebariApp.Namespace}, route)
+
+			if tt.expectRejected {
+				if cond.Status != metav1.ConditionFalse || cond.Reason != appsv1.ReasonTooManyRoutes {
+					t.Errorf("expected RoutingReady=False/%s, got %s/%s", appsv1.ReasonTooManyRoutes, cond.Status, cond.Reason)
+				}
+				if !errors.IsNotFound(getErr) {
+					t.Errorf("expected no HTTPRoute to be created, got err=%v", getErr)
+				}
+				return
+			}
+			// The new route waits for the Gateway to accept it
+			if cond.Status != metav1.ConditionUnknown || cond.Reason != appsv1.ReasonAwaitingGatewayAcceptance {
+				t.Errorf("expected RoutingReady=Unknown/%s, got %s/%s", appsv1.ReasonAwaitingGatewayAcceptance, cond.Status, cond.Reason)
+			}
+			if getErr != nil {
+				t.Errorf("expected HTTPRoute to be created: %v", getErr)
+			}
+		})
+	}
+}
+
+func TestReconcileRouting_RestoresManagedMetadata(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+		},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.PublicGatewayName,
+			Namespace: constants.GatewayNamespace,
+		},
+	}
+	existingRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.HTTPRouteName(nebariApp),
+			Namespace: "default",
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "nebariapp",
+				"app.kubernetes.io/instance":   "tampered",
+				"app.kubernetes.io/managed-by": "nebari-operator",
+				"team":                         "data-science",
+			},
+			Annotations: map[string]string{
+				"nebari.dev/tls-enabled":        "false",
+				constants.AnnotationDescription: "stale description",
+				"example.com/owner":             "alice",
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nebariApp, gateway, existingRoute).
+		Build()
+	reconciler := &RoutingReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	if err := reconciler.ReconcileRouting(context.Background(), nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	route := &gatewayv1.HTTPRoute{}
+	key := types.NamespacedName{Name: naming.HTTPRouteName(nebariApp), Namespace: "default"}
+	if err := c.Get(context.Background(), key, route); err != nil {
+		t.Fatalf("failed to get HTTPRoute: %v", err)
+	}
+
+	if got := route.Labels["app.kubernetes.io/instance"]; got != "test-app" {
+		t.Errorf("expected managed label to be restored to %q, got %q", "test-app", got)
+	}
+	if got := route.Labels["team"]; got != "data-science" {
+		t.Errorf("expected user-added label to be preserved, got %q", got)
+	}
+	if got := route.Annotations["nebari.dev/tls-enabled"]; got != "true" {
+		t.Errorf("expected managed annotation to be restored to %q, got %q", "true", got)
+	}
+	if _, ok := route.Annotations[constants.AnnotationDescription]; ok {
+		t.Error("expected stale description annotation to be removed")
+	}
+	if got := route.Annotations["example.com/owner"]; got != "alice" {
+		t.Errorf("expected user-added annotation to be preserved, got %q", got)
+	}
+}
+
+func TestReconcileRouting_TLSToggleMovesListener(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Routing: &appsv1.RoutingConfig{
+				PublicRoutes: []appsv1.RouteMatch{{PathPrefix: "/health"}},
+			},
+		},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.PublicGatewayName,
+			Namespace: constants.GatewayNamespace,
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp, gateway).Build()
+	reconciler := &RoutingReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(20),
+	}
+	reconcile := func() {
+		t.Helper()
+		if err := reconciler.ReconcileRouting(context.Background(), nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+			t.Fatalf("ReconcileRouting: %v", err)
+		}
+		if err := reconciler.ReconcilePublicRoute(context.Background(), nebariApp, "", nil, nebariApp.Spec.Service.Port); err != nil {
+			t.Fatalf("ReconcilePublicRoute: %v", err)
+		}
+	}
+	assertSection := func(expected string) {
+		t.Helper()
+		for _, name := range []string{naming.HTTPRouteName(nebariApp), naming.PublicHTTPRouteName(nebariApp)} {
+			route := &gatewayv1.HTTPRoute{}
+			if err := c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, route); err != nil {
+				t.Fatalf("failed to get HTTPRoute %s: %v", name, err)
+			}
+			if len(route.Spec.ParentRefs) != 1 {
+				t.Fatalf("expected 1 parentRef on %s, got %d", name, len(route.Spec.ParentRefs))
+			}
+			section := route.Spec.ParentRefs[0].SectionName
+			if section == nil || string(*section) != expected {
+				t.Errorf("expected %s to attach to listener %q, got %v", name, expected, section)
+			}
+			if got := route.Annotations["nebari.dev/tls-enabled"]; got != strconv.FormatBool(expected == "https") {
+				t.Errorf("expected tls-enabled annotation on %s to follow the listener, got %q", name, got)
+			}
+		}
+	}
+
+	reconcile()
+	assertSection("https")
+
+	nebariApp.Spec.Routing.TLS = &appsv1.RoutingTLSConfig{Enabled: ptr.To(false)}
+	reconcile()
+	assertSection("http")
+
+	nebariApp.Spec.Routing.TLS.Enabled = ptr.To(true)
+	reconcile()
+	assertSection("https")
+}
+
+func TestReconcileRouting_DNSTarget(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Routing: &appsv1.RoutingConfig{
+				DNSTarget:    "lb.example.com",
+				PublicRoutes: []appsv1.RouteMatch{{PathPrefix: "/healthz"}},
+			},
+		},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nebariApp, gateway).
+		Build()
+	reconciler := &RoutingReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(20),
+	}
+	ctx := context.Background()
+	routeNames := []string{"test-app-route", "test-app-public-route"}
+
+	if err := reconciler.ReconcileRouting(ctx, nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := reconciler.ReconcilePublicRoute(ctx, nebariApp, "", nil, nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range routeNames {
+		route := &gatewayv1.HTTPRoute{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, route); err != nil {
+			t.Fatalf("failed to get HTTPRoute %s: %v", name, err)
+		}
+		if got := route.Annotations[constants.AnnotationExternalDNSTarget]; got != "lb.example.com" {
+			t.Errorf("%s: expected external-dns target %q, got %q", name, "lb.example.com", got)
+		}
+		if got := route.Annotations[constants.AnnotationExternalDNSHostname]; got != "test.nebari.local" {
+			t.Errorf("%s: expected external-dns hostname %q, got %q", name, "test.nebari.local", got)
+		}
+	}
+
+	// Clearing dnsTarget removes both annotations.
+	nebariApp.Spec.Routing.DNSTarget = ""
+	if err := reconciler.ReconcileRouting(ctx, nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := reconciler.ReconcilePublicRoute(ctx, nebariApp, "", nil, nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range routeNames {
+		route := &gatewayv1.HTTPRoute{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, route); err != nil {
+			t.Fatalf("failed to get HTTPRoute %s: %v", name, err)
+		}
+		for _, key := range []string{constants.AnnotationExternalDNSTarget, constants.AnnotationExternalDNSHostname} {
+			if _, ok := route.Annotations[key]; ok {
+				t.Errorf("%s: expected annotation %s to be removed", name, key)
+			}
+		}
+	}
+}
+
+func TestReconcileRouting_GatewayRouting(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Gateway:  "public",
+			Routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{{PathPrefix: "/api"}},
+				GatewayRouting: []appsv1.GatewayHeaderRoute{{
+					Gateway: "internal",
+					Headers: []appsv1.HeaderMatch{{Name: "X-Internal", Value: "true"}},
+				}},
+			},
+		},
+	}
+	publicGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+	}
+	internalGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InternalGatewayName, Namespace: constants.GatewayNamespace},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nebariApp, publicGateway, internalGateway).
+		Build()
+	reconciler := &RoutingReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(20),
+	}
+	ctx := context.Background()
+
+	if err := reconciler.ReconcileRouting(ctx, nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("ReconcileRouting: %v", err)
+	}
+
+	// The header-matched route attaches to the internal gateway.
+	internalRoute := &gatewayv1.HTTPRoute{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "test-app-route-internal", Namespace: "default"}, internalRoute); err != nil {
+		t.Fatalf("expected internal HTTPRoute: %v", err)
+	}
+	if len(internalRoute.Spec.ParentRefs) != 1 || string(internalRoute.Spec.ParentRefs[0].Name) != constants.InternalGatewayName {
+		t.Fatalf("expected internal route to attach to %s, got %+v", constants.InternalGatewayName, internalRoute.Spec.ParentRefs)
+	}
+	exact := gatewayv1.HeaderMatchExact
+	expectedHeaders := []gatewayv1.HTTPHeaderMatch{{Type: &exact, Name: "X-Internal", Value: "true"}}
+	for _, rule := range internalRoute.Spec.Rules {
+		if len(rule.Matches) == 0 {
+			t.Fatal("expected internal route rules to carry matches")
+		}
+		for _, match := range rule.Matches {
+			if !reflect.DeepEqual(match.Headers, expectedHeaders) {
+				t.Errorf("expected internal route header match %+v, got %+v", expectedHeaders, match.Headers)
+			}
+			if match.Path == nil || *match.Path.Value != "/api" {
+				t.Errorf("expected internal route to keep the /api path match, got %+v", match.Path)
+			}
+		}
+	}
+
+	// The public gateway keeps matching on paths alone.
+	publicRoute := &gatewayv1.HTTPRoute{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "test-app-route", Namespace: "default"}, publicRoute); err != nil {
+		t.Fatalf("expected public HTTPRoute: %v", err)
+	}
+	if string(publicRoute.Spec.ParentRefs[0].Name) != constants.PublicGatewayName {
+		t.Errorf("expected public route to attach to %s, got %s", constants.PublicGatewayName, publicRoute.Spec.ParentRefs[0].Name)
+	}
+	for _, rule := range publicRoute.Spec.Rules {
+		for _, match := range rule.Matches {
+			if len(match.Headers) != 0 {
+				t.Errorf("expected no header matches on the public route, got %+v", match.Headers)
+			}
+		}
+	}
+}
+
+func TestReconcileRouting_GatewayAcceptance(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Routing:  &appsv1.RoutingConfig{},
+		},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp, gateway).Build()
+	reconciler := &RoutingReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(20),
+	}
+	ctx := context.Background()
+	routeKey := types.NamespacedName{Name: "test-app-route", Namespace: "default"}
+
+	// setAccepted reports the route's Accepted condition as the Gateway
+	// controller would.
+	setAccepted := func(parentName string, status metav1.ConditionStatus, reason string, observedGeneration int64) {
+		t.Helper()
+		route := &gatewayv1.HTTPRoute{}
+		if err := fakeClient.Get(ctx, routeKey, route); err != nil {
+			t.Fatalf("expected HTTPRoute: %v", err)
+		}
+		namespace := gatewayv1.Namespace(constants.GatewayNamespace)
+		route.Status.Parents = []gatewayv1.RouteParentStatus{{
+			ParentRef:      gatewayv1.ParentReference{Name: gatewayv1.ObjectName(parentName), Namespace: &namespace},
+			ControllerName: "gateway.envoyproxy.io/gatewayclass-controller",
+			Conditions: []metav1.Condition{{
+				Type:               string(gatewayv1.RouteConditionAccepted),
+				Status:             status,
+				Reason:             reason,
+				Message:            "Route is " + reason,
+				ObservedGeneration: observedGeneration,
+				LastTransitionTime: metav1.Now(),
+			}},
+		}}
+		if err := fakeClient.Update(ctx, route); err != nil {
+			t.Fatalf("failed to update HTTPRoute status: %v", err)
+		}
+	}
+	expectRoutingReady := func(status metav1.ConditionStatus, reason string) {
+		t.Helper()
+		if err := reconciler.ReconcileRouting(ctx, nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+			t.Fatalf("ReconcileRouting: %v", err)
+		}
+		cond := meta.FindStatusCondition(nebariApp.Status.Conditions, appsv1.ConditionTypeRoutingReady)
+		if cond == nil || cond.Status != status || cond.Reason != reason {
+			t.Fatalf("expected RoutingReady=%s/%s, got %+v", status, reason, cond)
+		}
+	}
+
+	// Right after creation nothing has reported on the route
+	expectRoutingReady(metav1.ConditionUnknown, appsv1.ReasonAwaitingGatewayAcceptance)
+
+	// Another Gateway accepting the route does not count
+	setAccepted(constants.InternalGatewayName, metav1.ConditionTrue, "Accepted", 0)
+	expectRoutingReady(metav1.ConditionUnknown, appsv1.ReasonAwaitingGatewayAcceptance)
+
+	setAccepted(constants.PublicGatewayName, metav1.ConditionTrue, "Accepted", 0)
+	expectRoutingReady(metav1.ConditionTrue, "HTTPRouteReady")
+
+	// A verdict on an older generation of the route is stale
+	route := &gatewayv1.HTTPRoute{}
+	if err := fakeClient.Get(ctx, routeKey, route); err != nil {
+		t.Fatalf("expected HTTPRoute: %v", err)
+	}
+	route.Generation = 2
+	if err := fakeClient.Update(ctx, route); err != nil {
+		t.Fatalf("failed to update HTTPRoute: %v", err)
+	}
+	setAccepted(constants.PublicGatewayName, metav1.ConditionTrue, "Accepted", 1)
+	expectRoutingReady(metav1.ConditionUnknown, appsv1.ReasonAwaitingGatewayAcceptance)
+
+	setAccepted(constants.PublicGatewayName, metav1.ConditionFalse, "NotAllowedByListeners", 2)
+	expectRoutingReady(metav1.ConditionFalse, appsv1.ReasonHTTPRouteNotAccepted)
+	cond := meta.FindStatusCondition(nebariApp.Status.Conditions, appsv1.ConditionTypeRoutingReady)
+	if !strings.Contains(cond.Message, "NotAllowedByListeners") {
+		t.Errorf("expected the Gateway's reason in the message, got %q", cond.Message)
+	}
+}
+
+func TestReportRouteAcceptance_RouteNotInCache(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+
+	nebariApp := &appsv1.NebariApp{ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"}}
+	reconciler := &RoutingReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme: scheme,
+	}
+
+	// The cached client may not see a route created moments ago
+	err := reconciler.reportRouteAcceptance(context.Background(), nebariApp,
+		[]string{constants.PublicGatewayName}, []string{"test-app-route"})
+	if err != nil {
+		t.Fatalf("expected a route missing from the cache to count as pending, got %v", err)
+	}
+	cond := meta.FindStatusCondition(nebariApp.Status.Conditions, appsv1.ConditionTypeRoutingReady)
+	if cond == nil || cond.Status != metav1.ConditionUnknown || cond.Reason != appsv1.ReasonAwaitingGatewayAcceptance {
+		t.Errorf("expected RoutingReady=Unknown/%s, got %+v", appsv1.ReasonAwaitingGatewayAcceptance, cond)
+	}
+}
+
+func TestBuildHTTPRouteRules_GatewayRoutingWithoutRoutes(t *testing.T) {
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Service: appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Routing: &appsv1.RoutingConfig{
+				GatewayRouting: []appsv1.GatewayHeaderRoute{{
+					Gateway: "internal",
+					Headers: []appsv1.HeaderMatch{{Name: "X-Source", Type: "RegularExpression", Value: "^office-.*"}},
+				}},
+			},
+		},
+	}
+
+	rules := (&RoutingReconciler{}).buildHTTPRouteRules(nebariApp, constants.InternalGatewayName, nebariApp.Spec.Service.Port)
+	if len(rules) != 1 || len(rules[0].Matches) != 1 {
+		t.Fatalf("expected one rule with one match, got %+v", rules)
+	}
+	match := rules[0].Matches[0]
+	if match.Path == nil || *match.Path.Value != "/" || *match.Path.Type != gatewayv1.PathMatchPathPrefix {
+		t.Errorf("expected an explicit / prefix match, got %+v", match.Path)
+	}
+	if len(match.Headers) != 1 || *match.Headers[0].Type != gatewayv1.HeaderMatchRegularExpression {
+		t.Errorf("expected a regular expression header match, got %+v", match.Headers)
+	}
+}
+
+func TestReconcileRouting_GatewayDurationMetric(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	publicApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "public-app", Namespace: "default", UID: "public-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "public.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Gateway:  "public",
+		},
+	}
+	internalApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal-app", Namespace: "default", UID: "internal-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "internal.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Gateway:  "internal",
+		},
+	}
+	publicGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+	}
+	internalGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InternalGatewayName, Namespace: constants.GatewayNamespace},
+	}
 
-			client := builder.Build()
-			reconciler := &RoutingReconciler{
-				Client:   client,
-				Scheme:   scheme,
-				Recorder: record.NewFakeRecorder(10),
-			}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(publicApp, internalApp, publicGateway, internalGateway).
+		Build()
+	reconciler := &RoutingReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(20),
+	}
 
-			err := reconciler.ReconcileRouting(context.Background(), tt.nebariApp, "")
-			if (err != nil) != tt.expectError {
-				t.Errorf("expected error=%v, got error=%v", tt.expectError, err)
-			}
+	sampleCount := func(gatewayName string) uint64 {
+		m := &dto.Metric{}
+		if err := metrics.RoutingReconcileDuration.WithLabelValues(gatewayName).(prometheus.Metric).Write(m); err != nil {
+			t.Fatalf("failed to read histogram for %s: %v", gatewayName, err)
+		}
+		return m.GetHistogram().GetSampleCount()
+	}
+	publicBefore := sampleCount(constants.PublicGatewayName)
+	internalBefore := sampleCount(constants.InternalGatewayName)
 
-			if !tt.expectError {
-				// Verify condition was set
-				var foundCondition bool
-				for _, cond := range tt.nebariApp.Status.Conditions {
-					if cond.Type == appsv1.ConditionTypeRoutingReady {
-						foundCondition = true
-						break
-					}
-				}
-				if !foundCondition {
-					t.Error("expected RoutingReady condition to be set")
-				}
-			}
-		})
+	for _, app := range []*appsv1.NebariApp{publicApp, internalApp} {
+		if err := reconciler.ReconcileRouting(context.Background(), app, "", app.Spec.Service.Port); err != nil {
+			t.Fatalf("ReconcileRouting %s: %v", app.Name, err)
+		}
+	}
+
+	if got := sampleCount(constants.PublicGatewayName) - publicBefore; got != 1 {
+		t.Errorf("expected 1 observation for %s, got %d", constants.PublicGatewayName, got)
+	}
+	if got := sampleCount(constants.InternalGatewayName) - internalBefore; got != 1 {
+		t.Errorf("expected 1 observation for %s, got %d", constants.InternalGatewayName, got)
 	}
 }
 
-func TestReconcileRouting_BuildError(t *testing.T) {
-	// Use a properly initialised scheme for the fake client so validateGateway
-	// passes, but give the reconciler an empty scheme so SetControllerReference
-	// fails — confirming ReconcileRouting surfaces the error and sets the
-	// RoutingReady condition to False.
-	clientScheme := runtime.NewScheme()
-	_ = appsv1.AddToScheme(clientScheme)
-	_ = gatewayv1.Install(clientScheme)
-	_ = corev1.AddToScheme(clientScheme)
+func TestReconcileRouting_MultipleGateways(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
 
 	nebariApp := &appsv1.NebariApp{
-		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
 		Spec: appsv1.NebariAppSpec{
 			Hostname: "test.nebari.local",
 			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Gateways: []string{"public", "internal"},
+			Routing: &appsv1.RoutingConfig{
+				PublicRoutes: []appsv1.RouteMatch{{PathPrefix: "/healthz"}},
+			},
 		},
 	}
-
-	gateway := &gatewayv1.Gateway{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      constants.PublicGatewayName,
-			Namespace: constants.GatewayNamespace,
-		},
+	publicGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+	}
+	internalGateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InternalGatewayName, Namespace: constants.GatewayNamespace},
 	}
 
-	fakeClient := fake.NewClientBuilder().
-		WithScheme(clientScheme).
-		WithObjects(nebariApp, gateway).
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nebariApp, publicGateway, internalGateway).
 		Build()
-
 	reconciler := &RoutingReconciler{
-		Client:   fakeClient,
-		Scheme:   runtime.NewScheme(), // empty — causes SetControllerReference to fail
-		Recorder: record.NewFakeRecorder(10),
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(20),
 	}
+	ctx := context.Background()
 
-	err := reconciler.ReconcileRouting(context.Background(), nebariApp, "")
-	if err == nil {
-		t.Fatal("expected error from ReconcileRouting when buildHTTPRoute fails, got nil")
+	if err := reconciler.ReconcileRouting(ctx, nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("ReconcileRouting: %v", err)
+	}
+	if err := reconciler.ReconcilePublicRoute(ctx, nebariApp, "", nil, nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("ReconcilePublicRoute: %v", err)
 	}
 
-	// Confirm the condition is set so the failure is observable on the resource
-	var foundFalse bool
-	for _, cond := range nebariApp.Status.Conditions {
-		if cond.Type == appsv1.ConditionTypeRoutingReady && cond.Status == metav1.ConditionFalse {
-			foundFalse = true
-			break
+	expected := map[string]string{
+		"test-app-route":                 constants.PublicGatewayName,
+		"test-app-route-internal":        constants.InternalGatewayName,
+		"test-app-public-route":          constants.PublicGatewayName,
+		"test-app-public-route-internal": constants.InternalGatewayName,
+	}
+	for routeName, gatewayName := range expected {
+		route := &gatewayv1.HTTPRoute{}
+		if err := c.Get(ctx, types.NamespacedName{Name: routeName, Namespace: "default"}, route); err != nil {
+			t.Fatalf("expected HTTPRoute %s: %v", routeName, err)
+		}
+		if len(route.Spec.ParentRefs) != 1 {
+			t.Fatalf("HTTPRoute %s: expected 1 parentRef, got %d", routeName, len(route.Spec.ParentRefs))
+		}
+		parent := route.Spec.ParentRefs[0]
+		if string(parent.Name) != gatewayName {
+			t.Errorf("HTTPRoute %s: expected parentRef %s, got %s", routeName, gatewayName, parent.Name)
+		}
+		if parent.Namespace == nil || string(*parent.Namespace) != constants.GatewayNamespace {
+			t.Errorf("HTTPRoute %s: expected parentRef namespace %s, got %v", routeName, constants.GatewayNamespace, parent.Namespace)
 		}
 	}
-	if !foundFalse {
-		t.Error("expected RoutingReady condition to be set to False on build error")
+
+	// Dropping the internal gateway removes its routes and keeps the public ones.
+	nebariApp.Spec.Gateways = []string{"public"}
+	if err := reconciler.ReconcileRouting(ctx, nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("ReconcileRouting after dropping internal: %v", err)
+	}
+	if err := reconciler.ReconcilePublicRoute(ctx, nebariApp, "", nil, nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("ReconcilePublicRoute after dropping internal: %v", err)
+	}
+	routes := &gatewayv1.HTTPRouteList{}
+	if err := c.List(ctx, routes); err != nil {
+		t.Fatalf("list HTTPRoutes: %v", err)
+	}
+	var names []string
+	for _, route := range routes.Items {
+		names = append(names, route.Name)
+	}
+	if len(names) != 2 || !slices.Contains(names, "test-app-route") || !slices.Contains(names, "test-app-public-route") {
+		t.Errorf("expected only the public gateway routes to remain, got %v", names)
+	}
+
+	// Cleanup removes the routes on every gateway.
+	nebariApp.Spec.Gateways = []string{"public", "internal"}
+	if err := reconciler.ReconcileRouting(ctx, nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("ReconcileRouting: %v", err)
+	}
+	if err := reconciler.CleanupHTTPRoute(ctx, nebariApp); err != nil {
+		t.Fatalf("CleanupHTTPRoute: %v", err)
+	}
+	if err := reconciler.CleanupPublicHTTPRoute(ctx, nebariApp); err != nil {
+		t.Fatalf("CleanupPublicHTTPRoute: %v", err)
+	}
+	if err := c.List(ctx, routes); err != nil {
+		t.Fatalf("list HTTPRoutes: %v", err)
+	}
+	if len(routes.Items) != 0 {
+		t.Errorf("expected all HTTPRoutes to be removed, %d remain", len(routes.Items))
 	}
 }
 
@@ -712,7 +2252,7 @@ func TestBuildPublicHTTPRoute(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			route, err := reconciler.buildPublicHTTPRoute(tt.nebariApp, tt.gatewayName, "")
+			route, err := reconciler.buildPublicHTTPRoute(tt.nebariApp, tt.gatewayName, "", tt.nebariApp.Spec.Routing.PublicRoutes, tt.nebariApp.Spec.Service.Port)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -844,7 +2384,7 @@ func TestReconcilePublicRoute(t *testing.T) {
 				Scheme:   scheme,
 				Recorder: record.NewFakeRecorder(10),
 			}
-			err := reconciler.ReconcilePublicRoute(context.Background(), tt.nebariApp, "")
+			err := reconciler.ReconcilePublicRoute(context.Background(), tt.nebariApp, "", nil, tt.nebariApp.Spec.Service.Port)
 			if (err != nil) != tt.expectError {
 				t.Errorf("expected error=%v, got error=%v", tt.expectError, err)
 			}
@@ -922,10 +2462,11 @@ func TestBuildHTTPRouteWithTLSListener(t *testing.T) {
 	boolPtr := func(b bool) *bool { return &b }
 
 	tests := []struct {
-		name                string
-		nebariApp           *appsv1.NebariApp
-		tlsListenerName     string
-		expectedSectionName string
+		name                 string
+		nebariApp            *appsv1.NebariApp
+		tlsListenerName      string
+		tlsDisabledByDefault bool
+		expectedSectionName  string
 	}{
 		{
 			name: "TLS listener provided",
@@ -987,12 +2528,53 @@ func TestBuildHTTPRouteWithTLSListener(t *testing.T) {
 			tlsListenerName:     "tls-test-app-default",
 			expectedSectionName: "http",
 		},
+		{
+			name: "Global TLS default disabled uses http when app omits TLS",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Service:  appsv1.ServiceReference{Name: "test-svc", Port: 8080},
+					Routing:  &appsv1.RoutingConfig{},
+				},
+			},
+			tlsDisabledByDefault: true,
+			expectedSectionName:  "http",
+		},
+		{
+			name: "Global TLS default enabled uses https when app omits TLS",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Service:  appsv1.ServiceReference{Name: "test-svc", Port: 8080},
+					Routing:  &appsv1.RoutingConfig{},
+				},
+			},
+			expectedSectionName: "https",
+		},
+		{
+			name: "App-level TLS enabled wins over global default",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.example.com",
+					Service:  appsv1.ServiceReference{Name: "test-svc", Port: 8080},
+					Routing: &appsv1.RoutingConfig{
+						TLS: &appsv1.RoutingTLSConfig{Enabled: boolPtr(true)},
+					},
+				},
+			},
+			tlsListenerName:      "tls-test-app-default",
+			tlsDisabledByDefault: true,
+			expectedSectionName:  "tls-test-app-default",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			reconciler := &RoutingReconciler{Scheme: scheme}
-			route, err := reconciler.buildHTTPRoute(tt.nebariApp, constants.PublicGatewayName, tt.tlsListenerName)
+			reconciler := &RoutingReconciler{Scheme: scheme, TLSDisabledByDefault: tt.tlsDisabledByDefault}
+			route, err := reconciler.buildHTTPRoute(tt.nebariApp, constants.PublicGatewayName, tt.tlsListenerName, tt.nebariApp.Spec.Service.Port)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -1020,6 +2602,7 @@ func TestBuildHTTPRouteAnnotations(t *testing.T) {
 	tests := []struct {
 		name                string
 		routingAnnotations  map[string]string
+		description         string
 		expectedAnnotations map[string]string
 	}{
 		{
@@ -1057,6 +2640,15 @@ func TestBuildHTTPRouteAnnotations(t *testing.T) {
 				"nebari.dev/tls-enabled": "true", // operator wins
 			},
 		},
+		{
+			name:               "description is propagated as annotation",
+			routingAnnotations: nil,
+			description:        "JupyterHub for the data science team",
+			expectedAnnotations: map[string]string{
+				"nebari.dev/tls-enabled":        "true",
+				constants.AnnotationDescription: "JupyterHub for the data science team",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1068,8 +2660,9 @@ func TestBuildHTTPRouteAnnotations(t *testing.T) {
 					Namespace: "nebari-system",
 				},
 				Spec: appsv1.NebariAppSpec{
-					Hostname: "my-app.nebari.dev",
-					Service:  appsv1.ServiceReference{Name: "my-app", Port: 8080},
+					Hostname:    "my-app.nebari.dev",
+					Description: tt.description,
+					Service:     appsv1.ServiceReference{Name: "my-app", Port: 8080},
 					Routing: &appsv1.RoutingConfig{
 						TLS:         &appsv1.RoutingTLSConfig{Enabled: &tlsEnabled},
 						Annotations: tt.routingAnnotations,
@@ -1077,7 +2670,7 @@ func TestBuildHTTPRouteAnnotations(t *testing.T) {
 				},
 			}
 
-			route, err := reconciler.buildHTTPRoute(nebariApp, constants.PublicGatewayName, "")
+			route, err := reconciler.buildHTTPRoute(nebariApp, constants.PublicGatewayName, "", nebariApp.Spec.Service.Port)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
@@ -1116,6 +2709,8 @@ func TestBuildBackendRefs(t *testing.T) {
 		nebariApp         *appsv1.NebariApp
 		expectNamespace   bool
 		expectedNamespace string
+		expectedGroup     *string
+		expectedKind      *string
 	}{
 		{
 			name: "Same namespace - namespace not set in backend ref",
@@ -1151,11 +2746,31 @@ func TestBuildBackendRefs(t *testing.T) {
 			expectNamespace:   true,
 			expectedNamespace: "other-namespace",
 		},
+		{
+			name: "Envoy Gateway Backend - group and kind set in backend ref",
+			nebariApp: &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-app",
+					Namespace: "default",
+				},
+				Spec: appsv1.NebariAppSpec{
+					Service: appsv1.ServiceReference{
+						Name:  "external-api",
+						Port:  443,
+						Group: "gateway.envoyproxy.io",
+						Kind:  "Backend",
+					},
+				},
+			},
+			expectNamespace: false,
+			expectedGroup:   ptr.To("gateway.envoyproxy.io"),
+			expectedKind:    ptr.To("Backend"),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			backendRefs := reconciler.buildBackendRefs(tt.nebariApp)
+			backendRefs := reconciler.buildBackendRefs(tt.nebariApp, tt.nebariApp.Spec.Service.Port)
 
 			if len(backendRefs) != 1 {
 				t.Fatalf("expected 1 backend ref, got %d", len(backendRefs))
@@ -1186,6 +2801,270 @@ func TestBuildBackendRefs(t *testing.T) {
 					t.Errorf("expected namespace to be nil, got=%s", *backendRef.Namespace)
 				}
 			}
+
+			// Check group and kind, which are left unset for core Services
+			if (backendRef.Group == nil) != (tt.expectedGroup == nil) ||
+				(backendRef.Group != nil && string(*backendRef.Group) != *tt.expectedGroup) {
+				t.Errorf("expected group=%v, got=%v", tt.expectedGroup, backendRef.Group)
+			}
+			if (backendRef.Kind == nil) != (tt.expectedKind == nil) ||
+				(backendRef.Kind != nil && string(*backendRef.Kind) != *tt.expectedKind) {
+				t.Errorf("expected kind=%v, got=%v", tt.expectedKind, backendRef.Kind)
+			}
 		})
 	}
 }
+
+func TestReconcileRouting_ServerDefaultsAreNoOp(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+		},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+	}
+
+	// serverDefaults stands in for the Gateway API CRD defaulting the API
+	// server applies to stored HTTPRoutes.
+	serverDefaults := func(route *gatewayv1.HTTPRoute) {
+		for i := range route.Spec.ParentRefs {
+			route.Spec.ParentRefs[i].Group = ptr.To(gatewayv1.Group("gateway.networking.k8s.io"))
+			route.Spec.ParentRefs[i].Kind = ptr.To(gatewayv1.Kind("Gateway"))
+		}
+		for i := range route.Spec.Rules {
+			rule := &route.Spec.Rules[i]
+			if len(rule.Matches) == 0 {
+				rule.Matches = []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{
+					Type: ptr.To(gatewayv1.PathMatchPathPrefix), Value: ptr.To("/"),
+				}}}
+			}
+			for j := range rule.BackendRefs {
+				ref := &rule.BackendRefs[j]
+				if ref.Group == nil {
+					ref.Group = ptr.To(gatewayv1.Group(""))
+				}
+				if ref.Kind == nil {
+					ref.Kind = ptr.To(gatewayv1.Kind("Service"))
+				}
+				if ref.Weight == nil {
+					ref.Weight = ptr.To(int32(1))
+				}
+			}
+		}
+	}
+
+	updates := 0
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(app, gateway).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if route, ok := obj.(*gatewayv1.HTTPRoute); ok {
+					serverDefaults(route)
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*gatewayv1.HTTPRoute); ok {
+					updates++
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	reconciler := &RoutingReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(20),
+	}
+
+	for range 2 {
+		if err := reconciler.ReconcileRouting(context.Background(), app, "", app.Spec.Service.Port); err != nil {
+			t.Fatalf("ReconcileRouting: %v", err)
+		}
+	}
+	if updates != 0 {
+		t.Errorf("expected no update for a route that only differs by server defaults, got %d", updates)
+	}
+}
+
+func TestReconcileRouting_SkipsNoOpUpdates(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "old.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{{PathPrefix: "/app"}},
+			},
+		},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+	}
+
+	updates := 0
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(app, gateway).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*gatewayv1.HTTPRoute); ok {
+					updates++
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	reconciler := &RoutingReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(20),
+	}
+
+	if err := reconciler.ReconcileRouting(context.Background(), app, "", app.Spec.Service.Port); err != nil {
+		t.Fatalf("initial ReconcileRouting: %v", err)
+	}
+	if err := reconciler.ReconcileRouting(context.Background(), app, "", app.Spec.Service.Port); err != nil {
+		t.Fatalf("repeat ReconcileRouting: %v", err)
+	}
+	if updates != 0 {
+		t.Fatalf("expected no updates for an unchanged app, got %d", updates)
+	}
+
+	// One edit changing both the hostname and the routes, followed by the
+	// reconcile the HTTPRoute's own update event triggers
+	app.Spec.Hostname = "new.nebari.local"
+	app.Spec.Routing.Routes = []appsv1.RouteMatch{{PathPrefix: "/app"}, {PathPrefix: "/api"}}
+	for range 2 {
+		if err := reconciler.ReconcileRouting(context.Background(), app, "", app.Spec.Service.Port); err != nil {
+			t.Fatalf("ReconcileRouting: %v", err)
+		}
+	}
+	if updates != 1 {
+		t.Errorf("expected exactly 1 HTTPRoute update, got %d", updates)
+	}
+
+	route := &gatewayv1.HTTPRoute{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test-app-route", Namespace: "default"}, route); err != nil {
+		t.Fatalf("failed to get HTTPRoute: %v", err)
+	}
+	if len(route.Spec.Hostnames) != 1 || route.Spec.Hostnames[0] != "new.nebari.local" {
+		t.Errorf("expected hostname new.nebari.local, got %v", route.Spec.Hostnames)
+	}
+	paths := 0
+	for _, rule := range route.Spec.Rules {
+		paths += len(rule.Matches)
+	}
+	if paths != 2 {
+		t.Errorf("expected 2 path matches, got %d", paths)
+	}
+}
+
+func TestReconcileRouting_ServerSideApply(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Routing:  &appsv1.RoutingConfig{},
+		},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp, gateway).WithReturnManagedFields().Build()
+	recorder := record.NewFakeRecorder(20)
+	reconciler := &RoutingReconciler{
+		Client:          fakeClient,
+		Scheme:          scheme,
+		Recorder:        recorder,
+		ServerSideApply: true,
+	}
+	ctx := context.Background()
+	routeKey := types.NamespacedName{Name: "test-app-route", Namespace: "default"}
+
+	// reconcile runs ReconcileRouting and returns the route and the events it recorded.
+	reconcile := func() (*gatewayv1.HTTPRoute, []string) {
+		t.Helper()
+		if err := reconciler.ReconcileRouting(ctx, nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+			t.Fatalf("ReconcileRouting: %v", err)
+		}
+		route := &gatewayv1.HTTPRoute{}
+		if err := fakeClient.Get(ctx, routeKey, route); err != nil {
+			t.Fatalf("expected HTTPRoute: %v", err)
+		}
+		var events []string
+		for len(recorder.Events) > 0 {
+			events = append(events, <-recorder.Events)
+		}
+		return route, events
+	}
+	expectAppliedBy := func(route *gatewayv1.HTTPRoute) {
+		t.Helper()
+		for _, entry := range route.ManagedFields {
+			if entry.Manager == constants.FieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+				return
+			}
+		}
+		t.Errorf("expected field manager %s with operation Apply, got %+v", constants.FieldManager, route.ManagedFields)
+	}
+	expectEvent := func(events []string, prefix string) {
+		t.Helper()
+		for _, event := range events {
+			if strings.Contains(event, prefix) {
+				return
+			}
+		}
+		t.Errorf("expected an event containing %q, got %v", prefix, events)
+	}
+
+	route, events := reconcile()
+	expectAppliedBy(route)
+	expectEvent(events, "Created HTTPRoute test-app-route")
+	if !metav1.IsControlledBy(route, nebariApp) {
+		t.Errorf("expected the HTTPRoute to be controlled by the NebariApp, got %+v", route.OwnerReferences)
+	}
+
+	// A label another tool sets is left alone by later applies
+	route.Labels["team"] = "platform"
+	if err := fakeClient.Update(ctx, route, client.FieldOwner("kubectl-label")); err != nil {
+		t.Fatalf("failed to label HTTPRoute: %v", err)
+	}
+
+	nebariApp.Spec.Hostname = "renamed.nebari.local"
+	route, events = reconcile()
+	expectAppliedBy(route)
+	expectEvent(events, "Updated HTTPRoute test-app-route")
+	if len(route.Spec.Hostnames) != 1 || route.Spec.Hostnames[0] != "renamed.nebari.local" {
+		t.Errorf("expected hostname renamed.nebari.local, got %v", route.Spec.Hostnames)
+	}
+	if route.Labels["team"] != "platform" {
+		t.Errorf("expected the label set by another field manager to be kept, got %v", route.Labels)
+	}
+
+	_, events = reconcile()
+	for _, event := range events {
+		if strings.Contains(event, "HTTPRoute test-app-route") {
+			t.Errorf("expected no event for an unchanged HTTPRoute, got %q", event)
+		}
+	}
+}
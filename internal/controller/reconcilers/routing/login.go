@@ -0,0 +1,119 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/auth/providers"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
+)
+
+// optionalAuth reports whether the app uses auth.optional: auth is enabled,
+// optional and enforced at the gateway.
+func optionalAuth(nebariApp *appsv1.NebariApp) bool {
+	auth := nebariApp.Spec.Auth
+	if auth == nil || !auth.Enabled || !auth.Optional {
+		return false
+	}
+	return auth.EnforceAtGateway == nil || *auth.EnforceAtGateway
+}
+
+// reconcileLoginRoutes creates or updates the login HTTPRoutes of an
+// auth.optional app, one per Gateway it is exposed on, and removes them when
+// auth is no longer optional.
+//
+// The main routes of such an app only validate tokens that are present, so the
+// OIDC login, its callback and the logout path are served by these routes,
+// which the login SecurityPolicy guards. They match exactly and so take
+// precedence over the main route's prefix matches.
+func (r *RoutingReconciler) reconcileLoginRoutes(ctx context.Context, nebariApp *appsv1.NebariApp, tlsListenerName string,
+	servicePort int32) error {
+	if !optionalAuth(nebariApp) {
+		return r.cleanupHTTPRoutes(ctx, nebariApp, routeTypeLogin, nil)
+	}
+
+	gatewayNames := naming.GatewayNames(nebariApp)
+	log.FromContext(ctx).Info("Reconciling login routes", "gateways", gatewayNames)
+
+	keep := make([]string, 0, len(gatewayNames))
+	for _, gatewayName := range gatewayNames {
+		desiredRoute, err := r.buildLoginHTTPRoute(nebariApp, gatewayName, tlsListenerName, servicePort)
+		if err != nil {
+			return err
+		}
+		keep = append(keep, desiredRoute.Name)
+
+		if err := r.applyHTTPRoute(ctx, nebariApp, desiredRoute); err != nil {
+			return err
+		}
+	}
+
+	return r.cleanupHTTPRoutes(ctx, nebariApp, routeTypeLogin, keep)
+}
+
+// buildLoginHTTPRoute generates the login HTTPRoute for gatewayName. It
+// attaches like the main route. The login path redirects to the app's root,
+// which only happens once the login SecurityPolicy has signed the user in. The
+// callback and logout paths are answered by the gateway's OIDC filter and are
+// forwarded to the app's Service only to give them a backend.
+func (r *RoutingReconciler) buildLoginHTTPRoute(nebariApp *appsv1.NebariApp, gatewayName string, tlsListenerName string,
+	servicePort int32) (*gatewayv1.HTTPRoute, error) {
+	route, err := r.buildHTTPRoute(nebariApp, gatewayName, tlsListenerName, servicePort)
+	if err != nil {
+		return nil, err
+	}
+	route.Name = naming.GatewayLoginHTTPRouteName(nebariApp, gatewayName)
+	route.Labels["nebari.dev/route-type"] = routeTypeLogin
+
+	exactMatch := func(path string) gatewayv1.HTTPRouteMatch {
+		return gatewayv1.HTTPRouteMatch{Path: &gatewayv1.HTTPPathMatch{
+			Type:  ptr.To(gatewayv1.PathMatchExact),
+			Value: ptr.To(path),
+		}}
+	}
+	route.Spec.Rules = []gatewayv1.HTTPRouteRule{
+		{
+			Matches: []gatewayv1.HTTPRouteMatch{exactMatch(constants.OptionalLoginPath)},
+			Filters: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+					Path: &gatewayv1.HTTPPathModifier{
+						Type:            gatewayv1.FullPathHTTPPathModifier,
+						ReplaceFullPath: ptr.To("/"),
+					},
+					StatusCode: ptr.To(302),
+				},
+			}},
+		},
+		{
+			Matches: []gatewayv1.HTTPRouteMatch{
+				exactMatch(providers.RedirectPath(nebariApp)),
+				exactMatch(constants.DefaultLogoutPath),
+			},
+			BackendRefs: r.buildBackendRefs(nebariApp, servicePort),
+		},
+	}
+
+	return route, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+func TestReconcileRouting_OptionalLoginRoutes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Gateway:  "public",
+			Routing:  &appsv1.RoutingConfig{},
+			Auth: &appsv1.AuthConfig{
+				Enabled:  true,
+				Optional: true,
+			},
+		},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nebariApp, gateway).
+		Build()
+	reconciler := &RoutingReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(20),
+	}
+	ctx := context.Background()
+	loginKey := types.NamespacedName{Name: "test-app-login-route", Namespace: "default"}
+
+	if err := reconciler.ReconcileRouting(ctx, nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("ReconcileRouting: %v", err)
+	}
+
+	loginRoute := &gatewayv1.HTTPRoute{}
+	if err := c.Get(ctx, loginKey, loginRoute); err != nil {
+		t.Fatalf("expected login HTTPRoute: %v", err)
+	}
+	if loginRoute.Labels["nebari.dev/route-type"] != "login" {
+		t.Errorf("expected login route-type label, got %v", loginRoute.Labels)
+	}
+	if len(loginRoute.Spec.Rules) != 2 {
+		t.Fatalf("expected two login rules, got %d", len(loginRoute.Spec.Rules))
+	}
+
+	redirectRule := loginRoute.Spec.Rules[0]
+	if len(redirectRule.Matches) != 1 || *redirectRule.Matches[0].Path.Value != constants.OptionalLoginPath ||
+		*redirectRule.Matches[0].Path.Type != gatewayv1.PathMatchExact {
+		t.Errorf("expected an exact match on %s, got %+v", constants.OptionalLoginPath, redirectRule.Matches)
+	}
+	if len(redirectRule.Filters) != 1 || redirectRule.Filters[0].RequestRedirect == nil ||
+		*redirectRule.Filters[0].RequestRedirect.Path.ReplaceFullPath != "/" {
+		t.Errorf("expected a redirect to /, got %+v", redirectRule.Filters)
+	}
+
+	var paths []string
+	for _, match := range loginRoute.Spec.Rules[1].Matches {
+		if match.Path == nil || *match.Path.Type != gatewayv1.PathMatchExact {
+			t.Fatalf("expected exact path matches, got %+v", match.Path)
+		}
+		paths = append(paths, *match.Path.Value)
+	}
+	if len(paths) != 2 || paths[0] != "/oauth2/callback" || paths[1] != constants.DefaultLogoutPath {
+		t.Errorf("expected callback and logout paths, got %v", paths)
+	}
+	if len(loginRoute.Spec.Rules[1].BackendRefs) != 1 {
+		t.Errorf("expected the app's Service as backend, got %+v", loginRoute.Spec.Rules[1].BackendRefs)
+	}
+
+	// Making auth mandatory again removes the login route.
+	nebariApp.Spec.Auth.Optional = false
+	if err := reconciler.ReconcileRouting(ctx, nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("ReconcileRouting: %v", err)
+	}
+	if err := c.Get(ctx, loginKey, &gatewayv1.HTTPRoute{}); !errors.IsNotFound(err) {
+		t.Errorf("expected login HTTPRoute to be deleted, got err=%v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "test-app-route", Namespace: "default"}, &gatewayv1.HTTPRoute{}); err != nil {
+		t.Errorf("expected main HTTPRoute to remain: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"fmt"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
+)
+
+// ReconcileRateLimitPolicy creates or updates the BackendTrafficPolicy carrying
+// routing.rateLimit, attached to the app's main HTTPRoute. With byClaim the limit
+// is keyed on the header the SecurityPolicy's JWT provider copies the claim into,
+// which only exists when the gateway validates bearer JWTs for the app; otherwise
+// a Warning event is recorded and no limit is applied. Any existing policy is
+// removed when rateLimit is unset.
+func (r *RoutingReconciler) ReconcileRateLimitPolicy(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	logger := log.FromContext(ctx)
+
+	if nebariApp.Spec.Routing == nil || nebariApp.Spec.Routing.RateLimit == nil {
+		return r.CleanupRateLimitPolicy(ctx, nebariApp)
+	}
+
+	if nebariApp.Spec.Routing.RateLimit.ByClaim != "" && !gatewayValidatesJWT(nebariApp) {
+		r.Recorder.Event(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonRateLimitNotApplied,
+			"routing.rateLimit.byClaim requires auth.enabled, auth.enforceAtGateway and auth.jwt.enabled")
+		return r.CleanupRateLimitPolicy(ctx, nebariApp)
+	}
+
+	policyName := naming.RateLimitPolicyName(nebariApp)
+	policy := &egv1alpha1.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      policyName,
+			Namespace: nebariApp.Namespace,
+		},
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		if err := controllerutil.SetControllerReference(nebariApp, policy, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		policy.Spec = buildRateLimitPolicySpec(nebariApp)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or update rate limit BackendTrafficPolicy: %w", err)
+	}
+
+	logger.Info("Rate limit BackendTrafficPolicy reconciled", "name", policyName, "operation", op)
+
+	switch op {
+	case controllerutil.OperationResultCreated:
+		r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonRateLimitPolicyCreated,
+			fmt.Sprintf("Created rate limit BackendTrafficPolicy %s", policyName))
+	case controllerutil.OperationResultUpdated:
+		r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonRateLimitPolicyUpdated,
+			fmt.Sprintf("Updated rate limit BackendTrafficPolicy %s", policyName))
+	}
+
+	return nil
+}
+
+// gatewayValidatesJWT reports whether the app's SecurityPolicy carries a JWT
+// provider, which is what copies claims into request headers.
+func gatewayValidatesJWT(nebariApp *appsv1.NebariApp) bool {
+	auth := nebariApp.Spec.Auth
+	if auth == nil || !auth.Enabled || auth.JWT == nil || !auth.JWT.Enabled {
+		return false
+	}
+	return auth.EnforceAtGateway == nil || *auth.EnforceAtGateway
+}
+
+// buildRateLimitPolicySpec maps routing.rateLimit onto a local rate limit rule.
+// Without byClaim the rule has no client selector, so one bucket covers the
+// route; with byClaim a Distinct header selector gives each claim value its own.
+func buildRateLimitPolicySpec(nebariApp *appsv1.NebariApp) egv1alpha1.BackendTrafficPolicySpec {
+	rateLimit := nebariApp.Spec.Routing.RateLimit
+
+	unit := egv1alpha1.RateLimitUnit(rateLimit.Unit)
+	if unit == "" {
+		unit = egv1alpha1.RateLimitUnitMinute
+	}
+
+	rule := egv1alpha1.RateLimitRule{
+		Limit: egv1alpha1.RateLimitValue{
+			Requests: rateLimit.Requests,
+			Unit:     unit,
+		},
+	}
+	if rateLimit.ByClaim != "" {
+		rule.ClientSelectors = []egv1alpha1.RateLimitSelectCondition{{
+			Headers: []egv1alpha1.HeaderMatch{{
+				Type: ptr.To(egv1alpha1.HeaderMatchDistinct),
+				Name: naming.RateLimitClaimHeader(rateLimit.ByClaim),
+			}},
+		}}
+	}
+
+	// Limit the app's HTTPRoute on every Gateway it is exposed on
+	var targetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName
+	for _, routeName := range naming.HTTPRouteNames(nebariApp) {
+		targetRefs = append(targetRefs, gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: gatewayv1.GroupName,
+				Kind:  "HTTPRoute",
+				Name:  gatewayv1.ObjectName(routeName),
+			},
+		})
+	}
+
+	return egv1alpha1.BackendTrafficPolicySpec{
+		PolicyTargetReferences: egv1alpha1.PolicyTargetReferences{
+			TargetRefs: targetRefs,
+		},
+		RateLimit: &egv1alpha1.RateLimitSpec{
+			Local: &egv1alpha1.LocalRateLimit{
+				Rules: []egv1alpha1.RateLimitRule{rule},
+			},
+		},
+	}
+}
+
+// CleanupRateLimitPolicy deletes the rate limit BackendTrafficPolicy if it exists
+// and is controlled by this NebariApp. Missing policies and clusters without the
+// Envoy Gateway CRDs are ignored.
+func (r *RoutingReconciler) CleanupRateLimitPolicy(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+	logger := log.FromContext(ctx)
+	policyName := naming.RateLimitPolicyName(nebariApp)
+
+	policy := &egv1alpha1.BackendTrafficPolicy{}
+	if err := r.Client.Get(ctx, client.ObjectKey{
+		Name:      policyName,
+		Namespace: nebariApp.Namespace,
+	}, policy); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get rate limit BackendTrafficPolicy for cleanup: %w", err)
+	}
+
+	if !metav1.IsControlledBy(policy, nebariApp) {
+		logger.V(1).Info("BackendTrafficPolicy is not controlled by this NebariApp, leaving it alone", "name", policyName)
+		return nil
+	}
+
+	if err := client.IgnoreNotFound(r.Client.Delete(ctx, policy)); err != nil {
+		return fmt.Errorf("failed to delete rate limit BackendTrafficPolicy: %w", err)
+	}
+
+	logger.Info("Deleted rate limit BackendTrafficPolicy", "name", policyName)
+	r.Recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonRateLimitPolicyDeleted,
+		fmt.Sprintf("Deleted rate limit BackendTrafficPolicy %s", policyName))
+	return nil
+}
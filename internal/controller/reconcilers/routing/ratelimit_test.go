@@ -0,0 +1,155 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+)
+
+func TestReconcileRateLimitPolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = egv1alpha1.AddToScheme(scheme)
+
+	jwtAuth := &appsv1.AuthConfig{Enabled: true, JWT: &appsv1.JWTAuthConfig{Enabled: true}}
+
+	tests := []struct {
+		name           string
+		rateLimit      *appsv1.RateLimitConfig
+		auth           *appsv1.AuthConfig
+		expectPolicy   bool
+		expectUnit     egv1alpha1.RateLimitUnit
+		expectHeader   string // empty means a route-wide rule without selectors
+		expectNotApply bool
+	}{
+		{
+			name:         "route-wide limit defaults to per minute",
+			rateLimit:    &appsv1.RateLimitConfig{Requests: 100},
+			expectPolicy: true,
+			expectUnit:   egv1alpha1.RateLimitUnitMinute,
+		},
+		{
+			name:         "byClaim keys the limit on the claim header",
+			rateLimit:    &appsv1.RateLimitConfig{Requests: 10, Unit: "Second", ByClaim: "sub"},
+			auth:         jwtAuth,
+			expectPolicy: true,
+			expectUnit:   egv1alpha1.RateLimitUnitSecond,
+			expectHeader: "x-nebari-claim-sub",
+		},
+		{
+			name:           "byClaim without gateway JWT validation is not applied",
+			rateLimit:      &appsv1.RateLimitConfig{Requests: 10, ByClaim: "sub"},
+			auth:           &appsv1.AuthConfig{Enabled: true},
+			expectNotApply: true,
+		},
+		{
+			name: "rateLimit unset creates nothing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+				Spec: appsv1.NebariAppSpec{
+					Hostname: "test.nebari.local",
+					Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+					Routing:  &appsv1.RoutingConfig{RateLimit: tt.rateLimit},
+					Auth:     tt.auth,
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp).Build()
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &RoutingReconciler{Client: fakeClient, Scheme: scheme, Recorder: recorder}
+
+			if err := reconciler.ReconcileRateLimitPolicy(context.Background(), nebariApp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			policy := &egv1alpha1.BackendTrafficPolicy{}
+			err := fakeClient.Get(context.Background(), client.ObjectKey{
+				Name:      naming.RateLimitPolicyName(nebariApp),
+				Namespace: nebariApp.Namespace,
+			}, policy)
+
+			notApplied := false
+			close(recorder.Events)
+			for event := range recorder.Events {
+				if strings.Contains(event, appsv1.EventReasonRateLimitNotApplied) {
+					notApplied = true
+				}
+			}
+			if notApplied != tt.expectNotApply {
+				t.Errorf("expected %s event=%v, got %v", appsv1.EventReasonRateLimitNotApplied, tt.expectNotApply, notApplied)
+			}
+
+			if !tt.expectPolicy {
+				if !errors.IsNotFound(err) {
+					t.Errorf("expected no BackendTrafficPolicy, got err=%v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected BackendTrafficPolicy to be created: %v", err)
+			}
+
+			if !metav1.IsControlledBy(policy, nebariApp) {
+				t.Error("expected BackendTrafficPolicy to be controlled by the NebariApp")
+			}
+			if len(policy.Spec.TargetRefs) != 1 || string(policy.Spec.TargetRefs[0].Name) != naming.HTTPRouteName(nebariApp) {
+				t.Errorf("expected targetRef to HTTPRoute %s, got %+v", naming.HTTPRouteName(nebariApp), policy.Spec.TargetRefs)
+			}
+			if policy.Spec.RateLimit == nil || policy.Spec.RateLimit.Local == nil || len(policy.Spec.RateLimit.Local.Rules) != 1 {
+				t.Fatalf("expected one local rate limit rule, got %+v", policy.Spec.RateLimit)
+			}
+			rule := policy.Spec.RateLimit.Local.Rules[0]
+			if rule.Limit.Requests != tt.rateLimit.Requests || rule.Limit.Unit != tt.expectUnit {
+				t.Errorf("expected limit %d/%s, got %d/%s", tt.rateLimit.Requests, tt.expectUnit, rule.Limit.Requests, rule.Limit.Unit)
+			}
+
+			if tt.expectHeader == "" {
+				if len(rule.ClientSelectors) != 0 {
+					t.Errorf("expected no client selectors, got %+v", rule.ClientSelectors)
+				}
+				return
+			}
+			if len(rule.ClientSelectors) != 1 || len(rule.ClientSelectors[0].Headers) != 1 {
+				t.Fatalf("expected one header selector, got %+v", rule.ClientSelectors)
+			}
+			header := rule.ClientSelectors[0].Headers[0]
+			if header.Name != tt.expectHeader {
+				t.Errorf("expected selector on header %q, got %q", tt.expectHeader, header.Name)
+			}
+			if header.Type == nil || *header.Type != egv1alpha1.HeaderMatchDistinct {
+				t.Errorf("expected Distinct header match, got %v", header.Type)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+)
+
+// stepUpPaths returns auth.stepUpPaths when step-up authentication applies:
+// auth is enabled and enforced at the gateway, and both stepUpScopes and
+// stepUpPaths are set. It returns nil otherwise.
+func stepUpPaths(nebariApp *appsv1.NebariApp) []string {
+	auth := nebariApp.Spec.Auth
+	if auth == nil || !auth.Enabled || len(auth.StepUpScopes) == 0 || len(auth.StepUpPaths) == 0 {
+		return nil
+	}
+	if auth.EnforceAtGateway != nil && !*auth.EnforceAtGateway {
+		return nil
+	}
+	return auth.StepUpPaths
+}
+
+// reconcileStepUpRoutes creates or updates the step-up HTTPRoutes for a
+// NebariApp, one per Gateway it is exposed on, and removes them when step-up
+// authentication no longer applies.
+//
+// The step-up routes carry auth.stepUpPaths as prefix matches. Gateway API
+// prefers the longest matching prefix across routes for the same hostname, so
+// these paths leave the main route and are guarded by the step-up
+// SecurityPolicy, which requests the additional scopes.
+func (r *RoutingReconciler) reconcileStepUpRoutes(ctx context.Context, nebariApp *appsv1.NebariApp, tlsListenerName string,
+	servicePort int32) error {
+	paths := stepUpPaths(nebariApp)
+	if len(paths) == 0 {
+		return r.cleanupHTTPRoutes(ctx, nebariApp, routeTypeStepUp, nil)
+	}
+
+	gatewayNames := naming.GatewayNames(nebariApp)
+	log.FromContext(ctx).Info("Reconciling step-up routes", "gateways", gatewayNames, "stepUpPaths", paths)
+
+	keep := make([]string, 0, len(gatewayNames))
+	for _, gatewayName := range gatewayNames {
+		desiredRoute, err := r.buildStepUpHTTPRoute(nebariApp, gatewayName, tlsListenerName, servicePort)
+		if err != nil {
+			return err
+		}
+		keep = append(keep, desiredRoute.Name)
+
+		if err := r.applyHTTPRoute(ctx, nebariApp, desiredRoute); err != nil {
+			return err
+		}
+	}
+
+	return r.cleanupHTTPRoutes(ctx, nebariApp, routeTypeStepUp, keep)
+}
+
+// buildStepUpHTTPRoute generates the step-up HTTPRoute for gatewayName. It
+// attaches like the main route and forwards auth.stepUpPaths where the main
+// route would have sent them (see stepUpRoutes), with the same authenticated
+// request headers.
+func (r *RoutingReconciler) buildStepUpHTTPRoute(nebariApp *appsv1.NebariApp, gatewayName string, tlsListenerName string,
+	servicePort int32) (*gatewayv1.HTTPRoute, error) {
+	route, err := r.buildHTTPRoute(nebariApp, gatewayName, tlsListenerName, servicePort)
+	if err != nil {
+		return nil, err
+	}
+	route.Name = naming.GatewayStepUpHTTPRouteName(nebariApp, gatewayName)
+	route.Labels["nebari.dev/route-type"] = routeTypeStepUp
+
+	rules := r.buildRules(nebariApp, gatewayName, stepUpRoutes(nebariApp), gatewayv1.PathMatchPathPrefix, servicePort)
+	if filter := buildAuthenticatedHeaderFilter(nebariApp); filter != nil {
+		for i := range rules {
+			if len(rules[i].BackendRefs) > 0 {
+				rules[i].Filters = append(rules[i].Filters, *filter)
+			}
+		}
+	}
+	route.Spec.Rules = rules
+
+	return route, nil
+}
+
+// stepUpRoutes returns the routing entries for auth.stepUpPaths. A step-up path
+// below a routing.routes prefix takes the targets of the routes with the longest
+// such prefix, headers and method included, so its requests reach the same
+// backends as they would on the main route. A path without a plain route
+// covering it also gets a match for the app's Service.
+func stepUpRoutes(nebariApp *appsv1.NebariApp) []appsv1.RouteMatch {
+	var routes []appsv1.RouteMatch
+	if routing := nebariApp.Spec.Routing; routing != nil {
+		routes = routing.Routes
+	}
+
+	var stepUp []appsv1.RouteMatch
+	for _, path := range stepUpPaths(nebariApp) {
+		longest := -1
+		for _, route := range routes {
+			if route.PathType != "Exact" && pathUnder(path, route.PathPrefix) {
+				longest = max(longest, len(strings.TrimRight(route.PathPrefix, "/")))
+			}
+		}
+
+		covered := false
+		for _, route := range routes {
+			if route.PathType == "Exact" || !pathUnder(path, route.PathPrefix) ||
+				len(strings.TrimRight(route.PathPrefix, "/")) != longest {
+				continue
+			}
+			route.PathPrefix, route.PathType = path, "PathPrefix"
+			stepUp = append(stepUp, route)
+			if len(route.Headers) == 0 && route.Method == "" {
+				covered = true
+			}
+		}
+		if !covered {
+			stepUp = append(stepUp, appsv1.RouteMatch{PathPrefix: path})
+		}
+	}
+	return stepUp
+}
+
+// pathUnder reports whether path is prefix or below it, comparing whole path
+// segments as a Gateway API PathPrefix match does. An empty prefix is "/".
+func pathUnder(path, prefix string) bool {
+	path, prefix = strings.TrimRight(path, "/"), strings.TrimRight(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
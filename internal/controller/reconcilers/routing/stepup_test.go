@@ -0,0 +1,164 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+func TestReconcileRouting_StepUpRoutes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Gateway:  "public",
+			Routing:  &appsv1.RoutingConfig{},
+			Auth: &appsv1.AuthConfig{
+				Enabled:      true,
+				StepUpScopes: []string{"admin"},
+				StepUpPaths:  []string{"/admin", "/api/billing"},
+			},
+		},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.PublicGatewayName, Namespace: constants.GatewayNamespace},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nebariApp, gateway).
+		Build()
+	reconciler := &RoutingReconciler{
+		Client:   c,
+		Scheme:   scheme,
+		Recorder: record.NewFakeRecorder(20),
+	}
+	ctx := context.Background()
+	stepUpKey := types.NamespacedName{Name: "test-app-stepup-route", Namespace: "default"}
+
+	if err := reconciler.ReconcileRouting(ctx, nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("ReconcileRouting: %v", err)
+	}
+
+	stepUpRoute := &gatewayv1.HTTPRoute{}
+	if err := c.Get(ctx, stepUpKey, stepUpRoute); err != nil {
+		t.Fatalf("expected step-up HTTPRoute: %v", err)
+	}
+	if stepUpRoute.Labels["nebari.dev/route-type"] != "step-up" {
+		t.Errorf("expected step-up route-type label, got %v", stepUpRoute.Labels)
+	}
+	if len(stepUpRoute.Spec.Rules) != 1 {
+		t.Fatalf("expected a single step-up rule, got %d", len(stepUpRoute.Spec.Rules))
+	}
+	var paths []string
+	for _, match := range stepUpRoute.Spec.Rules[0].Matches {
+		if match.Path == nil || *match.Path.Type != gatewayv1.PathMatchPathPrefix {
+			t.Fatalf("expected prefix path matches, got %+v", match.Path)
+		}
+		paths = append(paths, *match.Path.Value)
+	}
+	if len(paths) != 2 || paths[0] != "/admin" || paths[1] != "/api/billing" {
+		t.Errorf("expected step-up paths [/admin /api/billing], got %v", paths)
+	}
+
+	// The main route is left as it was.
+	mainRoute := &gatewayv1.HTTPRoute{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "test-app-route", Namespace: "default"}, mainRoute); err != nil {
+		t.Fatalf("expected main HTTPRoute: %v", err)
+	}
+	if _, ok := mainRoute.Labels["nebari.dev/route-type"]; ok {
+		t.Errorf("expected main route to carry no route-type label, got %v", mainRoute.Labels)
+	}
+
+	// Dropping the step-up scopes removes the step-up route.
+	nebariApp.Spec.Auth.StepUpScopes = nil
+	if err := reconciler.ReconcileRouting(ctx, nebariApp, "", nebariApp.Spec.Service.Port); err != nil {
+		t.Fatalf("ReconcileRouting: %v", err)
+	}
+	if err := c.Get(ctx, stepUpKey, &gatewayv1.HTTPRoute{}); !errors.IsNotFound(err) {
+		t.Errorf("expected step-up HTTPRoute to be deleted, got err=%v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "test-app-route", Namespace: "default"}, mainRoute); err != nil {
+		t.Errorf("expected main HTTPRoute to remain: %v", err)
+	}
+}
+
+func TestBuildStepUpHTTPRoute_UsesRouteBackends(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default", UID: "test-uid"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "test.nebari.local",
+			Service:  appsv1.ServiceReference{Name: "test-service", Port: 8080},
+			Routing: &appsv1.RoutingConfig{
+				Routes: []appsv1.RouteMatch{
+					{PathPrefix: "/", Backends: []appsv1.WeightedBackend{{Name: "frontend", Port: 3000}}},
+					{PathPrefix: "/api", Backends: []appsv1.WeightedBackend{{Name: "api", Port: 9000}}},
+				},
+			},
+			Auth: &appsv1.AuthConfig{
+				Enabled:      true,
+				StepUpScopes: []string{"admin"},
+				StepUpPaths:  []string{"/api/billing", "/admin"},
+			},
+		},
+	}
+	reconciler := &RoutingReconciler{Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+
+	route, err := reconciler.buildStepUpHTTPRoute(nebariApp, constants.PublicGatewayName, "", nebariApp.Spec.Service.Port)
+	if err != nil {
+		t.Fatalf("buildStepUpHTTPRoute: %v", err)
+	}
+
+	// Each step-up path goes to the backend of the longest route prefix above it
+	backends := map[string]string{}
+	for _, rule := range route.Spec.Rules {
+		for _, match := range rule.Matches {
+			if len(rule.BackendRefs) != 1 {
+				t.Fatalf("expected one backend for %s, got %d", *match.Path.Value, len(rule.BackendRefs))
+			}
+			backends[*match.Path.Value] = string(rule.BackendRefs[0].Name)
+		}
+	}
+	expected := map[string]string{"/api/billing": "api", "/admin": "frontend"}
+	if !reflect.DeepEqual(backends, expected) {
+		t.Errorf("expected step-up backends %v, got %v", expected, backends)
+	}
+}
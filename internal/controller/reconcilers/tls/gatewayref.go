@@ -0,0 +1,132 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tls
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/conditions"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// reconcileGatewayRefTLS handles the path where routing.tls.gatewayRef points
+// the app at an existing HTTPS listener. The operator owns no TLS resources on
+// this path, so any per-app listener and owned Certificate left from an earlier
+// spec are removed. The referenced listener must exist on one of the app's
+// Gateways and be HTTPS; TLSReady then follows its Programmed condition.
+func (r *TLSReconciler) reconcileGatewayRefTLS(ctx context.Context, nebariApp *appsv1.NebariApp, ref *appsv1.GatewayListenerRef) (*TLSResult, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Using referenced Gateway listener for TLS",
+		"gateway", ref.Name,
+		"listener", ref.SectionName,
+		"hostname", nebariApp.Spec.Hostname)
+
+	if err := r.cleanupOwnedCertificate(ctx, nebariApp); err != nil {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeTLSReady, metav1.ConditionFalse,
+			"CertificateCleanupFailed", fmt.Sprintf("Failed to clean up owned Certificate: %v", err))
+		return nil, err
+	}
+	for _, gatewayName := range naming.AllGatewayNames() {
+		if err := r.removeGatewayListener(ctx, nebariApp, gatewayName); err != nil {
+			conditions.SetCondition(nebariApp, appsv1.ConditionTypeTLSReady, metav1.ConditionFalse,
+				"GatewayListenerFailed", fmt.Sprintf("Failed to remove per-app Gateway listener: %v", err))
+			return nil, err
+		}
+	}
+
+	listener, status, err := r.findGatewayListener(ctx, nebariApp, ref)
+	if err != nil {
+		reason := appsv1.ReasonGatewayListenerNotFound
+		if listener != nil {
+			reason = appsv1.ReasonGatewayListenerNotHTTPS
+		}
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeTLSReady, metav1.ConditionFalse, reason, err.Error())
+		return nil, err
+	}
+
+	ready := status != nil && apimeta.IsStatusConditionTrue(status.Conditions, string(gatewayv1.ListenerConditionProgrammed))
+	if ready {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeTLSReady, metav1.ConditionTrue,
+			appsv1.ReasonGatewayListenerReady,
+			fmt.Sprintf("Using HTTPS listener %s on Gateway %s", ref.SectionName, ref.Name))
+	} else {
+		conditions.SetCondition(nebariApp, appsv1.ConditionTypeTLSReady, metav1.ConditionFalse,
+			appsv1.ReasonGatewayListenerNotReady,
+			fmt.Sprintf("Waiting for listener %s on Gateway %s to be programmed", ref.SectionName, ref.Name))
+	}
+
+	return &TLSResult{
+		ListenerName: ref.SectionName,
+		CertReady:    ready,
+	}, nil
+}
+
+// findGatewayListener returns the listener routing.tls.gatewayRef names and its
+// status, if the Gateway reports one. It returns an error when the Gateway is
+// not one the app is exposed on, when the Gateway or listener does not exist,
+// or when the listener is not HTTPS; the listener is non-nil only in the last
+// case.
+func (r *TLSReconciler) findGatewayListener(ctx context.Context, nebariApp *appsv1.NebariApp, ref *appsv1.GatewayListenerRef) (*gatewayv1.Listener, *gatewayv1.ListenerStatus, error) {
+	if !slices.Contains(naming.GatewayNames(nebariApp), ref.Name) {
+		return nil, nil, fmt.Errorf("routing.tls.gatewayRef names Gateway %s, which the app is not exposed on (%v)",
+			ref.Name, naming.GatewayNames(nebariApp))
+	}
+
+	gateway := &gatewayv1.Gateway{}
+	if err := r.Client.Get(ctx, types.NamespacedName{
+		Name:      ref.Name,
+		Namespace: constants.GatewayNamespace,
+	}, gateway); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("gateway %s not found in namespace %s", ref.Name, constants.GatewayNamespace)
+		}
+		return nil, nil, fmt.Errorf("failed to get Gateway: %w", err)
+	}
+
+	var listener *gatewayv1.Listener
+	for i := range gateway.Spec.Listeners {
+		if string(gateway.Spec.Listeners[i].Name) == ref.SectionName {
+			listener = &gateway.Spec.Listeners[i]
+			break
+		}
+	}
+	if listener == nil {
+		return nil, nil, fmt.Errorf("listener %s not found on Gateway %s/%s",
+			ref.SectionName, constants.GatewayNamespace, ref.Name)
+	}
+	if listener.Protocol != gatewayv1.HTTPSProtocolType {
+		return listener, nil, fmt.Errorf("listener %s on Gateway %s/%s has protocol %s, expected HTTPS",
+			ref.SectionName, constants.GatewayNamespace, ref.Name, listener.Protocol)
+	}
+
+	for i := range gateway.Status.Listeners {
+		if string(gateway.Status.Listeners[i].Name) == ref.SectionName {
+			return listener, &gateway.Status.Listeners[i], nil
+		}
+	}
+	return listener, nil, nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tls
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/conditions"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/naming"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestReconcileTLS_GatewayRef(t *testing.T) {
+	scheme := newScheme()
+
+	newApp := func(ref appsv1.GatewayListenerRef) *appsv1.NebariApp {
+		return &appsv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+			Spec: appsv1.NebariAppSpec{
+				Hostname: "test.example.com",
+				Service:  appsv1.ServiceReference{Name: "test-svc", Port: 8080},
+				Routing: &appsv1.RoutingConfig{
+					TLS: &appsv1.RoutingTLSConfig{GatewayRef: &ref},
+				},
+			},
+		}
+	}
+	httpsListener := gatewayv1.Listener{Name: "wildcard-https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType}
+	httpListener := gatewayv1.Listener{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}
+	programmed := func(gateway *gatewayv1.Gateway, status metav1.ConditionStatus) *gatewayv1.Gateway {
+		gateway.Status.Listeners = []gatewayv1.ListenerStatus{{
+			Name: httpsListener.Name,
+			Conditions: []metav1.Condition{{
+				Type:               string(gatewayv1.ListenerConditionProgrammed),
+				Status:             status,
+				Reason:             "Programmed",
+				LastTransitionTime: metav1.Now(),
+			}},
+		}}
+		return gateway
+	}
+
+	tests := []struct {
+		name           string
+		ref            appsv1.GatewayListenerRef
+		gateway        *gatewayv1.Gateway
+		expectError    bool
+		expectReady    bool
+		expectedReason string
+	}{
+		{
+			name:           "programmed HTTPS listener",
+			ref:            appsv1.GatewayListenerRef{Name: constants.PublicGatewayName, SectionName: "wildcard-https"},
+			gateway:        programmed(newGateway(constants.PublicGatewayName, httpsListener), metav1.ConditionTrue),
+			expectReady:    true,
+			expectedReason: appsv1.ReasonGatewayListenerReady,
+		},
+		{
+			name:           "listener not programmed yet",
+			ref:            appsv1.GatewayListenerRef{Name: constants.PublicGatewayName, SectionName: "wildcard-https"},
+			gateway:        programmed(newGateway(constants.PublicGatewayName, httpsListener), metav1.ConditionFalse),
+			expectedReason: appsv1.ReasonGatewayListenerNotReady,
+		},
+		{
+			name:           "listener not on the Gateway",
+			ref:            appsv1.GatewayListenerRef{Name: constants.PublicGatewayName, SectionName: "missing"},
+			gateway:        newGateway(constants.PublicGatewayName, httpsListener),
+			expectError:    true,
+			expectedReason: appsv1.ReasonGatewayListenerNotFound,
+		},
+		{
+			name:           "Gateway the app is not exposed on",
+			ref:            appsv1.GatewayListenerRef{Name: constants.InternalGatewayName, SectionName: "wildcard-https"},
+			gateway:        newGateway(constants.InternalGatewayName, httpsListener),
+			expectError:    true,
+			expectedReason: appsv1.ReasonGatewayListenerNotFound,
+		},
+		{
+			name:           "listener is not HTTPS",
+			ref:            appsv1.GatewayListenerRef{Name: constants.PublicGatewayName, SectionName: "http"},
+			gateway:        newGateway(constants.PublicGatewayName, httpListener),
+			expectError:    true,
+			expectedReason: appsv1.ReasonGatewayListenerNotHTTPS,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := newApp(tt.ref)
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(nebariApp, tt.gateway).Build()
+			r := &TLSReconciler{
+				Client:            c,
+				Scheme:            scheme,
+				Recorder:          record.NewFakeRecorder(10),
+				ClusterIssuerName: "letsencrypt-prod",
+			}
+
+			result, err := r.ReconcileTLS(context.Background(), nebariApp)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("ReconcileTLS: %v", err)
+				}
+				if result.ListenerName != tt.ref.SectionName {
+					t.Errorf("expected listener %q, got %q", tt.ref.SectionName, result.ListenerName)
+				}
+				if result.CertReady != tt.expectReady {
+					t.Errorf("expected CertReady=%t, got %t", tt.expectReady, result.CertReady)
+				}
+			}
+
+			cond := conditions.GetCondition(nebariApp, appsv1.ConditionTypeTLSReady)
+			if cond == nil {
+				t.Fatal("expected TLSReady condition to be set")
+			}
+			if cond.Reason != tt.expectedReason {
+				t.Errorf("expected reason %s, got %s (%s)", tt.expectedReason, cond.Reason, cond.Message)
+			}
+			if (cond.Status == metav1.ConditionTrue) != tt.expectReady {
+				t.Errorf("expected TLSReady ready=%t, got %s", tt.expectReady, cond.Status)
+			}
+
+			// The operator adds no per-app listener on this path.
+			gateway := &gatewayv1.Gateway{}
+			if err := c.Get(context.Background(), types.NamespacedName{
+				Name: tt.gateway.Name, Namespace: constants.GatewayNamespace,
+			}, gateway); err != nil {
+				t.Fatalf("get Gateway: %v", err)
+			}
+			for _, l := range gateway.Spec.Listeners {
+				if string(l.Name) == naming.ListenerName(nebariApp) {
+					t.Errorf("expected no per-app listener, found %s", l.Name)
+				}
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package tls
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -45,6 +46,14 @@ type TLSReconciler struct {
 	Scheme            *runtime.Scheme
 	Recorder          record.EventRecorder
 	ClusterIssuerName string
+
+	// TLSDisabledByDefault treats NebariApps that leave routing.tls.enabled
+	// unset as TLS-disabled instead of TLS-enabled.
+	TLSDisabledByDefault bool
+
+	// ManagedBy is the app.kubernetes.io/managed-by value on the resources it
+	// creates and selects on. Empty means constants.DefaultManagedBy.
+	ManagedBy string
 }
 
 // TLSResult contains the outcome of a TLS reconciliation.
@@ -65,23 +74,25 @@ type TLSResult struct {
 }
 
 // isTLSEnabled returns true if TLS is enabled for the NebariApp.
-// TLS defaults to enabled unless explicitly set to false.
+// When routing.tls.enabled is unset, defaultEnabled applies.
 // When routing is nil (externally managed routing), TLS is considered disabled
 // since the operator won't create HTTPRoutes that would use the certificate.
-func isTLSEnabled(nebariApp *appsv1.NebariApp) bool {
+func isTLSEnabled(nebariApp *appsv1.NebariApp, defaultEnabled bool) bool {
 	if nebariApp.Spec.Routing == nil {
 		return false
 	}
 	if nebariApp.Spec.Routing.TLS == nil {
-		return true
+		return defaultEnabled
 	}
 	if nebariApp.Spec.Routing.TLS.Enabled == nil {
-		return true
+		return defaultEnabled
 	}
 	return *nebariApp.Spec.Routing.TLS.Enabled
 }
 
 // ReconcileTLS handles TLS configuration for a NebariApp.
+// When routing.tls.gatewayRef is set, the app uses the referenced listener and
+// the operator manages no Certificate or listener for it.
 // When routing.tls.secretName is set, the user-provided secret path is taken:
 // any owned cert-manager Certificate is cleaned up, the Gateway listener is
 // pointed at the named secret, and TLSReady reflects the secret's validity.
@@ -89,7 +100,7 @@ func isTLSEnabled(nebariApp *appsv1.NebariApp) bool {
 func (r *TLSReconciler) ReconcileTLS(ctx context.Context, nebariApp *appsv1.NebariApp) (*TLSResult, error) {
 	logger := log.FromContext(ctx)
 
-	if !isTLSEnabled(nebariApp) {
+	if !isTLSEnabled(nebariApp, !r.TLSDisabledByDefault) {
 		logger.Info("TLS not enabled, skipping TLS reconciliation")
 		conditions.SetCondition(nebariApp, appsv1.ConditionTypeTLSReady, metav1.ConditionFalse,
 			"TLSDisabled", "TLS is not enabled for this app")
@@ -98,6 +109,9 @@ func (r *TLSReconciler) ReconcileTLS(ctx context.Context, nebariApp *appsv1.Neba
 
 	userSecret := ""
 	if nebariApp.Spec.Routing != nil && nebariApp.Spec.Routing.TLS != nil {
+		if ref := nebariApp.Spec.Routing.TLS.GatewayRef; ref != nil {
+			return r.reconcileGatewayRefTLS(ctx, nebariApp, ref)
+		}
 		userSecret = nebariApp.Spec.Routing.TLS.SecretName
 	}
 
@@ -122,7 +136,7 @@ func (r *TLSReconciler) ReconcileTLS(ctx context.Context, nebariApp *appsv1.Neba
 	logger.Info("Reconciling TLS",
 		"hostname", nebariApp.Spec.Hostname,
 		"clusterIssuer", r.ClusterIssuerName,
-		"gateway", naming.GatewayName(nebariApp))
+		"gateways", naming.GatewayNames(nebariApp))
 
 	if err := r.reconcileCertificate(ctx, nebariApp); err != nil {
 		conditions.SetCondition(nebariApp, appsv1.ConditionTypeTLSReady, metav1.ConditionFalse,
@@ -229,7 +243,7 @@ func (r *TLSReconciler) reconcileUserProvidedTLS(ctx context.Context, nebariApp
 }
 
 // CleanupTLS removes TLS resources for a NebariApp.
-// It removes the per-app listener from the Gateway and deletes the owned
+// It removes the per-app listener from every shared Gateway and deletes the owned
 // Certificate (if any). Both operations are attempted even if one fails, to
 // minimize orphaned resources. Certificate deletion goes through
 // cleanupOwnedCertificate, which only removes Certificates whose ownership
@@ -239,9 +253,11 @@ func (r *TLSReconciler) CleanupTLS(ctx context.Context, nebariApp *appsv1.Nebari
 	logger := log.FromContext(ctx)
 	var errs []error
 
-	if err := r.removeGatewayListener(ctx, nebariApp); err != nil {
-		logger.Error(err, "Failed to remove Gateway listener during cleanup")
-		errs = append(errs, err)
+	for _, gatewayName := range naming.AllGatewayNames() {
+		if err := r.removeGatewayListener(ctx, nebariApp, gatewayName); err != nil {
+			logger.Error(err, "Failed to remove Gateway listener during cleanup", "gateway", gatewayName)
+			errs = append(errs, err)
+		}
 	}
 
 	if err := r.cleanupOwnedCertificate(ctx, nebariApp); err != nil {
@@ -274,8 +290,8 @@ func (r *TLSReconciler) reconcileCertificate(ctx context.Context, nebariApp *app
 		if cert.Labels == nil {
 			cert.Labels = make(map[string]string)
 		}
-		cert.Labels["app.kubernetes.io/managed-by"] = "nebari-operator"
-		cert.Labels["nebari.dev/nebariapp-name"] = nebariApp.Name
+		cert.Labels["app.kubernetes.io/managed-by"] = naming.ManagedBy(r.ManagedBy)
+		cert.Labels["nebari.dev/nebariapp-name"] = naming.LabelValue(nebariApp.Name)
 		cert.Labels["nebari.dev/nebariapp-namespace"] = nebariApp.Namespace
 
 		cert.Spec = certmanagerv1.CertificateSpec{
@@ -308,13 +324,33 @@ func (r *TLSReconciler) reconcileCertificate(ctx context.Context, nebariApp *app
 	return nil
 }
 
-// reconcileGatewayListener adds or updates a per-app HTTPS listener on the shared Gateway.
+// reconcileGatewayListener adds or updates the per-app HTTPS listener on every shared
+// Gateway the app is exposed on, and removes it from Gateways the app no longer selects.
+func (r *TLSReconciler) reconcileGatewayListener(ctx context.Context, nebariApp *appsv1.NebariApp, secretName string) error {
+	gatewayNames := naming.GatewayNames(nebariApp)
+	for _, gatewayName := range gatewayNames {
+		if err := r.applyGatewayListener(ctx, nebariApp, gatewayName, secretName); err != nil {
+			return err
+		}
+	}
+
+	for _, gatewayName := range naming.AllGatewayNames() {
+		if slices.Contains(gatewayNames, gatewayName) {
+			continue
+		}
+		if err := r.removeGatewayListener(ctx, nebariApp, gatewayName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyGatewayListener adds or updates a per-app HTTPS listener on a shared Gateway.
 // It uses a Get→upsert-in-slice→Update pattern so that concurrent reconcilers operating
 // on the same Gateway each own exactly one named listener without rewriting the whole spec.
-func (r *TLSReconciler) reconcileGatewayListener(ctx context.Context, nebariApp *appsv1.NebariApp, secretName string) error {
+func (r *TLSReconciler) applyGatewayListener(ctx context.Context, nebariApp *appsv1.NebariApp, gatewayName, secretName string) error {
 	logger := log.FromContext(ctx)
 
-	gatewayName := naming.GatewayName(nebariApp)
 	listenerName := naming.ListenerName(nebariApp)
 	hostname := gatewayv1.Hostname(nebariApp.Spec.Hostname)
 	tlsMode := gatewayv1.TLSModeTerminate
@@ -460,11 +496,10 @@ func (r *TLSReconciler) isCertificateReady(ctx context.Context, nebariApp *appsv
 	return false, nil
 }
 
-// removeGatewayListener removes the per-app listener from the Gateway.
-func (r *TLSReconciler) removeGatewayListener(ctx context.Context, nebariApp *appsv1.NebariApp) error {
+// removeGatewayListener removes the per-app listener from the named Gateway.
+func (r *TLSReconciler) removeGatewayListener(ctx context.Context, nebariApp *appsv1.NebariApp, gatewayName string) error {
 	logger := log.FromContext(ctx)
 
-	gatewayName := naming.GatewayName(nebariApp)
 	listenerName := naming.ListenerName(nebariApp)
 
 	// Get the Gateway
@@ -533,7 +568,7 @@ func (r *TLSReconciler) cleanupOwnedCertificate(ctx context.Context, nebariApp *
 		return fmt.Errorf("failed to get Certificate for cleanup check: %w", err)
 	}
 
-	if cert.Labels["nebari.dev/nebariapp-name"] != nebariApp.Name ||
+	if cert.Labels["nebari.dev/nebariapp-name"] != naming.LabelValue(nebariApp.Name) ||
 		cert.Labels["nebari.dev/nebariapp-namespace"] != nebariApp.Namespace {
 		logger.V(1).Info("Certificate exists with mismatched ownership labels, leaving it alone",
 			"name", certName, "namespace", constants.GatewayNamespace)
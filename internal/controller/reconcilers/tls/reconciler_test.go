@@ -1471,3 +1471,123 @@ func TestIsCertificateReady(t *testing.T) {
 		})
 	}
 }
+
+func TestIsTLSEnabled(t *testing.T) {
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name           string
+		routing        *appsv1.RoutingConfig
+		defaultEnabled bool
+		expected       bool
+	}{
+		{name: "No routing is always disabled", routing: nil, defaultEnabled: true, expected: false},
+		{name: "Omitted TLS follows enabled default", routing: &appsv1.RoutingConfig{}, defaultEnabled: true, expected: true},
+		{name: "Omitted TLS follows disabled default", routing: &appsv1.RoutingConfig{}, defaultEnabled: false, expected: false},
+		{
+			name:           "Unset enabled follows disabled default",
+			routing:        &appsv1.RoutingConfig{TLS: &appsv1.RoutingTLSConfig{SecretName: "my-tls"}},
+			defaultEnabled: false,
+			expected:       false,
+		},
+		{
+			name:           "Explicit enabled wins over disabled default",
+			routing:        &appsv1.RoutingConfig{TLS: &appsv1.RoutingTLSConfig{Enabled: &enabled}},
+			defaultEnabled: false,
+			expected:       true,
+		},
+		{
+			name:           "Explicit disabled wins over enabled default",
+			routing:        &appsv1.RoutingConfig{TLS: &appsv1.RoutingTLSConfig{Enabled: &disabled}},
+			defaultEnabled: true,
+			expected:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{Spec: appsv1.NebariAppSpec{Routing: tt.routing}}
+			if got := isTLSEnabled(nebariApp, tt.defaultEnabled); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestReconcileTLS_MultipleGateways(t *testing.T) {
+	scheme := newScheme()
+
+	app := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "both", Namespace: "default"},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: "both.example.com",
+			Service:  appsv1.ServiceReference{Name: "svc", Port: 8080},
+			Gateways: []string{"public", "internal"},
+			Routing: &appsv1.RoutingConfig{
+				TLS: &appsv1.RoutingTLSConfig{Enabled: boolPtr(true)},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(app, newGateway(constants.PublicGatewayName), newGateway(constants.InternalGatewayName)).
+		Build()
+	reconciler := &TLSReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		Recorder:          record.NewFakeRecorder(20),
+		ClusterIssuerName: "letsencrypt-prod",
+	}
+
+	hasListener := func(gatewayName string) bool {
+		t.Helper()
+		gw := &gatewayv1.Gateway{}
+		if err := fakeClient.Get(context.Background(), types.NamespacedName{
+			Name: gatewayName, Namespace: constants.GatewayNamespace,
+		}, gw); err != nil {
+			t.Fatalf("get Gateway %s: %v", gatewayName, err)
+		}
+		for _, l := range gw.Spec.Listeners {
+			if string(l.Name) == naming.ListenerName(app) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if _, err := reconciler.ReconcileTLS(context.Background(), app); err != nil {
+		t.Fatalf("ReconcileTLS: %v", err)
+	}
+	for _, gatewayName := range []string{constants.PublicGatewayName, constants.InternalGatewayName} {
+		if !hasListener(gatewayName) {
+			t.Errorf("expected listener on Gateway %s", gatewayName)
+		}
+	}
+
+	// Dropping the internal gateway removes its listener.
+	app.Spec.Gateways = []string{"public"}
+	if _, err := reconciler.ReconcileTLS(context.Background(), app); err != nil {
+		t.Fatalf("ReconcileTLS after dropping internal: %v", err)
+	}
+	if !hasListener(constants.PublicGatewayName) {
+		t.Error("expected listener to remain on the public Gateway")
+	}
+	if hasListener(constants.InternalGatewayName) {
+		t.Error("expected listener to be removed from the internal Gateway")
+	}
+
+	// Cleanup removes listeners from every Gateway.
+	app.Spec.Gateways = []string{"public", "internal"}
+	if _, err := reconciler.ReconcileTLS(context.Background(), app); err != nil {
+		t.Fatalf("ReconcileTLS: %v", err)
+	}
+	if err := reconciler.CleanupTLS(context.Background(), app); err != nil {
+		t.Fatalf("CleanupTLS: %v", err)
+	}
+	for _, gatewayName := range []string{constants.PublicGatewayName, constants.InternalGatewayName} {
+		if hasListener(gatewayName) {
+			t.Errorf("expected listener on Gateway %s to be removed by cleanup", gatewayName)
+		}
+	}
+}
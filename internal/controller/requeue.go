@@ -0,0 +1,70 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/auth"
+	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/auth/providers"
+)
+
+const (
+	// defaultRequeueAfter is used after a failed reconcile step whose error is
+	// not classified, and after every successful reconcile.
+	defaultRequeueAfter = time.Minute
+
+	// validationRequeueAfter is used when the NebariApp's spec is invalid.
+	// Retrying cannot fix it, and editing the spec triggers a reconcile anyway.
+	validationRequeueAfter = 5 * time.Minute
+)
+
+// resultForError returns the reconcile result after a step failed with err.
+// Failures that usually clear on their own, such as an unreachable provider or
+// an overloaded API server, are returned as errors so the workqueue's rate
+// limiter retries them with per-app exponential backoff. Other failures are
+// retried after a fixed delay.
+func resultForError(err error) (ctrl.Result, error) {
+	if isTransient(err) {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfterError(err)}, nil
+}
+
+// isTransient reports whether err is a failure that usually clears on its own.
+func isTransient(err error) bool {
+	return errors.Is(err, providers.ErrProviderUnreachable) ||
+		apierrors.IsConflict(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err)
+}
+
+// requeueAfterError returns how long to wait before retrying a reconcile step
+// that failed with err, which is not transient.
+func requeueAfterError(err error) time.Duration {
+	if errors.Is(err, auth.ErrInvalidConfig) {
+		return validationRequeueAfter
+	}
+	return defaultRequeueAfter
+}
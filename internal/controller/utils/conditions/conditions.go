@@ -17,6 +17,8 @@ limitations under the License.
 package conditions
 
 import (
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -73,3 +75,31 @@ func IsConditionUnknown(nebariApp *appsv1.NebariApp, conditionType string) bool
 	condition := GetCondition(nebariApp, conditionType)
 	return condition != nil && condition.Status == metav1.ConditionUnknown
 }
+
+// DefaultReadyConditions are the sub-conditions that gate the aggregate Ready
+// condition when the operator is not configured otherwise.
+var DefaultReadyConditions = []string{
+	appsv1.ConditionTypeRoutingReady,
+	appsv1.ConditionTypeTLSReady,
+	appsv1.ConditionTypeAuthReady,
+}
+
+// Aggregate computes the aggregate Ready status from the given sub-condition types.
+// Ready is True only when every required condition is present and True. Otherwise
+// the first blocking condition is reported: False when it is False, Unknown when it
+// is missing or Unknown. Conditions not listed in required are advisory and never
+// block Ready.
+func Aggregate(nebariApp *appsv1.NebariApp, required []string) (metav1.ConditionStatus, string, string) {
+	for _, conditionType := range required {
+		condition := GetCondition(nebariApp, conditionType)
+		if condition == nil {
+			return metav1.ConditionUnknown, appsv1.ReasonDependencyNotReady,
+				fmt.Sprintf("%s has not been reported yet", conditionType)
+		}
+		if condition.Status != metav1.ConditionTrue {
+			return condition.Status, appsv1.ReasonDependencyNotReady,
+				fmt.Sprintf("%s is %s (%s): %s", conditionType, condition.Status, condition.Reason, condition.Message)
+		}
+	}
+	return metav1.ConditionTrue, appsv1.ReasonReconcileSuccess, "NebariApp reconciled successfully"
+}
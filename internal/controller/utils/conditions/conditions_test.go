@@ -231,3 +231,75 @@ func TestSetCondition_UpdatesLastTransitionTimeOnStatusChange(t *testing.T) {
 		t.Errorf("expected reason 'NotGood', got '%s'", cond.Reason)
 	}
 }
+
+func TestAggregate(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []metav1.Condition
+		required   []string
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name: "all required conditions true",
+			conditions: []metav1.Condition{
+				{Type: appsv1.ConditionTypeRoutingReady, Status: metav1.ConditionTrue, Reason: "Ok"},
+				{Type: appsv1.ConditionTypeTLSReady, Status: metav1.ConditionTrue, Reason: "Ok"},
+			},
+			required:   DefaultReadyConditions[:2],
+			wantStatus: metav1.ConditionTrue,
+			wantReason: appsv1.ReasonReconcileSuccess,
+		},
+		{
+			name: "TLS not ready and required",
+			conditions: []metav1.Condition{
+				{Type: appsv1.ConditionTypeRoutingReady, Status: metav1.ConditionTrue, Reason: "Ok"},
+				{Type: appsv1.ConditionTypeTLSReady, Status: metav1.ConditionFalse, Reason: "SecretNotReady"},
+			},
+			required:   []string{appsv1.ConditionTypeRoutingReady, appsv1.ConditionTypeTLSReady},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: appsv1.ReasonDependencyNotReady,
+		},
+		{
+			name: "TLS not ready but excluded from the gate",
+			conditions: []metav1.Condition{
+				{Type: appsv1.ConditionTypeRoutingReady, Status: metav1.ConditionTrue, Reason: "Ok"},
+				{Type: appsv1.ConditionTypeTLSReady, Status: metav1.ConditionFalse, Reason: "SecretNotReady"},
+			},
+			required:   []string{appsv1.ConditionTypeRoutingReady},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: appsv1.ReasonReconcileSuccess,
+		},
+		{
+			name: "required condition missing",
+			conditions: []metav1.Condition{
+				{Type: appsv1.ConditionTypeRoutingReady, Status: metav1.ConditionTrue, Reason: "Ok"},
+			},
+			required:   []string{appsv1.ConditionTypeRoutingReady, appsv1.ConditionTypeAuthReady},
+			wantStatus: metav1.ConditionUnknown,
+			wantReason: appsv1.ReasonDependencyNotReady,
+		},
+		{
+			name:       "nothing required",
+			required:   nil,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: appsv1.ReasonReconcileSuccess,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				Status: appsv1.NebariAppStatus{Conditions: tt.conditions},
+			}
+
+			status, reason, _ := Aggregate(nebariApp, tt.required)
+			if status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, status)
+			}
+			if reason != tt.wantReason {
+				t.Errorf("expected reason %s, got %s", tt.wantReason, reason)
+			}
+		})
+	}
+}
@@ -32,11 +32,45 @@ const (
 	// GatewayClassName is the GatewayClass used by the gateway
 	GatewayClassName = "envoy-gateway"
 
+	// DefaultGatewayControllerName is the controllerName Envoy Gateway watches
+	// GatewayClasses for
+	DefaultGatewayControllerName = "gateway.envoyproxy.io/gatewayclass-controller"
+
 	// DefaultTLSSecretName is the wildcard certificate used by the gateway
 	// This corresponds to the nebari-gateway-tls secret created by cert-manager
 	DefaultTLSSecretName = "nebari-gateway-tls"
+
+	// DefaultBackendWeight is the weight on the spec.service backendRef when
+	// spec.service.weight is not set
+	DefaultBackendWeight = 100
+
+	// DefaultMaxRoutesPerApp is the largest routing.routes list accepted when
+	// the operator is not configured with a different limit
+	DefaultMaxRoutesPerApp = 50
+
+	// MaxRecentEvents caps the status.recentEvents buffer on a NebariApp
+	MaxRecentEvents = 10
 )
 
+// DefaultProtectedNamespaces are the namespaces whose NebariApps are refused even
+// when the namespace carries the opt-in label, unless the operator is configured
+// with a different list.
+var DefaultProtectedNamespaces = []string{"kube-system", "kube-public", GatewayNamespace}
+
+// DefaultAllowedRedirectSchemes are the URI schemes permitted on provisioned
+// OIDC client redirect URIs, unless the operator is configured with a
+// different list.
+var DefaultAllowedRedirectSchemes = []string{"https", "http"}
+
+// AllowAnyScope, listed in the allowed scopes, disables the scope allowlist.
+const AllowAnyScope = "*"
+
+// DefaultAllowedScopes are the OIDC scopes a NebariApp may request in
+// spec.auth.scopes and spec.auth.stepUpScopes, unless the operator is
+// configured with a different list. Any scope is allowed by default, so
+// existing apps keep working until an allowlist is configured.
+var DefaultAllowedScopes = []string{AllowAnyScope}
+
 // Resource naming suffixes
 const (
 	// HTTPRouteSuffix is appended to NebariApp name for HTTPRoute resources
@@ -45,9 +79,21 @@ const (
 	// PublicHTTPRouteSuffix is appended to NebariApp name for public (unauthenticated) HTTPRoute resources
 	PublicHTTPRouteSuffix = "public-route"
 
+	// StepUpHTTPRouteSuffix is appended to NebariApp name for HTTPRoute resources serving auth.stepUpPaths
+	StepUpHTTPRouteSuffix = "stepup-route"
+
+	// LoginHTTPRouteSuffix is appended to NebariApp name for HTTPRoute resources serving the auth.optional login
+	LoginHTTPRouteSuffix = "login-route"
+
 	// SecurityPolicySuffix is appended to NebariApp name for SecurityPolicy resources
 	SecurityPolicySuffix = "security"
 
+	// StepUpSecurityPolicySuffix is appended to NebariApp name for the SecurityPolicy guarding auth.stepUpPaths
+	StepUpSecurityPolicySuffix = "stepup-security"
+
+	// LoginSecurityPolicySuffix is appended to NebariApp name for the SecurityPolicy doing the auth.optional login
+	LoginSecurityPolicySuffix = "login-security"
+
 	// CertificateSuffix is appended to NebariApp name for Certificate resources
 	CertificateSuffix = "cert"
 
@@ -56,6 +102,22 @@ const (
 
 	// ClientSecretSuffix is appended to NebariApp name for OIDC client secret resources
 	ClientSecretSuffix = "oidc-client"
+
+	// ClientConfigSuffix is appended to NebariApp name for the exported OIDC client config ConfigMap
+	ClientConfigSuffix = "oidc-client-config"
+
+	// IdPBackendSuffix is appended to NebariApp name for the Envoy Gateway Backend of the IdP token endpoint
+	IdPBackendSuffix = "oidc-idp"
+
+	// ClientTrafficPolicySuffix is appended to NebariApp name for the ClientTrafficPolicy carrying client timeouts
+	ClientTrafficPolicySuffix = "client-traffic"
+
+	// RateLimitPolicySuffix is appended to NebariApp name for the BackendTrafficPolicy carrying rate limits
+	RateLimitPolicySuffix = "rate-limit"
+
+	// RateLimitClaimHeaderPrefix prefixes the request header the gateway copies
+	// the routing.rateLimit.byClaim JWT claim into
+	RateLimitClaimHeaderPrefix = "x-nebari-claim-"
 )
 
 // Annotation constants
@@ -65,6 +127,38 @@ const (
 	// is unchanged. The annotation is automatically removed once re-provisioning
 	// completes. Any non-empty value triggers a forced reprovision.
 	AnnotationForceReprovision = "nebari.dev/force-reprovision"
+
+	// AnnotationClientSecretHash is set by the operator on the client Secret it
+	// writes. It holds a digest of the client-secret value at the time, so an
+	// edited Secret can be told apart without asking the provider.
+	AnnotationClientSecretHash = "nebari.dev/client-secret-hash"
+
+	// AnnotationDescription carries the NebariApp's spec.description onto
+	// generated child resources (HTTPRoute, SecurityPolicy).
+	AnnotationDescription = "nebari.dev/description"
+
+	// AnnotationDrainingUntil marks an HTTPRoute of a deleted NebariApp that is
+	// kept serving for routing.deletionGracePeriod. The value is the RFC 3339
+	// time after which the route is removed.
+	AnnotationDrainingUntil = "nebari.dev/draining-until"
+
+	// AnnotationExternalDNSTarget tells external-dns which address to publish
+	// for an HTTPRoute's hostnames. Set from routing.dnsTarget.
+	AnnotationExternalDNSTarget = "external-dns.alpha.kubernetes.io/target"
+
+	// AnnotationExternalDNSHostname tells external-dns which hostnames to
+	// publish for an HTTPRoute. Set alongside AnnotationExternalDNSTarget.
+	AnnotationExternalDNSHostname = "external-dns.alpha.kubernetes.io/hostname"
+
+	// AnnotationPriority sets a NebariApp's reconcile priority. Apps with a
+	// higher integer value are dequeued before other apps when the controller
+	// is under load. Apps without the annotation use the default priority (0).
+	AnnotationPriority = "nebari.dev/priority"
+
+	// AnnotationProbePaths lists, comma-separated, the health and readiness
+	// probe paths a backend Service serves. NebariApps with
+	// auth.autoExemptProbes route them as public routes.
+	AnnotationProbePaths = "nebari.dev/probe-paths"
 )
 
 // Auth/OIDC provider constants
@@ -81,6 +175,18 @@ const (
 	// DefaultLogoutPath is the default logout path
 	DefaultLogoutPath = "/logout"
 
+	// OptionalLoginPath is where users of an auth.optional app start a login.
+	// Once signed in they are redirected to the app's root.
+	OptionalLoginPath = "/oauth2/login"
+
+	// AccessTokenCookiePrefix starts the name of the cookie holding the access
+	// token of an auth.optional session
+	AccessTokenCookiePrefix = "AccessToken-"
+
+	// DefaultGroupsClaim is the token claim listing the user's groups when
+	// auth.groupsClaim is not set
+	DefaultGroupsClaim = "groups"
+
 	// DefaultKeycloakNamespace is the namespace where Keycloak is deployed
 	DefaultKeycloakNamespace = "keycloak"
 
@@ -97,11 +203,38 @@ const (
 	DefaultKeycloakContextPath = ""
 )
 
+// Client secret generation
+const (
+	// DefaultClientSecretLength is the number of random bytes in a generated
+	// OIDC client secret
+	DefaultClientSecretLength = 32
+
+	// MinClientSecretLength is the fewest random bytes (128 bits) a generated
+	// OIDC client secret may carry
+	MinClientSecretLength = 16
+
+	// MaxClientSecretLength is the most random bytes a generated OIDC client
+	// secret may carry, keeping the encoded secret within what IdPs accept
+	MaxClientSecretLength = 128
+
+	// ClientSecretEncodingBase64URL encodes generated client secrets as
+	// unpadded base64url (A-Z, a-z, 0-9, "-" and "_")
+	ClientSecretEncodingBase64URL = "base64url"
+
+	// ClientSecretEncodingHex encodes generated client secrets as lowercase hex
+	ClientSecretEncodingHex = "hex"
+)
+
 // Secret keys
 const (
 	// ClientSecretKey is the key name for OIDC client secret data
 	ClientSecretKey = "client-secret"
 
+	// LegacyClientSecretKey is the previous key name for OIDC client secret data.
+	// Secrets that only use it fail validation with a hint to rename the key;
+	// operator-provisioned Secrets are rewritten with ClientSecretKey.
+	LegacyClientSecretKey = "client_secret"
+
 	// SPAClientIDKey is the key name for SPA client ID data
 	// Used to store the public client ID for browser-based SPAs
 	SPAClientIDKey = "spa-client-id"
@@ -109,6 +242,9 @@ const (
 	// ClientIDKey is the key name for the OIDC client ID
 	ClientIDKey = "client-id"
 
+	// ClientConfigKey is the key name for the exported OIDC client config JSON
+	ClientConfigKey = "client.json"
+
 	// DeviceClientIDKey is the key name for device flow client ID data
 	DeviceClientIDKey = "device-client-id"
 
@@ -121,3 +257,17 @@ const (
 	// NebariAppFinalizer is the finalizer added to NebariApp resources
 	NebariAppFinalizer = "apps.nebari.dev/finalizer"
 )
+
+// Field managers
+const (
+	// FieldManager is the field manager the operator server-side applies child
+	// resources under when USE_SERVER_SIDE_APPLY is enabled.
+	FieldManager = "nebari-operator"
+)
+
+// Labels
+const (
+	// DefaultManagedBy is the app.kubernetes.io/managed-by value on resources the
+	// operator creates, unless overridden with MANAGED_BY.
+	DefaultManagedBy = "nebari-operator"
+)
@@ -0,0 +1,90 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events keeps a short timeline of the events recorded for a NebariApp
+// in its status, next to the regular Kubernetes events.
+package events
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+// Recorder is a record.EventRecorder that also appends every event recorded
+// for a NebariApp to its status.recentEvents. The entry lands on the in-memory
+// object and is persisted with the next status update.
+type Recorder struct {
+	record.EventRecorder
+}
+
+// NewRecorder wraps recorder so events on NebariApps are kept in their status.
+func NewRecorder(recorder record.EventRecorder) *Recorder {
+	return &Recorder{EventRecorder: recorder}
+}
+
+// Event records an event and appends it to the NebariApp's recent events.
+func (r *Recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	appendTo(object, eventtype, reason)
+	r.EventRecorder.Event(object, eventtype, reason, message)
+}
+
+// Eventf is Event with a formatted message.
+func (r *Recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	appendTo(object, eventtype, reason)
+	r.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+// AnnotatedEventf is Eventf with annotations on the event.
+func (r *Recorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	appendTo(object, eventtype, reason)
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+}
+
+func appendTo(object runtime.Object, eventtype, reason string) {
+	if nebariApp, ok := object.(*appsv1.NebariApp); ok {
+		Append(nebariApp, eventtype, reason, time.Now())
+	}
+}
+
+// Append adds an event to the NebariApp's status.recentEvents. A repeat of the
+// latest entry bumps its count and timestamp; otherwise a new entry is added
+// and the oldest ones are dropped beyond constants.MaxRecentEvents.
+func Append(nebariApp *appsv1.NebariApp, eventtype, reason string, now time.Time) {
+	recent := nebariApp.Status.RecentEvents
+	timestamp := metav1.NewTime(now)
+	if n := len(recent); n > 0 && recent[n-1].Type == eventtype && recent[n-1].Reason == reason {
+		recent[n-1].Count++
+		recent[n-1].LastTimestamp = timestamp
+		return
+	}
+
+	recent = append(recent, appsv1.RecentEvent{
+		Type:          eventtype,
+		Reason:        reason,
+		Count:         1,
+		LastTimestamp: timestamp,
+	})
+	if len(recent) > constants.MaxRecentEvents {
+		recent = recent[len(recent)-constants.MaxRecentEvents:]
+	}
+	nebariApp.Status.RecentEvents = recent
+}
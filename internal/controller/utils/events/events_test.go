@@ -0,0 +1,91 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+func TestRecorderAccumulatesEvents(t *testing.T) {
+	fakeRecorder := record.NewFakeRecorder(10)
+	recorder := NewRecorder(fakeRecorder)
+	nebariApp := &appsv1.NebariApp{}
+
+	recorder.Event(nebariApp, corev1.EventTypeNormal, appsv1.EventReasonHTTPRouteCreated, "Created HTTPRoute test-route")
+	recorder.Eventf(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonServiceNotFound, "service %s not found", "svc")
+	recorder.Eventf(nebariApp, corev1.EventTypeWarning, appsv1.EventReasonServiceNotFound, "service %s not found", "svc")
+
+	recent := nebariApp.Status.RecentEvents
+	if len(recent) != 2 {
+		t.Fatalf("expected 2 recent events, got %+v", recent)
+	}
+	if recent[0].Reason != appsv1.EventReasonHTTPRouteCreated || recent[0].Type != corev1.EventTypeNormal || recent[0].Count != 1 {
+		t.Errorf("unexpected first event %+v", recent[0])
+	}
+	if recent[1].Reason != appsv1.EventReasonServiceNotFound || recent[1].Type != corev1.EventTypeWarning || recent[1].Count != 2 {
+		t.Errorf("expected the repeated event to be counted, got %+v", recent[1])
+	}
+	if recent[1].LastTimestamp.IsZero() {
+		t.Error("expected the event timestamp to be set")
+	}
+
+	// The wrapped recorder still receives every event.
+	if len(fakeRecorder.Events) != 3 {
+		t.Errorf("expected 3 events passed through, got %d", len(fakeRecorder.Events))
+	}
+}
+
+func TestAppendCapsBuffer(t *testing.T) {
+	nebariApp := &appsv1.NebariApp{}
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	total := constants.MaxRecentEvents + 5
+	for i := 0; i < total; i++ {
+		Append(nebariApp, corev1.EventTypeNormal, fmt.Sprintf("Reason%d", i), start.Add(time.Duration(i)*time.Second))
+	}
+
+	recent := nebariApp.Status.RecentEvents
+	if len(recent) != constants.MaxRecentEvents {
+		t.Fatalf("expected the buffer to cap at %d, got %d", constants.MaxRecentEvents, len(recent))
+	}
+	if recent[0].Reason != "Reason5" {
+		t.Errorf("expected the oldest events to be dropped, first is %q", recent[0].Reason)
+	}
+	if last := recent[len(recent)-1]; last.Reason != fmt.Sprintf("Reason%d", total-1) ||
+		!last.LastTimestamp.Time.Equal(start.Add(time.Duration(total-1)*time.Second)) {
+		t.Errorf("expected the newest event last, got %+v", last)
+	}
+}
+
+func TestRecorderIgnoresOtherObjects(t *testing.T) {
+	fakeRecorder := record.NewFakeRecorder(10)
+	recorder := NewRecorder(fakeRecorder)
+
+	recorder.Event(&corev1.Service{}, corev1.EventTypeNormal, "Created", "created")
+
+	if len(fakeRecorder.Events) != 1 {
+		t.Errorf("expected the event to be passed through, got %d", len(fakeRecorder.Events))
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics defines the operator's own Prometheus metrics. They are
+// registered with controller-runtime's registry, so the manager serves them on
+// its metrics endpoint next to the built-in controller metrics.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// RoutingReconcileDuration observes how long routing reconciliation takes for
+// an app, labeled by the name of each Gateway the app is exposed on. The label
+// only takes the operator's Gateway names, which keeps its cardinality low.
+var RoutingReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "nebari_operator_routing_reconcile_duration_seconds",
+	Help:    "Time spent reconciling a NebariApp's routing, by Gateway.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"gateway"})
+
+// ConfigInfo is an info-style gauge, always 1, whose labels carry the feature
+// switches the operator was started with. Only booleans and the default
+// provider name are exposed, never URLs or credentials.
+var ConfigInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "nebari_operator_config_info",
+	Help: "Effective operator configuration; the labels carry the enabled features.",
+}, []string{
+	"keycloak_enabled",
+	"webhooks_enabled",
+	"default_tls_enabled",
+	"issuer_preflight_enabled",
+	"connectivity_probe_enabled",
+	"tracing_enabled",
+	"default_auth_provider",
+})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(RoutingReconcileDuration, ConfigInfo)
+}
+
+// ConfigLabels are the values of the ConfigInfo labels.
+type ConfigLabels struct {
+	KeycloakEnabled          bool
+	WebhooksEnabled          bool
+	DefaultTLSEnabled        bool
+	IssuerPreflightEnabled   bool
+	ConnectivityProbeEnabled bool
+	TracingEnabled           bool
+	DefaultAuthProvider      string
+}
+
+// SetConfigInfo replaces the ConfigInfo series with one for labels.
+func SetConfigInfo(labels ConfigLabels) {
+	ConfigInfo.Reset()
+	ConfigInfo.WithLabelValues(
+		strconv.FormatBool(labels.KeycloakEnabled),
+		strconv.FormatBool(labels.WebhooksEnabled),
+		strconv.FormatBool(labels.DefaultTLSEnabled),
+		strconv.FormatBool(labels.IssuerPreflightEnabled),
+		strconv.FormatBool(labels.ConnectivityProbeEnabled),
+		strconv.FormatBool(labels.TracingEnabled),
+		labels.DefaultAuthProvider,
+	).Set(1)
+}
+
+// ObserveRoutingReconcile records a routing reconciliation of duration d for
+// each of gatewayNames.
+func ObserveRoutingReconcile(gatewayNames []string, d time.Duration) {
+	for _, gatewayName := range gatewayNames {
+		RoutingReconcileDuration.WithLabelValues(gatewayName).Observe(d.Seconds())
+	}
+}
@@ -1,7 +1,11 @@
 package naming
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"slices"
+	"strings"
 
 	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
@@ -11,8 +15,46 @@ import (
 // and Gateway API SectionName values.
 const maxKubernetesNameLength = 253
 
+// maxLabelValueLength is the maximum length of a Kubernetes label value.
+const maxLabelValueLength = 63
+
+// nameHashLength is the number of hex characters of the full name's SHA-256
+// appended to names that had to be truncated.
+const nameHashLength = 8
+
+// fitName returns name unchanged when it fits within maxKubernetesNameLength.
+// Longer names are truncated and suffixed with a hash of the full name, so the
+// result is deterministic and two long names that share a prefix stay distinct.
+func fitName(name string) string {
+	return truncateWithHash(name, maxKubernetesNameLength)
+}
+
+// LabelValue returns value unchanged when it fits in a label value (63
+// characters). Longer values, such as the name of a NebariApp, are truncated
+// and suffixed with a hash of the full value, like resource names. Code that
+// reads an app name back from a label must compare against LabelValue(name)
+// rather than the name itself.
+func LabelValue(value string) string {
+	return truncateWithHash(value, maxLabelValueLength)
+}
+
+// truncateWithHash shortens s to maxLength by truncating it and appending a
+// hash of the full string.
+func truncateWithHash(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+	sum := sha256.Sum256([]byte(s))
+	// Trim separators so the truncated prefix never ends in "-", "." or "_",
+	// which would leave an empty or dash-led DNS label before the hash.
+	prefix := strings.TrimRight(s[:maxLength-nameHashLength-1], "-._")
+	return prefix + "-" + hex.EncodeToString(sum[:])[:nameHashLength]
+}
+
 // ValidateResourceNames checks that all derived resource names for a NebariApp
 // fit within Kubernetes naming limits (253 characters for object names and SectionName).
+// The name helpers below already shorten long names with fitName, so this is a
+// guard against a helper that forgets to.
 func ValidateResourceNames(nebariApp *appsv1.NebariApp) error {
 	checks := []struct {
 		label string
@@ -25,6 +67,10 @@ func ValidateResourceNames(nebariApp *appsv1.NebariApp) error {
 		{"CertificateSecret", CertificateSecretName(nebariApp)},
 		{"GatewayListener", ListenerName(nebariApp)},
 		{"OIDCClientSecret", ClientSecretName(nebariApp)},
+		{"OIDCClientConfigMap", ClientConfigMapName(nebariApp)},
+		{"OIDCIdPBackend", IdPBackendName(nebariApp)},
+		{"ClientTrafficPolicy", ClientTrafficPolicyName(nebariApp)},
+		{"RateLimitPolicy", RateLimitPolicyName(nebariApp)},
 	}
 
 	for _, c := range checks {
@@ -38,13 +84,15 @@ func ValidateResourceNames(nebariApp *appsv1.NebariApp) error {
 
 // ResourceName generates a consistent resource name for NebariApp-owned resources.
 // Pattern: <nebariapp-name>-<resource-type>
+// Names longer than 253 characters are truncated and suffixed with a hash of the
+// full name; the same applies to every name generated in this package.
 //
 // Examples:
 //   - ResourceName(nebariApp, "route") -> "my-app-route"
 //   - ResourceName(nebariApp, "security") -> "my-app-security"
 //   - ResourceName(nebariApp, "certificate") -> "my-app-certificate"
 func ResourceName(nebariApp *appsv1.NebariApp, resourceType string) string {
-	return fmt.Sprintf("%s-%s", nebariApp.Name, resourceType)
+	return fitName(fmt.Sprintf("%s-%s", nebariApp.Name, resourceType))
 }
 
 // SecurityPolicyName generates the name for a SecurityPolicy.
@@ -53,6 +101,18 @@ func SecurityPolicyName(nebariApp *appsv1.NebariApp) string {
 	return ResourceName(nebariApp, constants.SecurityPolicySuffix)
 }
 
+// StepUpSecurityPolicyName generates the name for the SecurityPolicy guarding auth.stepUpPaths.
+// Pattern: <nebariapp-name>-stepup-security
+func StepUpSecurityPolicyName(nebariApp *appsv1.NebariApp) string {
+	return ResourceName(nebariApp, constants.StepUpSecurityPolicySuffix)
+}
+
+// LoginSecurityPolicyName generates the name for the SecurityPolicy doing the auth.optional login.
+// Pattern: <nebariapp-name>-login-security
+func LoginSecurityPolicyName(nebariApp *appsv1.NebariApp) string {
+	return ResourceName(nebariApp, constants.LoginSecurityPolicySuffix)
+}
+
 // HTTPRouteName generates the name for an HTTPRoute.
 // Pattern: <nebariapp-name>-route
 func HTTPRouteName(nebariApp *appsv1.NebariApp) string {
@@ -65,50 +125,249 @@ func PublicHTTPRouteName(nebariApp *appsv1.NebariApp) string {
 	return ResourceName(nebariApp, constants.PublicHTTPRouteSuffix)
 }
 
+// StepUpHTTPRouteName generates the name for the HTTPRoute serving auth.stepUpPaths.
+// Pattern: <nebariapp-name>-stepup-route
+func StepUpHTTPRouteName(nebariApp *appsv1.NebariApp) string {
+	return ResourceName(nebariApp, constants.StepUpHTTPRouteSuffix)
+}
+
+// LoginHTTPRouteName generates the name for the HTTPRoute serving the auth.optional login.
+// Pattern: <nebariapp-name>-login-route
+func LoginHTTPRouteName(nebariApp *appsv1.NebariApp) string {
+	return ResourceName(nebariApp, constants.LoginHTTPRouteSuffix)
+}
+
+// AccessTokenCookieName returns the name of the cookie holding the access token
+// of an auth.optional session, shared by the login and main SecurityPolicies.
+// Pattern: AccessToken-<namespace>-<nebariapp-name>
+func AccessTokenCookieName(nebariApp *appsv1.NebariApp) string {
+	return constants.AccessTokenCookiePrefix + ClientID(nebariApp)
+}
+
 // ClientSecretName generates the name for the OIDC client secret.
 // Pattern: <nebariapp-name>-oidc-client
 func ClientSecretName(nebariApp *appsv1.NebariApp) string {
 	return ResourceName(nebariApp, constants.ClientSecretSuffix)
 }
 
+// ClientConfigMapName generates the name for the ConfigMap exporting the
+// non-secret OIDC client configuration.
+func ClientConfigMapName(nebariApp *appsv1.NebariApp) string {
+	return ResourceName(nebariApp, constants.ClientConfigSuffix)
+}
+
+// IdPBackendName generates the name for the Envoy Gateway Backend that the
+// SecurityPolicy uses to reach the IdP token endpoint with a client certificate.
+// Pattern: <nebariapp-name>-oidc-idp
+func IdPBackendName(nebariApp *appsv1.NebariApp) string {
+	return ResourceName(nebariApp, constants.IdPBackendSuffix)
+}
+
 // ClientID generates the OIDC client ID for a NebariApp.
 // Pattern: <namespace>-<nebariapp-name>
 // This ensures uniqueness across namespaces.
 func ClientID(nebariApp *appsv1.NebariApp) string {
-	return fmt.Sprintf("%s-%s", nebariApp.Namespace, nebariApp.Name)
+	return fitName(fmt.Sprintf("%s-%s", nebariApp.Namespace, nebariApp.Name))
 }
 
 // DeviceFlowClientID generates the OIDC device flow client ID for a NebariApp.
 // Pattern: <namespace>-<nebariapp-name>-device
 func DeviceFlowClientID(nebariApp *appsv1.NebariApp) string {
-	return fmt.Sprintf("%s-%s-device", nebariApp.Namespace, nebariApp.Name)
+	return fitName(fmt.Sprintf("%s-%s-device", nebariApp.Namespace, nebariApp.Name))
+}
+
+// AppGroupName generates the name of the app-scoped Keycloak group.
+// Pattern: <namespace>-<nebariapp-name>
+func AppGroupName(nebariApp *appsv1.NebariApp) string {
+	return ClientID(nebariApp)
 }
 
 // CertificateName generates the name for a cert-manager Certificate.
 // Includes namespace to avoid collisions since Certificates live in the Gateway namespace.
 // Pattern: <nebariapp-name>-<namespace>-cert
 func CertificateName(nebariApp *appsv1.NebariApp) string {
-	return fmt.Sprintf("%s-%s-%s", nebariApp.Name, nebariApp.Namespace, constants.CertificateSuffix)
+	return fitName(fmt.Sprintf("%s-%s-%s", nebariApp.Name, nebariApp.Namespace, constants.CertificateSuffix))
 }
 
 // CertificateSecretName generates the name for the TLS secret created by cert-manager.
 // Pattern: <nebariapp-name>-<namespace>-tls
 func CertificateSecretName(nebariApp *appsv1.NebariApp) string {
-	return fmt.Sprintf("%s-%s-%s", nebariApp.Name, nebariApp.Namespace, constants.CertificateSecretSuffix)
+	return fitName(fmt.Sprintf("%s-%s-%s", nebariApp.Name, nebariApp.Namespace, constants.CertificateSecretSuffix))
+}
+
+// ClientTrafficPolicyName generates the name for the ClientTrafficPolicy carrying
+// client timeouts. Includes namespace since the policy lives in the Gateway namespace.
+// Pattern: <nebariapp-name>-<namespace>-client-traffic
+func ClientTrafficPolicyName(nebariApp *appsv1.NebariApp) string {
+	return fitName(fmt.Sprintf("%s-%s-%s", nebariApp.Name, nebariApp.Namespace, constants.ClientTrafficPolicySuffix))
+}
+
+// RateLimitPolicyName generates the name for the BackendTrafficPolicy carrying rate limits.
+// Pattern: <nebariapp-name>-rate-limit
+func RateLimitPolicyName(nebariApp *appsv1.NebariApp) string {
+	return ResourceName(nebariApp, constants.RateLimitPolicySuffix)
+}
+
+// RateLimitClaimHeader returns the request header the gateway copies a JWT claim
+// into for per-user rate limiting. Characters outside [a-z0-9-] become dashes,
+// so "realm_access.id" maps to "x-nebari-claim-realm-access-id".
+func RateLimitClaimHeader(claim string) string {
+	header := []byte(strings.ToLower(claim))
+	for i, c := range header {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			header[i] = '-'
+		}
+	}
+	return constants.RateLimitClaimHeaderPrefix + string(header)
+}
+
+// GroupsClaim returns the token claim that lists the user's groups.
+// Defaults to "groups" when auth.groupsClaim is not set.
+func GroupsClaim(nebariApp *appsv1.NebariApp) string {
+	if auth := nebariApp.Spec.Auth; auth != nil && auth.GroupsClaim != "" {
+		return auth.GroupsClaim
+	}
+	return constants.DefaultGroupsClaim
+}
+
+// ManagedBy returns the app.kubernetes.io/managed-by value for generated
+// resources: the configured value, or "nebari-operator" when it is empty.
+func ManagedBy(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	return constants.DefaultManagedBy
 }
 
 // ListenerName generates the name for the per-app Gateway HTTPS listener.
 // Pattern: tls-<nebariapp-name>-<namespace>
 func ListenerName(nebariApp *appsv1.NebariApp) string {
-	return fmt.Sprintf("tls-%s-%s", nebariApp.Name, nebariApp.Namespace)
+	return fitName(fmt.Sprintf("tls-%s-%s", nebariApp.Name, nebariApp.Namespace))
 }
 
-// GatewayName returns the Gateway name for a NebariApp based on its gateway spec.
-// Returns the internal gateway name when spec.gateway is "internal",
-// otherwise returns the public gateway name.
+// GatewayName returns the primary Gateway name for a NebariApp: the first entry
+// of GatewayNames.
 func GatewayName(nebariApp *appsv1.NebariApp) string {
-	if nebariApp.Spec.Gateway == "internal" {
+	return GatewayNames(nebariApp)[0]
+}
+
+// GatewayNames returns the names of every Gateway a NebariApp is exposed on,
+// primary first. spec.gateways wins when set; otherwise the single spec.gateway
+// applies. Gateways named in routing.gatewayRouting are appended when not
+// already selected. "internal" maps to the internal gateway and anything else
+// to the public gateway.
+func GatewayNames(nebariApp *appsv1.NebariApp) []string {
+	var names []string
+	if len(nebariApp.Spec.Gateways) == 0 {
+		names = []string{GatewayNameFor(nebariApp.Spec.Gateway)}
+	} else {
+		names = make([]string, 0, len(nebariApp.Spec.Gateways))
+		for _, gateway := range nebariApp.Spec.Gateways {
+			names = append(names, GatewayNameFor(gateway))
+		}
+	}
+	if routing := nebariApp.Spec.Routing; routing != nil {
+		for _, headerRoute := range routing.GatewayRouting {
+			if name := GatewayNameFor(headerRoute.Gateway); !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// AllGatewayNames returns every shared Gateway an app can attach to. Cleanup
+// walks all of them so resources left on a Gateway the app no longer selects
+// are removed too.
+func AllGatewayNames() []string {
+	return []string{constants.PublicGatewayName, constants.InternalGatewayName}
+}
+
+// GatewayNameFor maps a spec gateway value ("public" or "internal") to the
+// shared Gateway's name.
+func GatewayNameFor(gateway string) string {
+	if gateway == "internal" {
 		return constants.InternalGatewayName
 	}
 	return constants.PublicGatewayName
 }
+
+// gatewaySuffix returns the short spec value ("public" or "internal") for a Gateway name.
+func gatewaySuffix(gatewayName string) string {
+	if gatewayName == constants.InternalGatewayName {
+		return "internal"
+	}
+	return "public"
+}
+
+// GatewayHTTPRouteName returns the name of the HTTPRoute attaching a NebariApp to
+// gatewayName. The primary Gateway keeps HTTPRouteName so single-gateway apps are
+// unaffected; other Gateways get a suffixed name.
+// Pattern: <nebariapp-name>-route or <nebariapp-name>-route-<public|internal>
+func GatewayHTTPRouteName(nebariApp *appsv1.NebariApp, gatewayName string) string {
+	if gatewayName == GatewayName(nebariApp) {
+		return HTTPRouteName(nebariApp)
+	}
+	return ResourceName(nebariApp, constants.HTTPRouteSuffix+"-"+gatewaySuffix(gatewayName))
+}
+
+// GatewayPublicHTTPRouteName is GatewayHTTPRouteName for the public (unauthenticated) HTTPRoute.
+// Pattern: <nebariapp-name>-public-route or <nebariapp-name>-public-route-<public|internal>
+func GatewayPublicHTTPRouteName(nebariApp *appsv1.NebariApp, gatewayName string) string {
+	if gatewayName == GatewayName(nebariApp) {
+		return PublicHTTPRouteName(nebariApp)
+	}
+	return ResourceName(nebariApp, constants.PublicHTTPRouteSuffix+"-"+gatewaySuffix(gatewayName))
+}
+
+// GatewayStepUpHTTPRouteName is GatewayHTTPRouteName for the HTTPRoute serving auth.stepUpPaths.
+// Pattern: <nebariapp-name>-stepup-route or <nebariapp-name>-stepup-route-<public|internal>
+func GatewayStepUpHTTPRouteName(nebariApp *appsv1.NebariApp, gatewayName string) string {
+	if gatewayName == GatewayName(nebariApp) {
+		return StepUpHTTPRouteName(nebariApp)
+	}
+	return ResourceName(nebariApp, constants.StepUpHTTPRouteSuffix+"-"+gatewaySuffix(gatewayName))
+}
+
+// GatewayLoginHTTPRouteName is GatewayHTTPRouteName for the HTTPRoute serving the auth.optional login.
+// Pattern: <nebariapp-name>-login-route or <nebariapp-name>-login-route-<public|internal>
+func GatewayLoginHTTPRouteName(nebariApp *appsv1.NebariApp, gatewayName string) string {
+	if gatewayName == GatewayName(nebariApp) {
+		return LoginHTTPRouteName(nebariApp)
+	}
+	return ResourceName(nebariApp, constants.LoginHTTPRouteSuffix+"-"+gatewaySuffix(gatewayName))
+}
+
+// HTTPRouteNames returns the names of the main HTTPRoutes for every Gateway the
+// NebariApp is exposed on, primary first. Policies that attach to the app's
+// routes target all of them.
+func HTTPRouteNames(nebariApp *appsv1.NebariApp) []string {
+	gateways := GatewayNames(nebariApp)
+	names := make([]string, 0, len(gateways))
+	for _, gatewayName := range gateways {
+		names = append(names, GatewayHTTPRouteName(nebariApp, gatewayName))
+	}
+	return names
+}
+
+// StepUpHTTPRouteNames returns the names of the step-up HTTPRoutes for every
+// Gateway the NebariApp is exposed on, primary first.
+func StepUpHTTPRouteNames(nebariApp *appsv1.NebariApp) []string {
+	gateways := GatewayNames(nebariApp)
+	names := make([]string, 0, len(gateways))
+	for _, gatewayName := range gateways {
+		names = append(names, GatewayStepUpHTTPRouteName(nebariApp, gatewayName))
+	}
+	return names
+}
+
+// LoginHTTPRouteNames returns the names of the auth.optional login HTTPRoutes
+// for every Gateway the NebariApp is exposed on, primary first.
+func LoginHTTPRouteNames(nebariApp *appsv1.NebariApp) []string {
+	gateways := GatewayNames(nebariApp)
+	names := make([]string, 0, len(gateways))
+	for _, gatewayName := range gateways {
+		names = append(names, GatewayLoginHTTPRouteName(nebariApp, gatewayName))
+	}
+	return names
+}
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
 	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
@@ -68,6 +69,46 @@ func TestSecurityPolicyName(t *testing.T) {
 	}
 }
 
+func TestRateLimitClaimHeader(t *testing.T) {
+	tests := []struct {
+		claim    string
+		expected string
+	}{
+		{"sub", "x-nebari-claim-sub"},
+		{"preferred_username", "x-nebari-claim-preferred-username"},
+		{"realm_access.ID", "x-nebari-claim-realm-access-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.claim, func(t *testing.T) {
+			if result := RateLimitClaimHeader(tt.claim); result != tt.expected {
+				t.Errorf("RateLimitClaimHeader(%q) = %q, want %q", tt.claim, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClaimNames(t *testing.T) {
+	tests := []struct {
+		name           string
+		auth           *appsv1.AuthConfig
+		expectedGroups string
+	}{
+		{"no auth", nil, "groups"},
+		{"defaults", &appsv1.AuthConfig{}, "groups"},
+		{"configured", &appsv1.AuthConfig{GroupsClaim: "memberOf"}, "memberOf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{Spec: appsv1.NebariAppSpec{Auth: tt.auth}}
+			if result := GroupsClaim(nebariApp); result != tt.expectedGroups {
+				t.Errorf("GroupsClaim() = %q, want %q", result, tt.expectedGroups)
+			}
+		})
+	}
+}
+
 func TestHTTPRouteName(t *testing.T) {
 	nebariApp := &appsv1.NebariApp{
 		ObjectMeta: metav1.ObjectMeta{
@@ -254,10 +295,10 @@ func TestValidateResourceNames(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name:        "long app name exceeds limit",
+			name:        "long app name is shortened to fit",
 			appName:     strings.Repeat("a", 250),
 			namespace:   "default",
-			expectError: true,
+			expectError: false,
 		},
 		{
 			name:        "names just under limit pass",
@@ -283,6 +324,116 @@ func TestValidateResourceNames(t *testing.T) {
 	}
 }
 
+func TestGeneratedNamesFitKubernetesLimits(t *testing.T) {
+	longApp := func(name string) *appsv1.NebariApp {
+		return &appsv1.NebariApp{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: strings.Repeat("n", 63)},
+		}
+	}
+	generators := map[string]func(*appsv1.NebariApp) string{
+		"HTTPRoute":           HTTPRouteName,
+		"PublicHTTPRoute":     PublicHTTPRouteName,
+		"SecurityPolicy":      SecurityPolicyName,
+		"Certificate":         CertificateName,
+		"CertificateSecret":   CertificateSecretName,
+		"GatewayListener":     ListenerName,
+		"OIDCClientSecret":    ClientSecretName,
+		"OIDCClientConfigMap": ClientConfigMapName,
+		"OIDCIdPBackend":      IdPBackendName,
+		"ClientTrafficPolicy": ClientTrafficPolicyName,
+		"RateLimitPolicy":     RateLimitPolicyName,
+		"ClientID":            ClientID,
+		"DeviceFlowClientID":  DeviceFlowClientID,
+	}
+
+	// The longest name the API server accepts, made of several DNS labels so
+	// truncation can land next to a "." separator.
+	appName := strings.Repeat(strings.Repeat("a", 62)+".", 5)[:253]
+	app := longApp(appName)
+	// Same prefix, different tail: the truncated names must still differ.
+	sibling := longApp(appName[:252] + "b")
+
+	for label, generate := range generators {
+		t.Run(label, func(t *testing.T) {
+			name := generate(app)
+			if len(name) > maxKubernetesNameLength {
+				t.Errorf("name has %d characters, want at most %d", len(name), maxKubernetesNameLength)
+			}
+			if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+				t.Errorf("name %q is not a valid DNS subdomain: %v", name, errs)
+			}
+			if again := generate(longApp(appName)); again != name {
+				t.Errorf("name is not stable across calls: %q then %q", name, again)
+			}
+			if other := generate(sibling); other == name {
+				t.Errorf("names for different apps collide: %q", name)
+			}
+		})
+	}
+
+	if err := ValidateResourceNames(app); err != nil {
+		t.Errorf("expected generated names to pass validation, got: %v", err)
+	}
+}
+
+func TestFitName(t *testing.T) {
+	short := "my-app-route"
+	if got := fitName(short); got != short {
+		t.Errorf("expected short name to be unchanged, got %q", got)
+	}
+
+	exact := strings.Repeat("a", maxKubernetesNameLength)
+	if got := fitName(exact); got != exact {
+		t.Errorf("expected name at the limit to be unchanged, got %q", got)
+	}
+
+	long := strings.Repeat("a", maxKubernetesNameLength+1)
+	got := fitName(long)
+	if len(got) > maxKubernetesNameLength {
+		t.Errorf("expected at most %d characters, got %d", maxKubernetesNameLength, len(got))
+	}
+	if !strings.HasPrefix(got, strings.Repeat("a", 200)) {
+		t.Errorf("expected truncated name to keep its prefix, got %q", got)
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		unchanged bool
+	}{
+		{name: "short value is unchanged", value: "my-app", unchanged: true},
+		{name: "value at the limit is unchanged", value: strings.Repeat("a", maxLabelValueLength), unchanged: true},
+		{name: "long value is truncated", value: strings.Repeat("a", maxLabelValueLength+1)},
+		{name: "long value ending at a separator is truncated", value: strings.Repeat("a", 53) + "-.-" + strings.Repeat("b", 20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LabelValue(tt.value)
+			if tt.unchanged {
+				if got != tt.value {
+					t.Errorf("expected %q to be unchanged, got %q", tt.value, got)
+				}
+				return
+			}
+			if len(got) > maxLabelValueLength {
+				t.Errorf("expected at most %d characters, got %d", maxLabelValueLength, len(got))
+			}
+			if errs := validation.IsValidLabelValue(got); len(errs) > 0 {
+				t.Errorf("expected a valid label value, got %q: %v", got, errs)
+			}
+			if again := LabelValue(tt.value); again != got {
+				t.Errorf("expected a stable result, got %q then %q", got, again)
+			}
+			if other := LabelValue(tt.value + "x"); other == got {
+				t.Errorf("expected values sharing a prefix to stay distinct, both got %q", got)
+			}
+		})
+	}
+}
+
 func TestGatewayName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -308,3 +459,88 @@ func TestGatewayName(t *testing.T) {
 		})
 	}
 }
+
+func TestGatewayNames(t *testing.T) {
+	tests := []struct {
+		name           string
+		gateway        string
+		gateways       []string
+		gatewayRouting []string
+		expected       []string
+	}{
+		{"falls back to gateway", "internal", nil, nil, []string{constants.InternalGatewayName}},
+		{"gateways wins over gateway", "internal", []string{"public"}, nil, []string{constants.PublicGatewayName}},
+		{
+			"both gateways keep order", "", []string{"internal", "public"}, nil,
+			[]string{constants.InternalGatewayName, constants.PublicGatewayName},
+		},
+		{
+			"gatewayRouting appends its gateway", "public", nil, []string{"internal"},
+			[]string{constants.PublicGatewayName, constants.InternalGatewayName},
+		},
+		{
+			"gatewayRouting does not duplicate a selected gateway", "", []string{"public", "internal"}, []string{"internal"},
+			[]string{constants.PublicGatewayName, constants.InternalGatewayName},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nebariApp := &appsv1.NebariApp{
+				Spec: appsv1.NebariAppSpec{
+					Gateway:  tt.gateway,
+					Gateways: tt.gateways,
+				},
+			}
+			for _, gateway := range tt.gatewayRouting {
+				if nebariApp.Spec.Routing == nil {
+					nebariApp.Spec.Routing = &appsv1.RoutingConfig{}
+				}
+				nebariApp.Spec.Routing.GatewayRouting = append(nebariApp.Spec.Routing.GatewayRouting,
+					appsv1.GatewayHeaderRoute{Gateway: gateway, Headers: []appsv1.HeaderMatch{{Name: "X-Internal", Value: "true"}}})
+			}
+			result := GatewayNames(nebariApp)
+			if strings.Join(result, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("GatewayNames() = %v, want %v", result, tt.expected)
+			}
+			if GatewayName(nebariApp) != tt.expected[0] {
+				t.Errorf("GatewayName() = %q, want primary %q", GatewayName(nebariApp), tt.expected[0])
+			}
+		})
+	}
+}
+
+func TestGatewayHTTPRouteName(t *testing.T) {
+	nebariApp := &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-app",
+		},
+		Spec: appsv1.NebariAppSpec{
+			Gateways: []string{"public", "internal"},
+		},
+	}
+
+	tests := []struct {
+		gatewayName    string
+		expected       string
+		expectedPublic string
+	}{
+		{constants.PublicGatewayName, "my-app-route", "my-app-public-route"},
+		{constants.InternalGatewayName, "my-app-route-internal", "my-app-public-route-internal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.gatewayName, func(t *testing.T) {
+			if got := GatewayHTTPRouteName(nebariApp, tt.gatewayName); got != tt.expected {
+				t.Errorf("GatewayHTTPRouteName() = %q, want %q", got, tt.expected)
+			}
+			if got := GatewayPublicHTTPRouteName(nebariApp, tt.gatewayName); got != tt.expectedPublic {
+				t.Errorf("GatewayPublicHTTPRouteName() = %q, want %q", got, tt.expectedPublic)
+			}
+		})
+	}
+
+	if got := HTTPRouteNames(nebariApp); strings.Join(got, ",") != "my-app-route,my-app-route-internal" {
+		t.Errorf("HTTPRouteNames() = %v", got)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priority
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+// FromAnnotations returns the reconcile priority declared by the
+// nebari.dev/priority annotation. The second return value is false when the
+// annotation is missing or is not an integer.
+func FromAnnotations(annotations map[string]string) (int, bool) {
+	value, ok := annotations[constants.AnnotationPriority]
+	if !ok {
+		return 0, false
+	}
+	p, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return p, true
+}
+
+// Effective combines the priority requested by the enqueuer with the app's
+// annotated priority. An annotated priority only ever raises the requested
+// one, so re-queues (which carry the priority the item was dequeued with) do
+// not compound, and unannotated apps keep controller-runtime's defaults.
+func Effective(requested *int, annotated int, hasAnnotation bool) *int {
+	if !hasAnnotation {
+		return requested
+	}
+	if requested == nil || annotated > *requested {
+		return &annotated
+	}
+	return requested
+}
+
+// Queue wraps a controller-runtime priority queue so that NebariApps
+// annotated with nebari.dev/priority are dequeued before other apps,
+// whichever event source enqueued them.
+type Queue struct {
+	priorityqueue.PriorityQueue[reconcile.Request]
+
+	// Reader looks up the enqueued NebariApp, usually the manager's cache.
+	Reader client.Reader
+}
+
+// NewQueue returns a Queue wrapping inner.
+func NewQueue(inner priorityqueue.PriorityQueue[reconcile.Request], reader client.Reader) *Queue {
+	return &Queue{PriorityQueue: inner, Reader: reader}
+}
+
+// Add adds an item to the queue with the app's priority.
+func (q *Queue) Add(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{}, item)
+}
+
+// AddAfter adds an item to the queue after the given delay with the app's priority.
+func (q *Queue) AddAfter(item reconcile.Request, after time.Duration) {
+	q.AddWithOpts(priorityqueue.AddOpts{After: after}, item)
+}
+
+// AddRateLimited adds a rate-limited item to the queue with the app's priority.
+func (q *Queue) AddRateLimited(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{RateLimited: true}, item)
+}
+
+// AddWithOpts adds items to the queue, raising each item's priority to the
+// priority annotated on its NebariApp.
+func (q *Queue) AddWithOpts(o priorityqueue.AddOpts, items ...reconcile.Request) {
+	for _, item := range items {
+		annotated, ok := q.lookup(item.NamespacedName)
+		opts := o
+		opts.Priority = Effective(o.Priority, annotated, ok)
+		q.PriorityQueue.AddWithOpts(opts, item)
+	}
+}
+
+// lookup returns the annotated priority of the NebariApp, if any. Lookup
+// failures (for example, the app was deleted) fall back to no annotation.
+func (q *Queue) lookup(key types.NamespacedName) (int, bool) {
+	if q.Reader == nil {
+		return 0, false
+	}
+	app := &appsv1.NebariApp{}
+	if err := q.Reader.Get(context.Background(), key, app); err != nil {
+		return 0, false
+	}
+	return FromAnnotations(app.GetAnnotations())
+}
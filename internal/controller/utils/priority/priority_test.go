@@ -0,0 +1,119 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priority
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/ptr"
+)
+
+func TestFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        int
+		wantOK      bool
+	}{
+		{name: "missing annotation", annotations: nil, want: 0, wantOK: false},
+		{name: "positive priority", annotations: map[string]string{constants.AnnotationPriority: "10"}, want: 10, wantOK: true},
+		{name: "negative priority", annotations: map[string]string{constants.AnnotationPriority: "-5"}, want: -5, wantOK: true},
+		{name: "non-integer value", annotations: map[string]string{constants.AnnotationPriority: "high"}, want: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FromAnnotations(tt.annotations)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("FromAnnotations() = (%d, %v), want (%d, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEffective(t *testing.T) {
+	tests := []struct {
+		name          string
+		requested     *int
+		annotated     int
+		hasAnnotation bool
+		want          *int
+	}{
+		{name: "no annotation keeps default", requested: nil, hasAnnotation: false, want: nil},
+		{name: "no annotation keeps requested", requested: ptr.To(-100), hasAnnotation: false, want: ptr.To(-100)},
+		{name: "annotation sets unset priority", requested: nil, annotated: 10, hasAnnotation: true, want: ptr.To(10)},
+		{name: "annotation raises low priority", requested: ptr.To(-100), annotated: 10, hasAnnotation: true, want: ptr.To(10)},
+		{name: "annotation does not lower higher priority", requested: ptr.To(20), annotated: 10, hasAnnotation: true, want: ptr.To(20)},
+		{name: "requeue with same priority does not compound", requested: ptr.To(10), annotated: 10, hasAnnotation: true, want: ptr.To(10)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Effective(tt.requested, tt.annotated, tt.hasAnnotation)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("Effective() = %v, want %v", deref(got), deref(tt.want))
+			}
+		})
+	}
+}
+
+func TestQueue_DequeuesHighPriorityAppsFirst(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	normal := &appsv1.NebariApp{ObjectMeta: metav1.ObjectMeta{Name: "normal", Namespace: "default"}}
+	critical := &appsv1.NebariApp{ObjectMeta: metav1.ObjectMeta{
+		Name:        "critical",
+		Namespace:   "default",
+		Annotations: map[string]string{constants.AnnotationPriority: "100"},
+	}}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(normal, critical).Build()
+
+	q := NewQueue(priorityqueue.New[reconcile.Request]("test"), reader)
+	defer q.ShutDown()
+
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: "normal", Namespace: "default"}})
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: "critical", Namespace: "default"}})
+
+	item, p, _ := q.GetWithPriority()
+	if item.Name != "critical" || p != 100 {
+		t.Errorf("expected critical app with priority 100 first, got %s with priority %d", item.Name, p)
+	}
+	q.Done(item)
+
+	item, p, _ = q.GetWithPriority()
+	if item.Name != "normal" || p != 0 {
+		t.Errorf("expected normal app with priority 0 second, got %s with priority %d", item.Name, p)
+	}
+	q.Done(item)
+}
+
+func deref(p *int) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
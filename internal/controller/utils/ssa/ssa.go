@@ -0,0 +1,112 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ssa writes operator-managed child resources with server-side apply.
+package ssa
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+// updateManager is the field manager the API server records for the operator's
+// create and update calls: the user agent up to the first "/", which client-go
+// derives from the binary name (e.g. "manager").
+var updateManager, _, _ = strings.Cut(rest.DefaultKubernetesUserAgent(), "/")
+
+// Apply server-side applies obj under constants.FieldManager, forcing
+// ownership of any field another manager set, and reports whether the object
+// was created, updated or already up to date. obj must hold the complete
+// desired state: fields the operator applied before and leaves out now are
+// removed. On success obj holds the object returned by the API server.
+//
+// An object last written with create/update has its fields owned by
+// updateManager. Those fields are handed to constants.FieldManager first, so
+// dropping one from the desired state removes it instead of leaving it owned
+// by the old manager.
+func Apply(ctx context.Context, c client.Client, obj client.Object) (controllerutil.OperationResult, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	existing, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return controllerutil.OperationResultNone, fmt.Errorf("%T is not a client.Object", obj)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return controllerutil.OperationResultNone, err
+		}
+		existing = nil
+	}
+
+	if existing != nil {
+		patch, err := csaupgrade.UpgradeManagedFieldsPatch(existing, sets.New(updateManager), constants.FieldManager)
+		if err != nil {
+			return controllerutil.OperationResultNone, fmt.Errorf("failed to upgrade managed fields of %s: %w", obj.GetName(), err)
+		}
+		if patch != nil {
+			if err := c.Patch(ctx, existing, client.RawPatch(types.JSONPatchType, patch)); err != nil {
+				return controllerutil.OperationResultNone, fmt.Errorf("failed to upgrade managed fields of %s: %w", obj.GetName(), err)
+			}
+		}
+	}
+
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+	if err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(constants.FieldManager), client.ForceOwnership); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	switch {
+	case existing == nil:
+		return controllerutil.OperationResultCreated, nil
+	case sameObject(existing, obj):
+		return controllerutil.OperationResultNone, nil
+	default:
+		return controllerutil.OperationResultUpdated, nil
+	}
+}
+
+// sameObject reports whether before and after hold the same object, ignoring
+// the bookkeeping an apply touches even when it changes nothing else.
+func sameObject(before, after client.Object) bool {
+	strip := func(obj client.Object) runtime.Object {
+		stripped := obj.DeepCopyObject().(client.Object)
+		stripped.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+		stripped.SetResourceVersion("")
+		stripped.SetManagedFields(nil)
+		return stripped
+	}
+	return equality.Semantic.DeepEqual(strip(before), strip(after))
+}
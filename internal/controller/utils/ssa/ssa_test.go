@@ -0,0 +1,133 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssa
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/nebari-dev/nebari-operator/internal/controller/utils/constants"
+)
+
+func newRoute(labels map[string]string, hostname string) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-route", Namespace: "default", Labels: labels},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{gatewayv1.Hostname(hostname)},
+		},
+	}
+}
+
+// managers returns the operation each field manager last used on obj.
+func managers(obj client.Object) map[string]metav1.ManagedFieldsOperationType {
+	result := map[string]metav1.ManagedFieldsOperationType{}
+	for _, entry := range obj.GetManagedFields() {
+		result[entry.Manager] = entry.Operation
+	}
+	return result
+}
+
+func TestApply(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = gatewayv1.Install(scheme)
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithReturnManagedFields().Build()
+
+	op, err := Apply(ctx, fakeClient, newRoute(map[string]string{"tier": "web"}, "app.example.com"))
+	if err != nil {
+		t.Fatalf("failed to create route: %v", err)
+	}
+	if op != controllerutil.OperationResultCreated {
+		t.Errorf("expected operation %q, got %q", controllerutil.OperationResultCreated, op)
+	}
+
+	route := &gatewayv1.HTTPRoute{}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: "app-route", Namespace: "default"}, route); err != nil {
+		t.Fatalf("failed to get route: %v", err)
+	}
+	if got := managers(route)[constants.FieldManager]; got != metav1.ManagedFieldsOperationApply {
+		t.Errorf("expected %s to own the route with operation Apply, got managers %v", constants.FieldManager, managers(route))
+	}
+
+	op, err = Apply(ctx, fakeClient, newRoute(map[string]string{"tier": "web"}, "app.example.com"))
+	if err != nil {
+		t.Fatalf("failed to reapply route: %v", err)
+	}
+	if op != controllerutil.OperationResultNone {
+		t.Errorf("expected an unchanged route to report %q, got %q", controllerutil.OperationResultNone, op)
+	}
+
+	op, err = Apply(ctx, fakeClient, newRoute(nil, "new.example.com"))
+	if err != nil {
+		t.Fatalf("failed to update route: %v", err)
+	}
+	if op != controllerutil.OperationResultUpdated {
+		t.Errorf("expected operation %q, got %q", controllerutil.OperationResultUpdated, op)
+	}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: "app-route", Namespace: "default"}, route); err != nil {
+		t.Fatalf("failed to get route: %v", err)
+	}
+	if string(route.Spec.Hostnames[0]) != "new.example.com" {
+		t.Errorf("expected hostname new.example.com, got %v", route.Spec.Hostnames)
+	}
+	if _, ok := route.Labels["tier"]; ok {
+		t.Errorf("expected a label dropped from the applied state to be removed, got %v", route.Labels)
+	}
+}
+
+func TestApply_TakesOverFieldsFromUpdateManager(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = gatewayv1.Install(scheme)
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithReturnManagedFields().Build()
+
+	// A route written by an operator version without server-side apply.
+	if err := fakeClient.Create(ctx, newRoute(map[string]string{"tier": "web"}, "app.example.com"),
+		client.FieldOwner(updateManager)); err != nil {
+		t.Fatalf("failed to create route: %v", err)
+	}
+
+	op, err := Apply(ctx, fakeClient, newRoute(nil, "app.example.com"))
+	if err != nil {
+		t.Fatalf("failed to apply route: %v", err)
+	}
+	if op != controllerutil.OperationResultUpdated {
+		t.Errorf("expected operation %q, got %q", controllerutil.OperationResultUpdated, op)
+	}
+
+	route := &gatewayv1.HTTPRoute{}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: "app-route", Namespace: "default"}, route); err != nil {
+		t.Fatalf("failed to get route: %v", err)
+	}
+	owners := managers(route)
+	if _, ok := owners[updateManager]; ok {
+		t.Errorf("expected %s to no longer own fields, got managers %v", updateManager, owners)
+	}
+	if owners[constants.FieldManager] != metav1.ManagedFieldsOperationApply {
+		t.Errorf("expected %s to own the route with operation Apply, got managers %v", constants.FieldManager, owners)
+	}
+	if _, ok := route.Labels["tier"]; ok {
+		t.Errorf("expected the label set by the update manager to be removed, got %v", route.Labels)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStartAndEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx, parent := Start(context.Background(), "parent", attribute.String("nebariapp", "default/test-app"))
+	_, child := Start(ctx, "child")
+	End(child, errors.New("boom"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 ended spans, got %d", len(spans))
+	}
+	childSpan, parentSpan := spans[0], spans[1]
+	if childSpan.Name() != "child" || parentSpan.Name() != "parent" {
+		t.Fatalf("expected spans [child parent], got [%s %s]", childSpan.Name(), parentSpan.Name())
+	}
+	if childSpan.Parent().SpanID() != parentSpan.SpanContext().SpanID() {
+		t.Error("expected child span to be parented to the parent span")
+	}
+	if childSpan.Status().Code != codes.Error || childSpan.Status().Description != "boom" {
+		t.Errorf("expected child span status Error/boom, got %+v", childSpan.Status())
+	}
+	if len(childSpan.Events()) != 1 {
+		t.Errorf("expected the error to be recorded as an event, got %d events", len(childSpan.Events()))
+	}
+	if parentSpan.Status().Code != codes.Unset {
+		t.Errorf("expected parent span status Unset, got %+v", parentSpan.Status())
+	}
+	if len(parentSpan.Attributes()) != 1 || parentSpan.Attributes()[0].Value.AsString() != "default/test-app" {
+		t.Errorf("expected parent span attributes to be kept, got %v", parentSpan.Attributes())
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+	"github.com/nebari-dev/nebari-operator/internal/controller/reconcilers/core"
+)
+
+var nebariapplog = logf.Log.WithName("nebariapp-webhook")
+
+// SetupNebariAppWebhookWithManager registers the NebariApp validating webhook
+// with the manager.
+func SetupNebariAppWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&appsv1.NebariApp{}).
+		WithValidator(&NebariAppCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-reconcilers-nebari-dev-v1-nebariapp,mutating=false,failurePolicy=fail,sideEffects=None,groups=reconcilers.nebari.dev,resources=nebariapps,verbs=create;update,versions=v1,name=vnebariapp-v1.kb.io,admissionReviewVersions=v1
+
+// NebariAppCustomValidator rejects NebariApps whose hostname and paths are
+// already routed by another NebariApp on the same Gateway, so the collision is
+// refused up front instead of surfacing later as a PathConflict condition.
+type NebariAppCustomValidator struct {
+	Client client.Client
+}
+
+var _ admission.CustomValidator = &NebariAppCustomValidator{}
+
+// ValidateCreate checks a new NebariApp against the apps already on its
+// hostname and Gateway.
+func (v *NebariAppCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	nebariApp, ok := obj.(*appsv1.NebariApp)
+	if !ok {
+		return nil, fmt.Errorf("expected a NebariApp object but got %T", obj)
+	}
+	nebariapplog.V(1).Info("Validating NebariApp create", "name", nebariApp.GetName(), "namespace", nebariApp.GetNamespace())
+
+	// The API server sets creationTimestamp after admission. The new app is the
+	// newest one, so give it the current time to make every existing app on the
+	// hostname take precedence over it.
+	nebariApp = nebariApp.DeepCopy()
+	if nebariApp.CreationTimestamp.IsZero() {
+		nebariApp.CreationTimestamp = metav1.Now()
+	}
+	return nil, core.ValidateSharedHostnamePaths(ctx, v.Client, nebariApp)
+}
+
+// ValidateUpdate checks an updated NebariApp against the apps already on its
+// hostname and Gateway. As in the controller, an older app keeps the paths it
+// shares with a newer one, so only updates that the controller would report
+// as conflicting are rejected.
+func (v *NebariAppCustomValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	nebariApp, ok := newObj.(*appsv1.NebariApp)
+	if !ok {
+		return nil, fmt.Errorf("expected a NebariApp object for the newObj but got %T", newObj)
+	}
+	nebariapplog.V(1).Info("Validating NebariApp update", "name", nebariApp.GetName(), "namespace", nebariApp.GetNamespace())
+
+	// Let deletion proceed: finalizer removal is an update too.
+	if !nebariApp.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	return nil, core.ValidateSharedHostnamePaths(ctx, v.Client, nebariApp)
+}
+
+// ValidateDelete allows every deletion.
+func (v *NebariAppCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
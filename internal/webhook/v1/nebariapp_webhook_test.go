@@ -0,0 +1,170 @@
+/*
+Copyright 2026, OpenTeams.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "github.com/nebari-dev/nebari-operator/api/v1"
+)
+
+func newApp(name, hostname string, created metav1.Time, paths ...string) *appsv1.NebariApp {
+	routing := &appsv1.RoutingConfig{}
+	for _, path := range paths {
+		routing.Routes = append(routing.Routes, appsv1.RouteMatch{PathPrefix: path})
+	}
+	return &appsv1.NebariApp{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", CreationTimestamp: created},
+		Spec: appsv1.NebariAppSpec{
+			Hostname: hostname,
+			Service:  appsv1.ServiceReference{Name: name, Port: 8080},
+			Routing:  routing,
+		},
+	}
+}
+
+func TestNebariAppCustomValidator_ValidateCreate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	existing := newApp("app-a", "app.example.com", metav1.NewTime(time.Now().Add(-time.Hour)), "/a")
+
+	tests := []struct {
+		name        string
+		app         *appsv1.NebariApp
+		expectError bool
+	}{
+		{
+			name:        "Duplicate hostname and path is rejected",
+			app:         newApp("app-b", "app.example.com", metav1.Time{}, "/a"),
+			expectError: true,
+		},
+		{
+			name:        "Duplicate hostname without routes claims / and is allowed next to /a",
+			app:         newApp("app-b", "app.example.com", metav1.Time{}),
+			expectError: false,
+		},
+		{
+			name:        "Path-disjoint app on the same hostname is allowed",
+			app:         newApp("app-b", "app.example.com", metav1.Time{}, "/b"),
+			expectError: false,
+		},
+		{
+			name:        "Same path on a different hostname is allowed",
+			app:         newApp("app-b", "other.example.com", metav1.Time{}, "/a"),
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &NebariAppCustomValidator{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing.DeepCopy()).Build(),
+			}
+
+			_, err := validator.ValidateCreate(context.Background(), tt.app)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected the create to be rejected")
+				}
+				if !strings.Contains(err.Error(), "test-ns/app-a") {
+					t.Errorf("expected the error to name the existing app, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tt.app.CreationTimestamp.IsZero() {
+				t.Error("expected the admitted object to be left unchanged")
+			}
+		})
+	}
+}
+
+func TestNebariAppCustomValidator_ValidateUpdate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	older := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(older.Add(time.Hour))
+
+	tests := []struct {
+		name        string
+		app         *appsv1.NebariApp
+		other       *appsv1.NebariApp
+		expectError bool
+	}{
+		{
+			name:        "Newer app moving onto an existing path is rejected",
+			app:         newApp("app-b", "app.example.com", newer, "/a"),
+			other:       newApp("app-a", "app.example.com", older, "/a"),
+			expectError: true,
+		},
+		{
+			name:        "Older app keeps a path a newer app also claims",
+			app:         newApp("app-a", "app.example.com", older, "/a"),
+			other:       newApp("app-b", "app.example.com", newer, "/a"),
+			expectError: false,
+		},
+		{
+			name:        "Path-disjoint update is allowed",
+			app:         newApp("app-b", "app.example.com", newer, "/b"),
+			other:       newApp("app-a", "app.example.com", older, "/a"),
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &NebariAppCustomValidator{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.app.DeepCopy(), tt.other).Build(),
+			}
+
+			_, err := validator.ValidateUpdate(context.Background(), tt.app, tt.app)
+			if tt.expectError && err == nil {
+				t.Error("expected the update to be rejected")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNebariAppCustomValidator_ValidateUpdate_Deleting(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	older := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	app := newApp("app-b", "app.example.com", metav1.NewTime(older.Add(time.Hour)), "/a")
+	now := metav1.Now()
+	app.DeletionTimestamp = &now
+	validator := &NebariAppCustomValidator{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(newApp("app-a", "app.example.com", older, "/a")).Build(),
+	}
+
+	if _, err := validator.ValidateUpdate(context.Background(), app, app); err != nil {
+		t.Errorf("expected updates to a deleting app to be allowed, got %v", err)
+	}
+}